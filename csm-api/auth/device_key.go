@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"csm-api/utils"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// 근태인식기 api key 접두어
+// 형식: dk_{랜덤값}. 발급시에만 원문을 보여주고 DB에는 sha256 해시만 저장한다.
+const deviceApiKeyPrefix = "dk_"
+
+// func: 근태인식기 api key 발급
+// @return
+// - key: api key 원문 (장치에 설정)
+// - hash: 저장할 해시
+func NewDeviceApiKey() (key string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", utils.CustomErrorf(err)
+	}
+	key = deviceApiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, HashDeviceApiKey(key), nil
+}
+
+// func: 근태인식기 api key 해시
+func HashDeviceApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// func: 근태인식기 api key 확인
+// @param
+// - key: 요청 헤더의 api key
+// - hash: 저장된 해시
+func VerifyDeviceApiKey(key string, hash string) bool {
+	if key == "" || hash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashDeviceApiKey(key)), []byte(hash)) == 1
+}
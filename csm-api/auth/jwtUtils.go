@@ -5,6 +5,7 @@ import (
 	"csm-api/clock"
 	"csm-api/config"
 	"csm-api/utils"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,10 +15,20 @@ import (
 )
 
 type JWTUtils struct {
-	Cfg   *config.JwtConfig
-	Clock clock.Clocker
+	Cfg      *config.JwtConfig
+	Keys     *KeySet // 비대칭 서명 키 (nil이면 Cfg의 비밀 키로 HS256 서명)
+	Clock    clock.Clocker
+	Sessions SessionStore
 }
 
+const (
+	AccessTokenTTL       = 10 * time.Minute    // access token 만료 시간
+	RefreshTokenTTL      = 1 * time.Hour       // refresh token 만료 시간 (아이디 저장 안 한 경우, 갱신할 때마다 연장)
+	SavedRefreshTokenTTL = 30 * 24 * time.Hour // refresh token 만료 시간 (아이디 저장한 경우)
+	RefreshReuseGrace    = 30 * time.Second    // 교체 직후 이전 refresh token을 허용하는 시간 (동시 재발급 요청)
+	SessionPurgeAfter    = 7 * 24 * time.Hour  // 만료, 폐기된 세션 보관 기간
+)
+
 type JWTRole string
 
 const (
@@ -37,12 +48,13 @@ const (
 
 // claims 정의
 type JWTClaims struct {
-	Uno      int64   `json:"uno"`
-	UserId   string  `json:"user_id"`
-	UserName string  `json:"user_name"`
-	Role     JWTRole `json:"role"`
-	Token    string
-	IsSaved  bool
+	Uno       int64   `json:"uno"`
+	UserId    string  `json:"user_id"`
+	UserName  string  `json:"user_name"`
+	Role      JWTRole `json:"role"`
+	SessionId string  `json:"session_id"`
+	Token     string
+	IsSaved   bool
 }
 
 // context에 저장하는 claims value
@@ -50,9 +62,12 @@ type UserId struct{}
 type Role struct{}
 type Uno struct{}
 type UserName struct{}
+type IsRole struct{}
+type IsMember struct{}
+type SessionId struct{}
 
 // JWTUtils 구조체 생성
-func JwtNew(c clock.Clocker) (*JWTUtils, error) {
+func JwtNew(c clock.Clocker, sessions SessionStore) (*JWTUtils, error) {
 	jwt := &JWTUtils{}
 
 	jwtConfig, err := config.GetJwtConfig()
@@ -63,19 +78,26 @@ func JwtNew(c clock.Clocker) (*JWTUtils, error) {
 	jwt.Cfg = jwtConfig
 	jwt.Clock = c
 
+	// 비대칭 서명 키 설정
+	keyConfig, err := config.GetJwtKeyConfig()
+	if err != nil {
+		return nil, err
+	}
+	if keyConfig.KeysFile != "" {
+		if jwt.Keys, err = LoadKeySet(keyConfig.KeysFile); err != nil {
+			return nil, err
+		}
+		if _, err = jwt.Keys.SigningKey(c.Now()); err != nil {
+			return nil, err
+		}
+	}
+	jwt.Sessions = sessions
+
 	return jwt, nil
 }
 
 // 토큰 생성
 func (j *JWTUtils) GenerateToken(jwtClaims *JWTClaims) (string, error) {
-	// 비밀 키 선택 (아이디 저장 여부에 따라 다름)
-	var secretKey []byte
-	if jwtClaims.IsSaved {
-		secretKey = []byte(j.Cfg.SavedSecretKey) // "아이디 저장"한 경우 다른 키 사용
-	} else {
-		secretKey = []byte(j.Cfg.SecretKey)
-	}
-
 	// JWT 클레임 설정
 	claims := jwt.MapClaims{
 		"uno":      jwtClaims.Uno,
@@ -83,18 +105,12 @@ func (j *JWTUtils) GenerateToken(jwtClaims *JWTClaims) (string, error) {
 		"userName": jwtClaims.UserName,
 		"role":     jwtClaims.Role,
 		"isSaved":  jwtClaims.IsSaved, // "아이디 저장" 여부 추가
+		"sid":      jwtClaims.SessionId,
+		"exp":      j.Clock.Now().Add(AccessTokenTTL).Unix(), // 아이디 저장 여부와 관계없이 만료 (refresh token으로 재발급)
 	}
 
-	// 만료 시간 설정 (아이디 저장 안 한 경우 1시간 후 만료)
-	if !jwtClaims.IsSaved {
-		claims["exp"] = j.Clock.Now().Add(1 * time.Hour).Unix()
-	}
-
-	// 토큰 생성
-	parseToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// 비밀 키로 서명
-	tokenString, err := parseToken.SignedString(secretKey)
+	// 토큰 생성 및 서명
+	tokenString, err := j.sign(claims, jwtClaims.IsSaved)
 	if err != nil {
 		return "", utils.CustomErrorf(err)
 	}
@@ -104,6 +120,205 @@ func (j *JWTUtils) GenerateToken(jwtClaims *JWTClaims) (string, error) {
 	return tokenString, nil
 }
 
+// 토큰 서명
+// 비대칭 서명 키가 있으면 현재 서명 키로 서명하고 header에 kid를 추가한다.
+func (j *JWTUtils) sign(claims jwt.MapClaims, isSaved bool) (string, error) {
+	if j.Keys != nil {
+		key, err := j.Keys.SigningKey(j.Clock.Now())
+		if err != nil {
+			return "", err
+		}
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.Kid
+		return token.SignedString(key.Private)
+	}
+
+	// 비밀 키 선택 (아이디 저장 여부에 따라 다름)
+	var secretKey []byte
+	if isSaved {
+		secretKey = []byte(j.Cfg.SavedSecretKey) // "아이디 저장"한 경우 다른 키 사용
+	} else {
+		secretKey = []byte(j.Cfg.SecretKey)
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secretKey)
+}
+
+// 토큰 검증 키 조회
+func (j *JWTUtils) verificationKey(token *jwt.Token) (interface{}, error) {
+	if j.Keys != nil {
+		kid, _ := token.Header["kid"].(string)
+		key, err := j.Keys.VerificationKey(kid, j.Clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		// 서명 방법 확인
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, utils.CustomMessageErrorf("unexpected signing method", fmt.Errorf("%v", token.Header["alg"]))
+		}
+		return key.Public, nil
+	}
+
+	// 서명 방법 확인
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, utils.CustomMessageErrorf("unexpected signing method", fmt.Errorf("%v", token.Header["alg"]))
+	}
+
+	// "아이디 저장" 여부 확인 후 적절한 키 반환
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, utils.CustomErrorf(fmt.Errorf("invalid token claims"))
+	}
+
+	if isSaved, ok := claims["isSaved"].(bool); ok && isSaved {
+		return []byte(j.Cfg.SavedSecretKey), nil // "아이디 저장"한 경우 SavedSecretKey 사용
+	}
+	return []byte(j.Cfg.SecretKey), nil
+}
+
+// 토큰 검증용 공개키 목록 (비대칭 서명 키가 없으면 빈 목록)
+func (j *JWTUtils) JWKS() JWKS {
+	if j.Keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return j.Keys.JWKS(j.Clock.Now())
+}
+
+// 로그인 세션 생성 및 토큰 발급
+// @return
+// - access: access token
+// - refresh: refresh token
+func (j *JWTUtils) IssueTokens(ctx context.Context, jwtClaims *JWTClaims) (access string, refresh string, err error) {
+	sessionId, err := NewSessionId()
+	if err != nil {
+		return "", "", utils.CustomErrorf(err)
+	}
+	secret, err := newRefreshSecret()
+	if err != nil {
+		return "", "", utils.CustomErrorf(err)
+	}
+
+	session := Session{
+		SessionId:   sessionId,
+		Uno:         jwtClaims.Uno,
+		UserId:      jwtClaims.UserId,
+		UserName:    jwtClaims.UserName,
+		Role:        jwtClaims.Role,
+		IsSaved:     jwtClaims.IsSaved,
+		RefreshHash: HashRefreshSecret(secret),
+		ExpireDate:  j.refreshExpireDate(jwtClaims.IsSaved),
+	}
+	if err = j.Sessions.CreateSession(ctx, session); err != nil {
+		return "", "", utils.CustomErrorf(err)
+	}
+
+	jwtClaims.SessionId = sessionId
+	access, err = j.GenerateToken(jwtClaims)
+	if err != nil {
+		return "", "", utils.CustomErrorf(err)
+	}
+
+	return access, formatRefreshToken(sessionId, secret), nil
+}
+
+// refresh token으로 토큰 재발급 (refresh token 교체)
+// 이미 사용된 refresh token으로 요청한 경우 탈취로 보고 세션을 폐기한다.
+// 단, 교체 직후 RefreshReuseGrace 이내의 이전 refresh token은 동시 요청으로 보고 access token만 재발급한다. (refresh는 빈 값)
+func (j *JWTUtils) RefreshTokens(ctx context.Context, refreshToken string) (jwtClaims *JWTClaims, access string, refresh string, err error) {
+	sessionId, secret, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	session, err := j.Sessions.GetSession(ctx, sessionId)
+	if err != nil {
+		return nil, "", "", utils.CustomErrorf(err)
+	}
+	if !session.RevokeDate.IsZero() {
+		return nil, "", "", utils.CustomErrorf(ErrSessionRevoked)
+	}
+	if !j.Clock.Now().Before(session.ExpireDate) {
+		return nil, "", "", utils.CustomErrorf(ErrSessionExpired)
+	}
+
+	oldHash := HashRefreshSecret(secret)
+	if session.RefreshHash != oldHash {
+		return j.refreshPrevious(ctx, session, oldHash)
+	}
+
+	newSecret, err := newRefreshSecret()
+	if err != nil {
+		return nil, "", "", utils.CustomErrorf(err)
+	}
+	if err = j.Sessions.RotateSession(ctx, sessionId, oldHash, HashRefreshSecret(newSecret), j.refreshExpireDate(session.IsSaved), j.Clock.Now()); err != nil {
+		if !errors.Is(err, ErrRefreshReused) {
+			return nil, "", "", utils.CustomErrorf(err)
+		}
+		// 동시에 다른 요청이 먼저 교체한 경우
+		if session, err = j.Sessions.GetSession(ctx, sessionId); err != nil {
+			return nil, "", "", utils.CustomErrorf(err)
+		}
+		return j.refreshPrevious(ctx, session, oldHash)
+	}
+
+	jwtClaims = sessionClaims(session)
+	access, err = j.GenerateToken(jwtClaims)
+	if err != nil {
+		return nil, "", "", utils.CustomErrorf(err)
+	}
+
+	return jwtClaims, access, formatRefreshToken(sessionId, newSecret), nil
+}
+
+// 현재 refresh token이 아닌 토큰으로 재발급 요청한 경우
+// 직전 refresh token이고 교체한 지 RefreshReuseGrace 이내면 access token만 재발급하고, 그 외는 세션을 폐기한다.
+func (j *JWTUtils) refreshPrevious(ctx context.Context, session Session, hash string) (*JWTClaims, string, string, error) {
+	if session.RevokeDate.IsZero() && session.PrevRefreshHash == hash && j.Clock.Now().Sub(session.RotateDate) <= RefreshReuseGrace {
+		jwtClaims := sessionClaims(session)
+		access, err := j.GenerateToken(jwtClaims)
+		if err != nil {
+			return nil, "", "", utils.CustomErrorf(err)
+		}
+		return jwtClaims, access, "", nil
+	}
+
+	_ = j.Sessions.RevokeSession(ctx, session.SessionId)
+	return nil, "", "", utils.CustomErrorf(ErrRefreshReused)
+}
+
+func sessionClaims(session Session) *JWTClaims {
+	return &JWTClaims{
+		Uno:       session.Uno,
+		UserId:    session.UserId,
+		UserName:  session.UserName,
+		Role:      session.Role,
+		SessionId: session.SessionId,
+		IsSaved:   session.IsSaved,
+	}
+}
+
+// 세션 폐기 (로그아웃)
+func (j *JWTUtils) RevokeSession(ctx context.Context, sessionId string) error {
+	if err := j.Sessions.RevokeSession(ctx, sessionId); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 사용자의 모든 세션 폐기 (모든 기기 로그아웃, 권한 변경)
+func (j *JWTUtils) RevokeUserSessions(ctx context.Context, uno int64) error {
+	if err := j.Sessions.RevokeUserSessions(ctx, uno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+func (j *JWTUtils) refreshExpireDate(isSaved bool) time.Time {
+	if isSaved {
+		return j.Clock.Now().Add(SavedRefreshTokenTTL)
+	}
+	return j.Clock.Now().Add(RefreshTokenTTL)
+}
+
 // 토큰 유효성 검사
 func (j *JWTUtils) ValidateJWT(r *http.Request) (*JWTClaims, error) {
 	// 쿠키 읽기
@@ -114,23 +329,7 @@ func (j *JWTUtils) ValidateJWT(r *http.Request) (*JWTClaims, error) {
 	tokenString := cookie.Value
 
 	// 토큰 파싱 및 검증
-	parseToken, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// 서명 방법 확인
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, utils.CustomMessageErrorf("unexpected signing method", fmt.Errorf("%v", token.Header["alg"]))
-		}
-
-		// "아이디 저장" 여부 확인 후 적절한 키 반환
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return nil, utils.CustomErrorf(fmt.Errorf("invalid token claims"))
-		}
-
-		if isSaved, ok := claims["isSaved"].(bool); ok && isSaved {
-			return []byte(j.Cfg.SavedSecretKey), nil // "아이디 저장"한 경우 SavedSecretKey 사용
-		}
-		return []byte(j.Cfg.SecretKey), nil
-	})
+	parseToken, err := jwt.Parse(tokenString, j.verificationKey)
 	if err != nil {
 		return nil, utils.CustomMessageErrorf("jwtUtils.go/invalid token", err)
 	}
@@ -149,6 +348,20 @@ func (j *JWTUtils) ValidateJWT(r *http.Request) (*JWTClaims, error) {
 		IsSaved:  claims["isSaved"].(bool), // "아이디 저장" 여부 확인
 	}
 
+	// 폐기된 세션 확인
+	sessionId, _ := claims["sid"].(string)
+	if sessionId == "" {
+		return nil, utils.CustomErrorf(ErrSessionNotFound)
+	}
+	revoked, err := j.Sessions.IsRevoked(r.Context(), sessionId)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if revoked {
+		return nil, utils.CustomErrorf(ErrSessionRevoked)
+	}
+	jwtClaims.SessionId = sessionId
+
 	// 역할(Role) 처리
 	roleVal, exists := claims["role"]
 	if exists && roleVal != nil {
@@ -173,15 +386,18 @@ func (j *JWTUtils) FillContext(r *http.Request) (*http.Request, *JWTClaims, erro
 		return nil, &JWTClaims{}, err
 	}
 
-	// claims 데이터 context에 저장
+	return WithClaims(r, claims), claims, nil
+}
+
+// claims 데이터를 context에 저장한 요청 반환
+func WithClaims(r *http.Request, claims *JWTClaims) *http.Request {
 	ctx := SetContext(r.Context(), UserId{}, claims.UserId)
 	ctx = SetContext(ctx, Role{}, string(claims.Role))
 	ctx = SetContext(ctx, Uno{}, strconv.FormatInt(claims.Uno, 10))
 	ctx = SetContext(ctx, UserName{}, claims.UserName)
+	ctx = SetContext(ctx, SessionId{}, claims.SessionId)
 
-	httpRequestClone := r.Clone(ctx)
-
-	return httpRequestClone, claims, nil
+	return r.Clone(ctx)
 }
 
 func SetContext(ctx context.Context, key interface{}, value string) context.Context {
@@ -193,10 +409,52 @@ func GetContext(ctx context.Context, key interface{}) (string, bool) {
 	return value, ok
 }
 
-// 쿠키 만료 시간 설정 (아이디 저장 여부에 따라)
+// 전체 프로젝트 조회 권한 여부 (RoleScopeMiddleware에서 context에 저장한 값)
+func GetIsRole(ctx context.Context) bool {
+	value, ok := GetContext(ctx, IsRole{})
+	return ok && value == "true"
+}
+
+// 요청 프로젝트(jno) 권한 여부 (RoleScopeMiddleware에서 context에 저장한 값)
+func GetIsMember(ctx context.Context) bool {
+	value, ok := GetContext(ctx, IsMember{})
+	return ok && value == "true"
+}
+
+// 역할만으로 전체 프로젝트 조회 권한이 있는지 확인
+func (r JWTRole) IsAdmin() bool {
+	switch r {
+	case SystemAdmin, SuperAdmin, Admin:
+		return true
+	}
+	return false
+}
+
+// 개인정보 항목 (마스킹 대상)
+const (
+	PiiPhone = "phone"  // 핸드폰번호
+	PiiRegNo = "reg_no" // 주민번호
+	PiiBirth = "birth"  // 생년월일
+)
+
+// 개인정보를 마스킹하지 않고 조회할 수 있는지 확인
+// 시스템 관리자는 모든 항목, 협력업체는 모두 마스킹, 그 외 역할은 주민번호만 마스킹
+// @param
+// - field: 개인정보 항목 (PiiPhone, PiiRegNo, PiiBirth)
+func (r JWTRole) CanViewPii(field string) bool {
+	switch r {
+	case SystemAdmin:
+		return true
+	case CoUser, CoManager:
+		return false
+	}
+	return field != PiiRegNo
+}
+
+// refresh token 쿠키 만료 시간 설정 (아이디 저장 여부에 따라)
 func GetCookieMaxAge(isSaved bool) int {
 	if isSaved {
-		return 60 * 60 * 24 * 30 // "아이디 저장"하면 30일 저장
+		return int(SavedRefreshTokenTTL.Seconds()) // "아이디 저장"하면 30일 저장
 	}
-	return 60 * 60 // "아이디 저장" 안 하면 1시간 후 만료
+	return int(RefreshTokenTTL.Seconds()) // "아이디 저장" 안 하면 1시간 후 만료
 }
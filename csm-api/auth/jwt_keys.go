@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"csm-api/utils"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+	ErrUnknownKid         = errors.New("unknown kid")
+)
+
+// 서명 키 목록 파일의 항목
+// - Kid: 키 아이디 (토큰 header의 kid)
+// - Alg: 서명 방식 (RS256, ES256, EdDSA)
+// - PrivateKey: 개인키 PEM 경로 (없으면 검증 전용 키)
+// - PublicKey: 공개키 PEM 경로 (개인키가 있으면 생략 가능)
+// - NotBefore: 서명에 사용하기 시작하는 시간
+// - NotAfter: 서명에 사용하지 않는 시간 (이후 AccessTokenTTL 동안은 검증에 사용)
+type keyFileEntry struct {
+	Kid        string    `json:"kid"`
+	Alg        string    `json:"alg"`
+	PrivateKey string    `json:"private_key"`
+	PublicKey  string    `json:"public_key"`
+	NotBefore  time.Time `json:"not_before"`
+	NotAfter   time.Time `json:"not_after"`
+}
+
+// 서명/검증 키
+type SigningKey struct {
+	Kid       string
+	Method    jwt.SigningMethod
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// 서명 키 목록 (NotBefore 순)
+type KeySet struct {
+	Keys []SigningKey
+}
+
+// func: 서명 키 목록 파일 로드
+// 경로가 상대경로이면 목록 파일 위치 기준으로 찾는다.
+// @param
+// - path: 서명 키 목록(json) 경로
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	var entries []keyFileEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	dir := filepath.Dir(path)
+	keySet := &KeySet{}
+	for _, entry := range entries {
+		key, err := loadSigningKey(dir, entry)
+		if err != nil {
+			return nil, utils.CustomMessageErrorf(fmt.Sprintf("kid %s", entry.Kid), err)
+		}
+		keySet.Keys = append(keySet.Keys, key)
+	}
+
+	sort.SliceStable(keySet.Keys, func(i, k int) bool {
+		return keySet.Keys[i].NotBefore.Before(keySet.Keys[k].NotBefore)
+	})
+	return keySet, nil
+}
+
+func loadSigningKey(dir string, entry keyFileEntry) (SigningKey, error) {
+	if entry.Kid == "" {
+		return SigningKey{}, utils.CustomErrorf(fmt.Errorf("kid is empty"))
+	}
+
+	key := SigningKey{
+		Kid:       entry.Kid,
+		Method:    jwt.GetSigningMethod(entry.Alg),
+		NotBefore: entry.NotBefore,
+		NotAfter:  entry.NotAfter,
+	}
+	switch key.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+	default:
+		return SigningKey{}, utils.CustomErrorf(fmt.Errorf("unsupported alg: %s", entry.Alg))
+	}
+
+	if entry.PrivateKey != "" {
+		data, err := os.ReadFile(resolvePath(dir, entry.PrivateKey))
+		if err != nil {
+			return SigningKey{}, utils.CustomErrorf(err)
+		}
+		if key.Private, err = parsePrivateKey(key.Method, data); err != nil {
+			return SigningKey{}, err
+		}
+		key.Public = key.Private.Public()
+	}
+
+	if entry.PublicKey != "" {
+		data, err := os.ReadFile(resolvePath(dir, entry.PublicKey))
+		if err != nil {
+			return SigningKey{}, utils.CustomErrorf(err)
+		}
+		if key.Public, err = parsePublicKey(key.Method, data); err != nil {
+			return SigningKey{}, err
+		}
+	}
+
+	if key.Public == nil {
+		return SigningKey{}, utils.CustomErrorf(fmt.Errorf("private_key or public_key is required"))
+	}
+	return key, nil
+}
+
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func parsePrivateKey(method jwt.SigningMethod, data []byte) (crypto.Signer, error) {
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		key, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+	case *jwt.SigningMethodECDSA:
+		key, err = jwt.ParseECPrivateKeyFromPEM(data)
+	case *jwt.SigningMethodEd25519:
+		var edKey crypto.PrivateKey
+		edKey, err = jwt.ParseEdPrivateKeyFromPEM(data)
+		if err == nil {
+			key = edKey.(ed25519.PrivateKey)
+		}
+	}
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return key, nil
+}
+
+func parsePublicKey(method jwt.SigningMethod, data []byte) (crypto.PublicKey, error) {
+	var (
+		key crypto.PublicKey
+		err error
+	)
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		key, err = jwt.ParseRSAPublicKeyFromPEM(data)
+	case *jwt.SigningMethodECDSA:
+		key, err = jwt.ParseECPublicKeyFromPEM(data)
+	case *jwt.SigningMethodEd25519:
+		key, err = jwt.ParseEdPublicKeyFromPEM(data)
+	}
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return key, nil
+}
+
+// func: 현재 서명에 사용할 키 조회
+// 개인키가 있고 NotBefore가 지난 키 중 가장 최근 키를 사용한다. (NotBefore로 교체 일정을 미리 등록)
+// @param
+// - now: 현재 시간
+func (k *KeySet) SigningKey(now time.Time) (SigningKey, error) {
+	for i := len(k.Keys) - 1; i >= 0; i-- {
+		key := k.Keys[i]
+		if key.Private == nil || now.Before(key.NotBefore) {
+			continue
+		}
+		if !key.NotAfter.IsZero() && !now.Before(key.NotAfter) {
+			continue
+		}
+		return key, nil
+	}
+	return SigningKey{}, utils.CustomErrorf(ErrSigningKeyNotFound)
+}
+
+// func: 검증 키 조회
+// 서명 중지(NotAfter) 후에도 발급된 토큰이 만료될 때까지(AccessTokenTTL) 검증에 사용한다.
+// @param
+// - kid: 토큰 header의 kid
+// - now: 현재 시간
+func (k *KeySet) VerificationKey(kid string, now time.Time) (SigningKey, error) {
+	for _, key := range k.VerificationKeys(now) {
+		if key.Kid == kid {
+			return key, nil
+		}
+	}
+	return SigningKey{}, utils.CustomErrorf(ErrUnknownKid)
+}
+
+// 현재 검증에 사용하는 키 목록 (JWKS로 공개)
+// 아직 서명에 사용하지 않는(NotBefore 전) 키도 미리 공개하여 교체 시점에 다른 서비스가 검증할 수 있게 한다.
+func (k *KeySet) VerificationKeys(now time.Time) []SigningKey {
+	keys := make([]SigningKey, 0, len(k.Keys))
+	for _, key := range k.Keys {
+		if !key.NotAfter.IsZero() && !now.Before(key.NotAfter.Add(AccessTokenTTL)) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// JWK (RFC 7517) 공개키
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWK Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// 현재 검증에 사용하는 공개키를 JWK Set으로 변환
+func (k *KeySet) JWKS(now time.Time) JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range k.VerificationKeys(now) {
+		jwk := JWK{Kid: key.Kid, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
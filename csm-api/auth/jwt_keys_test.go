@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PEM 파일 생성 (목록 파일 기준 상대경로 반환)
+func writePem(t *testing.T, dir string, name string, blockType string, der []byte) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+// 서명 키 항목 (privateKey가 false면 공개키만 등록)
+func signingKeyEntry(t *testing.T, dir string, kid string, alg string, privateKey bool, notBefore time.Time, notAfter time.Time) keyFileEntry {
+	t.Helper()
+	var signer crypto.Signer
+	switch alg {
+	case "ES256":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer = key
+	case "EdDSA":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer = key
+	default:
+		t.Fatalf("unsupported alg: %s", alg)
+	}
+
+	entry := keyFileEntry{Kid: kid, Alg: alg, NotBefore: notBefore, NotAfter: notAfter}
+	if privateKey {
+		der, err := x509.MarshalPKCS8PrivateKey(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry.PrivateKey = writePem(t, dir, kid+".key", "PRIVATE KEY", der)
+		return entry
+	}
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.PublicKey = writePem(t, dir, kid+".pub", "PUBLIC KEY", der)
+	return entry
+}
+
+// 서명 키 목록 파일을 로드한 키 목록
+func loadKeySet(t *testing.T, dir string, entries ...keyFileEntry) *KeySet {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "keys.json")
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keySet, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keySet
+}
+
+func TestKeySetSigningKey(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	keySet := loadKeySet(t, dir,
+		signingKeyEntry(t, dir, "v2", "EdDSA", true, now.Add(time.Hour), time.Time{}),
+		signingKeyEntry(t, dir, "v1", "ES256", true, now.Add(-2*time.Hour), now.Add(2*time.Hour)),
+		signingKeyEntry(t, dir, "ext", "ES256", false, now.Add(-time.Hour), time.Time{}),
+	)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		want    string
+		wantErr error
+	}{
+		{name: "공개키만 있는 키는 서명에 사용하지 않음", now: now, want: "v1"},
+		{name: "NotBefore가 지난 가장 최근 키", now: now.Add(90 * time.Minute), want: "v2"},
+		{name: "NotAfter가 지난 키는 사용하지 않음", now: now.Add(3 * time.Hour), want: "v2"},
+		{name: "사용 시작한 키가 없음", now: now.Add(-3 * time.Hour), wantErr: ErrSigningKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := keySet.SigningKey(tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SigningKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if key.Kid != tt.want {
+				t.Errorf("SigningKey() = %s, want %s", key.Kid, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeySetVerificationKey(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	keySet := loadKeySet(t, dir,
+		signingKeyEntry(t, dir, "v1", "ES256", true, now.Add(-2*time.Hour), now),
+		signingKeyEntry(t, dir, "v2", "EdDSA", true, now, time.Time{}),
+	)
+
+	tests := []struct {
+		name    string
+		kid     string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "서명 중지 후 access token 만료 전까지 검증", kid: "v1", now: now.Add(AccessTokenTTL - time.Second)},
+		{name: "서명 중지 후 access token 만료 시간이 지나면 검증하지 않음", kid: "v1", now: now.Add(AccessTokenTTL), wantErr: ErrUnknownKid},
+		{name: "현재 서명 키", kid: "v2", now: now.Add(AccessTokenTTL)},
+		{name: "없는 키 아이디", kid: "v9", now: now, wantErr: ErrUnknownKid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keySet.VerificationKey(tt.kid, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerificationKey(%s) error = %v, want %v", tt.kid, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTUtilsKeyRotation(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	keySet := loadKeySet(t, dir,
+		signingKeyEntry(t, dir, "v1", "ES256", true, now.Add(-time.Hour), now.Add(time.Minute)),
+		signingKeyEntry(t, dir, "v2", "EdDSA", true, now.Add(time.Minute), time.Time{}),
+	)
+
+	tests := []struct {
+		name    string
+		wait    time.Duration // v1로 서명한 뒤 검증까지 시간
+		wantErr error
+	}{
+		{name: "서명 키 교체 전", wait: 0},
+		{name: "교체 후 이전 키로 서명한 토큰", wait: 2 * time.Minute},
+		{name: "이전 키 검증 기간 종료", wait: time.Minute + AccessTokenTTL, wantErr: ErrUnknownKid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := &testClock{now: now}
+			j := &JWTUtils{Keys: keySet, Clock: c, Sessions: NewMemorySessionStore(c)}
+
+			access, _, err := j.IssueTokens(ctx, &JWTClaims{Uno: 1, UserId: "user", Role: User})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid := tokenKid(t, access); kid != "v1" {
+				t.Fatalf("token kid = %s, want v1", kid)
+			}
+			c.now = now.Add(tt.wait)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(&http.Cookie{Name: "jwt", Value: access})
+			if _, err = j.ValidateJWT(r); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateJWT() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// 교체 후 새 토큰은 새 키로 서명한다.
+			if tt.wait > time.Minute {
+				token, err := j.GenerateToken(&JWTClaims{Uno: 1, UserId: "user", Role: User})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if kid := tokenKid(t, token); kid != "v2" {
+					t.Errorf("token kid = %s, want v2", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	keySet := loadKeySet(t, dir,
+		signingKeyEntry(t, dir, "retired", "ES256", true, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+		signingKeyEntry(t, dir, "v1", "ES256", true, now.Add(-time.Hour), time.Time{}),
+		signingKeyEntry(t, dir, "v2", "EdDSA", true, now.Add(time.Hour), time.Time{}),
+		signingKeyEntry(t, dir, "ext", "ES256", false, now.Add(-time.Hour), time.Time{}),
+	)
+
+	// NotBefore 순으로 공개
+	jwks := keySet.JWKS(now)
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		kid  string
+		kty  string
+	}{
+		{name: "현재 서명 키", kid: "v1", kty: "EC"},
+		{name: "검증 전용 키", kid: "ext", kty: "EC"},
+		{name: "서명 시작 전 키도 미리 공개", kid: "v2", kty: "OKP"},
+	}
+	if len(raw.Keys) != len(tests) {
+		t.Fatalf("JWKS() = %d keys, want %d (retired key must not be published)", len(raw.Keys), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := raw.Keys[i]
+			if key["kid"] != tt.kid || key["kty"] != tt.kty {
+				t.Errorf("JWKS() key = %v, want kid %s kty %s", key, tt.kid, tt.kty)
+			}
+			// 공개키 값만 포함 (개인키 d 없음)
+			if _, ok := key["d"]; ok {
+				t.Errorf("JWKS() key %s has private key", tt.kid)
+			}
+			for name := range key {
+				switch name {
+				case "kty", "kid", "use", "alg", "crv", "x", "y":
+				default:
+					t.Errorf("JWKS() key %s has unexpected member %s", tt.kid, name)
+				}
+			}
+		})
+	}
+}
+
+// 토큰 header의 kid
+func tokenKid(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
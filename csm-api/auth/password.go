@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"csm-api/utils"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 비밀번호 해시 방식
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hashed string, password string) bool
+	IsHash(hashed string) bool // 해당 방식으로 생성된 해시인지 확인
+}
+
+// 해시 이전에 저장된 비밀번호 비교 방식 (평문, MD5 등)
+type LegacyPassword func(stored string, password string) bool
+
+// 평문으로 저장된 비밀번호 비교 (협력업체 JOB_SUBCON_INFO.PW)
+func PlainPassword(stored string, password string) bool {
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+}
+
+// MD5 hex로 저장된 비밀번호 비교 (직원 V_BIZ_USER_INFO.USER_PWD)
+func MD5Password(stored string, password string) bool {
+	hash := md5.Sum([]byte(password))
+	return subtle.ConstantTimeCompare([]byte(strings.ToLower(stored)), []byte(hex.EncodeToString(hash[:]))) == 1
+}
+
+// 저장된 비밀번호 검증
+// - Hasher: 신규 저장(재해시)에 사용하는 방식
+// - Hashers: 검증 가능한 해시 방식 (Hasher 포함)
+// - Legacy: 해시가 아닌 값으로 저장된 경우 비교 방식 (nil이면 허용하지 않음)
+type CredentialVerifier struct {
+	Hasher  PasswordHasher
+	Hashers []PasswordHasher
+	Legacy  LegacyPassword
+}
+
+// 기본 검증기 생성 (bcrypt로 저장, bcrypt/argon2id 검증)
+func NewCredentialVerifier(legacy LegacyPassword) *CredentialVerifier {
+	hasher := BcryptHasher{Cost: bcrypt.DefaultCost}
+	return &CredentialVerifier{
+		Hasher:  hasher,
+		Hashers: []PasswordHasher{hasher, DefaultArgon2Hasher},
+		Legacy:  legacy,
+	}
+}
+
+// func: 비밀번호 검증
+// @param
+// - stored: 저장된 비밀번호(해시 또는 레거시 값)
+// - password: 입력 비밀번호
+// @return
+// - ok: 일치 여부
+// - rehash: 일치했지만 신규 방식으로 다시 저장해야 하는지 여부
+func (v *CredentialVerifier) Verify(stored string, password string) (ok bool, rehash bool) {
+	if stored == "" || password == "" {
+		return false, false
+	}
+
+	for _, hasher := range v.Hashers {
+		if hasher.IsHash(stored) {
+			return hasher.Verify(stored, password), false
+		}
+	}
+
+	if v.Legacy != nil && v.Legacy(stored, password) {
+		return true, true
+	}
+	return false, false
+}
+
+// 신규 방식으로 비밀번호 해시
+func (v *CredentialVerifier) Hash(password string) (string, error) {
+	return v.Hasher.Hash(password)
+}
+
+// bcrypt 해시
+type BcryptHasher struct {
+	Cost int
+}
+
+func (b BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return string(hashed), nil
+}
+
+func (b BcryptHasher) Verify(hashed string, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+}
+
+func (b BcryptHasher) IsHash(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") || strings.HasPrefix(hashed, "$2b$") || strings.HasPrefix(hashed, "$2y$")
+}
+
+// argon2id 해시 ($argon2id$v=19$m=65536,t=1,p=4$salt$hash 형식)
+type Argon2Hasher struct {
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen int
+}
+
+var DefaultArgon2Hasher = Argon2Hasher{Memory: 64 * 1024, Time: 1, Threads: 4, KeyLen: 32, SaltLen: 16}
+
+func (a Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, a.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.Memory, a.Time, a.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a Argon2Hasher) Verify(hashed string, password string) bool {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var (
+		memory, time uint32
+		threads      uint8
+	)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false
+	}
+
+	compare := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, compare) == 1
+}
+
+func (a Argon2Hasher) IsHash(hashed string) bool {
+	return strings.HasPrefix(hashed, "$argon2id$")
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCredentialVerifierVerify(t *testing.T) {
+	md5Hash := md5.Sum([]byte("password"))
+	argon2Hash, err := DefaultArgon2Hasher.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := NewCredentialVerifier(nil).Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		legacy     LegacyPassword
+		stored     string
+		password   string
+		wantOk     bool
+		wantRehash bool
+	}{
+		{name: "평문 일치시 재해시", legacy: PlainPassword, stored: "password", password: "password", wantOk: true, wantRehash: true},
+		{name: "평문 불일치", legacy: PlainPassword, stored: "password", password: "wrong", wantOk: false},
+		{name: "MD5 일치시 재해시", legacy: MD5Password, stored: hex.EncodeToString(md5Hash[:]), password: "password", wantOk: true, wantRehash: true},
+		{name: "MD5 대문자 저장", legacy: MD5Password, stored: strings.ToUpper(hex.EncodeToString(md5Hash[:])), password: "password", wantOk: true, wantRehash: true},
+		{name: "레거시 비교 방식이 없으면 평문 거부", legacy: nil, stored: "password", password: "password", wantOk: false},
+		{name: "bcrypt 해시는 재해시하지 않음", legacy: PlainPassword, stored: bcryptHash, password: "password", wantOk: true},
+		{name: "bcrypt 해시 불일치는 평문 비교하지 않음", legacy: PlainPassword, stored: bcryptHash, password: bcryptHash, wantOk: false},
+		{name: "argon2id 해시", legacy: PlainPassword, stored: argon2Hash, password: "password", wantOk: true},
+		{name: "argon2id 해시 불일치", legacy: PlainPassword, stored: argon2Hash, password: "wrong", wantOk: false},
+		{name: "저장된 비밀번호 없음", legacy: PlainPassword, stored: "", password: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash := NewCredentialVerifier(tt.legacy).Verify(tt.stored, tt.password)
+			if ok != tt.wantOk || rehash != tt.wantRehash {
+				t.Errorf("Verify() = (%v, %v), want (%v, %v)", ok, rehash, tt.wantOk, tt.wantRehash)
+			}
+		})
+	}
+}
+
+func TestCredentialVerifierUpgrade(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy LegacyPassword
+		stored string
+	}{
+		{name: "평문", legacy: PlainPassword, stored: "password"},
+		{name: "MD5", legacy: MD5Password, stored: "5f4dcc3b5aa765d61d8327deb882cf99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewCredentialVerifier(tt.legacy)
+			ok, rehash := verifier.Verify(tt.stored, "password")
+			if !ok || !rehash {
+				t.Fatalf("Verify(legacy) = (%v, %v), want (true, true)", ok, rehash)
+			}
+
+			// 로그인 성공시 저장하는 해시는 다음 로그인에서 재해시 없이 검증된다.
+			hashed, err := verifier.Hash("password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !verifier.Hasher.IsHash(hashed) {
+				t.Fatalf("Hash() = %q, want %T hash", hashed, verifier.Hasher)
+			}
+			if ok, rehash = verifier.Verify(hashed, "password"); !ok || rehash {
+				t.Errorf("Verify(hashed) = (%v, %v), want (true, false)", ok, rehash)
+			}
+			if ok, _ = verifier.Verify(hashed, "wrong"); ok {
+				t.Errorf("Verify(hashed, wrong) = true, want false")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"csm-api/utils"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// 라우트별 접근 권한 정책
+// - Method: http method ("*"인 경우 모든 method)
+// - Pattern: chi 라우트 패턴 ({param}은 한 구간, 마지막 "/*"는 하위 경로 전체)
+// - Roles: 접근 가능한 역할 (Roles, MenuId 모두 비어있으면 인증된 모든 사용자)
+// - MenuId: 메뉴 접근 권한(IRIS_USER_MENU)으로 허용할 메뉴 아이디
+type RoutePolicy struct {
+	Method  string
+	Pattern string
+	Roles   []JWTRole
+	MenuId  string
+}
+
+type RoutePolicies []RoutePolicy
+
+// 역할만으로 접근 가능한지 확인 (메뉴 권한은 호출하는 쪽에서 확인)
+func (p RoutePolicy) AllowRole(role JWTRole) bool {
+	if len(p.Roles) == 0 && p.MenuId == "" {
+		return true
+	}
+	return slices.Contains(p.Roles, role)
+}
+
+// func: 요청 method, path에 해당하는 정책 조회
+// 여러 정책이 일치하는 경우 구간이 더 많이 일치하는 정책, 같으면 method가 지정된 정책을 사용
+// @param
+// - method: http method
+// - path: 요청 경로 또는 chi 라우트 패턴
+func (ps RoutePolicies) Find(method string, path string) (RoutePolicy, bool) {
+	var (
+		found RoutePolicy
+		best  = -1
+	)
+	for _, policy := range ps {
+		if policy.Method != "*" && policy.Method != method {
+			continue
+		}
+		score, ok := matchPattern(policy.Pattern, path)
+		if !ok {
+			continue
+		}
+		score *= 2
+		if policy.Method != "*" {
+			score++
+		}
+		if score > best {
+			found, best = policy, score
+		}
+	}
+	return found, best >= 0
+}
+
+// func: 마운트된 모든 라우트에 정책이 있는지 확인 (서버 시작시 사용)
+// @param
+// - routes: 라우팅이 끝난 chi 라우터
+func (ps RoutePolicies) Validate(routes chi.Routes) error {
+	var missing []string
+	err := chi.Walk(routes, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if _, ok := ps.Find(method, route); !ok {
+			missing = append(missing, fmt.Sprintf("%s %s", method, route))
+		}
+		return nil
+	})
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if len(missing) > 0 {
+		return utils.CustomErrorf(fmt.Errorf("route policy not found: %s", strings.Join(missing, ", ")))
+	}
+	return nil
+}
+
+// 패턴과 경로를 구간별로 비교하여 일치 여부와 일치한 구간 수를 반환
+func matchPattern(pattern string, path string) (int, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if part == "*" && i == len(patternParts)-1 {
+			return i, true
+		}
+		if i >= len(pathParts) {
+			return 0, false
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != pathParts[i] {
+			return 0, false
+		}
+	}
+
+	if len(patternParts) != len(pathParts) {
+		return 0, false
+	}
+	return len(patternParts), true
+}
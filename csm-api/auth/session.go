@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"csm-api/utils"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session revoked")
+	ErrSessionExpired  = errors.New("session expired")
+	ErrRefreshReused   = errors.New("refresh token reused")
+	ErrInvalidRefresh  = errors.New("invalid refresh token")
+)
+
+// 로그인 세션 (refresh token 단위)
+// - RefreshHash: refresh token 비밀값의 sha256 hex (원문은 저장하지 않음)
+// - PrevRefreshHash: 직전 refresh token 비밀값의 sha256 hex (동시 재발급 요청 확인용)
+// - ExpireDate: refresh token 만료 시간 (갱신할 때마다 연장)
+// - RotateDate: 마지막 refresh token 교체 시간
+// - RevokeDate: 폐기 시간 (zero value면 유효)
+type Session struct {
+	SessionId       string
+	Uno             int64
+	UserId          string
+	UserName        string
+	Role            JWTRole
+	IsSaved         bool
+	RefreshHash     string
+	PrevRefreshHash string
+	ExpireDate      time.Time
+	RotateDate      time.Time
+	RevokeDate      time.Time
+}
+
+// 세션 저장소 (메모리 / Oracle)
+type SessionStore interface {
+	// 세션 생성
+	CreateSession(ctx context.Context, session Session) error
+	// 세션 조회 (없으면 ErrSessionNotFound)
+	GetSession(ctx context.Context, sessionId string) (Session, error)
+	// refresh token 교체 (oldHash는 직전 해시로 보관). 저장된 해시가 oldHash와 다르면 ErrRefreshReused
+	RotateSession(ctx context.Context, sessionId string, oldHash string, newHash string, expireDate time.Time, rotateDate time.Time) error
+	// 세션 폐기
+	RevokeSession(ctx context.Context, sessionId string) error
+	// 사용자의 모든 세션 폐기
+	RevokeUserSessions(ctx context.Context, uno int64) error
+	// 폐기(또는 존재하지 않는) 세션인지 확인
+	IsRevoked(ctx context.Context, sessionId string) (bool, error)
+	// before 이전에 만료, 폐기된 세션 삭제
+	PurgeSessions(ctx context.Context, before time.Time) (int64, error)
+}
+
+// 세션 아이디 생성
+func NewSessionId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// refresh token 비밀값 생성
+func newRefreshSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// refresh token 비밀값 해시
+func HashRefreshSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// refresh token 형식: {세션아이디}.{비밀값}
+func formatRefreshToken(sessionId string, secret string) string {
+	return fmt.Sprintf("%s.%s", sessionId, secret)
+}
+
+func parseRefreshToken(token string) (sessionId string, secret string, err error) {
+	sessionId, secret, ok := strings.Cut(token, ".")
+	if !ok || sessionId == "" || secret == "" {
+		return "", "", utils.CustomErrorf(ErrInvalidRefresh)
+	}
+	return sessionId, secret, nil
+}
+
+// refresh token의 세션 아이디 (로그아웃시 사용)
+func RefreshSessionId(token string) (string, error) {
+	sessionId, _, err := parseRefreshToken(token)
+	return sessionId, err
+}
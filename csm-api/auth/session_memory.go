@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"csm-api/clock"
+	"csm-api/utils"
+	"sync"
+	"time"
+)
+
+// 메모리 세션 저장소 (단일 서버, 로컬 개발용)
+// 서버 재시작시 모든 세션이 사라진다.
+type MemorySessionStore struct {
+	Clock    clock.Clocker
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+func NewMemorySessionStore(c clock.Clocker) *MemorySessionStore {
+	return &MemorySessionStore{
+		Clock:    c,
+		sessions: make(map[string]Session),
+	}
+}
+
+func (m *MemorySessionStore) CreateSession(ctx context.Context, session Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session.SessionId] = session
+	return nil
+}
+
+func (m *MemorySessionStore) GetSession(ctx context.Context, sessionId string) (Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, ok := m.sessions[sessionId]
+	if !ok {
+		return Session{}, utils.CustomErrorf(ErrSessionNotFound)
+	}
+	return session, nil
+}
+
+func (m *MemorySessionStore) RotateSession(ctx context.Context, sessionId string, oldHash string, newHash string, expireDate time.Time, rotateDate time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionId]
+	if !ok {
+		return utils.CustomErrorf(ErrSessionNotFound)
+	}
+	if session.RefreshHash != oldHash {
+		return utils.CustomErrorf(ErrRefreshReused)
+	}
+
+	session.PrevRefreshHash = oldHash
+	session.RefreshHash = newHash
+	session.ExpireDate = expireDate
+	session.RotateDate = rotateDate
+	m.sessions[sessionId] = session
+	return nil
+}
+
+func (m *MemorySessionStore) RevokeSession(ctx context.Context, sessionId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, ok := m.sessions[sessionId]; ok && session.RevokeDate.IsZero() {
+		session.RevokeDate = m.Clock.Now()
+		m.sessions[sessionId] = session
+	}
+	return nil
+}
+
+func (m *MemorySessionStore) RevokeUserSessions(ctx context.Context, uno int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.Clock.Now()
+	for id, session := range m.sessions {
+		if session.Uno == uno && session.RevokeDate.IsZero() {
+			session.RevokeDate = now
+			m.sessions[id] = session
+		}
+	}
+	return nil
+}
+
+func (m *MemorySessionStore) IsRevoked(ctx context.Context, sessionId string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, ok := m.sessions[sessionId]
+	return !ok || !session.RevokeDate.IsZero(), nil
+}
+
+func (m *MemorySessionStore) PurgeSessions(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for id, session := range m.sessions {
+		if session.ExpireDate.Before(before) || (!session.RevokeDate.IsZero() && session.RevokeDate.Before(before)) {
+			delete(m.sessions, id)
+			count++
+		}
+	}
+	return count, nil
+}
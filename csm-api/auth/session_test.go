@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"csm-api/config"
+	"errors"
+	"testing"
+	"time"
+)
+
+// 테스트용 시계 (now를 옮겨가며 사용)
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// 메모리 세션 저장소를 사용하는 JWTUtils
+func newTestJWTUtils() (*JWTUtils, *testClock) {
+	c := &testClock{now: time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)}
+	return &JWTUtils{
+		Cfg:      &config.JwtConfig{SecretKey: "secret", SavedSecretKey: "saved-secret"},
+		Clock:    c,
+		Sessions: NewMemorySessionStore(c),
+	}, c
+}
+
+func TestRefreshTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		wait        time.Duration // 교체 후 이전 refresh token을 다시 사용하기까지 시간
+		revoke      bool          // 재사용 전에 세션 폐기
+		wantErr     error
+		wantRevoked bool
+	}{
+		{name: "교체 직후 동시 요청", wait: time.Second},
+		{name: "유예 시간 경계", wait: RefreshReuseGrace},
+		{name: "유예 시간 이후 재사용은 세션 폐기", wait: RefreshReuseGrace + time.Second, wantErr: ErrRefreshReused, wantRevoked: true},
+		{name: "폐기된 세션의 토큰 재사용", wait: time.Second, revoke: true, wantErr: ErrSessionRevoked, wantRevoked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			j, c := newTestJWTUtils()
+
+			_, first, err := j.IssueTokens(ctx, &JWTClaims{Uno: 1, UserId: "user", Role: User})
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, _, second, err := j.RefreshTokens(ctx, first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if second == "" || second == first {
+				t.Fatalf("RefreshTokens() refresh = %q, want new token", second)
+			}
+
+			if tt.revoke {
+				if err = j.RevokeSession(ctx, claims.SessionId); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c.now = c.now.Add(tt.wait)
+
+			_, access, refresh, err := j.RefreshTokens(ctx, first)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RefreshTokens(reused) error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (access == "" || refresh != "") {
+				t.Errorf("RefreshTokens(reused) = (%q, %q), want access token only", access, refresh)
+			}
+
+			revoked, err := j.Sessions.IsRevoked(ctx, claims.SessionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.wantRevoked)
+			}
+
+			// 유예 시간 안의 재사용은 현재 refresh token에 영향을 주지 않는다.
+			if !tt.wantRevoked {
+				if _, _, _, err = j.RefreshTokens(ctx, second); err != nil {
+					t.Errorf("RefreshTokens(current) error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshTokensExpired(t *testing.T) {
+	ctx := context.Background()
+	j, c := newTestJWTUtils()
+
+	_, refresh, err := j.IssueTokens(ctx, &JWTClaims{Uno: 1, UserId: "user", Role: User})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.now = c.now.Add(RefreshTokenTTL)
+
+	if _, _, _, err = j.RefreshTokens(ctx, refresh); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("RefreshTokens() error = %v, want %v", err, ErrSessionExpired)
+	}
+}
+
+func TestSessionStoreRotate(t *testing.T) {
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		oldHash string
+		wantErr error
+	}{
+		{name: "현재 해시로 교체", oldHash: "hash1"},
+		{name: "이미 교체된 해시", oldHash: "hash0", wantErr: ErrRefreshReused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var sessions SessionStore = NewMemorySessionStore(&testClock{now: now})
+			if err := sessions.CreateSession(ctx, Session{SessionId: "sid", Uno: 1, RefreshHash: "hash1", PrevRefreshHash: "hash0", ExpireDate: now.Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := sessions.RotateSession(ctx, "sid", tt.oldHash, "hash2", now.Add(2*time.Hour), now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RotateSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			session, err := sessions.GetSession(ctx, "sid")
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantHash, wantPrev := "hash2", "hash1"
+			if tt.wantErr != nil {
+				wantHash, wantPrev = "hash1", "hash0"
+			}
+			if session.RefreshHash != wantHash || session.PrevRefreshHash != wantPrev {
+				t.Errorf("GetSession() hash = (%s, %s), want (%s, %s)", session.RefreshHash, session.PrevRefreshHash, wantHash, wantPrev)
+			}
+		})
+	}
+}
+
+func TestSessionStoreRevoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+	sessions := NewMemorySessionStore(&testClock{now: now})
+	for _, session := range []Session{
+		{SessionId: "a", Uno: 1, ExpireDate: now.Add(time.Hour)},
+		{SessionId: "b", Uno: 1, ExpireDate: now.Add(time.Hour)},
+		{SessionId: "c", Uno: 2, ExpireDate: now.Add(time.Hour)},
+		{SessionId: "expired", Uno: 3, ExpireDate: now.Add(-time.Hour)},
+	} {
+		if err := sessions.CreateSession(ctx, session); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sessions.RevokeUserSessions(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		sessionId string
+		want      bool
+	}{
+		{name: "사용자 세션 폐기", sessionId: "a", want: true},
+		{name: "같은 사용자의 다른 세션 폐기", sessionId: "b", want: true},
+		{name: "다른 사용자 세션은 유지", sessionId: "c", want: false},
+		{name: "없는 세션은 폐기로 처리", sessionId: "none", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sessions.IsRevoked(ctx, tt.sessionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRevoked(%s) = %v, want %v", tt.sessionId, got, tt.want)
+			}
+		})
+	}
+
+	// 만료 세션과 폐기 세션 삭제
+	count, err := sessions.PurgeSessions(ctx, now.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("PurgeSessions() = %d, want 3", count)
+	}
+}
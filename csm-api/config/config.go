@@ -16,8 +16,15 @@ type Config struct {
 	ExcelPath      string `env:"EXCEL_PATH" envDefault:"resources/excel"`
 	ConsoleLogPath string `env:"CONSOLE_LOG_PATH" envDefault:"logs/console"`
 	SecretKey      string `env:"SECRET_KEY" envDefault:"regno_secret_key"`
+	SessionStore   string `env:"SESSION_STORE" envDefault:"oracle"` // 로그인 세션 저장소 (oracle, memory)
+
+	// X-Forwarded-For를 신뢰할 프록시 (IP 또는 CIDR, 쉼표로 구분. 비어있으면 X-Forwarded-For를 사용하지 않음)
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
 }
 
+// SECRET_KEY 기본값 (운영 키가 설정되지 않은 상태)
+const DefaultSecretKey = "regno_secret_key"
+
 // caarlos0/env 패키지를 사용하여 struct의 envDefault값을 환경변수로 넘겨준다.
 func NewConfig() (*Config, error) {
 	cfg := &Config{}
@@ -26,3 +33,71 @@ func NewConfig() (*Config, error) {
 	}
 	return cfg, nil
 }
+
+// jwt 비대칭 서명 키 설정
+// - KeysFile: 서명 키 목록(json) 경로. 비어있으면 JwtConfig의 비밀 키로 HS256 서명
+type JwtKeyConfig struct {
+	KeysFile string `env:"JWT_KEYS_FILE" envDefault:""`
+}
+
+func GetJwtKeyConfig() (*JwtKeyConfig, error) {
+	cfg := &JwtKeyConfig{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return cfg, nil
+}
+
+// 주민번호 암호화 키 설정
+// - KeysFile: 데이터 키 목록(json) 경로 (필수, Config.SecretKey(v0)는 이전 암호문 복호화에만 사용)
+// - MasterKey: 데이터 키를 암호화한 마스터 키 (base64, 32 bytes)
+type RegNoKeyConfig struct {
+	KeysFile  string `env:"REGNO_KEYS_FILE" envDefault:""`
+	MasterKey string `env:"REGNO_MASTER_KEY" envDefault:""`
+}
+
+func GetRegNoKeyConfig() (*RegNoKeyConfig, error) {
+	cfg := &RegNoKeyConfig{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return cfg, nil
+}
+
+// 데이터 보관 기간 설정 (일, 0이면 기간 없이 보관)
+// - RetiredWorkerDays: 퇴직 근로자 개인정보 (퇴직일과 마지막 출근일 기준, 지나면 익명화)
+// - TbmFileDays, DeductionFileDays, WorkerFileDays: 업로드 엑셀 파일 (작업일 기준)
+// - CorrectionDays: 출퇴근 정정 요청 첨부파일 (요청일 기준)
+// - ArchivePath: 보관 기간이 지난 업로드 파일을 옮길 경로 (비어있으면 삭제)
+// - Execute: 스케줄러 실행 여부 (false면 dry-run 보고만 남김)
+type RetentionConfig struct {
+	RetiredWorkerDays int    `env:"RETENTION_RETIRED_WORKER_DAYS" envDefault:"1095"`
+	TbmFileDays       int    `env:"RETENTION_TBM_FILE_DAYS" envDefault:"365"`
+	DeductionFileDays int    `env:"RETENTION_DEDUCTION_FILE_DAYS" envDefault:"365"`
+	WorkerFileDays    int    `env:"RETENTION_WORKER_FILE_DAYS" envDefault:"365"`
+	CorrectionDays    int    `env:"RETENTION_CORRECTION_FILE_DAYS" envDefault:"365"`
+	ArchivePath       string `env:"RETENTION_ARCHIVE_PATH" envDefault:""`
+	Execute           bool   `env:"RETENTION_EXECUTE" envDefault:"false"`
+}
+
+func GetRetentionConfig() (*RetentionConfig, error) {
+	cfg := &RetentionConfig{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return cfg, nil
+}
+
+// 일일 근로자 비교 자동 대사 설정
+// - TimeDiffMinutes: 근태와 퇴직공제 출퇴근 시간 허용 차이(분), 넘으면 예외로 등록
+type ReconcileConfig struct {
+	TimeDiffMinutes int `env:"RECONCILE_TIME_DIFF_MINUTES" envDefault:"30"`
+}
+
+func GetReconcileConfig() (*ReconcileConfig, error) {
+	cfg := &ReconcileConfig{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return cfg, nil
+}
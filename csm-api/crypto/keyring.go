@@ -0,0 +1,300 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"csm-api/config"
+	"csm-api/utils"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+/**
+ * @description: 주민번호 등 개인정보 필드 암호화 (envelope encryption)
+ * - 데이터 키로 AES-GCM 암호화하고, 데이터 키는 마스터 키로 암호화하여 키 목록 파일에 보관한다.
+ * - 암호문은 "키아이디:base64(nonce|암호문)" 형식이며 키 아이디가 없는 암호문은 v0(Config.SecretKey)으로 복호화한다.
+ * - 키 교체: 키 목록 파일에 새 키를 NotBefore와 함께 등록하고 재암호화 배치를 실행한다. (이전 키는 복호화를 위해 남겨둔다)
+ */
+
+// 키 아이디가 없는 이전 암호문(entity.Worker.Encode 형식)의 키 아이디
+const LegacyKeyID = "v0"
+
+// 암호문의 키 아이디 구분자 (base64에는 ':'가 없다)
+const keyIDSeparator = ":"
+
+var (
+	ErrUnknownKeyID      = errors.New("unknown key id")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// 데이터 키 목록 파일의 항목
+// - KeyId: 키 아이디 (암호문 앞에 붙는다)
+// - WrappedKey: 마스터 키로 암호화한 데이터 키 (base64, WrapKey로 생성)
+// - NotBefore: 암호화에 사용하기 시작하는 시간
+type keyFileEntry struct {
+	KeyId      string    `json:"key_id"`
+	WrappedKey string    `json:"wrapped_key"`
+	NotBefore  time.Time `json:"not_before"`
+}
+
+// 데이터 키
+type DataKey struct {
+	ID        string
+	Key       []byte
+	NotBefore time.Time
+}
+
+// 데이터 키 목록 (NotBefore 순, 첫 번째는 v0)
+type Keyring struct {
+	Keys []DataKey
+}
+
+// func: 주민번호 암호화 키 목록 생성
+// 키 목록 파일(REGNO_KEYS_FILE)이 없거나 SECRET_KEY가 기본값이면 실행하지 않는다.
+// v0(Config.SecretKey)는 이전 암호문 복호화에만 사용하고, 암호화는 항상 키 목록 파일의 키로 한다.
+// @param
+// - cfg: SecretKey(v0 키)
+func NewRegNoKeyring(cfg *config.Config) (*Keyring, error) {
+	keyConfig, err := config.GetRegNoKeyConfig()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if keyConfig.KeysFile == "" {
+		return nil, utils.CustomErrorf(fmt.Errorf("REGNO_KEYS_FILE is not set"))
+	}
+	if cfg.SecretKey == "" || cfg.SecretKey == config.DefaultSecretKey {
+		return nil, utils.CustomErrorf(fmt.Errorf("SECRET_KEY is not set (default key is not allowed)"))
+	}
+
+	keyring, err := NewKeyring([]byte(cfg.SecretKey))
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(keyConfig.MasterKey)
+	if err != nil {
+		return nil, utils.CustomMessageErrorf("REGNO_MASTER_KEY", err)
+	}
+	if err = keyring.Load(keyConfig.KeysFile, masterKey); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if keyring.ActiveKey(time.Now()).ID == LegacyKeyID {
+		return nil, utils.CustomErrorf(fmt.Errorf("no data key is active in %s", keyConfig.KeysFile))
+	}
+	return keyring, nil
+}
+
+// func: v0 키만 있는 키 목록 생성
+// @param
+// - legacyKey: v0 키 (16, 24, 32 bytes)
+func NewKeyring(legacyKey []byte) (*Keyring, error) {
+	if _, err := aes.NewCipher(legacyKey); err != nil {
+		return nil, utils.CustomMessageErrorf(LegacyKeyID, err)
+	}
+	return &Keyring{Keys: []DataKey{{ID: LegacyKeyID, Key: legacyKey}}}, nil
+}
+
+// func: 데이터 키 목록 파일 로드
+// @param
+// - path: 데이터 키 목록(json) 경로
+// - masterKey: 데이터 키를 복호화할 마스터 키
+func (k *Keyring) Load(path string, masterKey []byte) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	var entries []keyFileEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	for _, entry := range entries {
+		if entry.KeyId == "" || entry.KeyId == LegacyKeyID || strings.Contains(entry.KeyId, keyIDSeparator) {
+			return utils.CustomErrorf(fmt.Errorf("invalid key_id: %q", entry.KeyId))
+		}
+		if _, err = k.key(entry.KeyId); err == nil {
+			return utils.CustomErrorf(fmt.Errorf("duplicate key_id: %s", entry.KeyId))
+		}
+
+		key, err := UnwrapKey(masterKey, entry.KeyId, entry.WrappedKey)
+		if err != nil {
+			return utils.CustomMessageErrorf(fmt.Sprintf("key_id %s", entry.KeyId), err)
+		}
+		k.Keys = append(k.Keys, DataKey{ID: entry.KeyId, Key: key, NotBefore: entry.NotBefore})
+	}
+
+	sort.SliceStable(k.Keys, func(i, j int) bool {
+		if k.Keys[i].ID == LegacyKeyID {
+			return true
+		}
+		if k.Keys[j].ID == LegacyKeyID {
+			return false
+		}
+		return k.Keys[i].NotBefore.Before(k.Keys[j].NotBefore)
+	})
+	return nil
+}
+
+// func: 현재 암호화에 사용할 키 조회
+// NotBefore가 지난 키 중 가장 최근 키를 사용한다. (없으면 v0)
+// @param
+// - now: 현재 시간
+func (k *Keyring) ActiveKey(now time.Time) DataKey {
+	for i := len(k.Keys) - 1; i > 0; i-- {
+		if !now.Before(k.Keys[i].NotBefore) {
+			return k.Keys[i]
+		}
+	}
+	return k.Keys[0]
+}
+
+func (k *Keyring) key(id string) (DataKey, error) {
+	for _, key := range k.Keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return DataKey{}, fmt.Errorf("%w: %s", ErrUnknownKeyID, id)
+}
+
+// func: 암호화
+// 현재 키로 암호화하고 키 아이디를 붙인다. (키 아이디를 AAD로 사용)
+// @param
+// - plain: 평문
+func (k *Keyring) Encrypt(plain string) (string, error) {
+	key := k.ActiveKey(time.Now())
+
+	aad := []byte(key.ID)
+	if key.ID == LegacyKeyID {
+		aad = nil
+	}
+	sealed, err := seal(key.Key, []byte(plain), aad)
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return key.ID + keyIDSeparator + sealed, nil
+}
+
+// func: 복호화
+// @param
+// - ciphertext: Encrypt 결과 또는 키 아이디가 없는 v0 암호문
+func (k *Keyring) Decrypt(ciphertext string) (string, error) {
+	id := KeyID(ciphertext)
+	key, err := k.key(id)
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+
+	aad := []byte(id)
+	if id == LegacyKeyID {
+		aad = nil
+	}
+	plain, err := open(key.Key, strings.TrimPrefix(ciphertext, id+keyIDSeparator), aad)
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return string(plain), nil
+}
+
+// func: 재암호화가 필요한지 확인 (현재 키로 암호화되지 않은 암호문)
+// @param
+// - ciphertext: 암호문
+func (k *Keyring) NeedsRotation(ciphertext string) bool {
+	return KeyID(ciphertext) != k.ActiveKey(time.Now()).ID
+}
+
+// func: 암호문의 키 아이디 조회
+// 키 아이디가 없으면 v0
+// @param
+// - ciphertext: 암호문
+func KeyID(ciphertext string) string {
+	if id, _, ok := strings.Cut(ciphertext, keyIDSeparator); ok {
+		return id
+	}
+	return LegacyKeyID
+}
+
+// func: 데이터 키 암호화 (키 목록 파일의 wrapped_key 생성)
+// @param
+// - masterKey: 마스터 키 (32 bytes)
+// - keyID: 데이터 키 아이디 (AAD로 사용하여 다른 항목으로 옮길 수 없다)
+// - dataKey: 데이터 키 (32 bytes)
+func WrapKey(masterKey []byte, keyID string, dataKey []byte) (string, error) {
+	if len(masterKey) != 32 || len(dataKey) != 32 {
+		return "", utils.CustomErrorf(fmt.Errorf("master key and data key must be 32 bytes"))
+	}
+	return seal(masterKey, dataKey, []byte(keyID))
+}
+
+// func: 데이터 키 복호화
+// @param
+// - masterKey: 마스터 키 (32 bytes)
+// - keyID: 데이터 키 아이디
+// - wrapped: WrapKey 결과
+func UnwrapKey(masterKey []byte, keyID string, wrapped string) ([]byte, error) {
+	if len(masterKey) != 32 {
+		return nil, utils.CustomErrorf(fmt.Errorf("master key must be 32 bytes"))
+	}
+	key, err := open(masterKey, wrapped, []byte(keyID))
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if len(key) != 32 {
+		return nil, utils.CustomErrorf(fmt.Errorf("data key must be 32 bytes"))
+	}
+	return key, nil
+}
+
+// AES-GCM 암호화: base64(nonce|암호문)
+func seal(key []byte, plain []byte, aad []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plain, aad)), nil
+}
+
+// AES-GCM 복호화: base64(nonce|암호문)
+func open(key []byte, encoded string, aad []byte) ([]byte, error) {
+	ct, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ct) < nonceSize {
+		return nil, fmt.Errorf("%w: ciphertext too short", ErrInvalidCiphertext)
+	}
+	plain, err := gcm.Open(nil, ct[:nonceSize], ct[nonceSize:], aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
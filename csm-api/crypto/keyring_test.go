@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var (
+	testMasterKey = bytes.Repeat([]byte{1}, 32)
+	testLegacyKey = []byte("0123456789abcdef")
+)
+
+// 데이터 키 목록 파일 생성
+func writeKeyFile(t *testing.T, entries []keyFileEntry) string {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 마스터 키로 암호화한 데이터 키 항목
+func keyEntry(t *testing.T, id string, fill byte, notBefore time.Time) keyFileEntry {
+	t.Helper()
+	wrapped, err := WrapKey(testMasterKey, id, bytes.Repeat([]byte{fill}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyFileEntry{KeyId: id, WrappedKey: wrapped, NotBefore: notBefore}
+}
+
+// 키 목록 파일을 로드한 키 목록
+func loadKeyring(t *testing.T, entries ...keyFileEntry) *Keyring {
+	t.Helper()
+	keyring, err := NewKeyring(testLegacyKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = keyring.Load(writeKeyFile(t, entries), testMasterKey); err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func TestKeyringEncryptDecrypt(t *testing.T) {
+	now := time.Now()
+	keyring := loadKeyring(t, keyEntry(t, "v1", 1, now.Add(-time.Hour)))
+
+	ciphertext, err := keyring.Encrypt("900101-1234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if KeyID(ciphertext) != "v1" {
+		t.Errorf("KeyID() = %s, want v1", KeyID(ciphertext))
+	}
+	plain, err := keyring.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "900101-1234567" {
+		t.Errorf("Decrypt() = %q, want %q", plain, "900101-1234567")
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	now := time.Now()
+	old := loadKeyring(t, keyEntry(t, "v1", 1, now.Add(-2*time.Hour)))
+	ciphertext, err := old.Encrypt("900101-1234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 새 키를 등록해도 이전 키로 복호화할 수 있고, 재암호화하면 새 키를 사용한다.
+	keyring := loadKeyring(t, keyEntry(t, "v1", 1, now.Add(-2*time.Hour)), keyEntry(t, "v2", 2, now.Add(-time.Hour)))
+	if !keyring.NeedsRotation(ciphertext) {
+		t.Fatalf("NeedsRotation(%s) = false, want true", KeyID(ciphertext))
+	}
+	plain, err := keyring.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := keyring.Encrypt(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if KeyID(rotated) != "v2" || keyring.NeedsRotation(rotated) {
+		t.Errorf("Encrypt() key id = %s, want v2", KeyID(rotated))
+	}
+}
+
+func TestKeyringActiveKey(t *testing.T) {
+	now := time.Now()
+	keyring := loadKeyring(t,
+		keyEntry(t, "v2", 2, now.Add(time.Hour)),
+		keyEntry(t, "v1", 1, now.Add(-time.Hour)),
+	)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{name: "사용 시작 전 키는 사용하지 않음", now: now, want: "v1"},
+		{name: "사용 시작한 가장 최근 키", now: now.Add(2 * time.Hour), want: "v2"},
+		{name: "사용 시작한 키가 없으면 v0", now: now.Add(-2 * time.Hour), want: LegacyKeyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyring.ActiveKey(tt.now).ID; got != tt.want {
+				t.Errorf("ActiveKey() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyringDecryptError(t *testing.T) {
+	now := time.Now()
+	keyring := loadKeyring(t, keyEntry(t, "v1", 1, now.Add(-time.Hour)))
+	ciphertext, err := keyring.Encrypt("900101-1234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		want       error
+	}{
+		{name: "없는 키 아이디", ciphertext: "v9:" + ciphertext[len("v1:"):], want: ErrUnknownKeyID},
+		{name: "base64 아님", ciphertext: "v1:%%%", want: ErrInvalidCiphertext},
+		{name: "너무 짧은 암호문", ciphertext: "v1:AAAA", want: ErrInvalidCiphertext},
+		{name: "변조된 암호문", ciphertext: ciphertext[:len(ciphertext)-4] + "AAAA", want: ErrInvalidCiphertext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keyring.Decrypt(tt.ciphertext); !errors.Is(err, tt.want) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyringLoadError(t *testing.T) {
+	now := time.Now()
+	moved := keyEntry(t, "v1", 1, now)
+	moved.KeyId = "v2"
+
+	tests := []struct {
+		name    string
+		entries []keyFileEntry
+	}{
+		{name: "v0 키 아이디", entries: []keyFileEntry{keyEntry(t, LegacyKeyID, 1, now)}},
+		{name: "구분자가 있는 키 아이디", entries: []keyFileEntry{keyEntry(t, "v1:a", 1, now)}},
+		{name: "중복 키 아이디", entries: []keyFileEntry{keyEntry(t, "v1", 1, now), keyEntry(t, "v1", 2, now)}},
+		{name: "다른 항목으로 옮긴 데이터 키", entries: []keyFileEntry{moved}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := NewKeyring(testLegacyKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = keyring.Load(writeKeyFile(t, tt.entries), testMasterKey); err == nil {
+				t.Errorf("Load() error = nil, want error")
+			}
+		})
+	}
+}
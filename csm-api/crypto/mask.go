@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"strings"
+)
+
+// func: 주민번호 마스킹
+// 생년월일과 뒷자리 첫번째(성별)만 남긴다. ex) 000101-1234567 -> 000101-1******
+// @param
+// - regNo: 주민번호 (' ', '-' 포함 가능)
+func MaskRegNo(regNo string) string {
+	cleaned := strings.NewReplacer(" ", "", "-", "").Replace(regNo)
+
+	switch {
+	case cleaned == "":
+		return ""
+	case len(cleaned) < 6:
+		return strings.Repeat("*", len(cleaned))
+	case len(cleaned) == 6:
+		return cleaned + "-*******"
+	}
+	return cleaned[:6] + "-" + cleaned[6:7] + "******"
+}
+
+// func: 마스킹된 주민번호인지 확인
+// @param
+// - regNo: 주민번호
+func IsMaskedRegNo(regNo string) bool {
+	return strings.Contains(regNo, "*")
+}
+
+// func: 핸드폰번호 마스킹
+// 가운데 자리를 가린다. ex) 010-1234-5678 -> 010-****-5678
+// 국번이 없는 번호(10자리 미만)는 끝 4자리만 남긴다. ex) 123-4567 -> ***-4567
+// @param
+// - phone: 핸드폰번호 ('-' 포함 가능)
+func MaskPhone(phone string) string {
+	cleaned := strings.NewReplacer(" ", "", "-", "").Replace(phone)
+
+	switch {
+	case len(cleaned) < 7:
+		return strings.Repeat("*", len(cleaned))
+	case len(cleaned) < 10:
+		return strings.Repeat("*", len(cleaned)-4) + "-" + cleaned[len(cleaned)-4:]
+	}
+	head, tail := cleaned[:3], cleaned[len(cleaned)-4:]
+	return head + "-" + strings.Repeat("*", len(cleaned)-7) + "-" + tail
+}
+
+// func: 마스킹된 핸드폰번호(아이디)인지 확인
+// @param
+// - phone: 핸드폰번호 또는 근로자 아이디
+func IsMaskedPhone(phone string) bool {
+	return strings.Contains(phone, "*")
+}
+
+// func: 생년월일 마스킹
+// 출생연도만 남긴다. ex) 000101 -> 00****, 00-01-01 -> 00-**-**
+// @param
+// - birth: 생년월일
+func MaskBirth(birth string) string {
+	masked := []rune(birth)
+	digits := 0
+	for i, r := range masked {
+		if r < '0' || r > '9' {
+			continue
+		}
+		if digits >= 2 {
+			masked[i] = '*'
+		}
+		digits++
+	}
+	return string(masked)
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import "testing"
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name string
+		mask func(string) string
+		in   string
+		want string
+	}{
+		{name: "주민번호", mask: MaskRegNo, in: "900101-1234567", want: "900101-1******"},
+		{name: "주민번호 공백, - 없음", mask: MaskRegNo, in: "9001011234567", want: "900101-1******"},
+		{name: "주민번호 앞자리만", mask: MaskRegNo, in: "900101", want: "900101-*******"},
+		{name: "짧은 주민번호", mask: MaskRegNo, in: "9001", want: "****"},
+		{name: "빈 주민번호", mask: MaskRegNo, in: "", want: ""},
+		{name: "핸드폰번호", mask: MaskPhone, in: "010-1234-5678", want: "010-****-5678"},
+		{name: "10자리 핸드폰번호", mask: MaskPhone, in: "0111235678", want: "011-***-5678"},
+		{name: "7자리 전화번호", mask: MaskPhone, in: "1234567", want: "***-4567"},
+		{name: "8자리 전화번호", mask: MaskPhone, in: "1588-1234", want: "****-1234"},
+		{name: "짧은 핸드폰번호", mask: MaskPhone, in: "12345", want: "*****"},
+		{name: "생년월일", mask: MaskBirth, in: "900101", want: "90****"},
+		{name: "생년월일 구분자", mask: MaskBirth, in: "90-01-01", want: "90-**-**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mask(tt.in); got != tt.want {
+				t.Errorf("mask(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMasked(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(string) bool
+		in    string
+		want  bool
+	}{
+		{name: "마스킹된 주민번호", check: IsMaskedRegNo, in: MaskRegNo("900101-1234567"), want: true},
+		{name: "주민번호", check: IsMaskedRegNo, in: "900101-1234567", want: false},
+		{name: "마스킹된 핸드폰번호", check: IsMaskedPhone, in: MaskPhone("010-1234-5678"), want: true},
+		{name: "핸드폰번호", check: IsMaskedPhone, in: "01012345678", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check(tt.in); got != tt.want {
+				t.Errorf("check(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
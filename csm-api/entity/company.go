@@ -71,7 +71,7 @@ type CompanyInfo struct {
 	Jno       null.Int    `json:"jno" db:"JNO"`
 	Cno       null.Int    `json:"cno" db:"CNO"`
 	Id        null.String `json:"id" db:"ID"`
-	Pw        null.String `json:"pw" db:"PW"`
+	Pw        null.String `json:"-" db:"PW"` // 로그인 검증용, 응답에 포함하지 않음
 	Cellphone null.String `json:"cellphone" db:"CELLPHONE"`
 	Email     null.String `json:"email" db:"EMAIL"`
 	UserName  null.String `json:"username" db:"USER_NAME"`
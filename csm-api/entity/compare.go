@@ -22,4 +22,45 @@ type Compare struct {
 	DeductionInTime  null.Time   `json:"deduction_in_time" db:"DEDUCTION_IN_TIME"`
 	DeductionOutTime null.Time   `json:"deduction_out_time" db:"DEDUCTION_OUT_TIME"`
 	DeductionBirth   null.String `json:"deduction_birth" db:"DEDUCTION_BIRTH"`
+
+	// TBM, 퇴직공제 매칭 결과
+	MatchScore   null.Float     `json:"match_score" db:"-"`   // 매칭한 원본 행 중 가장 낮은 점수
+	IsWeakMatch  null.String    `json:"is_weak_match" db:"-"` // Y: 매칭 점수가 CompareWeakMatchScore 미만
+	MatchSources []CompareMatch `json:"match_sources" db:"-"`
+}
+
+// 비교 매칭 구분
+const (
+	CompareMatchExact = "EXACT"  // 이름+부서 일치
+	CompareMatchRegNo = "REG_NO" // 핸드폰번호+주민번호 일치
+	CompareMatchFuzzy = "FUZZY"  // 이름/부서 정규화, 유사도 매칭
+)
+
+// 비교 매칭 원본
+const (
+	CompareSourceTbm       = "TBM"
+	CompareSourceDeduction = "DEDUCTION"
+)
+
+const CompareWeakMatchScore = 1.0 // 매칭 점수가 이 값보다 낮으면 약한 매칭 (정규화 후에도 이름/부서가 다른 경우)
+
+// 일일 근로자 비교 매칭 원본 행
+type CompareMatch struct {
+	Source     string      `json:"source"`     // TBM, DEDUCTION
+	MatchType  string      `json:"match_type"` // EXACT, REG_NO, FUZZY
+	Score      float64     `json:"score"`      // 0 ~ 1
+	UserNm     null.String `json:"user_nm"`
+	Department null.String `json:"department"`
+	RecordDate null.Time   `json:"record_date"`
+	Order      null.String `json:"order"` // TBM_ORDER, DEDUCT_ORDER
+}
+
+// 프로젝트별 부서 별칭 (IRIS_DEPARTMENT_ALIAS)
+// TBM, 퇴직공제 자료의 부서명(별칭)을 근로자 부서명으로 매칭한다.
+type DepartmentAlias struct {
+	Jno        null.Int    `json:"jno" db:"JNO"`
+	Alias      null.String `json:"alias" db:"ALIAS"`
+	Department null.String `json:"department" db:"DEPARTMENT"`
+	Base
 }
+type DepartmentAliases []*DepartmentAlias
@@ -0,0 +1,78 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 일일 근로자 비교 예외 구분
+const (
+	CompareExceptionNoTbm       = "NO_TBM"       // 근태 기록은 있으나 TBM 없음
+	CompareExceptionNoDeduction = "NO_DEDUCTION" // 근태 기록은 있으나 퇴직공제 없음
+	CompareExceptionNoIris      = "NO_IRIS"      // TBM, 퇴직공제는 있으나 근태 기록 없음
+	CompareExceptionTimeDiff    = "TIME_DIFF"    // 근태와 퇴직공제 출퇴근 시간 차이가 허용 범위 초과
+	CompareExceptionWeakMatch   = "WEAK_MATCH"   // 이름/부서 유사도로 매칭 (확인 필요)
+
+	CompareExceptionMissingTime = "MISSING_TIME" // 근태 또는 퇴직공제 출퇴근 시간 없음 (시간 비교 불가)
+)
+
+// 일일 근로자 비교 예외 처리 상태
+const (
+	CompareExceptionOpen      = "O" // 처리 대기
+	CompareExceptionResolved  = "R" // 처리 완료
+	CompareExceptionDismissed = "D" // 예외 아님
+	CompareExceptionClosed    = "C" // 다시 대사할 때 해소됨 (같은 예외가 다시 나오면 처리 대기로)
+)
+
+// 일일 근로자 비교 예외 (IRIS_COMPARE_EXCEPTION)
+// 자동 대사에서 자동 반영하지 못한 근로자를 프로젝트, 날짜, 구분, 근로자(EXCEPTION_KEY)별로 하나씩 관리한다.
+type CompareException struct {
+	Exno             null.Int    `json:"exno" db:"EXNO"`
+	Sno              null.Int    `json:"sno" db:"SNO"`
+	Jno              null.Int    `json:"jno" db:"JNO"`
+	RecordDate       null.Time   `json:"record_date" db:"RECORD_DATE"`
+	ExceptionType    null.String `json:"exception_type" db:"EXCEPTION_TYPE"`
+	ExceptionKey     null.String `json:"exception_key" db:"EXCEPTION_KEY"` // USER_KEY, 근태 기록이 없으면 이름|부서
+	UserKey          null.String `json:"user_key" db:"USER_KEY"`
+	UserNm           null.String `json:"user_nm" db:"USER_NM"`
+	Department       null.String `json:"department" db:"DEPARTMENT"`
+	Detail           null.String `json:"detail" db:"DETAIL"`
+	WorkerInTime     null.Time   `json:"worker_in_time" db:"WORKER_IN_TIME"`
+	WorkerOutTime    null.Time   `json:"worker_out_time" db:"WORKER_OUT_TIME"`
+	DeductionInTime  null.Time   `json:"deduction_in_time" db:"DEDUCTION_IN_TIME"`
+	DeductionOutTime null.Time   `json:"deduction_out_time" db:"DEDUCTION_OUT_TIME"`
+	MatchScore       null.Float  `json:"match_score" db:"MATCH_SCORE"`
+	Status           null.String `json:"status" db:"STATUS"`
+	AssigneeUno      null.Int    `json:"assignee_uno" db:"ASSIGNEE_UNO"`
+	AssigneeName     null.String `json:"assignee_name" db:"ASSIGNEE_NAME"`
+	ResolveUno       null.Int    `json:"resolve_uno" db:"RESOLVE_UNO"`
+	ResolveUser      null.String `json:"resolve_user" db:"RESOLVE_USER"`
+	ResolveDate      null.Time   `json:"resolve_date" db:"RESOLVE_DATE"`
+	ResolveNote      null.String `json:"resolve_note" db:"RESOLVE_NOTE"`
+	DetectDate       null.Time   `json:"detect_date" db:"DETECT_DATE"` // 마지막으로 대사에서 나온 시간
+	Base
+}
+type CompareExceptions []*CompareException
+
+// 일일 근로자 비교 예외 처리 요청
+type CompareExceptionReview struct {
+	Exnos        []int64     `json:"exnos"`
+	Status       string      `json:"status"` // R: 처리 완료, D: 예외 아님 (담당자 지정은 없음)
+	AssigneeUno  null.Int    `json:"assignee_uno"`
+	AssigneeName null.String `json:"assignee_name"`
+	Note         null.String `json:"note"`
+}
+
+// 일일 근로자 비교 자동 대사 대상 (TBM, 퇴직공제 업로드 후 대사하지 않은 프로젝트, 날짜)
+type CompareReconcileTarget struct {
+	Sno        null.Int  `json:"sno" db:"SNO"`
+	Jno        null.Int  `json:"jno" db:"JNO"`
+	RecordDate null.Time `json:"record_date" db:"RECORD_DATE"`
+}
+
+// 일일 근로자 비교 자동 대사 결과
+type CompareReconcileResult struct {
+	CompareReconcileTarget
+	AppliedCount   int `json:"applied_count"`   // 자동 반영 근로자 수
+	ExceptionCount int `json:"exception_count"` // 등록(갱신)한 예외 수
+	ClosedCount    int `json:"closed_count"`    // 해소된 예외 수
+
+	Error string `json:"error,omitempty"` // 대사 실패 사유 (실패한 대상은 다음 대사에서 다시 한다)
+}
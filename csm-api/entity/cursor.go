@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	CursorKey    = "cursor"
+	CountModeKey = "count_mode"
+
+	// 개수 추정(estimate) 시 최대로 세는 행 수
+	EstimateCountLimit = 10000
+	// cursor 페이징 한번에 조회 가능한 최대 행 수
+	MaxCursorRowSize = 1000
+)
+
+// keyset 페이징 커서
+// 마지막 행의 정렬 키 값을 JSON 배열로 인코딩한 뒤 base64(url)로 감싼 문자열을 클라이언트에 전달한다.
+// 값은 바인드 변수로만 사용하므로 클라이언트가 변조해도 다른 조건에는 영향이 없다.
+type PageCursor []json.RawMessage
+
+// func: 커서 생성
+// @param
+// - values: 마지막 행의 정렬 키 값 (keyset 순서)
+func EncodeCursor(values ...any) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// func: 커서 해석 (빈 값이면 첫 페이지로 nil 반환)
+func DecodeCursor(token string) (PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var cursor PageCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || len(cursor) == 0 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+	return cursor, nil
+}
+
+// func: 커서 값을 정렬 키 타입으로 변환
+// @param
+// - dest: 정렬 키 값을 받을 포인터 (keyset 순서)
+func (c PageCursor) Scan(dest ...any) error {
+	if len(c) != len(dest) {
+		return fmt.Errorf("%w: expected %d keys, got %d", ErrInvalidCursor, len(dest), len(c))
+	}
+	for i, raw := range c {
+		if err := json.Unmarshal(raw, dest[i]); err != nil {
+			return fmt.Errorf("%w: key %d: %v", ErrInvalidCursor, i, err)
+		}
+	}
+	return nil
+}
+
+// keyset 페이징 결과
+type CursorPage struct {
+	NextCursor string `json:"next_cursor"`
+	HasNext    bool   `json:"has_next"`
+}
+
+// func: keyset 페이징 결과 정리
+// 조회는 limit보다 1건 더 하므로, 남는 행이 있으면 잘라내고 마지막 행으로 다음 커서를 만든다.
+// @param
+// - list: limit + 1건까지 조회한 목록
+// - limit: 페이지 크기
+// - key: 행의 정렬 키 값
+func NextCursorPage[S ~[]T, T any](list S, limit int, key func(T) []any) (S, CursorPage, error) {
+	if len(list) <= limit {
+		return list, CursorPage{}, nil
+	}
+
+	list = list[:limit]
+	next, err := EncodeCursor(key(list[limit-1])...)
+	if err != nil {
+		return nil, CursorPage{}, err
+	}
+	return list, CursorPage{NextCursor: next, HasNext: true}, nil
+}
+
+// 목록 개수 조회 방식
+type CountMode string
+
+const (
+	CountExact    CountMode = "exact"    // 전체 개수 조회 (기본)
+	CountEstimate CountMode = "estimate" // EstimateCountLimit건까지만 조회
+	CountNone     CountMode = "none"     // 개수 조회 안함
+)
+
+// func: 개수 조회 방식 변환 (빈 값이면 exact)
+func ParseCountMode(mode string) (CountMode, error) {
+	switch CountMode(mode) {
+	case "", CountExact:
+		return CountExact, nil
+	case CountEstimate, CountNone:
+		return CountMode(mode), nil
+	}
+	return "", fmt.Errorf("invalid count mode: %q", mode)
+}
+
+// func: 개수 조회시 최대 행 수 (0: 제한 없음)
+// 추정 개수가 한도를 넘었는지 알 수 있도록 1건 더 센다.
+func (m CountMode) Limit() int {
+	if m == CountEstimate {
+		return EstimateCountLimit + 1
+	}
+	return 0
+}
+
+// 목록 개수
+// - Count: 개수 (none: 0, estimate: 최대 EstimateCountLimit)
+// - Exact: 전체 개수 여부
+type PageCount struct {
+	Count int  `json:"count"`
+	Exact bool `json:"count_exact"`
+}
+
+// func: 조회한 개수를 조회 방식에 맞게 변환
+func (m CountMode) Result(count int) PageCount {
+	if m == CountEstimate && count > EstimateCountLimit {
+		return PageCount{Count: EstimateCountLimit, Exact: false}
+	}
+	return PageCount{Count: count, Exact: m != CountNone}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 일일 마감 상태 (프로젝트, 날짜별)
+// 마감 전 → 현장 관리자 마감 요청 → 현장소장 승인 → 마감(근로자 기록 IS_DEADLINE = 'Y')
+const (
+	DailyCloseOpen      = "O" // 마감 전 (반려 포함)
+	DailyCloseSubmitted = "S" // 마감 요청
+	DailyCloseApproved  = "A" // 승인
+	DailyCloseLocked    = "L" // 마감
+)
+
+// 일일 마감 처리
+const (
+	DailyCloseSubmit  = "SUBMIT"  // 마감 요청 (마감 전 → 마감 요청)
+	DailyCloseApprove = "APPROVE" // 승인 (마감 요청 → 승인)
+	DailyCloseReject  = "REJECT"  // 반려 (마감 요청, 승인 → 마감 전)
+	DailyCloseLock    = "LOCK"    // 마감 (승인 → 마감)
+)
+
+// 일일 마감 (IRIS_DAILY_CLOSE)
+type DailyClose struct {
+	Jno         null.Int    `json:"jno" db:"JNO"`
+	RecordDate  null.Time   `json:"record_date" db:"RECORD_DATE"`
+	Status      null.String `json:"status" db:"STATUS"`
+	WorkerCount null.Int    `json:"worker_count" db:"WORKER_COUNT"` // 근로자 수
+	IssueCount  null.Int    `json:"issue_count" db:"ISSUE_COUNT"`   // 비교 미처리(COMPARE_STATE) 근로자 수
+	SubmitUno   null.Int    `json:"submit_uno" db:"SUBMIT_UNO"`
+	SubmitUser  null.String `json:"submit_user" db:"SUBMIT_USER"`
+	SubmitDate  null.Time   `json:"submit_date" db:"SUBMIT_DATE"`
+	ApproveUno  null.Int    `json:"approve_uno" db:"APPROVE_UNO"`
+	ApproveUser null.String `json:"approve_user" db:"APPROVE_USER"`
+	ApproveDate null.Time   `json:"approve_date" db:"APPROVE_DATE"`
+	LockDate    null.Time   `json:"lock_date" db:"LOCK_DATE"`
+	Reason      null.String `json:"reason" db:"REASON"` // 반려 사유
+	Base
+}
+
+type DailyCloses []*DailyClose
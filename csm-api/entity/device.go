@@ -3,16 +3,17 @@ package entity
 import "github.com/guregu/null"
 
 type Device struct {
-	RowNum   null.Int    `json:"rnum" db:"RNUM"`
-	Dno      null.Int    `json:"dno" db:"DNO"`             // 홍채인식기 고유번호
-	Sno      null.Int    `json:"sno" db:"SNO"`             // 현장 고유번호
-	DeviceSn null.String `json:"device_sn" db:"DEVICE_SN"` // 홍채인식기 시리얼번호
-	DeviceNm null.String `json:"device_nm" db:"DEVICE_NM"` // 홍채인식기 장치명
-	Jno      null.Int    `json:"jno" db:"JNO"`
-	JobName  null.String `json:"job_name" db:"JOB_NAME"`
-	Etc      null.String `json:"etc" db:"ETC"`       // 비고
-	IsUse    null.String `json:"is_use" db:"IS_USE"` // 사용여부
-	SiteNm   null.String `json:"site_nm" db:"SITE_NM"`
+	RowNum     null.Int    `json:"rnum" db:"RNUM"`
+	Dno        null.Int    `json:"dno" db:"DNO"`             // 홍채인식기 고유번호
+	Sno        null.Int    `json:"sno" db:"SNO"`             // 현장 고유번호
+	DeviceSn   null.String `json:"device_sn" db:"DEVICE_SN"` // 홍채인식기 시리얼번호
+	DeviceNm   null.String `json:"device_nm" db:"DEVICE_NM"` // 홍채인식기 장치명
+	Jno        null.Int    `json:"jno" db:"JNO"`
+	JobName    null.String `json:"job_name" db:"JOB_NAME"`
+	Etc        null.String `json:"etc" db:"ETC"`       // 비고
+	IsUse      null.String `json:"is_use" db:"IS_USE"` // 사용여부
+	SiteNm     null.String `json:"site_nm" db:"SITE_NM"`
+	ApiKeyHash null.String `json:"-" db:"API_KEY_HASH"` // api key 해시 (인식 기록 수신시 장치 인증)
 	Base
 }
 
@@ -28,6 +29,20 @@ type RecdLog struct {
 	LastName      null.String `json:"lastName" db:"LAST_NAME"`
 	Role          null.String `json:"role" db:"ROLE"`
 	Department    null.String `json:"department" db:"DEPARTMENT"`
+	RecogTime     null.Time   `json:"recogTime" db:"RECOG_TIME"` // 인식 시간 (없으면 수신 시간)
+}
+
+// 인식 기록 수신 결과
+type RecdIngestResult struct {
+	Received   int                `json:"received"`   // 받은 건수
+	Inserted   int                `json:"inserted"`   // 새로 저장한 건수
+	Duplicated int                `json:"duplicated"` // 이미 받은 transactionID
+	Rejected   []RecdIngestReject `json:"rejected"`   // 저장하지 않은 기록
+}
+
+type RecdIngestReject struct {
+	TransactionID null.Int `json:"transactionID"`
+	Reason        string   `json:"reason"`
 }
 
 type RecdLogOrigin struct {
@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 로그인 결과
+const (
+	LoginSuccess = "S" // 성공
+	LoginFail    = "F" // 실패
+	LoginLocked  = "L" // 잠금으로 거부
+)
+
+// 로그인 기록(IRIS_LOGIN_LOG)
+type LoginLog struct {
+	UserId    null.String `json:"user_id" db:"USER_ID"`
+	IsCompany null.String `json:"is_company" db:"IS_COMPANY"` // 협력업체 계정 여부(Y/N)
+	Ip        null.String `json:"ip" db:"IP"`
+	Result    null.String `json:"result" db:"RESULT"` // S: 성공, F: 실패, L: 잠금
+	Reason    null.String `json:"reason" db:"REASON"`
+	RegDate   null.Time   `json:"reg_date" db:"REG_DATE"`
+	RegAgent  null.String `json:"reg_agent" db:"REG_AGENT"`
+}
+
+// 최근 로그인 실패 횟수
+type LoginFailCount struct {
+	FailCount    int       `json:"fail_count" db:"FAIL_COUNT"`
+	LastFailDate null.Time `json:"last_fail_date" db:"LAST_FAIL_DATE"`
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidOrder = errors.New("invalid order")
+
+// 정렬 가능 필드 (json 이름 → 컬럼)
+// 컬럼은 ORDER BY에 그대로 사용되므로 코드에서 지정한 값만 사용한다.
+type OrderFields map[string]string
+
+// func: json 이름이 컬럼명의 소문자인 정렬 가능 필드 생성
+// @param
+// - columns: 정렬 가능 컬럼 ex) "USER_NM" → json 이름 "user_nm"
+func OrderColumns(columns ...string) OrderFields {
+	fields := make(OrderFields, len(columns))
+	for _, column := range columns {
+		fields[strings.ToLower(column)] = column
+	}
+	return fields
+}
+
+// func: 정렬 문자열을 ORDER BY 절로 변환
+// 필드는 json 이름(대소문자 무시), 방향은 ASC/DESC(생략시 ASC), NULLS FIRST/LAST를 허용한다.
+// 정렬 가능 필드에 없는 필드나 형식이 맞지 않으면 ErrInvalidOrder를 반환한다.
+// @param
+// - order: ex) "user_nm ASC, reg_date DESC NULLS LAST"
+// @return
+// - ex) "USER_NM ASC, REG_DATE DESC NULLS LAST" (order가 비어있으면 "")
+func (f OrderFields) Parse(order string) (string, error) {
+	if strings.TrimSpace(order) == "" {
+		return "", nil
+	}
+
+	var orders []string
+	for _, item := range strings.Split(order, ",") {
+		words := strings.Fields(item)
+		if len(words) == 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidOrder, order)
+		}
+
+		column, ok := f[strings.ToLower(words[0])]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown field %q", ErrInvalidOrder, words[0])
+		}
+
+		direction := "ASC"
+		rest := words[1:]
+		if len(rest) > 0 {
+			switch strings.ToUpper(rest[0]) {
+			case "ASC", "DESC":
+				direction = strings.ToUpper(rest[0])
+				rest = rest[1:]
+			}
+		}
+
+		nulls := ""
+		if len(rest) == 2 && strings.ToUpper(rest[0]) == "NULLS" {
+			switch strings.ToUpper(rest[1]) {
+			case "FIRST", "LAST":
+				nulls = " NULLS " + strings.ToUpper(rest[1])
+				rest = nil
+			}
+		}
+		if len(rest) > 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidOrder, item)
+		}
+
+		orders = append(orders, column+" "+direction+nulls)
+	}
+	return strings.Join(orders, ", "), nil
+}
+
+// func: 정렬 방향 변환 (RnumOrder)
+// @param
+// - direction: "ASC", "DESC" 또는 빈 값
+func ParseOrderDirection(direction string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(direction)) {
+	case "":
+		return "", nil
+	case "ASC":
+		return "ASC", nil
+	case "DESC":
+		return "DESC", nil
+	}
+	return "", fmt.Errorf("%w: direction %q", ErrInvalidOrder, direction)
+}
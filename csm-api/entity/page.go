@@ -16,6 +16,8 @@ type Page struct {
 	RowSize   int    `json:"row_size"`
 	Order     string `json:"order"`
 	RnumOrder string `json:"rnum_order"`
+	Cursor    string `json:"cursor"`     // keyset 페이징 커서 (빈 값: 첫 페이지)
+	UseCursor bool   `json:"use_cursor"` // keyset 페이징 사용 여부 (PageNum 대신 Cursor 사용)
 }
 
 type PageSql struct {
@@ -23,6 +25,9 @@ type PageSql struct {
 	EndNum    sql.NullInt64  `db:"row_size"`
 	Order     sql.NullString `db:"order"`
 	RnumOrder string         `db:"rnum_order"`
+	Cursor    PageCursor
+	UseCursor bool
+	Limit     int
 }
 
 type PageReq struct {
@@ -31,6 +36,9 @@ type PageReq struct {
 }
 
 func (s PageSql) OfPageSql(p Page) (PageSql, error) {
+	if p.UseCursor {
+		return s.ofCursorPageSql(p)
+	}
 
 	if p.PageNum != 0 && p.RowSize != 0 {
 		s.StartNum = sql.NullInt64{Valid: true, Int64: int64((p.PageNum - 1) * p.RowSize)}
@@ -49,3 +57,46 @@ func (s PageSql) OfPageSql(p Page) (PageSql, error) {
 
 	return s, nil
 }
+
+// func: keyset 페이징 변환
+// 정렬은 목록별 keyset 순서로 고정되므로 Order는 사용할 수 없다.
+// 다음 페이지 여부를 알기 위해 RowSize보다 1건 더 조회한다. (RNUM 1 ~ RowSize + 1)
+func (s PageSql) ofCursorPageSql(p Page) (PageSql, error) {
+	if p.RowSize <= 0 || p.RowSize > MaxCursorRowSize {
+		return PageSql{}, fmt.Errorf("RowSize must be between 1 and %d", MaxCursorRowSize)
+	}
+	if p.Order != "" {
+		return PageSql{}, fmt.Errorf("%w: order is not supported with cursor", ErrInvalidOrder)
+	}
+
+	cursor, err := DecodeCursor(p.Cursor)
+	if err != nil {
+		return PageSql{}, err
+	}
+
+	s.UseCursor = true
+	s.Cursor = cursor
+	s.Limit = p.RowSize
+	s.StartNum = sql.NullInt64{Valid: true, Int64: 0}
+	s.EndNum = sql.NullInt64{Valid: true, Int64: int64(p.RowSize + 1)}
+	s.Order = sql.NullString{Valid: false}
+	s.RnumOrder = ""
+
+	return s, nil
+}
+
+// func: 정렬 조건을 ORDER BY 절로 변환
+// 정렬 조건이 없으면 ""를 반환하므로 호출하는 쪽에서 기본 정렬을 사용한다.
+// @param
+// - fields: 정렬 가능 필드
+func (s PageSql) OrderBy(fields OrderFields) (string, error) {
+	if !s.Order.Valid {
+		return "", nil
+	}
+	return fields.Parse(s.Order.String)
+}
+
+// func: RNUM 정렬 방향 변환 (ASC, DESC)
+func (s PageSql) RnumOrderBy() (string, error) {
+	return ParseOrderDirection(s.RnumOrder)
+}
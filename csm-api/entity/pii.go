@@ -0,0 +1,62 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 개인정보 접근 구분
+const (
+	PiiAccessList   = "L" // 목록 조회 (마스킹하지 않은 항목이 있는 경우)
+	PiiAccessReveal = "R" // 근로자 단건 항목 조회 (reveal)
+)
+
+// 개인정보 접근 대상 (목록 조회)
+const (
+	PiiTargetWorkerTotal    = "WORKER_TOTAL"     // 전체 근로자
+	PiiTargetWorkerSiteBase = "WORKER_SITE_BASE" // 현장 근로자
+	PiiTargetCompare        = "COMPARE"          // 일일 근로자 비교
+	PiiTargetWorker         = "WORKER"           // 근로자 단건 (reveal)
+)
+
+// 개인정보 접근 기록 (IRIS_PII_ACCESS_LOG)
+type PiiAccessLog struct {
+	Lno        null.Int    `json:"lno" db:"LNO"`
+	Uno        null.Int    `json:"uno" db:"UNO"`
+	UserName   null.String `json:"user_name" db:"USER_NAME"`
+	Role       null.String `json:"role" db:"ROLE"`
+	AccessType null.String `json:"access_type" db:"ACCESS_TYPE"` // L: 목록 조회, R: 단건 조회
+	Target     null.String `json:"target" db:"TARGET"`
+	UserKey    null.String `json:"user_key" db:"USER_KEY"` // 단건 조회한 근로자
+	Fields     null.String `json:"fields" db:"FIELDS"`     // 마스킹하지 않은 항목 (',' 구분)
+	RowCount   null.Int    `json:"row_count" db:"ROW_COUNT"`
+	Reason     null.String `json:"reason" db:"REASON"`
+	RegDate    null.Time   `json:"reg_date" db:"REG_DATE"`
+	RegAgent   null.String `json:"reg_agent" db:"REG_AGENT"`
+}
+type PiiAccessLogs []*PiiAccessLog
+
+// 근로자 개인정보 단건 조회 요청
+type PiiReveal struct {
+	UserKey null.String `json:"user_key"`
+	Field   string      `json:"field"` // phone, reg_no, birth
+	Reason  null.String `json:"reason"`
+}
+
+// 근로자 개인정보 단건 조회 결과
+type PiiRevealResult struct {
+	UserKey string `json:"user_key"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+}
+
+// 사용자별 개인정보 접근 현황
+type PiiAccessReport struct {
+	Uno         null.Int    `json:"uno" db:"UNO"`
+	UserName    null.String `json:"user_name" db:"USER_NAME"`
+	Role        null.String `json:"role" db:"ROLE"`
+	ListCount   null.Int    `json:"list_count" db:"LIST_COUNT"`     // 목록 조회 횟수
+	ListRows    null.Int    `json:"list_rows" db:"LIST_ROWS"`       // 목록 조회 근로자 수
+	RevealCount null.Int    `json:"reveal_count" db:"REVEAL_COUNT"` // 단건 조회 횟수
+	RevealUsers null.Int    `json:"reveal_users" db:"REVEAL_USERS"` // 단건 조회한 근로자 수
+	FirstDate   null.Time   `json:"first_date" db:"FIRST_DATE"`
+	LastDate    null.Time   `json:"last_date" db:"LAST_DATE"`
+}
+type PiiAccessReports []*PiiAccessReport
@@ -3,15 +3,23 @@ package entity
 import "github.com/guregu/null"
 
 type ProjectSetting struct {
-	Jno           null.Int    `json:"jno" db:"JNO"`
-	InTime        null.Time   `json:"in_time" db:"IN_TIME"`           // 출근시간
-	OutTime       null.Time   `json:"out_time" db:"OUT_TIME"`         // 퇴근시간
-	RespiteTime   null.Int    `json:"respite_time" db:"RESPITE_TIME"` // 출/퇴근 유예시간(분)
-	CancelCode    null.String `json:"cancel_code" db:"CANCEL_CODE"`   // 마감취소가능기한 CODE
-	CancelDay     null.Int    `json:"cancel_day" db:"CANCEL_DAY"`
-	ManHours      *ManHours   `json:"man_hours"`                          // 공수 정보
-	Message       null.String `json:"message" db:"MESSAGE"`               // 로그 message
-	ChangeSetting null.String `json:"change_setting" db:"CHANGE_SETTING"` // 변경된 테이블
+	Jno           null.Int       `json:"jno" db:"JNO"`
+	InTime        null.Time      `json:"in_time" db:"IN_TIME"`           // 출근시간
+	OutTime       null.Time      `json:"out_time" db:"OUT_TIME"`         // 퇴근시간
+	RespiteTime   null.Int       `json:"respite_time" db:"RESPITE_TIME"` // 출/퇴근 유예시간(분)
+	CancelCode    null.String    `json:"cancel_code" db:"CANCEL_CODE"`   // 마감취소가능기한 CODE
+	CancelDay     null.Int       `json:"cancel_day" db:"CANCEL_DAY"`
+	ScanPolicy    null.String    `json:"scan_policy" db:"SCAN_POLICY"`       // 퇴근 인식 기준 (LAST: 마지막 인식, FIRST: 첫 인식)
+	MinScanGap    null.Int       `json:"min_scan_gap" db:"MIN_SCAN_GAP"`     // 중복 인식 무시 시간(분)
+	WeekendRate   null.Float     `json:"weekend_rate" db:"WEEKEND_RATE"`     // 주말 공수 배율
+	HolidayRate   null.Float     `json:"holiday_rate" db:"HOLIDAY_RATE"`     // 공휴일 공수 배율
+	HalfDayHour   null.Int       `json:"half_day_hour" db:"HALF_DAY_HOUR"`   // 반일(0.5 공수) 기준 근무 시간
+	AutoClose     null.String    `json:"auto_close" db:"AUTO_CLOSE"`         // 일일 자동 마감 여부 (Y: 매일 밤 자동 마감, N: 마감 요청/승인 후 마감)
+	ManHours      *ManHours      `json:"man_hours"`                          // 공수 정보
+	Shifts        *ProjectShifts `json:"shifts"`                             // 근무 시간대
+	Breaks        *ProjectBreaks `json:"breaks"`                             // 휴게 시간 (없으면 12:00 ~ 13:00)
+	Message       null.String    `json:"message" db:"MESSAGE"`               // 로그 message
+	ChangeSetting null.String    `json:"change_setting" db:"CHANGE_SETTING"` // 변경된 테이블
 	Base
 }
 
@@ -29,3 +37,68 @@ type ManHour struct {
 }
 
 type ManHours []*ManHour
+
+// 프로젝트 근무 시간대 (출퇴근 분류 규칙)
+// 종료 시간이 시작 시간 이전이면 다음날 끝나는 근무
+type ProjectShift struct {
+	Shno          null.Int    `json:"shno" db:"SHNO"`
+	Jno           null.Int    `json:"jno" db:"JNO"`
+	ShiftName     null.String `json:"shift_name" db:"SHIFT_NAME"`
+	StartTime     null.Time   `json:"start_time" db:"START_TIME"`         // 근무 시작시간
+	EndTime       null.Time   `json:"end_time" db:"END_TIME"`             // 근무 종료시간
+	InUntilTime   null.Time   `json:"in_until_time" db:"IN_UNTIL_TIME"`   // 첫 인식을 출근으로 보는 마지막 시간 (없으면 근무 시간의 중간)
+	Message       null.String `json:"message" db:"MESSAGE"`               // 로그 message
+	ChangeSetting null.String `json:"change_setting" db:"CHANGE_SETTING"` // 변경된 테이블
+	Base
+}
+
+type ProjectShifts []*ProjectShift
+
+// 프로젝트 휴게 시간 (공수 계산시 근무 시간에서 제외)
+type ProjectBreak struct {
+	Brno      null.Int    `json:"brno" db:"BRNO"`
+	Jno       null.Int    `json:"jno" db:"JNO"`
+	BreakName null.String `json:"break_name" db:"BREAK_NAME"`
+	StartTime null.Time   `json:"start_time" db:"START_TIME"` // 휴게 시작시간
+	EndTime   null.Time   `json:"end_time" db:"END_TIME"`     // 휴게 종료시간
+	Base
+}
+
+type ProjectBreaks []*ProjectBreak
+
+// 변경 이력 사유 구분: 공수 단계 변경 적용
+const ReasonTypeManHourApply = "15"
+
+// 공수 단계 변경 미리보기
+// 변경할 공수 단계로 다시 계산한 근로자/날짜별 공수와 합계
+type ManHourPreview struct {
+	Jno         null.Int           `json:"jno"`
+	Token       string             `json:"token"`        // 적용 요청 시 그대로 보내는 값 (미리보기 내용이 바뀌면 달라짐)
+	Count       int                `json:"count"`        // 재계산 대상 근로자 수
+	Changed     int                `json:"changed"`      // 공수가 바뀌는 근로자 수
+	BeforeTotal float64            `json:"before_total"` // 현재 공수 합계
+	AfterTotal  float64            `json:"after_total"`  // 재계산 공수 합계
+	Rows        ManHourPreviewRows `json:"rows"`
+}
+
+type ManHourPreviewRow struct {
+	Sno            null.Int    `json:"sno" db:"SNO"`
+	Jno            null.Int    `json:"jno" db:"JNO"`
+	UserKey        null.String `json:"user_key" db:"USER_KEY"`
+	UserNm         null.String `json:"user_nm" db:"USER_NM"`
+	RecordDate     null.Time   `json:"record_date" db:"RECORD_DATE"`
+	InRecogTime    null.Time   `json:"in_recog_time" db:"IN_RECOG_TIME"`
+	OutRecogTime   null.Time   `json:"out_recog_time" db:"OUT_RECOG_TIME"`
+	BeforeWorkHour null.Float  `json:"before_work_hour" db:"BEFORE_WORK_HOUR"` // 현재 공수
+	AfterWorkHour  null.Float  `json:"after_work_hour" db:"AFTER_WORK_HOUR"`   // 재계산 공수
+	IsChanged      bool        `json:"is_changed"`
+}
+
+type ManHourPreviewRows []*ManHourPreviewRow
+
+// 공수 단계 변경 적용 요청
+type ManHourApply struct {
+	Token    string   `json:"token"`     // 미리보기 token
+	ManHours ManHours `json:"man_hours"` // 미리보기한 공수 단계
+	Base
+}
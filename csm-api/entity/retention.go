@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// 보관 기간 대상 (업로드 파일은 FILE_TYPE: TBM, DEDUCTION, ADD_WORKER, ADD_DAILY_WORKER, CORRECTION)
+const RetentionRetiredWorker = "RETIRED_WORKER" // 퇴직 근로자 개인정보
+
+// 보관 기간이 지난 데이터 처리
+const (
+	RetentionAnonymize = "ANONYMIZE" // 개인정보 익명화 (출퇴근 기록은 유지)
+	RetentionPurge     = "PURGE"     // 파일 삭제
+	RetentionArchive   = "ARCHIVE"   // 보관 경로로 이동
+)
+
+// 대상별 보관 기간 처리 결과
+type RetentionClass struct {
+	Class          string    `json:"class"`
+	Action         string    `json:"action"`
+	Days           int       `json:"days"`            // 보관 기간(일)
+	Cutoff         time.Time `json:"cutoff"`          // 이 날짜 이전 데이터가 대상
+	TargetCount    int       `json:"target_count"`    // 대상 수
+	TargetBytes    int64     `json:"target_bytes"`    // 대상 파일 크기
+	ProcessedCount int       `json:"processed_count"` // 처리한 수 (dry-run은 0)
+	MissingCount   int       `json:"missing_count"`   // 이미 없는 파일 (처리 완료로 기록)
+	SkippedCount   int       `json:"skipped_count"`   // 업로드 경로 밖 파일 (처리하지 않음)
+}
+
+// 보관 기간 처리 보고
+type RetentionReport struct {
+	DryRun  bool             `json:"dry_run"`
+	RunDate time.Time        `json:"run_date"`
+	Classes []RetentionClass `json:"classes"`
+	Token   string           `json:"token"` // 실행 요청 시 그대로 보내는 값 (대상이 바뀌면 달라짐)
+}
+
+// 보관 기간 처리 실행 요청
+type RetentionApply struct {
+	Token string `json:"token"` // dry-run 보고 token
+}
@@ -2,6 +2,9 @@ package entity
 
 import "github.com/guregu/null"
 
+// 출퇴근 정정 요청 첨부파일 종류 (IRIS_UPLOADED_FILES.FILE_TYPE)
+const UploadFileCorrection = "CORRECTION"
+
 type UploadFile struct {
 	FileType    null.String `json:"file_type" db:"FILE_TYPE"`
 	FilePath    null.String `json:"file_path" db:"FILE_PATH"`
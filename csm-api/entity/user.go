@@ -69,3 +69,9 @@ func AuthorizationCheck(list RoleList, roles string) bool {
 	return check
 
 }
+
+// 사용자 조회 범위 권한
+type UserScope struct {
+	IsGlobal bool // 전체 프로젝트 조회 권한 (관리자 역할)
+	IsMember bool // 해당 프로젝트 권한 (IRIS_USER_ROLE_MAP, 현장소장/현장관리자, 안전관리자/관리감독자)
+}
@@ -1,13 +1,7 @@
 package entity
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
-	"errors"
 	"github.com/guregu/null"
-	"io"
 )
 
 type Worker struct {
@@ -33,12 +27,18 @@ type Worker struct {
 	RetireDate  null.Time   `json:"retire_date" db:"RETIRE_DATE"`
 	RecordDate  null.String `json:"record_date" db:"RECORD_DATE"`
 	RegNo       null.String `json:"reg_no" db:"REG_NO"`
+	RegNoEnc    null.String `json:"-" db:"REG_NO_ENC"` // 주민번호 암호문 (crypto.Keyring)
 	FailReason  null.String `json:"fail_reason" db:"FAIL_REASON"`
 	WorkerReason
 	Base
 }
 type Workers []*Worker
 
+// func: keyset 페이징 커서 값 (SNO, USER_KEY)
+func (w *Worker) CursorValues() []any {
+	return []any{w.Sno.Int64, w.UserKey.String}
+}
+
 type WorkerDaily struct {
 	RowNum          null.Int    `json:"rnum" db:"RNUM"`
 	IrisNo          null.Int    `json:"iris_no" db:"IRIS_NO"`
@@ -53,6 +53,7 @@ type WorkerDaily struct {
 	DiscName        null.String `json:"disc_name" db:"DISC_NAME"` // 공종명
 	Phone           null.String `json:"phone" db:"PHONE"`
 	RegNo           null.String `json:"reg_no" db:"REG_NO"`
+	RegNoEnc        null.String `json:"-" db:"REG_NO_ENC"`
 	RecordDate      null.Time   `json:"record_date" db:"RECORD_DATE"`
 	InRecogTime     null.Time   `json:"in_recog_time" db:"IN_RECOG_TIME"`   //출근시간
 	OutRecogTime    null.Time   `json:"out_recog_time" db:"OUT_RECOG_TIME"` //퇴근시간
@@ -74,10 +75,42 @@ type WorkerDaily struct {
 }
 type WorkerDailys []*WorkerDaily
 
+// func: keyset 페이징 커서 값 (RECORD_DATE, USER_KEY, SNO)
+func (w *WorkerDaily) CursorValues() []any {
+	return []any{w.RecordDate.Time, w.UserKey.String, w.Sno.Int64}
+}
+
+// 홍채인식기 데이터 반영 위치
+// 마지막으로 반영한 인식 기록 (RECOG_TIME, IRIS_NO 순서)
+type RecdWatermark struct {
+	JobName       string    `json:"job_name" db:"JOB_NAME"`
+	LastRecogTime null.Time `json:"last_recog_time" db:"LAST_RECOG_TIME"`
+	LastIrisNo    null.Int  `json:"last_iris_no" db:"LAST_IRIS_NO"`
+}
+
+// 철야 처리 상태
+const (
+	OverTimePending  = "P" // 승인 대기
+	OverTimeApproved = "A" // 승인
+	OverTimeRejected = "R" // 반려
+)
+
+// 철야 (출근한 날 출근 기록과 다음날 퇴근 기록 합치기)
 type WorkerOverTime struct {
-	BeforeCno    null.Int  `json:"before_cno" db:"BEFORE_CNO"`         // 출근한 날 CNO
-	AfterCno     null.Int  `json:"after_cno" db:"AFTER_CNO"`           // 퇴근한 날 CNO
-	OutRecogTime null.Time `json:"out_recog_time" db:"OUT_RECOG_TIME"` // 퇴근시간
+	Ono          null.Int    `json:"ono" db:"ONO"`
+	BeforeCno    null.Int    `json:"before_cno" db:"BEFORE_CNO"` // 출근한 날 CNO
+	AfterCno     null.Int    `json:"after_cno" db:"AFTER_CNO"`   // 퇴근한 날 CNO
+	Sno          null.Int    `json:"sno" db:"SNO"`
+	Jno          null.Int    `json:"jno" db:"JNO"`
+	UserKey      null.String `json:"user_key" db:"USER_KEY"`
+	UserId       null.String `json:"user_id" db:"USER_ID"`
+	UserNm       null.String `json:"user_nm" db:"USER_NM"`
+	RecordDate   null.Time   `json:"record_date" db:"RECORD_DATE"`       // 출근한 날
+	InRecogTime  null.Time   `json:"in_recog_time" db:"IN_RECOG_TIME"`   // 출근시간
+	OutRecogTime null.Time   `json:"out_recog_time" db:"OUT_RECOG_TIME"` // 퇴근시간
+	Status       null.String `json:"status" db:"STATUS"`                 // P: 승인 대기, A: 승인, R: 반려
+	Reason       null.String `json:"reason" db:"REASON"`                 // 승인/반려 사유
+	Base
 }
 type WorkerOverTimes []*WorkerOverTime
 
@@ -142,54 +175,25 @@ type WorkerReason struct {
 	HisName    null.String `json:"his_name" db:"HIS_NAME"`
 }
 
-func (c *Worker) Decode(encKey string, secretKey string) (string, error) {
-	key := []byte(secretKey)
-	ct, err := base64.StdEncoding.DecodeString(encKey)
-	if err != nil {
-		return "", err
-	}
-
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
-	}
-
-	nonceSize := gcm.NonceSize()
-	if len(ct) < nonceSize {
-		return "", errors.New("ciphertext too short")
-	}
-	nonce, data := ct[:nonceSize], ct[nonceSize:]
-
-	plain, err := gcm.Open(nil, nonce, data, nil)
-	if err != nil {
-		return "", err
-	}
-	return string(plain), nil
-}
-
-func (c *Worker) Encode(regNo string, secretKey string) (string, error) {
-	key := []byte(secretKey)
-
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	aesGCM, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
-	}
-
-	nonce := make([]byte, aesGCM.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", err
-	}
+// 마감취소 거절 사유
+const (
+	DeadlineCancelNotFound       = "NOT_FOUND"       // 근로자 기록 없음
+	DeadlineCancelNotDeadline    = "NOT_DEADLINE"    // 마감되지 않은 기록
+	DeadlineCancelExpired        = "EXPIRED"         // 마감취소 가능 기한 지남
+	DeadlineCancelReasonRequired = "REASON_REQUIRED" // 기한이 지난 기록을 취소하려면 사유 필요
+)
 
-	ciphertext := aesGCM.Seal(nonce, nonce, []byte(regNo), nil)
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+// 마감취소 사유 구분: 기한이 지난 기록을 현장소장, 시스템관리자가 취소
+const ReasonTypeDeadlineCancelOverride = "11"
+
+// 마감취소 거절 기록
+type DeadlineCancelReject struct {
+	Sno        null.Int    `json:"sno"`
+	Jno        null.Int    `json:"jno"`
+	UserKey    null.String `json:"user_key"`
+	RecordDate null.Time   `json:"record_date"`
+	CancelDay  null.Int    `json:"cancel_day"` // 프로젝트 마감취소 가능 기한(일)
+	Code       string      `json:"code"`
+	Message    string      `json:"message"`
 }
+type DeadlineCancelRejects []*DeadlineCancelReject
@@ -0,0 +1,49 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 출퇴근 정정 요청 처리 상태
+const (
+	CorrectionPending  = "P" // 승인 대기
+	CorrectionApproved = "A" // 승인
+	CorrectionRejected = "R" // 반려
+)
+
+// 변경 이력 사유 구분: 협력업체 정정 요청 승인
+const ReasonTypeCorrection = "12"
+
+// 출퇴근 정정 요청 (IRIS_WORKER_CORRECTION)
+// 협력업체가 현장 근로자 기록의 출퇴근 시간, 공수 변경을 요청하고 현장 관리자가 승인/반려한다.
+// Before* 는 요청 당시 기록이며 승인할 때 기록이 바뀌었으면 승인하지 않는다.
+type WorkerCorrection struct {
+	Crno               null.Int    `json:"crno" db:"CRNO"`
+	Sno                null.Int    `json:"sno" db:"SNO"`
+	Jno                null.Int    `json:"jno" db:"JNO"`
+	UserKey            null.String `json:"user_key" db:"USER_KEY"`
+	UserNm             null.String `json:"user_nm" db:"USER_NM"`
+	Department         null.String `json:"department" db:"DEPARTMENT"`
+	RecordDate         null.Time   `json:"record_date" db:"RECORD_DATE"`
+	BeforeInRecogTime  null.Time   `json:"before_in_recog_time" db:"BEFORE_IN_RECOG_TIME"`   // 요청 당시 출근시간
+	BeforeOutRecogTime null.Time   `json:"before_out_recog_time" db:"BEFORE_OUT_RECOG_TIME"` // 요청 당시 퇴근시간
+	BeforeWorkHour     null.Float  `json:"before_work_hour" db:"BEFORE_WORK_HOUR"`           // 요청 당시 공수
+	InRecogTime        null.Time   `json:"in_recog_time" db:"IN_RECOG_TIME"`                 // 정정 출근시간 (없으면 변경 안함)
+	OutRecogTime       null.Time   `json:"out_recog_time" db:"OUT_RECOG_TIME"`               // 정정 퇴근시간 (없으면 변경 안함)
+	WorkHour           null.Float  `json:"work_hour" db:"WORK_HOUR"`                         // 정정 공수 (없으면 변경 안함)
+	Reason             null.String `json:"reason" db:"REASON"`                               // 요청 사유
+	FilePath           null.String `json:"-" db:"FILE_PATH"`                                 // 첨부파일 경로
+	FileName           null.String `json:"file_name" db:"FILE_NAME"`                         // 첨부파일명
+	Status             null.String `json:"status" db:"STATUS"`
+	ReviewUno          null.Int    `json:"review_uno" db:"REVIEW_UNO"`
+	ReviewUser         null.String `json:"review_user" db:"REVIEW_USER"`
+	ReviewDate         null.Time   `json:"review_date" db:"REVIEW_DATE"`
+	ReviewReason       null.String `json:"review_reason" db:"REVIEW_REASON"` // 반려 사유
+	Base
+}
+
+type WorkerCorrections []*WorkerCorrection
+
+// 출퇴근 정정 요청 일괄 승인/반려
+type WorkerCorrectionReview struct {
+	Crnos  []int64     `json:"crnos"`
+	Reason null.String `json:"reason"` // 반려 사유
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 중복 근로자 처리 상태 (IRIS_WORKER_MERGE)
+const (
+	WorkerMergeMerged    = "M" // 병합
+	WorkerMergeDismissed = "D" // 중복 아님
+)
+
+// 변경 이력 사유 구분: 중복 근로자 병합
+const ReasonTypeWorkerMerge = "14"
+
+// 중복 근로자 비교 정보 (현장 근로자)
+// 주민번호는 생년월일(앞 6자리)만 조회한다.
+type WorkerIdentity struct {
+	UserKey        null.String `json:"user_key" db:"USER_KEY"`
+	Sno            null.Int    `json:"sno" db:"SNO"`
+	UserId         null.String `json:"user_id" db:"USER_ID"`
+	UserNm         null.String `json:"user_nm" db:"USER_NM"`
+	Phone          null.String `json:"phone" db:"PHONE"`
+	Department     null.String `json:"department" db:"DEPARTMENT"`
+	Birth          null.String `json:"birth" db:"BIRTH"`                       // 생년월일 (주민번호 앞자리)
+	RegNoEnc       null.String `json:"-" db:"REG_NO_ENC"`                      // 주민번호 암호문
+	DailyCount     null.Int    `json:"daily_count" db:"DAILY_COUNT"`           // 출퇴근 기록 수
+	LastRecordDate null.Time   `json:"last_record_date" db:"LAST_RECORD_DATE"` // 마지막 출퇴근일
+	RegDate        null.Time   `json:"reg_date" db:"REG_DATE"`
+}
+type WorkerIdentities []*WorkerIdentity
+
+// 중복 근로자 후보
+// Worker는 남길 근로자(출퇴근 기록이 많은 근로자), Duplicate는 병합할 근로자
+type WorkerDuplicate struct {
+	Score     int             `json:"score"`
+	Reasons   []string        `json:"reasons"` // 일치 항목 (name, phone, birth, department)
+	Worker    *WorkerIdentity `json:"worker"`
+	Duplicate *WorkerIdentity `json:"duplicate"`
+}
+type WorkerDuplicates []*WorkerDuplicate
+
+// 중복 근로자 병합/중복 아님 처리 (IRIS_WORKER_MERGE)
+type WorkerMerge struct {
+	Mno           null.Int    `json:"mno" db:"MNO"`
+	Sno           null.Int    `json:"sno" db:"SNO"`
+	UserKey       null.String `json:"user_key" db:"USER_KEY"`               // 남길 근로자
+	MergedUserKey null.String `json:"merged_user_key" db:"MERGED_USER_KEY"` // 병합할 근로자
+	Status        null.String `json:"status" db:"STATUS"`
+	Reason        null.String `json:"reason" db:"REASON"`
+	DailyCount    null.Int    `json:"daily_count" db:"DAILY_COUNT"` // 옮긴 출퇴근 기록 수
+	Base
+}
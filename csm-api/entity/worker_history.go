@@ -0,0 +1,59 @@
+package entity
+
+import "github.com/guregu/null"
+
+// 변경 이력 사유 구분: 이력 복원
+const ReasonTypeHistoryRestore = "13"
+
+// 변경 이력 비교 항목
+const (
+	HistoryFieldInRecogTime  = "in_recog_time"
+	HistoryFieldOutRecogTime = "out_recog_time"
+	HistoryFieldWorkHour     = "work_hour"
+	HistoryFieldJno          = "jno"
+	HistoryFieldIsDeadline   = "is_deadline"
+)
+
+// 변경 이력 (IRIS_WORKER_DAILY_HIS) 한 건
+// REG_USER, REG_UNO는 변경한 사용자
+type WorkerHistory struct {
+	WorkerDaily
+	ReasonName null.String `json:"reason_name" db:"REASON_NAME"` // 사유 구분명
+	HisSeq     null.Int    `json:"his_seq" db:"HIS_SEQ"`         // 같은 변경의 변경전/변경후 순번
+}
+type WorkerHistories []*WorkerHistory
+
+// 변경 이력 항목별 변경 내용
+type WorkerHistoryChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+type WorkerHistoryChanges []WorkerHistoryChange
+
+// 변경 이력 비교 (변경전/변경후 한 쌍)
+// 변경전(BEFORE_CNO) 또는 변경후(AFTER_CNO) 버전으로 복원할 수 있다.
+type WorkerHistoryDiff struct {
+	BeforeCno  null.Int             `json:"before_cno"`
+	AfterCno   null.Int             `json:"after_cno"`
+	Sno        null.Int             `json:"sno"`
+	UserKey    null.String          `json:"user_key"`
+	UserId     null.String          `json:"user_id"`
+	UserNm     null.String          `json:"user_nm"`
+	Department null.String          `json:"department"`
+	RecordDate null.Time            `json:"record_date"`
+	ReasonType null.String          `json:"reason_type"`
+	ReasonName null.String          `json:"reason_name"`
+	Reason     null.String          `json:"reason"`
+	RegDate    null.Time            `json:"reg_date"` // 변경일시
+	RegUser    null.String          `json:"reg_user"` // 변경한 사용자
+	RegUno     null.Int             `json:"reg_uno"`
+	Changes    WorkerHistoryChanges `json:"changes"`
+}
+type WorkerHistoryDiffs []*WorkerHistoryDiff
+
+// 변경 이력 복원 요청
+type WorkerHistoryRestore struct {
+	Cno    null.Int    `json:"cno"` // 복원할 버전의 변경 이력pk
+	Reason null.String `json:"reason"`
+}
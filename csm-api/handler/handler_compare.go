@@ -21,14 +21,6 @@ func (h *HandlerCompare) List(w http.ResponseWriter, r *http.Request) {
 	order := r.URL.Query().Get("order")
 	retrySearch := r.URL.Query().Get("retry_search")
 
-	// 전체 조회할지 본인이 속한 프로젝트만 조회할지 권한
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-	if err != nil {
-		BadRequestResponse(r.Context(), w)
-		return
-	}
-
 	if snoString == "" || jnoString == "" || startDateString == "" {
 		BadRequestResponse(r.Context(), w)
 		return
@@ -40,7 +32,7 @@ func (h *HandlerCompare) List(w http.ResponseWriter, r *http.Request) {
 		RecordDate: utils.ParseNullDate(startDateString),
 	}
 
-	list, err := h.Service.GetCompareList(r.Context(), compare, isRole, retrySearch, order)
+	list, err := h.Service.GetCompareList(r.Context(), compare, retrySearch, order)
 	if err != nil {
 		FailResponse(r.Context(), w, err)
 		return
@@ -64,3 +56,128 @@ func (h *HandlerCompare) CompareState(w http.ResponseWriter, r *http.Request) {
 	}
 	SuccessResponse(r.Context(), w)
 }
+
+// func: 프로젝트별 부서 별칭 조회
+// @param
+// - jno: 프로젝트pk (없으면 전체)
+func (h *HandlerCompare) DepartmentAliasList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jno := utils.ParseNullInt(r.URL.Query().Get("jno"))
+
+	list, err := h.Service.GetDepartmentAliasList(ctx, jno)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 프로젝트별 부서 별칭 저장
+// @param
+// - jno: 프로젝트pk
+// - alias: TBM, 공제 자료 부서명
+// - department: 근로자 부서명
+func (h *HandlerCompare) MergeDepartmentAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	alias := entity.DepartmentAlias{}
+	if err := json.NewDecoder(r.Body).Decode(&alias); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.MergeDepartmentAlias(ctx, alias); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 프로젝트별 부서 별칭 삭제
+// @param
+// - jno: 프로젝트pk
+// - alias: TBM, 공제 자료 부서명
+func (h *HandlerCompare) RemoveDepartmentAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	alias := entity.DepartmentAlias{}
+	if err := json.NewDecoder(r.Body).Decode(&alias); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.RemoveDepartmentAlias(ctx, alias); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 일일 근로자 비교 예외 목록 조회
+// @param
+// - jno: 프로젝트pk
+// - start_date, end_date: 조회 기간 (YYYY-MM-DD)
+// - status: 처리 상태 (O: 처리 대기, R: 처리 완료, D: 예외 아님, C: 해소, 없으면 전체)
+// - assignee_uno: 담당자 (없으면 전체)
+func (h *HandlerCompare) ExceptionList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jno, err := strconv.ParseInt(r.URL.Query().Get("jno"), 10, 64)
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if err != nil || startDate == "" || endDate == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	assigneeUno := utils.ParseNullInt(r.URL.Query().Get("assignee_uno"))
+
+	list, err := h.Service.GetCompareExceptionList(ctx, jno, startDate, endDate, status, assigneeUno)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 일일 근로자 비교 예외 담당자 지정
+// @param
+// - exnos: 예외pk
+// - assignee_uno, assignee_name: 담당자
+func (h *HandlerCompare) ExceptionAssignee(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	review := entity.CompareExceptionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.ModifyCompareExceptionAssignee(ctx, review); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 일일 근로자 비교 예외 처리
+// @param
+// - exnos: 예외pk
+// - status: R(처리 완료), D(예외 아님)
+// - note: 처리 내용 (예외 아님은 필수)
+func (h *HandlerCompare) ExceptionResolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	review := entity.CompareExceptionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.ModifyCompareExceptionResolve(ctx, review); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
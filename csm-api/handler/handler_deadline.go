@@ -4,12 +4,15 @@ import (
 	"csm-api/entity"
 	"csm-api/service"
 	"csm-api/utils"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
 type HandlerDeadline struct {
 	UploadService service.UploadFileService
+	CloseService  service.DailyCloseService
 }
 
 // 일일마감 엑셀 자료 정보
@@ -33,3 +36,75 @@ func (h *HandlerDeadline) UploadFileList(w http.ResponseWriter, r *http.Request)
 
 	SuccessValuesResponse(r.Context(), w, list)
 }
+
+// func: 일일 마감 현황 조회
+// @param
+// - jno: 프로젝트pk
+// - start_date, end_date: 조회 기간 (YYYY-MM-DD)
+func (h *HandlerDeadline) CloseList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jno, err := strconv.ParseInt(r.URL.Query().Get("jno"), 10, 64)
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if err != nil || startDate == "" || endDate == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	list, err := h.CloseService.GetDailyCloseList(ctx, jno, startDate, endDate)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	values := struct {
+		List entity.DailyCloses `json:"list"`
+	}{List: list}
+
+	SuccessValuesResponse(ctx, w, values)
+}
+
+// func: 일일 마감 요청
+// @param
+// - dailyClose: jno, record_date
+func (h *HandlerDeadline) CloseSubmit(w http.ResponseWriter, r *http.Request) {
+	h.modifyDailyClose(w, r, entity.DailyCloseSubmit)
+}
+
+// func: 일일 마감 승인
+// @param
+// - dailyClose: jno, record_date
+func (h *HandlerDeadline) CloseApprove(w http.ResponseWriter, r *http.Request) {
+	h.modifyDailyClose(w, r, entity.DailyCloseApprove)
+}
+
+// func: 일일 마감 반려
+// @param
+// - dailyClose: jno, record_date, reason
+func (h *HandlerDeadline) CloseReject(w http.ResponseWriter, r *http.Request) {
+	h.modifyDailyClose(w, r, entity.DailyCloseReject)
+}
+
+// func: 일일 마감 (승인된 날짜의 근로자 마감처리)
+// @param
+// - dailyClose: jno, record_date
+func (h *HandlerDeadline) CloseLock(w http.ResponseWriter, r *http.Request) {
+	h.modifyDailyClose(w, r, entity.DailyCloseLock)
+}
+
+// func: 일일 마감 상태 변경
+func (h *HandlerDeadline) modifyDailyClose(w http.ResponseWriter, r *http.Request, action string) {
+	ctx := r.Context()
+
+	dailyClose := entity.DailyClose{}
+	if err := json.NewDecoder(r.Body).Decode(&dailyClose); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.CloseService.ModifyDailyClose(ctx, action, dailyClose); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
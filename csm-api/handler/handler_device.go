@@ -4,6 +4,7 @@ import (
 	"csm-api/entity"
 	"csm-api/service"
 	"csm-api/utils"
+	"encoding/json"
 	"net/http"
 	"strconv"
 )
@@ -150,3 +151,31 @@ func (d *DeviceHandler) CheckRegistered(w http.ResponseWriter, r *http.Request)
 	SuccessValuesResponse(ctx, w, values)
 
 }
+
+// func: 근태인식기 api key 발급
+// 발급한 key는 이 응답에서만 확인할 수 있으며, 기존 key는 바로 사용할 수 없다.
+// @param
+// - request: dno - json(raw)
+func (d *DeviceHandler) IssueApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body struct {
+		Dno int64 `json:"dno"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Dno == 0 {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	apiKey, err := d.Service.IssueDeviceApiKey(ctx, body.Dno)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	values := struct {
+		Dno    int64  `json:"dno"`
+		ApiKey string `json:"api_key"`
+	}{Dno: body.Dno, ApiKey: apiKey}
+	SuccessValuesResponse(ctx, w, values)
+}
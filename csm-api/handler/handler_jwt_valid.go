@@ -13,6 +13,10 @@ func (handler *JwtValidHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 
 	claims, err := handler.Jwt.ValidateJWT(r)
+	if err != nil {
+		// access token이 만료되었으면 refresh token으로 재발급
+		claims, err = RefreshTokenCookies(w, r, handler.Jwt)
+	}
 	if err != nil {
 		RespondJSON(
 			r.Context(),
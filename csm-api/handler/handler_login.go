@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"context"
 	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/service"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
 )
 
 type LoginHandler struct {
@@ -38,14 +43,30 @@ func (l *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		user entity.User
 		err  error
 	)
+	ip := ClientIp(r)
 	if login.IsCompany {
 		// 협력업체 유효성 검사
-		user, err = l.Service.GetCompanyUserValid(ctx, login.UserId, login.UserPwd, login.Admin)
+		user, err = l.Service.GetCompanyUserValid(ctx, login.UserId, login.UserPwd, login.Admin, ip)
 	} else {
 		// 직원 유효성 검사
-		user, err = l.Service.GetUserValid(ctx, login.UserId, login.UserPwd, login.Admin)
+		user, err = l.Service.GetUserValid(ctx, login.UserId, login.UserPwd, login.Admin, ip)
 	}
 	if err != nil {
+		// 로그인 잠금
+		if errors.Is(err, service.ErrLoginLocked) {
+			RespondJSON(
+				ctx,
+				w,
+				&ErrResponse{
+					Result:         Failure,
+					Message:        err.Error(),
+					Details:        LoginLocked,
+					HttpStatusCode: http.StatusTooManyRequests,
+				},
+				http.StatusOK)
+			return
+		}
+
 		RespondJSON(
 			ctx,
 			w,
@@ -59,8 +80,8 @@ func (l *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//jwt 생성
-	tokenString, err := l.Jwt.GenerateToken(&auth.JWTClaims{Uno: user.Uno, UserId: user.UserId, UserName: user.UserName, IsSaved: login.IsSaved, Role: auth.JWTRole(user.RoleCode)})
+	// 세션 생성 및 토큰 발급
+	access, refresh, err := l.Jwt.IssueTokens(ctx, &auth.JWTClaims{Uno: user.Uno, UserId: user.UserId, UserName: user.UserName, IsSaved: login.IsSaved, Role: auth.JWTRole(user.RoleCode)})
 	if err != nil {
 		RespondJSON(
 			ctx,
@@ -76,16 +97,7 @@ func (l *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 쿠키 설정
-	cookie := &http.Cookie{
-		Name:     "jwt",
-		Value:    tokenString,
-		Path:     "/",
-		MaxAge:   auth.GetCookieMaxAge(login.IsSaved),
-		HttpOnly: true,                    // JavaScript로 접근 불가
-		Secure:   false,                   // true:HTTPS, false:HTTPS/HTTP
-		SameSite: http.SameSiteStrictMode, // 동일 출처에서만 쿠키 전송
-	}
-	http.SetCookie(w, cookie)
+	SetTokenCookies(w, access, refresh, login.IsSaved)
 
 	rsp := Response{
 		Result: Success,
@@ -96,3 +108,94 @@ func (l *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	RespondJSON(ctx, w, &rsp, http.StatusOK)
 }
+
+// X-Forwarded-For를 신뢰할 프록시 목록
+type TrustedProxies []*net.IPNet
+
+// func: 신뢰할 프록시 목록 생성
+// @param
+// - proxies: IP 또는 CIDR
+func NewTrustedProxies(proxies []string) (TrustedProxies, error) {
+	trusted := make(TrustedProxies, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		trusted = append(trusted, ipNet)
+	}
+	return trusted, nil
+}
+
+// 신뢰할 프록시 IP인지 확인
+func (t TrustedProxies) Contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range t {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// context에 저장하는 요청 IP
+type clientIpKey struct{}
+
+// 요청 IP를 확인하여 context에 저장하는 미들웨어
+// 신뢰할 프록시에서 온 요청만 X-Forwarded-For를 사용하며, 오른쪽부터 신뢰할 프록시가 아닌 첫번째 IP를 요청 IP로 한다.
+func ClientIpMiddleware(trusted TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIpKey{}, resolveClientIp(r, trusted))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// 요청 IP 확인 (ClientIpMiddleware에서 저장한 값, 없으면 접속 IP)
+func ClientIp(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIpKey{}).(string); ok && ip != "" {
+		return ip
+	}
+	return resolveClientIp(r, nil)
+}
+
+func resolveClientIp(r *http.Request, trusted TrustedProxies) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !trusted.Contains(host) {
+		return host
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if ip == "" {
+			continue
+		}
+		if net.ParseIP(ip) == nil {
+			break
+		}
+		if !trusted.Contains(ip) {
+			return ip
+		}
+		host = ip
+	}
+	return host
+}
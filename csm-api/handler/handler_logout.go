@@ -1,24 +1,30 @@
 package handler
 
-import "net/http"
+import (
+	"csm-api/auth"
+	"net/http"
+)
 
-type LogoutHandler struct{}
+type LogoutHandler struct {
+	Jwt *auth.JWTUtils
+}
 
+// 로그아웃 (현재 세션 폐기)
 func (l *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// 쿠키 삭제
-	cookie := &http.Cookie{
-		Name:     "jwt",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,                      // 즉시 만료
-		HttpOnly: true,                    // JavaScript 접근 방지
-		Secure:   false,                   // HTTPS/HTTP 설정 (필요시 true로 변경)
-		SameSite: http.SameSiteStrictMode, // 동일 출처에서만 쿠키 전송
+	// 세션 폐기
+	if cookie, err := r.Cookie(RefreshTokenCookie); err == nil {
+		if sessionId, err := auth.RefreshSessionId(cookie.Value); err == nil {
+			if err = l.Jwt.RevokeSession(ctx, sessionId); err != nil {
+				FailResponse(ctx, w, err)
+				return
+			}
+		}
 	}
 
-	http.SetCookie(w, cookie)
+	// 쿠키 삭제
+	ClearTokenCookies(w)
 
 	// 로그아웃 성공 응답
 	RespondJSON(ctx, w, &Response{
@@ -26,3 +32,39 @@ func (l *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}, http.StatusOK)
 
 }
+
+// 모든 기기 로그아웃 (사용자의 모든 세션 폐기)
+func (l *LogoutHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, err := l.Jwt.ValidateJWT(r)
+	if err != nil {
+		claims, err = RefreshTokenCookies(w, r, l.Jwt)
+	}
+	if err != nil {
+		RespondJSON(
+			ctx,
+			w,
+			&ErrResponse{
+				Result:         Failure,
+				Message:        err.Error(),
+				Details:        InvalidToken,
+				HttpStatusCode: http.StatusUnauthorized,
+			},
+			http.StatusUnauthorized,
+		)
+		return
+	}
+
+	if err = l.Jwt.RevokeUserSessions(ctx, claims.Uno); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	// 쿠키 삭제
+	ClearTokenCookies(w)
+
+	RespondJSON(ctx, w, &Response{
+		Result: Success,
+	}, http.StatusOK)
+}
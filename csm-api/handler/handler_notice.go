@@ -51,16 +51,13 @@ func (n *NoticeHandler) List(w http.ResponseWriter, r *http.Request) {
 	search.Title = utils.ParseNullString(r.URL.Query().Get("title"))
 	search.UserInfo = utils.ParseNullString(r.URL.Query().Get("user_info"))
 
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-
-	notices, err := n.Service.GetNoticeList(ctx, page, isRole, search)
+	notices, err := n.Service.GetNoticeList(ctx, page, search)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
-	count, err := n.Service.GetNoticeListCount(ctx, isRole, search)
+	count, err := n.Service.GetNoticeListCount(ctx, search)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"csm-api/entity"
+	"csm-api/service"
+	"csm-api/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type HandlerPii struct {
+	Service service.PiiService
+}
+
+// func: 근로자 개인정보 단건 조회 (reveal)
+// @param
+// - user_key: 근로자
+// - field: 항목 (phone, reg_no, birth)
+// - reason: 조회 사유
+func (h *HandlerPii) Reveal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reveal := entity.PiiReveal{}
+	if err := json.NewDecoder(r.Body).Decode(&reveal); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	result, err := h.Service.RevealWorkerPii(ctx, reveal)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, result)
+}
+
+// func: 사용자별 개인정보 접근 현황
+// @param
+// - start_date, end_date: 조회 기간 (YYYY-MM-DD)
+func (h *HandlerPii) Report(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if startDate == "" || endDate == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	list, err := h.Service.GetPiiAccessReport(ctx, startDate, endDate)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 개인정보 접근 기록 조회
+// @param
+// - start_date, end_date: 조회 기간 (YYYY-MM-DD)
+// - uno: 접근자 (없으면 전체)
+// - access_type: 구분 (L: 목록 조회, R: 단건 조회, 없으면 전체)
+func (h *HandlerPii) LogList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if startDate == "" || endDate == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	uno := utils.ParseNullInt(r.URL.Query().Get("uno"))
+	accessType := r.URL.Query().Get("access_type")
+
+	list, err := h.Service.GetPiiAccessLogList(ctx, startDate, endDate, uno, accessType)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
@@ -60,16 +60,7 @@ func (h *HandlerProject) WorkerCountList(w http.ResponseWriter, r *http.Request)
 func (h *HandlerProject) JobNameList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	isRoleStr := r.URL.Query().Get("isRole")
-
-	isRole, err := strconv.ParseBool(isRoleStr)
-
-	if err != nil {
-		BadRequestResponse(ctx, w)
-		return
-	}
-
-	list, err := h.Service.GetProjectNmList(ctx, isRole)
+	list, err := h.Service.GetProjectNmList(ctx)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/service"
+	"csm-api/utils"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -25,15 +27,7 @@ func (h *HandlerProjectSetting) ProjectSettingList(w http.ResponseWriter, r *htt
 	}
 	jno, _ := strconv.ParseInt(jnoString, 10, 64)
 
-	// 전체 조회할지 본인이 속한 프로젝트만 조회할지 권한
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-	if err != nil {
-		BadRequestResponse(ctx, w)
-		return
-	}
-
-	setting, err := h.Service.GetProjectSetting(ctx, jno, isRole)
+	setting, err := h.Service.GetProjectSetting(ctx, jno)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
@@ -67,6 +61,39 @@ func (h *HandlerProjectSetting) MergeProjectSetting(w http.ResponseWriter, r *ht
 	SuccessResponse(ctx, w)
 }
 
+// func: 근무 시간대 수정
+// 출퇴근 분류 규칙의 근무 시간대를 요청한 목록으로 바꾼다. (빈 목록이면 기본 규칙)
+// @param
+// - jno: 프로젝트pk
+// - shifts: 근무 시간대 배열
+func (h *HandlerProjectSetting) MergeProjectShifts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jnoString := r.PathValue("jno")
+	if jnoString == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	jno, err := strconv.ParseInt(jnoString, 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	shifts := entity.ProjectShifts{}
+	if err = json.NewDecoder(r.Body).Decode(&shifts); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err = h.Service.MergeProjectShifts(ctx, jno, shifts); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	SuccessResponse(ctx, w)
+}
+
 // func: 프로젝트 공수 정보 확인
 // @param
 // - jno: 프로젝트pk
@@ -114,6 +141,80 @@ func (h *HandlerProjectSetting) MergeManHours(w http.ResponseWriter, r *http.Req
 	SuccessResponse(ctx, w)
 }
 
+// func: 공수 단계 변경 미리보기
+// 변경할 공수 단계로 다시 계산한 근로자별 공수와 현재 공수 비교 (저장하지 않음)
+// @param
+// - jno: 프로젝트pk
+// - manHours: 변경할 공수 단계
+func (h *HandlerProjectSetting) PreviewManHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jno, err := strconv.ParseInt(r.PathValue("jno"), 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	manhours := entity.ManHours{}
+	if err = json.NewDecoder(r.Body).Decode(&manhours); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	preview, err := h.Service.PreviewManHours(ctx, jno, manhours)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	values := struct {
+		Preview entity.ManHourPreview `json:"preview"`
+	}{Preview: *preview}
+
+	SuccessValuesResponse(ctx, w, values)
+}
+
+// func: 공수 단계 변경 적용
+// 미리보기한 공수 단계와 token으로 미리보기 결과를 그대로 저장
+// @param
+// - jno: 프로젝트pk
+// - apply: token, man_hours
+func (h *HandlerProjectSetting) ApplyManHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jno, err := strconv.ParseInt(r.PathValue("jno"), 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	apply := entity.ManHourApply{}
+	if err = json.NewDecoder(r.Body).Decode(&apply); err != nil || apply.Token == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	// 등록, 수정자는 요청 값이 아닌 로그인 사용자
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	apply.Base = entity.Base{
+		RegUno:  utils.ParseNullInt(uno),
+		RegUser: utils.ParseNullString(userName),
+		ModUno:  utils.ParseNullInt(uno),
+		ModUser: utils.ParseNullString(userName),
+	}
+
+	count, err := h.Service.ApplyManHours(ctx, jno, apply)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	values := struct {
+		Count int64 `json:"count"`
+	}{Count: count}
+
+	SuccessValuesResponse(ctx, w, values)
+}
+
 // func: 공수 삭제
 // @param
 // - mhno: 공수pk
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"csm-api/service"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+const (
+	DeviceSnHeader     = "X-Device-SN"  // 근태인식기 시리얼번호
+	DeviceApiKeyHeader = "X-Device-Key" // 근태인식기 api key
+
+	// 인식 기록 요청 최대 크기
+	maxRecdBodyBytes = 1 << 20
+)
+
+type deviceContextKey struct{}
+
+// struct: 홍채인식기 인식 기록 수신
+type RecdHandler struct {
+	Service service.RecdService
+}
+
+// 근태인식기 api key를 확인하는 미들웨어 (jwt 대신 장치 인증)
+// 인증된 장치는 context에 저장하고, 장치 시리얼번호를 사용자 아이디로 사용한다. (로그 기록용)
+func DeviceAuthMiddleware(recdService service.RecdService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			device, err := recdService.GetAuthorizedDevice(ctx, r.Header.Get(DeviceSnHeader), r.Header.Get(DeviceApiKeyHeader))
+			if err != nil {
+				status := http.StatusUnauthorized
+				if !errors.Is(err, service.ErrDeviceUnauthorized) {
+					_ = entity.WriteErrorLog(ctx, err)
+					status = http.StatusInternalServerError
+				}
+				RespondJSON(
+					ctx,
+					w,
+					&ErrResponse{
+						Result:         Failure,
+						Message:        err.Error(),
+						Details:        InvalidDevice,
+						HttpStatusCode: status,
+					},
+					status,
+				)
+				return
+			}
+
+			ctx = context.WithValue(ctx, deviceContextKey{}, device)
+			ctx = auth.SetContext(ctx, auth.UserId{}, device.DeviceSn.String)
+			ctx = auth.SetContext(ctx, auth.Uno{}, "0")
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// func: 인식 기록 수신
+// @param
+// - request: entity.RecdLog 또는 entity.RecdLog 배열 - json(raw)
+func (h *RecdHandler) Ingest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	device, ok := ctx.Value(deviceContextKey{}).(entity.Device)
+	if !ok {
+		FailResponse(ctx, w, service.ErrDeviceUnauthorized)
+		return
+	}
+
+	recds, err := decodeRecdLogs(w, r)
+	if err != nil || len(recds) == 0 || len(recds) > service.MaxRecdIngestSize {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	result, err := h.Service.AddRecdLogs(ctx, device, recds)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	SuccessValuesResponse(ctx, w, result)
+}
+
+// func: 인식 기록 파싱 (단건 객체 또는 배열)
+func decodeRecdLogs(w http.ResponseWriter, r *http.Request) ([]entity.RecdLog, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRecdBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var recds []entity.RecdLog
+		if err = json.Unmarshal(body, &recds); err != nil {
+			return nil, err
+		}
+		return recds, nil
+	}
+
+	var recd entity.RecdLog
+	if err = json.Unmarshal(body, &recd); err != nil {
+		return nil, err
+	}
+	return []entity.RecdLog{recd}, nil
+}
@@ -51,13 +51,6 @@ func (s *HandlerSite) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-	if err != nil {
-		BadRequestResponse(ctx, w)
-		return
-	}
-
 	isNonUseStr := r.URL.Query().Get("isNonUse")
 	isNonUse, err := strconv.ParseBool(isNonUseStr)
 	if err != nil {
@@ -66,7 +59,7 @@ func (s *HandlerSite) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 현장 관리 리스트 조회
-	sites, err := s.Service.GetSiteList(ctx, targetDate, isRole, isNonUse)
+	sites, err := s.Service.GetSiteList(ctx, targetDate, isNonUse)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
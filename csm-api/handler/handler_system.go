@@ -17,6 +17,8 @@ type SystemHandler struct {
 	ProjectSettingService service.ProjectSettingService
 	WeatherService        service.WeatherApiService
 	SiteService           service.SiteService
+	RetentionService      service.RetentionService
+	CompareService        service.CompareService
 }
 
 // 근로자 마감 처리
@@ -51,6 +53,54 @@ func (h *SystemHandler) WorkerOverTime(w http.ResponseWriter, r *http.Request) {
 	SuccessResponse(ctx, w)
 }
 
+// 보관 기간 처리 dry-run 보고
+func (h *SystemHandler) RetentionReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.RetentionService.GetRetentionReport(ctx)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	SuccessValuesResponse(ctx, w, report)
+}
+
+// 보관 기간 처리 (퇴직 근로자 익명화, 업로드 파일 정리)
+// dry-run 보고(GET)의 token이 필요하다.
+func (h *SystemHandler) Retention(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	apply := entity.RetentionApply{}
+	if err := json.NewDecoder(r.Body).Decode(&apply); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	report, err := h.RetentionService.ModifyRetention(ctx, apply.Token)
+	if err != nil {
+		_ = entity.WriteErrorLog(ctx, utils.CustomErrorf(err))
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	SuccessValuesResponse(ctx, w, report)
+}
+
+// 일일 근로자 비교 자동 대사
+func (h *SystemHandler) CompareReconcile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	results, err := h.CompareService.ModifyCompareReconcile(ctx)
+	if err != nil {
+		_ = entity.WriteErrorLog(ctx, utils.CustomErrorf(err))
+		FailResponse(ctx, w, err)
+		return
+	}
+
+	SuccessValuesResponse(ctx, w, results)
+}
+
 // 프로젝트 정보 업데이트(초기 세팅)
 func (h *SystemHandler) ProjectInitSetting(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"csm-api/auth"
+	"errors"
+	"net/http"
+)
+
+const (
+	AccessTokenCookie  = "jwt"
+	RefreshTokenCookie = "refresh_token"
+)
+
+type TokenHandler struct {
+	Jwt *auth.JWTUtils
+}
+
+// refresh token으로 토큰 재발급
+func (t *TokenHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, err := RefreshTokenCookies(w, r, t.Jwt)
+	if err != nil {
+		RespondJSON(
+			ctx,
+			w,
+			&ErrResponse{
+				Result:         Failure,
+				Message:        err.Error(),
+				Details:        InvalidToken,
+				HttpStatusCode: http.StatusUnauthorized,
+			},
+			http.StatusUnauthorized,
+		)
+		return
+	}
+
+	rsp := Response{
+		Result: Success,
+		Values: struct {
+			LoginUserId string `json:"login_user_id"`
+		}{LoginUserId: claims.UserId},
+	}
+	RespondJSON(ctx, w, &rsp, http.StatusOK)
+}
+
+// 토큰 검증용 공개키 목록 (JWKS)
+// 다른 서비스에서 토큰을 검증할 수 있도록 표준 JWK Set 형식으로 응답한다.
+func (t *TokenHandler) Jwks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	RespondJSON(r.Context(), w, t.Jwt.JWKS(), http.StatusOK)
+}
+
+// func: refresh token 쿠키로 토큰을 재발급하고 쿠키 교체
+// 이미 사용된 refresh token인 경우 세션이 폐기되므로 쿠키도 삭제한다.
+// @param
+// - jwt: jwt 유틸
+func RefreshTokenCookies(w http.ResponseWriter, r *http.Request, jwt *auth.JWTUtils) (*auth.JWTClaims, error) {
+	cookie, err := r.Cookie(RefreshTokenCookie)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, access, refresh, err := jwt.RefreshTokens(r.Context(), cookie.Value)
+	if err != nil {
+		if errors.Is(err, auth.ErrRefreshReused) || errors.Is(err, auth.ErrSessionRevoked) {
+			ClearTokenCookies(w)
+		}
+		return nil, err
+	}
+
+	SetTokenCookies(w, access, refresh, claims.IsSaved)
+	return claims, nil
+}
+
+// 토큰 쿠키 설정
+// access token 쿠키는 refresh token과 같이 유지하고 만료 여부는 토큰의 exp로 판단한다.
+// refresh token이 빈 값이면(동시 재발급 요청) refresh token 쿠키는 그대로 둔다.
+func SetTokenCookies(w http.ResponseWriter, access string, refresh string, isSaved bool) {
+	http.SetCookie(w, tokenCookie(AccessTokenCookie, access, auth.GetCookieMaxAge(isSaved)))
+	if refresh != "" {
+		http.SetCookie(w, tokenCookie(RefreshTokenCookie, refresh, auth.GetCookieMaxAge(isSaved)))
+	}
+}
+
+// 토큰 쿠키 삭제
+func ClearTokenCookies(w http.ResponseWriter) {
+	http.SetCookie(w, tokenCookie(AccessTokenCookie, "", -1))
+	http.SetCookie(w, tokenCookie(RefreshTokenCookie, "", -1))
+}
+
+func tokenCookie(name string, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,                    // JavaScript로 접근 불가
+		Secure:   false,                   // true:HTTPS, false:HTTPS/HTTP
+		SameSite: http.SameSiteStrictMode, // 동일 출처에서만 쿠키 전송
+	}
+}
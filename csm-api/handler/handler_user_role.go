@@ -1,14 +1,17 @@
 package handler
 
 import (
+	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/service"
+	"encoding/json"
 	"net/http"
 	"strconv"
 )
 
 type HandlerUserRole struct {
 	Service service.UserRoleService
+	Jwt     *auth.JWTUtils
 }
 
 // 사용자 권한 조회
@@ -41,6 +44,12 @@ func (h *HandlerUserRole) AddUserRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 권한이 변경된 사용자의 세션 폐기 (다시 로그인해야 변경된 권한 적용)
+	if err = h.revokeUserRoleSessions(r, userRoles); err != nil {
+		FailResponse(r.Context(), w, err)
+		return
+	}
+
 	entity.WriteLog(itemLog)
 	SuccessResponse(r.Context(), w)
 }
@@ -59,6 +68,12 @@ func (h *HandlerUserRole) RemoveUserRole(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// 권한이 변경된 사용자의 세션 폐기 (다시 로그인해야 변경된 권한 적용)
+	if err = h.revokeUserRoleSessions(r, userRoles); err != nil {
+		FailResponse(r.Context(), w, err)
+		return
+	}
+
 	entity.WriteLog(itemLog)
 	SuccessResponse(r.Context(), w)
 }
@@ -77,3 +92,36 @@ func (h *HandlerUserRole) UserMenuRoleCheck(w http.ResponseWriter, r *http.Reque
 	}
 	SuccessValuesResponse(r.Context(), w, valid)
 }
+
+// 사용자 세션 폐기 (강제 로그아웃)
+// param HTTP POST body uno (사용자번호)
+func (h *HandlerUserRole) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Uno int64 `json:"uno"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Uno == 0 {
+		BadRequestResponse(r.Context(), w)
+		return
+	}
+
+	if err := h.Jwt.RevokeUserSessions(r.Context(), body.Uno); err != nil {
+		FailResponse(r.Context(), w, err)
+		return
+	}
+	SuccessResponse(r.Context(), w)
+}
+
+func (h *HandlerUserRole) revokeUserRoleSessions(r *http.Request, userRoles []entity.UserRoleMap) error {
+	revoked := make(map[int64]bool)
+	for _, userRole := range userRoles {
+		uno := userRole.UserUno.Int64
+		if !userRole.UserUno.Valid || revoked[uno] {
+			continue
+		}
+		if err := h.Jwt.RevokeUserSessions(r.Context(), uno); err != nil {
+			return err
+		}
+		revoked[uno] = true
+	}
+	return nil
+}
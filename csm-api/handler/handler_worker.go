@@ -5,6 +5,7 @@ import (
 	"csm-api/service"
 	"csm-api/utils"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 )
@@ -28,14 +29,6 @@ type HandlerWorker struct {
 func (h *HandlerWorker) TotalList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// 전체 조회할지 본인이 속한 프로젝트만 조회할지 권한
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-	if err != nil {
-		BadRequestResponse(ctx, w)
-		return
-	}
-
 	// http get paramter를 저장할 구조체 생성 및 파싱
 	page := entity.Page{}
 	search := entity.Worker{}
@@ -54,7 +47,16 @@ func (h *HandlerWorker) TotalList(w http.ResponseWriter, r *http.Request) {
 
 	retrySearch := r.URL.Query().Get("retry_search")
 
-	if pageNum == "" || rowSize == "" {
+	// keyset 페이징(cursor 파라미터가 있으면 page_num 대신 사용), 개수 조회 방식
+	page.UseCursor = r.URL.Query().Has(entity.CursorKey)
+	page.Cursor = r.URL.Query().Get(entity.CursorKey)
+	countMode, err := entity.ParseCountMode(r.URL.Query().Get(entity.CountModeKey))
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if (pageNum == "" && !page.UseCursor) || rowSize == "" {
 		BadRequestResponse(ctx, w)
 		return
 	}
@@ -72,23 +74,24 @@ func (h *HandlerWorker) TotalList(w http.ResponseWriter, r *http.Request) {
 	search.DiscName = utils.ParseNullString(discName)
 
 	// 조회
-	list, err := h.Service.GetWorkerTotalList(ctx, page, isRole, search, retrySearch)
+	list, cursor, err := h.Service.GetWorkerTotalList(ctx, page, search, retrySearch)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	// 개수 조회
-	count, err := h.Service.GetWorkerTotalCount(ctx, isRole, search, retrySearch)
+	count, err := h.Service.GetWorkerTotalCount(ctx, search, retrySearch, countMode)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	values := struct {
-		List  entity.Workers `json:"list"`
-		Count int            `json:"count"`
-	}{List: *list, Count: count}
+		List entity.Workers `json:"list"`
+		entity.PageCount
+		entity.CursorPage
+	}{List: *list, PageCount: count, CursorPage: cursor}
 	SuccessValuesResponse(ctx, w, values)
 }
 
@@ -223,14 +226,6 @@ func (h *HandlerWorker) Remove(w http.ResponseWriter, r *http.Request) {
 func (h *HandlerWorker) SiteBaseList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// 전체 조회할지 본인이 속한 프로젝트만 조회할지 권한
-	isRoleStr := r.URL.Query().Get("isRole")
-	isRole, err := strconv.ParseBool(isRoleStr)
-	if err != nil {
-		BadRequestResponse(ctx, w)
-		return
-	}
-
 	// http get paramter를 저장할 구조체 생성 및 파싱
 	page := entity.Page{}
 	search := entity.WorkerDaily{}
@@ -247,7 +242,16 @@ func (h *HandlerWorker) SiteBaseList(w http.ResponseWriter, r *http.Request) {
 	searchStartTime := r.URL.Query().Get("search_start_time")
 	searchEndTime := r.URL.Query().Get("search_end_time")
 
-	if pageNum == "" || rowSize == "" || searchStartTime == "" || searchEndTime == "" || jno == "" {
+	// keyset 페이징(cursor 파라미터가 있으면 page_num 대신 사용), 개수 조회 방식
+	page.UseCursor = r.URL.Query().Has(entity.CursorKey)
+	page.Cursor = r.URL.Query().Get(entity.CursorKey)
+	countMode, err := entity.ParseCountMode(r.URL.Query().Get(entity.CountModeKey))
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if (pageNum == "" && !page.UseCursor) || rowSize == "" || searchStartTime == "" || searchEndTime == "" || jno == "" {
 		BadRequestResponse(ctx, w)
 		return
 	}
@@ -264,23 +268,24 @@ func (h *HandlerWorker) SiteBaseList(w http.ResponseWriter, r *http.Request) {
 	search.SearchEndTime = utils.ParseNullString(searchEndTime)
 
 	// 조회
-	list, err := h.Service.GetWorkerSiteBaseList(ctx, page, isRole, search, retrySearch)
+	list, cursor, err := h.Service.GetWorkerSiteBaseList(ctx, page, search, retrySearch)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	// 개수 조회
-	count, err := h.Service.GetWorkerSiteBaseCount(ctx, isRole, search, retrySearch)
+	count, err := h.Service.GetWorkerSiteBaseCount(ctx, search, retrySearch, countMode)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	values := struct {
-		List  entity.WorkerDailys `json:"list"`
-		Count int                 `json:"count"`
-	}{List: *list, Count: count}
+		List entity.WorkerDailys `json:"list"`
+		entity.PageCount
+		entity.CursorPage
+	}{List: *list, PageCount: count, CursorPage: cursor}
 	SuccessValuesResponse(ctx, w, values)
 }
 
@@ -306,7 +311,16 @@ func (h *HandlerWorker) SiteBaseListByCompany(w http.ResponseWriter, r *http.Req
 	searchStartTime := r.URL.Query().Get("search_start_time")
 	searchEndTime := r.URL.Query().Get("search_end_time")
 
-	if pageNum == "" || rowSize == "" || searchStartTime == "" || searchEndTime == "" || jno == "" {
+	// keyset 페이징(cursor 파라미터가 있으면 page_num 대신 사용), 개수 조회 방식
+	page.UseCursor = r.URL.Query().Has(entity.CursorKey)
+	page.Cursor = r.URL.Query().Get(entity.CursorKey)
+	countMode, err := entity.ParseCountMode(r.URL.Query().Get(entity.CountModeKey))
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if (pageNum == "" && !page.UseCursor) || rowSize == "" || searchStartTime == "" || searchEndTime == "" || jno == "" {
 		BadRequestResponse(ctx, w)
 		return
 	}
@@ -323,23 +337,24 @@ func (h *HandlerWorker) SiteBaseListByCompany(w http.ResponseWriter, r *http.Req
 	search.SearchEndTime = utils.ParseNullString(searchEndTime)
 
 	// 조회
-	list, err := h.Service.GetWorkerSiteBaseListByCompany(ctx, page, search, retrySearch)
+	list, cursor, err := h.Service.GetWorkerSiteBaseListByCompany(ctx, page, search, retrySearch)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	// 개수 조회
-	count, err := h.Service.GetWorkerSiteBaseByCompanyCount(ctx, search, retrySearch)
+	count, err := h.Service.GetWorkerSiteBaseByCompanyCount(ctx, search, retrySearch, countMode)
 	if err != nil {
 		FailResponse(ctx, w, err)
 		return
 	}
 
 	values := struct {
-		List  entity.WorkerDailys `json:"list"`
-		Count int                 `json:"count"`
-	}{List: *list, Count: count}
+		List entity.WorkerDailys `json:"list"`
+		entity.PageCount
+		entity.CursorPage
+	}{List: *list, PageCount: count, CursorPage: cursor}
 
 	SuccessValuesResponse(ctx, w, values)
 }
@@ -448,6 +463,28 @@ func (h *HandlerWorker) SiteBaseDeadlineCancel(w http.ResponseWriter, r *http.Re
 
 	err := h.Service.ModifyDeadlineCancel(ctx, workers)
 	if err != nil {
+		// 마감취소할 수 없는 기록이 있으면 거절된 기록과 사유를 응답
+		var rejected *service.DeadlineCancelError
+		if errors.As(err, &rejected) {
+			_ = entity.WriteErrorLog(ctx, err)
+			RespondJSON(
+				ctx,
+				w,
+				&struct {
+					ErrResponse
+					Rejects entity.DeadlineCancelRejects `json:"rejects"`
+				}{
+					ErrResponse: ErrResponse{
+						Result:         Failure,
+						Message:        err.Error(),
+						Details:        DeadlineCancelRejected,
+						HttpStatusCode: http.StatusConflict,
+					},
+					Rejects: rejected.Rejects,
+				},
+				http.StatusOK)
+			return
+		}
 		FailResponse(ctx, w, err)
 		return
 	}
@@ -538,3 +575,125 @@ func (h *HandlerWorker) GetDailyWorkerHistoryReason(w http.ResponseWriter, r *ht
 	}
 	SuccessValuesResponse(ctx, w, list)
 }
+
+// func: 변경 이력 비교 조회
+// 변경전/변경후를 한 쌍으로 바뀐 항목(출퇴근 시간, 공수, 프로젝트, 마감 여부)과 변경한 사용자, 사유를 조회한다.
+// @param
+// - start_date, end_date: 근무일 조회 기간 (YYYY-MM-DD)
+// - sno: 현장pk
+// - keys: 근로자 (없으면 전체)
+func (h *HandlerWorker) GetDailyWorkerHistoryDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	snoString := r.URL.Query().Get("sno")
+	if startDate == "" || endDate == "" || snoString == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	sno, err := strconv.ParseInt(snoString, 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	userKeys := r.URL.Query()["keys"]
+
+	list, err := h.Service.GetHistoryDailyWorkerDiffs(ctx, startDate, endDate, sno, userKeys)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 변경 이력 버전으로 복원
+// @param
+// - cno: 복원할 변경 이력pk
+// - reason: 복원 사유
+func (h *HandlerWorker) RestoreDailyWorkerHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	restore := entity.WorkerHistoryRestore{}
+	if err := json.NewDecoder(r.Body).Decode(&restore); err != nil || !restore.Cno.Valid {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.RestoreHistoryDailyWorker(ctx, restore); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 철야 승인 요청 목록 조회
+// @param
+// - sno: 현장pk
+// - status: 처리 상태 (P: 승인 대기, A: 승인, R: 반려, 없으면 전체)
+func (h *HandlerWorker) OverTimeList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	snoString := r.URL.Query().Get("sno")
+	status := r.URL.Query().Get("status")
+	if snoString == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	sno, err := strconv.ParseInt(snoString, 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	if status != "" && status != entity.OverTimePending && status != entity.OverTimeApproved && status != entity.OverTimeRejected {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	list, err := h.Service.GetWorkerOverTimeList(ctx, sno, status)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 철야 승인
+// @param
+// - ono: 철야 승인 요청pk
+// - reason: 승인 사유
+func (h *HandlerWorker) ApproveOverTime(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	request := entity.WorkerOverTime{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !request.Ono.Valid {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.ApproveWorkerOverTime(ctx, request); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 철야 반려
+// @param
+// - ono: 철야 승인 요청pk
+// - reason: 반려 사유
+func (h *HandlerWorker) RejectOverTime(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	request := entity.WorkerOverTime{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !request.Ono.Valid {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.RejectWorkerOverTime(ctx, request); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"csm-api/entity"
+	"csm-api/utils"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+)
+
+// func: 출퇴근 정정 요청 (협력업체)
+// multipart/form-data
+// @param
+// - corrections: 정정 요청 목록 json (jno, user_key, record_date, in_recog_time, out_recog_time, work_hour, reason)
+// - file: 첨부파일 (선택, 최대 10MB)
+func (h *HandlerWorker) AddCorrection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		FailResponseMessage(ctx, w, utils.CustomErrorf(fmt.Errorf("failed to parse multipart form: %v", err)), "파일 업로드 처리 중 오류가 발생했습니다. (최대 10MB까지 업로드 가능합니다)")
+		return
+	}
+
+	corrections := entity.WorkerCorrections{}
+	if err := json.Unmarshal([]byte(r.FormValue("corrections")), &corrections); err != nil || len(corrections) == 0 {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	// 첨부파일: 요청을 모두 확인한 후 서비스에서 저장
+	var fileName string
+	file, header, err := r.FormFile("file")
+	if err == nil {
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.Printf("file close error: %v", err)
+			}
+		}()
+		fileName = header.Filename
+	} else if err != http.ErrMissingFile {
+		FailResponseMessage(ctx, w, utils.CustomErrorf(fmt.Errorf("failed to receive the file: %v", err)), "파일을 받는 중 오류가 발생했습니다. 다시 시도해주세요.")
+		return
+	}
+
+	if err = h.Service.AddWorkerCorrections(ctx, corrections, file, fileName); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
+
+// func: 출퇴근 정정 요청 목록 조회
+// 협력업체는 본인이 요청한 건만 조회된다.
+// @param
+// - jno: 프로젝트pk
+// - status: 처리 상태 (P: 승인 대기, A: 승인, R: 반려, 없으면 전체)
+// - start_date, end_date: 근무일 조회 기간 (YYYY-MM-DD)
+func (h *HandlerWorker) CorrectionList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jnoString := r.URL.Query().Get("jno")
+	status := r.URL.Query().Get("status")
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if jnoString == "" || startDate == "" || endDate == "" {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	jno, err := strconv.ParseInt(jnoString, 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	if status != "" && status != entity.CorrectionPending && status != entity.CorrectionApproved && status != entity.CorrectionRejected {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	list, err := h.Service.GetWorkerCorrectionList(ctx, jno, status, startDate, endDate)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 출퇴근 정정 요청 일괄 승인
+// @param
+// - crnos: 출퇴근 정정 요청pk 목록
+func (h *HandlerWorker) ApproveCorrection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	review := entity.WorkerCorrectionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || len(review.Crnos) == 0 {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	count, err := h.Service.ApproveWorkerCorrections(ctx, review)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, count)
+}
+
+// func: 출퇴근 정정 요청 일괄 반려
+// @param
+// - crnos: 출퇴근 정정 요청pk 목록
+// - reason: 반려 사유
+func (h *HandlerWorker) RejectCorrection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	review := entity.WorkerCorrectionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || len(review.Crnos) == 0 {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	count, err := h.Service.RejectWorkerCorrections(ctx, review)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, count)
+}
+
+// func: 출퇴근 정정 요청 첨부파일 다운로드
+// @param
+// - crno: 출퇴근 정정 요청pk
+// - jno: 프로젝트pk (목록 조회한 프로젝트)
+func (h *HandlerWorker) CorrectionFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	crno, err := strconv.ParseInt(r.PathValue("crno"), 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	var jno int64
+	if jnoString := r.URL.Query().Get("jno"); jnoString != "" {
+		if jno, err = strconv.ParseInt(jnoString, 10, 64); err != nil {
+			BadRequestResponse(ctx, w)
+			return
+		}
+	}
+
+	correction, err := h.Service.GetWorkerCorrection(ctx, jno, crno)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	if !correction.FilePath.Valid {
+		FailResponse(ctx, w, utils.CustomErrorf(fmt.Errorf("file does not exist: %d", crno)))
+		return
+	}
+
+	f, err := os.Open(correction.FilePath.String)
+	if err != nil {
+		FailResponse(ctx, w, utils.CustomErrorf(fmt.Errorf("failed to open file: %v", err)))
+		return
+	}
+	defer func(f *os.File) {
+		if err := f.Close(); err != nil {
+			log.Printf("file close error: %v", err)
+		}
+	}(f)
+
+	// 다운로드용 응답 헤더 설정
+	encodedName := url.PathEscape(correction.FileName.String)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", encodedName))
+	w.Header().Set("File-Name", correction.FileName.String)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition, File-Name")
+
+	if _, err := io.Copy(w, f); err != nil {
+		log.Printf("failed to copy file stream: %v", err)
+	}
+}
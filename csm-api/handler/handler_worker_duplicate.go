@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"csm-api/entity"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// func: 중복 근로자 후보 조회
+// @param
+// - sno: 현장pk
+// - min_score: 최소 점수 (없으면 기본값)
+func (h *HandlerWorker) DuplicateList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sno, err := strconv.ParseInt(r.URL.Query().Get("sno"), 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+	minScore := 0
+	if minScoreString := r.URL.Query().Get("min_score"); minScoreString != "" {
+		if minScore, err = strconv.Atoi(minScoreString); err != nil {
+			BadRequestResponse(ctx, w)
+			return
+		}
+	}
+
+	list, err := h.Service.GetWorkerDuplicateList(ctx, sno, minScore)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 중복 근로자 처리 내역 조회
+// @param
+// - sno: 현장pk
+func (h *HandlerWorker) DuplicateHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sno, err := strconv.ParseInt(r.URL.Query().Get("sno"), 10, 64)
+	if err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	list, err := h.Service.GetWorkerMergeList(ctx, sno)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, list)
+}
+
+// func: 중복 근로자 병합
+// @param
+// - sno: 현장pk
+// - user_key: 남길 근로자
+// - merged_user_key: 병합할 근로자
+// - reason: 병합 사유
+func (h *HandlerWorker) MergeDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	merge := entity.WorkerMerge{}
+	if err := json.NewDecoder(r.Body).Decode(&merge); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	count, err := h.Service.MergeWorkerDuplicate(ctx, merge)
+	if err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessValuesResponse(ctx, w, count)
+}
+
+// func: 중복 근로자 아님 처리
+// @param
+// - sno: 현장pk
+// - user_key, merged_user_key: 근로자 쌍
+// - reason: 사유
+func (h *HandlerWorker) DismissDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	merge := entity.WorkerMerge{}
+	if err := json.NewDecoder(r.Body).Decode(&merge); err != nil {
+		BadRequestResponse(ctx, w)
+		return
+	}
+
+	if err := h.Service.DismissWorkerDuplicate(ctx, merge); err != nil {
+		FailResponse(ctx, w, err)
+		return
+	}
+	SuccessResponse(ctx, w)
+}
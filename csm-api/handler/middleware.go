@@ -3,66 +3,195 @@ package handler
 import (
 	"context"
 	"csm-api/auth"
+	"csm-api/clock"
 	"csm-api/entity"
+	"csm-api/service"
 	"csm-api/utils"
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
 )
 
 // api호출시 jwt를 확인하는 미들웨어
 func AuthMiddleware(jwt *auth.JWTUtils) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// jwt 유효성 검사
-			req, claims, err := jwt.FillContext(r)
+			// jwt 유효성 검사 (access token이 만료되었으면 refresh token으로 재발급)
+			req, _, err := jwt.FillContext(r)
 			if err != nil {
-				RespondJSON(
-					r.Context(),
-					w,
-					ErrResponse{
-						Result:         Failure,
-						Message:        err.Error(),
-						Details:        InvalidToken,
-						HttpStatusCode: http.StatusUnauthorized,
-					},
-					http.StatusUnauthorized,
-				)
-				return
-			}
-
-			// 아이디 저장을 안 했을 경우
-			if !claims.IsSaved {
-				//jwt 생성
-				tokenString, err := jwt.GenerateToken(claims)
-				if err != nil {
+				claims, refreshErr := RefreshTokenCookies(w, r, jwt)
+				if refreshErr != nil {
 					RespondJSON(
 						r.Context(),
 						w,
-						&ErrResponse{
+						ErrResponse{
 							Result:         Failure,
 							Message:        err.Error(),
-							Details:        TokenCreatedFail,
-							HttpStatusCode: http.StatusInternalServerError,
+							Details:        InvalidToken,
+							HttpStatusCode: http.StatusUnauthorized,
 						},
-						http.StatusOK)
+						http.StatusUnauthorized,
+					)
 					return
 				}
+				req = auth.WithClaims(r, claims)
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// 조회 범위 권한 캐시 유지 시간
+const roleScopeCacheTTL = 5 * time.Minute
+
+// 조회 범위 권한 캐시 (로그인 세션, 역할, 프로젝트별)
+// 요청마다 프로젝트 권한을 조회하지 않도록 roleScopeCacheTTL 동안 저장한다.
+type roleScopeCache struct {
+	clock   clock.Clocker
+	mu      sync.Mutex
+	entries map[string]roleScopeCacheEntry
+}
 
-				// 쿠키 설정(토큰 재발급)
-				cookie := &http.Cookie{
-					Name:     "jwt",
-					Value:    tokenString,
-					Path:     "/",
-					MaxAge:   auth.GetCookieMaxAge(claims.IsSaved),
-					HttpOnly: true,                    // JavaScript로 접근 불가
-					Secure:   false,                   // true:HTTPS, false:HTTPS/HTTP
-					SameSite: http.SameSiteStrictMode, // 동일 출처에서만 쿠키 전송
+type roleScopeCacheEntry struct {
+	scope      entity.UserScope
+	expireDate time.Time
+}
+
+func (c *roleScopeCache) get(key string) (entity.UserScope, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.clock.Now().Before(entry.expireDate) {
+		return entity.UserScope{}, false
+	}
+	return entry.scope, true
+}
+
+func (c *roleScopeCache) set(key string, scope entity.UserScope) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	// 만료된 항목 정리
+	for k, entry := range c.entries {
+		if !now.Before(entry.expireDate) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = roleScopeCacheEntry{scope: scope, expireDate: now.Add(roleScopeCacheTTL)}
+}
+
+// 조회 범위(전체 프로젝트 / 본인이 속한 프로젝트)를 jwt 역할과 프로젝트 권한으로 판단하여 context에 저장하는 미들웨어
+// 전체 프로젝트 조회(IsRole)는 관리자 역할만 가능하며, 프로젝트에 부여된 권한은 해당 프로젝트 권한(IsMember)으로만 저장한다.
+// 요청 파라미터 isRole은 요청 범위로만 사용하며(없으면 권한 내 최대 범위), 권한보다 넓은 범위를 요청하면 403을 반환한다.
+// (프로젝트 권한만 있는 사용자가 isRole=true로 전체 프로젝트를 요청한 경우도 범위를 줄이지 않고 403)
+// 권한은 로그인 세션별로 캐시한다.
+func RoleScopeMiddleware(userService service.UserService, c clock.Clocker) func(http.Handler) http.Handler {
+	cache := &roleScopeCache{clock: c, entries: make(map[string]roleScopeCacheEntry)}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// 요청한 범위
+			requested := true
+			if isRoleStr := r.URL.Query().Get("isRole"); isRoleStr != "" {
+				var err error
+				requested, err = strconv.ParseBool(isRoleStr)
+				if err != nil {
+					BadRequestResponse(ctx, w)
+					return
 				}
-				http.SetCookie(w, cookie)
 			}
 
-			next.ServeHTTP(w, req)
+			role, _ := auth.GetContext(ctx, auth.Role{})
+			unoString, _ := auth.GetContext(ctx, auth.Uno{})
+			uno, _ := strconv.ParseInt(unoString, 10, 64)
+			jno, _ := strconv.ParseInt(r.URL.Query().Get("jno"), 10, 64)
+			sessionId, _ := auth.GetContext(ctx, auth.SessionId{})
+
+			// 권한이 있는 범위
+			key := fmt.Sprintf("%s|%d|%s|%d", sessionId, uno, role, jno)
+			scope, ok := cache.get(key)
+			if !ok {
+				var err error
+				scope, err = userService.GetUserScope(ctx, jno, uno, role)
+				if err != nil {
+					FailResponse(ctx, w, err)
+					return
+				}
+				if sessionId != "" {
+					cache.set(key, scope)
+				}
+			}
+
+			if requested && !scope.IsGlobal && r.URL.Query().Get("isRole") != "" {
+				RespondJSON(
+					ctx,
+					w,
+					&ErrResponse{
+						Result:         Failure,
+						Message:        http.StatusText(http.StatusForbidden),
+						Details:        ForbiddenScope,
+						HttpStatusCode: http.StatusForbidden,
+					},
+					http.StatusForbidden,
+				)
+				return
+			}
+
+			ctx = auth.SetContext(ctx, auth.IsRole{}, strconv.FormatBool(requested && scope.IsGlobal))
+			ctx = auth.SetContext(ctx, auth.IsMember{}, strconv.FormatBool(scope.IsMember))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// 라우트별 접근 권한 정책(역할, 메뉴 권한)을 확인하는 미들웨어
+// 정책이 없거나 권한이 없는 경우 403을 반환한다.
+func PolicyMiddleware(policies auth.RoutePolicies, userRoleService service.UserRoleService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			roleString, _ := auth.GetContext(ctx, auth.Role{})
+			role := auth.JWTRole(roleString)
+
+			allowed := false
+			policy, ok := policies.Find(r.Method, r.URL.Path)
+			if ok {
+				allowed = policy.AllowRole(role)
+				if !allowed && policy.MenuId != "" {
+					valid, err := userRoleService.GetUserMenuRoleCheck(ctx, roleString, policy.MenuId)
+					if err != nil {
+						FailResponse(ctx, w, err)
+						return
+					}
+					allowed = valid
+				}
+			}
+
+			if !allowed {
+				RespondJSON(
+					ctx,
+					w,
+					&ErrResponse{
+						Result:         Failure,
+						Message:        http.StatusText(http.StatusForbidden),
+						Details:        ForbiddenRoute,
+						HttpStatusCode: http.StatusForbidden,
+					},
+					http.StatusForbidden,
+				)
+				return
+			}
+
+			next.ServeHTTP(w, r)
 		})
 	}
 }
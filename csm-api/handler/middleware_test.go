@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/handler"
+	"csm-api/route"
+	"csm-api/service"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 메뉴 권한 확인만 응답하는 역할 서비스
+type menuRoleService struct {
+	service.UserRoleService
+	menus map[string]bool
+}
+
+func (s menuRoleService) GetUserMenuRoleCheck(ctx context.Context, role string, menuId string) (bool, error) {
+	return s.menus[role+":"+menuId], nil
+}
+
+func TestPolicyMiddlewareMenuRole(t *testing.T) {
+	roleService := menuRoleService{menus: map[string]bool{
+		string(auth.SiteManager) + ":compare": true,
+	}}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := handler.PolicyMiddleware(route.Policies, roleService)(next)
+
+	tests := []struct {
+		name     string
+		role     auth.JWTRole
+		path     string
+		wantCode int
+	}{
+		{name: "관리자는 메뉴 권한 없이 허용", role: auth.Admin, path: "/csm/compare/list", wantCode: http.StatusOK},
+		{name: "메뉴 권한이 있는 역할", role: auth.SiteManager, path: "/csm/compare/list", wantCode: http.StatusOK},
+		{name: "메뉴 권한이 없는 역할", role: auth.SiteManager, path: "/csm/deadline/close", wantCode: http.StatusForbidden},
+		{name: "메뉴 권한이 없는 협력업체", role: auth.CoUser, path: "/csm/compare/list", wantCode: http.StatusForbidden},
+		{name: "메뉴와 무관한 조회", role: auth.CoUser, path: "/csm/project/list", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r = r.WithContext(auth.SetContext(r.Context(), auth.Role{}, string(tt.role)))
+			w := httptest.NewRecorder()
+			middleware.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusForbidden {
+				return
+			}
+			var body handler.ErrResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Details != handler.ForbiddenRoute {
+				t.Errorf("details = %q, want %q", body.Details, handler.ForbiddenRoute)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package handler
 import (
 	"context"
 	"csm-api/entity"
+	"csm-api/service"
+	"csm-api/worktime"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -18,18 +21,35 @@ const (
 type ErrDetailsRole string
 
 const (
-	ResponseEncodeError ErrDetailsRole = "Response Encode Error"
-	InvalidToken        ErrDetailsRole = "Invalid Token or Not Found Token"
-	BodyDataParseError  ErrDetailsRole = "Body Data Parse Error"
-	InvalidUser         ErrDetailsRole = "Invalid User"
-	TokenCreatedFail    ErrDetailsRole = "Token Created Fail"
-	NotFoundParam       ErrDetailsRole = "Not Found Parameter"
-	ParsingError        ErrDetailsRole = "Parsing Error"
-	DataAddFailed       ErrDetailsRole = "Data Add Failed"
-	DataModifyFailed    ErrDetailsRole = "Data Modify Failed"
-	DataRemoveFailed    ErrDetailsRole = "Data Remove Failed"
-	DataMergeFailed     ErrDetailsRole = "Data Merge Failed"
-	CallApiFailed       ErrDetailsRole = "Call Api Failed"
+	ResponseEncodeError    ErrDetailsRole = "Response Encode Error"
+	InvalidToken           ErrDetailsRole = "Invalid Token or Not Found Token"
+	BodyDataParseError     ErrDetailsRole = "Body Data Parse Error"
+	InvalidUser            ErrDetailsRole = "Invalid User"
+	TokenCreatedFail       ErrDetailsRole = "Token Created Fail"
+	NotFoundParam          ErrDetailsRole = "Not Found Parameter"
+	ParsingError           ErrDetailsRole = "Parsing Error"
+	DataAddFailed          ErrDetailsRole = "Data Add Failed"
+	DataModifyFailed       ErrDetailsRole = "Data Modify Failed"
+	DataRemoveFailed       ErrDetailsRole = "Data Remove Failed"
+	DataMergeFailed        ErrDetailsRole = "Data Merge Failed"
+	CallApiFailed          ErrDetailsRole = "Call Api Failed"
+	ForbiddenScope         ErrDetailsRole = "Forbidden Scope"
+	ForbiddenRoute         ErrDetailsRole = "Forbidden Route"
+	LoginLocked            ErrDetailsRole = "Login Locked"
+	InvalidOrder           ErrDetailsRole = "Invalid Order"
+	InvalidCursor          ErrDetailsRole = "Invalid Cursor"
+	InvalidDevice          ErrDetailsRole = "Invalid Device"
+	InvalidRule            ErrDetailsRole = "Invalid Rule"
+	StalePreview           ErrDetailsRole = "Stale Preview"
+	DeadlineCancelRejected ErrDetailsRole = "Deadline Cancel Rejected"
+	InvalidCloseState      ErrDetailsRole = "Invalid Close State"
+	UnresolvedCompare      ErrDetailsRole = "Unresolved Compare"
+	InvalidCorrection      ErrDetailsRole = "Invalid Correction"
+	InvalidOverTime        ErrDetailsRole = "Invalid OverTime"
+	InvalidRestore         ErrDetailsRole = "Invalid Restore"
+	InvalidMerge           ErrDetailsRole = "Invalid Merge"
+	InvalidReveal          ErrDetailsRole = "Invalid Reveal"
+	InvalidException       ErrDetailsRole = "Invalid Exception"
 )
 
 type ErrResponse struct {
@@ -73,6 +93,73 @@ func FailResponse(ctx context.Context, w http.ResponseWriter, err error) {
 	//에러 로그 기록
 	_ = entity.WriteErrorLog(ctx, err)
 
+	// 허용되지 않은 정렬 조건, 잘못된 커서, 잘못된 출퇴근 분류 규칙은 서버 오류가 아닌 잘못된 요청으로 응답
+	// 미리보기 이후 데이터가 바뀐 경우, 일일 마감 상태가 맞지 않는 경우는 충돌로 응답
+	var details ErrDetailsRole
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, entity.ErrInvalidOrder):
+		details = InvalidOrder
+	case errors.Is(err, entity.ErrInvalidCursor):
+		details = InvalidCursor
+	case errors.Is(err, worktime.ErrInvalidRule):
+		details = InvalidRule
+	case errors.Is(err, service.ErrStalePreview):
+		details = StalePreview
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrDailyCloseState):
+		details = InvalidCloseState
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrDailyCloseIssue):
+		details = UnresolvedCompare
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrDailyCloseForbidden):
+		details = ForbiddenScope
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrCorrectionState):
+		details = InvalidCorrection
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrCorrectionForbidden):
+		details = ForbiddenScope
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrOverTimeState):
+		details = InvalidOverTime
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrOverTimeForbidden):
+		details = ForbiddenScope
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrHistoryRestore):
+		details = InvalidRestore
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrHistoryForbidden):
+		details = ForbiddenScope
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrWorkerMerge):
+		details = InvalidMerge
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrPiiReveal):
+		details = InvalidReveal
+	case errors.Is(err, service.ErrCompareException):
+		details = InvalidException
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrCompareExceptionForbidden):
+		details = ForbiddenScope
+		status = http.StatusForbidden
+	}
+	if details != "" {
+		RespondJSON(
+			ctx,
+			w,
+			&ErrResponse{
+				Result:         Failure,
+				Message:        err.Error(),
+				Details:        details,
+				HttpStatusCode: status,
+			},
+			http.StatusOK)
+		return
+	}
+
 	RespondJSON(
 		ctx,
 		w,
@@ -41,15 +41,23 @@ func NewInit(safeDb *sqlx.DB) (*Init, error) {
 
 	init := &Init{
 		WorkerService: &service.ServiceWorker{
-			SafeDB:  safeDb,
-			SafeTDB: safeDb,
-			Store:   &r,
-			Config:  cfg,
+			SafeDB:              safeDb,
+			SafeTDB:             safeDb,
+			Store:               &r,
+			ProjectSettingStore: &r,
+			UserStore:           &r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     &r,
 		},
 		WorkHourService: &service.ServiceWorkHour{
 			SafeDB:  safeDb,
 			SafeTDB: safeDb,
 			Store:   &r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		WeatherService: &service.ServiceWeather{
 			ApiKey:       apiCfg,
@@ -117,6 +117,8 @@ func run(ctx context.Context) error {
 		return runWeb(ctx, cfg, safeDb, timesheetDb)
 	case "schedule":
 		return runSchedule(ctx, safeDb, timesheetDb)
+	case "regno-encryption":
+		return runRegNoEncryption(ctx, cfg, safeDb)
 	default:
 		return runWeb(ctx, cfg, safeDb, timesheetDb)
 	}
@@ -5,8 +5,10 @@ import (
 	"csm-api/auth"
 	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
 	"csm-api/handler"
 	"csm-api/route"
+	"csm-api/service"
 	"csm-api/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
@@ -45,42 +47,81 @@ func newMux(ctx context.Context, safeDb *sqlx.DB, timesheetDb *sqlx.DB) (http.Ha
 	})
 	r := store.Repository{Clocker: clock.RealClock{}}
 
-	// jwt struct 생성
-	jwt, err := auth.JwtNew(clock.RealClock{})
+	// api config 생성
+	apiCfg, err := config.GetApiConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	// api config 생성
-	apiCfg, err := config.GetApiConfig()
+	cfg, err := config.NewConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	cfg, err := config.NewConfig()
+	// 주민번호 암호화 키
+	regNoCipher, err := crypto.NewRegNoKeyring(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// 로그인 세션 저장소 (memory: 단일 서버 로컬 개발용)
+	var sessions auth.SessionStore = &store.OracleSessionStore{DB: safeDb}
+	if cfg.SessionStore == "memory" {
+		sessions = auth.NewMemorySessionStore(clock.RealClock{})
+	}
+
+	// 요청 IP 확인 (X-Forwarded-For는 신뢰할 프록시에서 온 요청만 사용)
+	trustedProxies, err := handler.NewTrustedProxies(cfg.TrustedProxies)
 	if err != nil {
 		return nil, err
 	}
+	mux.Use(handler.ClientIpMiddleware(trustedProxies))
+
+	// jwt struct 생성
+	jwt, err := auth.JwtNew(clock.RealClock{}, sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 조회 범위 권한 확인용 서비스
+	userService := &service.ServiceUser{
+		SafeDB:      safeDb,
+		TimeSheetDB: timesheetDb,
+		Store:       &r,
+	}
+
+	// 메뉴 접근 권한 확인용 서비스
+	userRoleService := &service.ServiceUserRole{
+		SafeDB:  safeDb,
+		SafeTDB: safeDb,
+		Store:   &r,
+	}
 
 	mux.Route("/csm", func(csm chi.Router) {
 		// 공개 라우팅
 		csm.Mount("/login", route.LoginRoute(jwt, safeDb, timesheetDb, &r)) // 로그인
-		csm.Mount("/logout", route.LogoutRoute())                           // 로그아웃
+		csm.Mount("/logout", route.LogoutRoute(jwt))                        // 로그아웃
 		csm.Mount("/jwt-validation", route.JwtVaildRoute(jwt))              // jwt 유효성 검사
+		csm.Mount("/token", route.TokenRoute(jwt))                          // 토큰 재발급
+		csm.Mount("/.well-known", route.JwksRoute(jwt))                     // 토큰 검증용 공개키 (JWKS)
+		csm.Mount("/recd", route.RecdRoute(safeDb, cfg, &r))                // 홍채인식기 인식 기록 수신 (근태인식기 api key 인증)
 		//csm.Mount("/init", route.InitApiRoute(safeDb, timesheetDb, apiCfg, cfg, &r)) // api로 초기 세팅
 
 		// 인증 라우팅
 		csm.Group(func(router chi.Router) {
 			router.Use(handler.AuthMiddleware(jwt))                                          // jwt 인증
+			router.Use(handler.PolicyMiddleware(route.Policies, userRoleService))            // 라우트 접근 권한
+			router.Use(handler.RoleScopeMiddleware(userService, clock.RealClock{}))          // 조회 범위 권한
 			router.Mount("/menu", route.MenuRoute(safeDb, &r))                               // 메뉴
 			router.Mount("/user", route.UserRoute(safeDb, timesheetDb, &r))                  // 사용자 {권한}
 			router.Mount("/api", route.ApiRoute(apiCfg, safeDb, &r))                         // api
-			router.Mount("/excel", route.ExcelRoute(safeDb, &r))                             // 엑셀
+			router.Mount("/excel", route.ExcelRoute(safeDb, regNoCipher, &r))                // 엑셀
 			router.Mount("/project", route.ProjectRoute(safeDb, timesheetDb, &r))            // 프로젝트
 			router.Mount("/organization", route.OrganiztionRoute(timesheetDb, &r))           // 조직도
 			router.Mount("/site", route.SiteRoute(safeDb, timesheetDb, &r, apiCfg))          // 현장
-			router.Mount("/worker", route.WorkerRoute(safeDb, cfg, &r))                      // 근로자
-			router.Mount("/compare", route.CompareRoute(safeDb, &r))                         // 일일 근로자 비교
+			router.Mount("/worker", route.WorkerRoute(safeDb, cfg, regNoCipher, &r, apiCfg)) // 근로자
+			router.Mount("/compare", route.CompareRoute(safeDb, regNoCipher, &r))            // 일일 근로자 비교
+			router.Mount("/pii", route.PiiRoute(safeDb, regNoCipher, &r))                    // 개인정보 조회, 접근 기록
 			router.Mount("/deadline", route.DeadlineRoute(safeDb, &r))                       // 일일마감
 			router.Mount("/equip", route.EquipRoute(safeDb, &r))                             // 장비 (임시)
 			router.Mount("/device", route.DeviceRoute(safeDb, &r))                           // 근태인식기
@@ -88,11 +129,18 @@ func newMux(ctx context.Context, safeDb *sqlx.DB, timesheetDb *sqlx.DB) (http.Ha
 			router.Mount("/schedule", route.ScheduleRoute(safeDb, &r))                       // 일정관리
 			router.Mount("/notice", route.NoticeRoute(safeDb, &r))                           // 공지사항
 			router.Mount("/code", route.CodeRoute(safeDb, &r))                               // 코드
-			router.Mount("/project-setting", route.ProjectSettingRoute(safeDb, &r))          // 프로젝트 설정
-			router.Mount("/user-role", route.UserRoleRoute(safeDb, &r))                      // 사용자 권한
-			router.Mount("/system", route.SystemRoute(safeDb, timesheetDb, apiCfg, cfg, &r)) // 시스템관리
+			router.Mount("/project-setting", route.ProjectSettingRoute(safeDb, &r, apiCfg))  // 프로젝트 설정
+			router.Mount("/user-role", route.UserRoleRoute(jwt, safeDb, &r))                 // 사용자 권한
+
+			// 시스템관리
+			router.Mount("/system", route.SystemRoute(safeDb, timesheetDb, apiCfg, cfg, regNoCipher, &r))
 		})
 	})
 
+	// 마운트된 모든 라우트에 접근 권한 정책이 있는지 확인
+	if err := route.Policies.Validate(mux); err != nil {
+		return nil, err
+	}
+
 	return c.Handler(mux), nil
 }
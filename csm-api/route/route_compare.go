@@ -1,6 +1,7 @@
 package route
 
 import (
+	"csm-api/crypto"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -8,19 +9,33 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func CompareRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
+func CompareRoute(safeDB *sqlx.DB, regNoCipher *crypto.Keyring, r *store.Repository) chi.Router {
 	router := chi.NewRouter()
 
 	compareHandler := &handler.HandlerCompare{
 		Service: &service.ServiceCompare{
-			SafeDB:  safeDB,
-			SafeTDB: safeDB,
-			Store:   r,
+			SafeDB:      safeDB,
+			SafeTDB:     safeDB,
+			Store:       r,
+			PiiStore:    r,
+			RegNoCipher: regNoCipher,
+			UserService: &service.ServiceUser{
+				SafeDB: safeDB,
+				Store:  r,
+			},
 		},
 	}
 
 	router.Get("/", compareHandler.List)         // 일일근로자비교 리스트
 	router.Put("/", compareHandler.CompareState) // 일일근로자비교 반영
 
+	router.Get("/department-alias", compareHandler.DepartmentAliasList)      // 부서 별칭 조회
+	router.Post("/department-alias", compareHandler.MergeDepartmentAlias)    // 부서 별칭 저장
+	router.Delete("/department-alias", compareHandler.RemoveDepartmentAlias) // 부서 별칭 삭제
+
+	router.Get("/exception", compareHandler.ExceptionList)              // 예외 목록
+	router.Put("/exception/assignee", compareHandler.ExceptionAssignee) // 예외 담당자 지정
+	router.Put("/exception/resolve", compareHandler.ExceptionResolve)   // 예외 처리
+
 	return router
 }
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"csm-api/clock"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -17,9 +18,21 @@ func DeadlineRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
 			TDB:   safeDB,
 			Store: r,
 		},
+		CloseService: &service.ServiceDailyClose{
+			SafeDB:    safeDB,
+			SafeTDB:   safeDB,
+			Store:     r,
+			UserStore: r,
+			Clock:     clock.RealClock{},
+		},
 	}
 
-	router.Get("/", deadlineHandler.UploadFileList) // 일일마감 엑셀 자료 정보
+	router.Get("/", deadlineHandler.UploadFileList)             // 일일마감 엑셀 자료 정보
+	router.Get("/close", deadlineHandler.CloseList)             // 일일 마감 현황
+	router.Post("/close/submit", deadlineHandler.CloseSubmit)   // 일일 마감 요청 (현장 관리자)
+	router.Post("/close/approve", deadlineHandler.CloseApprove) // 일일 마감 승인 (현장소장)
+	router.Post("/close/reject", deadlineHandler.CloseReject)   // 일일 마감 반려 (현장소장)
+	router.Post("/close/lock", deadlineHandler.CloseLock)       // 일일 마감 (현장소장)
 
 	return router
 }
@@ -24,6 +24,7 @@ func DeviceRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
 	router.Put("/", deviceHandler.Modify)                          // 수정
 	router.Post("/delete", deviceHandler.Remove)                   // 삭제
 	router.Get("/check-registered", deviceHandler.CheckRegistered) // 장치 등록 확인
+	router.Post("/api-key", deviceHandler.IssueApiKey)             // 인식 기록 수신용 api key 발급
 
 	return router
 }
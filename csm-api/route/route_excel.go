@@ -1,6 +1,7 @@
 package route
 
 import (
+	"csm-api/crypto"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -8,7 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func ExcelRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
+func ExcelRoute(safeDB *sqlx.DB, regNoCipher *crypto.Keyring, r *store.Repository) chi.Router {
 	router := chi.NewRouter()
 
 	excelHandler := &handler.HandlerExcel{
@@ -18,6 +19,7 @@ func ExcelRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
 			Store:       r,
 			WorkerStore: r,
 			FileStore:   r,
+			RegNoCipher: regNoCipher,
 		},
 		FileService: &service.ServiceUploadFile{
 			DB:    safeDB,
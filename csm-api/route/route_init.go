@@ -1,6 +1,7 @@
 package route
 
 import (
+	"csm-api/clock"
 	"csm-api/config"
 	"csm-api/handler"
 	"csm-api/service"
@@ -15,15 +16,23 @@ func InitApiRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfi
 	initApihandler := &handler.InitApiHandler{
 
 		WorkerService: &service.ServiceWorker{
-			SafeDB:  safeDb,
-			SafeTDB: safeDb,
-			Store:   r,
-			Config:  cfg,
+			SafeDB:              safeDb,
+			SafeTDB:             safeDb,
+			Store:               r,
+			ProjectSettingStore: r,
+			UserStore:           r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     r,
 		},
 		WorkHourService: &service.ServiceWorkHour{
 			SafeDB:  safeDb,
 			SafeTDB: safeDb,
 			Store:   r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		ProjectService: &service.ServiceProject{
 			SafeDB:  safeDb,
@@ -35,6 +44,11 @@ func InitApiRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfi
 			SafeTDB:       safeDb,
 			Store:         r,
 			WorkHourStore: r,
+			WorkerStore:   r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		WeatherService: &service.ServiceWeather{
 			ApiKey:       apiCfg,
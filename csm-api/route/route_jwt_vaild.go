@@ -16,3 +16,25 @@ func JwtVaildRoute(jwt *auth.JWTUtils) chi.Router {
 
 	return router
 }
+
+func TokenRoute(jwt *auth.JWTUtils) chi.Router {
+	router := chi.NewRouter()
+
+	tokenHandler := &handler.TokenHandler{
+		Jwt: jwt,
+	}
+	router.Post("/refresh", tokenHandler.Refresh) // 토큰 재발급
+
+	return router
+}
+
+func JwksRoute(jwt *auth.JWTUtils) chi.Router {
+	router := chi.NewRouter()
+
+	tokenHandler := &handler.TokenHandler{
+		Jwt: jwt,
+	}
+	router.Get("/jwks.json", tokenHandler.Jwks) // 토큰 검증용 공개키 목록
+
+	return router
+}
@@ -15,12 +15,15 @@ func LoginRoute(jwt *auth.JWTUtils, safeDB *sqlx.DB, timesheetDB *sqlx.DB, r *st
 	loginHandler := &handler.LoginHandler{
 		Service: &service.UserValid{
 			DB:    safeDB,
+			TDB:   safeDB,
 			Store: r,
 			UserService: &service.ServiceUser{
 				SafeDB:      safeDB,
 				TimeSheetDB: timesheetDB,
 				Store:       r,
 			},
+			Verifier:        auth.NewCredentialVerifier(auth.MD5Password),
+			CompanyVerifier: auth.NewCredentialVerifier(auth.PlainPassword),
 		},
 		Jwt: jwt,
 	}
@@ -30,11 +33,14 @@ func LoginRoute(jwt *auth.JWTUtils, safeDB *sqlx.DB, timesheetDB *sqlx.DB, r *st
 	return router
 }
 
-func LogoutRoute() chi.Router {
+func LogoutRoute(jwt *auth.JWTUtils) chi.Router {
 	router := chi.NewRouter()
 
-	logoutHandler := &handler.LogoutHandler{}
-	router.Post("/", logoutHandler.ServeHTTP)
+	logoutHandler := &handler.LogoutHandler{
+		Jwt: jwt,
+	}
+	router.Post("/", logoutHandler.ServeHTTP)    // 로그아웃
+	router.Post("/all", logoutHandler.LogoutAll) // 모든 기기 로그아웃
 
 	return router
 }
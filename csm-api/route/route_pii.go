@@ -0,0 +1,29 @@
+package route
+
+import (
+	"csm-api/crypto"
+	"csm-api/handler"
+	"csm-api/service"
+	"csm-api/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+func PiiRoute(safeDB *sqlx.DB, regNoCipher *crypto.Keyring, r *store.Repository) chi.Router {
+	router := chi.NewRouter()
+
+	piiHandler := &handler.HandlerPii{
+		Service: &service.ServicePii{
+			SafeDB:      safeDB,
+			SafeTDB:     safeDB,
+			Store:       r,
+			RegNoCipher: regNoCipher,
+		},
+	}
+
+	router.Post("/reveal", piiHandler.Reveal) // 근로자 개인정보 단건 조회 (사유 기록)
+	router.Get("/report", piiHandler.Report)  // 사용자별 개인정보 접근 현황
+	router.Get("/log", piiHandler.LogList)    // 개인정보 접근 기록
+
+	return router
+}
@@ -0,0 +1,166 @@
+package route
+
+import (
+	"csm-api/auth"
+	"net/http"
+)
+
+// 시스템 관리 권한
+var systemAdminRoles = []auth.JWTRole{auth.SystemAdmin}
+
+// 관리자 권한
+var adminRoles = []auth.JWTRole{auth.SystemAdmin, auth.SuperAdmin, auth.Admin}
+
+// 협력업체 권한
+var coRoles = []auth.JWTRole{auth.CoUser, auth.CoManager}
+
+// 직원 권한 (협력업체 제외, 현장별 권한은 서비스에서 확인)
+var staffRoles = []auth.JWTRole{
+	auth.SystemAdmin, auth.SuperAdmin, auth.Admin,
+	auth.SiteDirector, auth.SiteManager, auth.TempSiteManager, auth.SafetyManager, auth.Supervisor,
+	auth.Executive, auth.User,
+}
+
+// 메뉴 아이디 (IRIS_MENU_SET.MENU_ID)
+// 메뉴 화면 전용 라우트는 관리자 역할이 아니면 역할별 메뉴 권한(IRIS_USER_MENU)이 있어야 한다.
+const (
+	menuCompare        = "compare"         // 일일 근로자 비교
+	menuDeadline       = "deadline"        // 일일마감
+	menuEquip          = "equip"           // 장비
+	menuDevice         = "device"          // 근태인식기
+	menuProjectSetting = "project-setting" // 프로젝트 설정
+)
+
+// 라우트별 접근 권한 정책
+// newMux에 마운트된 모든 라우트는 이 목록 중 하나 이상의 정책과 일치해야 한다. (서버 시작시 확인)
+// 여러 정책이 일치하는 경우 더 구체적인 정책을 사용한다.
+// 인증 라우트의 GET 이외 요청은 허용하는 정책이 없으면 거부한다.
+var Policies = auth.RoutePolicies{
+	// 공개
+	{Method: "*", Pattern: "/csm/login/*"},          // 로그인
+	{Method: "*", Pattern: "/csm/logout/*"},         // 로그아웃
+	{Method: "*", Pattern: "/csm/jwt-validation/*"}, // jwt 유효성 검사
+	{Method: "*", Pattern: "/csm/token/*"},          // 토큰 재발급
+	{Method: "*", Pattern: "/csm/.well-known/*"},    // 토큰 검증용 공개키 (JWKS)
+	{Method: "*", Pattern: "/csm/recd/*"},           // 홍채인식기 인식 기록 수신 (근태인식기 api key 인증)
+
+	// 조회: 인증된 모든 사용자
+	{Method: http.MethodGet, Pattern: "/csm/menu/*"},         // 메뉴
+	{Method: http.MethodGet, Pattern: "/csm/user/*"},         // 사용자 {권한}
+	{Method: http.MethodGet, Pattern: "/csm/api/*"},          // api
+	{Method: http.MethodGet, Pattern: "/csm/excel/*"},        // 엑셀
+	{Method: http.MethodGet, Pattern: "/csm/project/*"},      // 프로젝트
+	{Method: http.MethodGet, Pattern: "/csm/organization/*"}, // 조직도
+	{Method: http.MethodGet, Pattern: "/csm/site/*"},         // 현장
+	{Method: http.MethodGet, Pattern: "/csm/worker/*"},       // 근로자
+	{Method: http.MethodGet, Pattern: "/csm/company/*"},      // 협력업체
+	{Method: http.MethodGet, Pattern: "/csm/schedule/*"},     // 일정관리
+	{Method: http.MethodGet, Pattern: "/csm/notice/*"},       // 공지사항
+	{Method: http.MethodGet, Pattern: "/csm/user-role/*"},    // 사용자 권한 조회
+
+	// 조회: 메뉴 권한이 있는 사용자 (관리자는 모두)
+	{Method: http.MethodGet, Pattern: "/csm/compare/*", Roles: adminRoles, MenuId: menuCompare},
+	{Method: http.MethodGet, Pattern: "/csm/deadline/*", Roles: adminRoles, MenuId: menuDeadline},
+	{Method: http.MethodGet, Pattern: "/csm/equip/*", Roles: adminRoles, MenuId: menuEquip},
+	{Method: http.MethodGet, Pattern: "/csm/device/*", Roles: adminRoles, MenuId: menuDevice},
+	{Method: http.MethodGet, Pattern: "/csm/project-setting/*", Roles: adminRoles, MenuId: menuProjectSetting},
+
+	// 코드: 조회는 모든 사용자, 변경은 시스템 관리자만
+	{Method: http.MethodGet, Pattern: "/csm/code/*"},
+	{Method: "*", Pattern: "/csm/code/*", Roles: systemAdminRoles},
+
+	// 엑셀: import는 직원만, 근태기록 export(조회 조건 POST)는 인증된 모든 사용자
+	{Method: http.MethodPost, Pattern: "/csm/excel/import", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/excel/daily-worker/record/export"},
+
+	// 사용자 권한 변경은 관리자만
+	{Method: http.MethodPost, Pattern: "/csm/user-role/add", Roles: adminRoles},
+	{Method: http.MethodPost, Pattern: "/csm/user-role/remove", Roles: adminRoles},
+	{Method: http.MethodPost, Pattern: "/csm/user-role/session/revoke", Roles: adminRoles},
+
+	// 현장, 현장 프로젝트 생성/삭제/사용여부 변경은 관리자만, 공정률은 직원
+	{Method: http.MethodPost, Pattern: "/csm/site", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site", Roles: adminRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/site/{sno}", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site/non-use", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site/use", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site/non-use/job", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site/use/job", Roles: adminRoles},
+	{Method: http.MethodPost, Pattern: "/csm/site/work-rate", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/site/work-rate", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/project/default", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/project/use", Roles: adminRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/project/{sno}/{jno}", Roles: adminRoles},
+
+	// 프로젝트 설정(근무 시간대, 공수, 공수 변경 미리보기/적용)은 직원
+	{Method: http.MethodPost, Pattern: "/csm/project-setting", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project-setting/shifts/{jno}", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project-setting/man-hours", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project-setting/man-hours/{mhno}", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project-setting/man-hours/preview/{jno}", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/project-setting/man-hours/apply/{jno}", Roles: staffRoles},
+
+	// 근로자 변경은 직원 (철야 승인/반려, 변경 이력 복원, 정정 승인/반려는 현장별 권한을 서비스에서 확인)
+	{Method: http.MethodPost, Pattern: "/csm/worker/total", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/worker/total", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/total/delete", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/deadline", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/project", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/delete", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/deadline-cancel", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/work-hours", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/site-base/restore", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/overtime/approve", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/overtime/reject", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/correction/approve", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/worker/correction/reject", Roles: staffRoles},
+
+	// 출퇴근 정정 요청은 협력업체만 (승인/반려 권한은 현장별로 확인)
+	{Method: http.MethodPost, Pattern: "/csm/worker/correction", Roles: coRoles},
+
+	// 중복 근로자 조회, 병합은 관리자만
+	{Method: "*", Pattern: "/csm/worker/duplicate/*", Roles: adminRoles},
+
+	// 일일 근로자 비교 반영, 예외 조회/담당자 지정/처리는 직원 (프로젝트 권한은 서비스에서 확인)
+	{Method: http.MethodPut, Pattern: "/csm/compare", Roles: staffRoles},
+	{Method: http.MethodGet, Pattern: "/csm/compare/exception", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/compare/exception/assignee", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/compare/exception/resolve", Roles: staffRoles},
+
+	// 일일 근로자 비교 부서 별칭 변경은 관리자만
+	{Method: http.MethodPost, Pattern: "/csm/compare/department-alias", Roles: adminRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/compare/department-alias", Roles: adminRoles},
+
+	// 일일 마감 요청/승인/반려/마감은 직원 (현장별 권한은 서비스에서 확인)
+	{Method: http.MethodPost, Pattern: "/csm/deadline/close/submit", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/deadline/close/approve", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/deadline/close/reject", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/deadline/close/lock", Roles: staffRoles},
+
+	// 장비, 일정(휴무일, 작업내용), 공지사항 변경은 직원
+	{Method: http.MethodPost, Pattern: "/csm/equip", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/schedule/rest", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/schedule/rest", Roles: staffRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/schedule/rest/{cno}", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/schedule/daily-job", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/schedule/daily-job", Roles: staffRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/schedule/daily-job/{idx}", Roles: staffRoles},
+	{Method: http.MethodPost, Pattern: "/csm/notice", Roles: staffRoles},
+	{Method: http.MethodPut, Pattern: "/csm/notice", Roles: staffRoles},
+	{Method: http.MethodDelete, Pattern: "/csm/notice/{idx}", Roles: staffRoles},
+
+	// 근태인식기 변경, api key 발급은 관리자만
+	{Method: http.MethodPost, Pattern: "/csm/device", Roles: adminRoles},
+	{Method: http.MethodPut, Pattern: "/csm/device", Roles: adminRoles},
+	{Method: http.MethodPost, Pattern: "/csm/device/delete", Roles: adminRoles},
+	{Method: http.MethodPost, Pattern: "/csm/device/api-key", Roles: adminRoles},
+
+	// 근로자 개인정보 단건 조회는 관리자만, 접근 기록 조회는 시스템 관리자만
+	{Method: http.MethodPost, Pattern: "/csm/pii/reveal", Roles: adminRoles},
+	{Method: "*", Pattern: "/csm/pii/*", Roles: systemAdminRoles},
+
+	// 시스템관리(배치 수동 실행)는 시스템 관리자만
+	{Method: "*", Pattern: "/csm/system/*", Roles: systemAdminRoles},
+}
@@ -1,6 +1,8 @@
 package route
 
 import (
+	"csm-api/clock"
+	"csm-api/config"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -8,7 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func ProjectSettingRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
+func ProjectSettingRoute(safeDB *sqlx.DB, r *store.Repository, apiConfig *config.ApiConfig) chi.Router {
 	router := chi.NewRouter()
 
 	projectSettingHandler := &handler.HandlerProjectSetting{
@@ -17,13 +19,21 @@ func ProjectSettingRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
 			SafeTDB:       safeDB,
 			Store:         r,
 			WorkHourStore: r,
+			WorkerStore:   r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiConfig,
+			},
+			Clock: clock.RealClock{},
 		},
 	}
 
-	router.Get("/{jno}", projectSettingHandler.ProjectSettingList)        // 프로젝트 기본 설정 정보 조회
-	router.Post("/", projectSettingHandler.MergeProjectSetting)           // 프로젝트 기본 정보 추가 및 수정
-	router.Get("/man-hours/{jno}", projectSettingHandler.ManHourList)     // 프로젝트 공수 정보 조회
-	router.Post("/man-hours", projectSettingHandler.MergeManHours)        // 프로젝트 공수 정보 추가 및 수정
-	router.Post("/man-hours/{mhno}", projectSettingHandler.DeleteManHour) // 프로젝트 공수정보 삭제
+	router.Get("/{jno}", projectSettingHandler.ProjectSettingList)                 // 프로젝트 기본 설정 정보 조회
+	router.Post("/", projectSettingHandler.MergeProjectSetting)                    // 프로젝트 기본 정보 추가 및 수정
+	router.Post("/shifts/{jno}", projectSettingHandler.MergeProjectShifts)         // 프로젝트 근무 시간대(출퇴근 분류 규칙) 수정
+	router.Get("/man-hours/{jno}", projectSettingHandler.ManHourList)              // 프로젝트 공수 정보 조회
+	router.Post("/man-hours", projectSettingHandler.MergeManHours)                 // 프로젝트 공수 정보 추가 및 수정
+	router.Post("/man-hours/preview/{jno}", projectSettingHandler.PreviewManHours) // 프로젝트 공수 변경 미리보기
+	router.Post("/man-hours/apply/{jno}", projectSettingHandler.ApplyManHours)     // 프로젝트 공수 변경 미리보기 적용
+	router.Post("/man-hours/{mhno}", projectSettingHandler.DeleteManHour)          // 프로젝트 공수정보 삭제
 	return router
 }
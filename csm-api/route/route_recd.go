@@ -0,0 +1,40 @@
+package route
+
+import (
+	"csm-api/clock"
+	"csm-api/config"
+	"csm-api/handler"
+	"csm-api/service"
+	"csm-api/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// 홍채인식기 인식 기록 수신 (jwt 대신 근태인식기 api key로 인증)
+func RecdRoute(safeDB *sqlx.DB, cfg *config.Config, r *store.Repository) chi.Router {
+	router := chi.NewRouter()
+
+	recdService := &service.ServiceRecd{
+		SafeDB:      safeDB,
+		SafeTDB:     safeDB,
+		Store:       r,
+		DeviceStore: r,
+		WorkerService: &service.ServiceWorker{
+			SafeDB:              safeDB,
+			SafeTDB:             safeDB,
+			Store:               r,
+			ProjectSettingStore: r,
+			UserStore:           r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     r,
+		},
+		Clock: clock.RealClock{},
+	}
+	recdHandler := &handler.RecdHandler{Service: recdService}
+
+	router.Use(handler.DeviceAuthMiddleware(recdService))
+	router.Post("/", recdHandler.Ingest) // 인식 기록 수신
+
+	return router
+}
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"csm-api/auth"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -8,7 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func UserRoleRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
+func UserRoleRoute(jwt *auth.JWTUtils, safeDB *sqlx.DB, r *store.Repository) chi.Router {
 	router := chi.NewRouter()
 
 	userRoleHandler := &handler.HandlerUserRole{
@@ -17,12 +18,14 @@ func UserRoleRoute(safeDB *sqlx.DB, r *store.Repository) chi.Router {
 			SafeTDB: safeDB,
 			Store:   r,
 		},
+		Jwt: jwt,
 	}
 
-	router.Get("/uno", userRoleHandler.GetUserRoleListByUno)     // 사용자 권한 조회
-	router.Post("/add", userRoleHandler.AddUserRole)             // 사용자 권한 추가
-	router.Post("/remove", userRoleHandler.RemoveUserRole)       // 사용자 권한 삭제
-	router.Get("/menu-valid", userRoleHandler.UserMenuRoleCheck) // 사용자 메뉴 접근 권한 체크
+	router.Get("/uno", userRoleHandler.GetUserRoleListByUno)          // 사용자 권한 조회
+	router.Post("/add", userRoleHandler.AddUserRole)                  // 사용자 권한 추가
+	router.Post("/remove", userRoleHandler.RemoveUserRole)            // 사용자 권한 삭제
+	router.Get("/menu-valid", userRoleHandler.UserMenuRoleCheck)      // 사용자 메뉴 접근 권한 체크
+	router.Post("/session/revoke", userRoleHandler.RevokeUserSession) // 사용자 세션 폐기
 
 	return router
 }
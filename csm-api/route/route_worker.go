@@ -1,7 +1,9 @@
 package route
 
 import (
+	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -9,15 +11,26 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func WorkerRoute(safeDB *sqlx.DB, cfg *config.Config, r *store.Repository) chi.Router {
+func WorkerRoute(safeDB *sqlx.DB, cfg *config.Config, regNoCipher *crypto.Keyring, r *store.Repository, apiConfig *config.ApiConfig) chi.Router {
 	router := chi.NewRouter()
 
 	workerHandler := handler.HandlerWorker{
 		Service: &service.ServiceWorker{
-			SafeDB:  safeDB,
-			SafeTDB: safeDB,
-			Store:   r,
-			Config:  cfg,
+			SafeDB:              safeDB,
+			SafeTDB:             safeDB,
+			Store:               r,
+			ProjectSettingStore: r,
+			UserStore:           r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     r,
+			UploadFileStore:     r,
+			WorkHourStore:       r,
+			RegNoCipher:         regNoCipher,
+			PiiStore:            r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiConfig,
+			},
 		},
 	}
 
@@ -38,6 +51,20 @@ func WorkerRoute(safeDB *sqlx.DB, cfg *config.Config, r *store.Repository) chi.R
 	router.Post("/site-base/work-hours", workerHandler.ModifyWorkHours)             // 현장근로자 일괄 공수 변경
 	router.Get("/site-base/history", workerHandler.GetDailyWorkerHistory)           // 변경 이력 조회
 	router.Get("/site-base/reason", workerHandler.GetDailyWorkerHistoryReason)      // 변경 이력 사유 조회
+	router.Get("/site-base/diff", workerHandler.GetDailyWorkerHistoryDiff)          // 변경 이력 비교 조회
+	router.Post("/site-base/restore", workerHandler.RestoreDailyWorkerHistory)      // 변경 이력 버전으로 복원
+	router.Get("/overtime", workerHandler.OverTimeList)                             // 철야 승인 요청 조회
+	router.Post("/overtime/approve", workerHandler.ApproveOverTime)                 // 철야 승인
+	router.Post("/overtime/reject", workerHandler.RejectOverTime)                   // 철야 반려
+	router.Post("/correction", workerHandler.AddCorrection)                         // 출퇴근 정정 요청 - 협력업체
+	router.Get("/correction", workerHandler.CorrectionList)                         // 출퇴근 정정 요청 조회
+	router.Post("/correction/approve", workerHandler.ApproveCorrection)             // 출퇴근 정정 요청 일괄 승인
+	router.Post("/correction/reject", workerHandler.RejectCorrection)               // 출퇴근 정정 요청 일괄 반려
+	router.Get("/correction/file/{crno}", workerHandler.CorrectionFile)             // 출퇴근 정정 요청 첨부파일 다운로드
+	router.Get("/duplicate", workerHandler.DuplicateList)                           // 중복 근로자 후보 조회
+	router.Get("/duplicate/history", workerHandler.DuplicateHistory)                // 중복 근로자 처리 내역 조회
+	router.Post("/duplicate/merge", workerHandler.MergeDuplicate)                   // 중복 근로자 병합
+	router.Post("/duplicate/dismiss", workerHandler.DismissDuplicate)               // 중복 근로자 아님 처리
 
 	return router
 }
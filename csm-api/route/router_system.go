@@ -1,7 +1,9 @@
 package route
 
 import (
+	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
 	"csm-api/handler"
 	"csm-api/service"
 	"csm-api/store"
@@ -9,22 +11,30 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func SystemRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfig, cfg *config.Config, r *store.Repository) chi.Router {
+func SystemRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfig, cfg *config.Config, regNoCipher *crypto.Keyring, r *store.Repository) chi.Router {
 
 	router := chi.NewRouter()
 
 	systemHandler := &handler.SystemHandler{
 
 		WorkerService: &service.ServiceWorker{
-			SafeDB:  safeDb,
-			SafeTDB: safeDb,
-			Store:   r,
-			Config:  cfg,
+			SafeDB:              safeDb,
+			SafeTDB:             safeDb,
+			Store:               r,
+			ProjectSettingStore: r,
+			UserStore:           r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     r,
 		},
 		WorkHourService: &service.ServiceWorkHour{
 			SafeDB:  safeDb,
 			SafeTDB: safeDb,
 			Store:   r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		ProjectService: &service.ServiceProject{
 			SafeDB:  safeDb,
@@ -36,6 +46,11 @@ func SystemRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfig
 			SafeTDB:       safeDb,
 			Store:         r,
 			WorkHourStore: r,
+			WorkerStore:   r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		WeatherService: &service.ServiceWeather{
 			ApiKey:       apiCfg,
@@ -58,6 +73,19 @@ func SystemRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfig
 				Store:       r,
 			},
 		},
+		RetentionService: &service.ServiceRetention{
+			SafeDB:          safeDb,
+			SafeTDB:         safeDb,
+			WorkerStore:     r,
+			UploadFileStore: r,
+			Config:          cfg,
+		},
+		CompareService: &service.ServiceCompare{
+			SafeDB:      safeDb,
+			SafeTDB:     safeDb,
+			Store:       r,
+			RegNoCipher: regNoCipher,
+		},
 	}
 
 	router.Get("/worker-deadline", systemHandler.WorkerDeadline)     // 근로자 마감 처리
@@ -66,6 +94,9 @@ func SystemRoute(safeDb *sqlx.DB, timesheetDb *sqlx.DB, apiCfg *config.ApiConfig
 	router.Get("/update-workhour", systemHandler.UpdateWorkHour)     // 근로자 공수 계산
 	router.Get("/setting-workrate", systemHandler.SettingWorkRate)   // 당일 공정률 기록
 	router.Post("/manhour", systemHandler.AddManHour)                // 공수 추가
+	router.Get("/retention", systemHandler.RetentionReport)          // 보관 기간 처리 dry-run 보고
+	router.Post("/retention", systemHandler.Retention)               // 보관 기간 처리
+	router.Get("/compare-reconcile", systemHandler.CompareReconcile) // 일일 근로자 비교 자동 대사
 
 	return router
 
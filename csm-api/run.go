@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
+	"csm-api/service"
+	"csm-api/store"
 	"csm-api/utils"
 	"fmt"
 	"github.com/jmoiron/sqlx"
@@ -65,3 +69,29 @@ func runSchedule(ctx context.Context, safeDb, timesheetDb *sqlx.DB) error {
 	// 스케줄러는 Run만 실행, 종료 신호는 내부에서 ctx.Done()으로 처리
 	return scheduler.Run(ctx)
 }
+
+// 주민번호 재암호화 (키 교체 후 1회 실행)
+// ROLE=regno-encryption
+func runRegNoEncryption(ctx context.Context, cfg *config.Config, safeDb *sqlx.DB) error {
+	regNoCipher, err := crypto.NewRegNoKeyring(cfg)
+	if err != nil {
+		return utils.CustomMessageErrorf("crypto.NewRegNoKeyring", err)
+	}
+
+	r := store.Repository{Clocker: clock.RealClock{}}
+	workerService := &service.ServiceWorker{
+		SafeDB:      safeDb,
+		SafeTDB:     safeDb,
+		Store:       &r,
+		Config:      cfg,
+		Clock:       clock.RealClock{},
+		RegNoCipher: regNoCipher,
+	}
+
+	count, err := workerService.ModifyRegNoEncryption(ctx)
+	if err != nil {
+		return utils.CustomMessageErrorf("ModifyRegNoEncryption", err)
+	}
+	log.Printf("ModifyRegNoEncryption %d completed (key: %s)\n", count, regNoCipher.ActiveKey(time.Now()).ID)
+	return nil
+}
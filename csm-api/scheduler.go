@@ -5,6 +5,7 @@ import (
 	"csm-api/auth"
 	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
 	"csm-api/entity"
 	"csm-api/service"
 	"csm-api/store"
@@ -31,6 +32,11 @@ type Scheduler struct {
 	ProjectSettingService service.ProjectSettingService
 	WeatherService        service.WeatherApiService
 	SiteService           service.SiteService
+	DailyCloseService     service.DailyCloseService
+	RetentionService      service.RetentionService
+	RetentionExecute      bool // 보관 기간 처리 실행 여부 (false면 dry-run 보고만)
+	CompareService        service.CompareService
+	Sessions              auth.SessionStore // 로그인 세션 저장소 (memory면 nil, 서버 재시작시 사라짐)
 	cron                  *cron.Cron
 }
 
@@ -38,17 +44,42 @@ func NewScheduler(safeDb *sqlx.DB, apiCfg *config.ApiConfig, cfg *config.Config,
 	r := store.Repository{Clocker: clock.RealClock{}}
 	c := cron.New(cron.WithSeconds())
 
+	regNoCipher, err := crypto.NewRegNoKeyring(cfg)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	retention, err := config.GetRetentionConfig()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	// 만료 세션 정리 대상 (memory 저장소는 웹 서버에만 있음)
+	var sessions auth.SessionStore
+	if cfg.SessionStore != "memory" {
+		sessions = &store.OracleSessionStore{DB: safeDb}
+	}
+
 	scheduler := &Scheduler{
 		WorkerService: &service.ServiceWorker{
-			SafeDB:  safeDb,
-			SafeTDB: safeDb,
-			Store:   &r,
-			Config:  cfg,
+			SafeDB:              safeDb,
+			SafeTDB:             safeDb,
+			Store:               &r,
+			ProjectSettingStore: &r,
+			UserStore:           &r,
+			Config:              cfg,
+			Clock:               clock.RealClock{},
+			DailyCloseStore:     &r,
+			RegNoCipher:         regNoCipher,
 		},
 		WorkHourService: &service.ServiceWorkHour{
 			SafeDB:  safeDb,
 			SafeTDB: safeDb,
 			Store:   &r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		ProjectService: &service.ServiceProject{
 			SafeDB:  safeDb,
@@ -60,6 +91,11 @@ func NewScheduler(safeDb *sqlx.DB, apiCfg *config.ApiConfig, cfg *config.Config,
 			SafeTDB:       safeDb,
 			Store:         &r,
 			WorkHourStore: &r,
+			WorkerStore:   &r,
+			RestDateApiService: &service.ServiceRestDate{
+				ApiKey: apiCfg,
+			},
+			Clock: clock.RealClock{},
 		},
 		WeatherService: &service.ServiceWeather{
 			ApiKey:       apiCfg,
@@ -82,6 +118,28 @@ func NewScheduler(safeDb *sqlx.DB, apiCfg *config.ApiConfig, cfg *config.Config,
 				Store:       &r,
 			},
 		},
+		DailyCloseService: &service.ServiceDailyClose{
+			SafeDB:    safeDb,
+			SafeTDB:   safeDb,
+			Store:     &r,
+			UserStore: &r,
+			Clock:     clock.RealClock{},
+		},
+		RetentionService: &service.ServiceRetention{
+			SafeDB:          safeDb,
+			SafeTDB:         safeDb,
+			WorkerStore:     &r,
+			UploadFileStore: &r,
+			Config:          cfg,
+		},
+		RetentionExecute: retention.Execute,
+		CompareService: &service.ServiceCompare{
+			SafeDB:      safeDb,
+			SafeTDB:     safeDb,
+			Store:       &r,
+			RegNoCipher: regNoCipher,
+		},
+		Sessions: sessions,
 
 		cron: c,
 	}
@@ -103,23 +161,30 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		} else {
 			log.Println("[Scheduler] ModifyWorkerDeadlineSchedule completed")
 		}
+
+		// 승인된 일일 마감 마감처리 (자동 마감하지 않는 프로젝트)
+		if count, err := s.DailyCloseService.ModifyDailyCloseApproved(ctx); err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyDailyCloseApproved", err))
+		} else if count != 0 {
+			log.Printf("[Scheduler] ModifyDailyCloseApproved %d locked\n", count)
+		}
 	})
 	if err != nil {
 		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
 	}
 
-	// 철야 확인 작업::1분마다
-	//_, err = s.cron.AddFunc("0 0/1 * * * *", func() {
-	//	var count int
-	//	if count, err = s.WorkerService.ModifyWorkerOverTime(ctx); err != nil {
-	//		_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyWorkerOverTime", err))
-	//	} else if count != 0 {
-	//		log.Println("[Scheduler] ModifyWorkerOverTime completed")
-	//	}
-	//})
-	//if err != nil {
-	//	return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
-	//}
+	// 철야 확인 작업 (승인 요청 생성, 현장 관리자가 승인해야 반영)::10분마다
+	_, err = s.cron.AddFunc("0 0/10 * * * *", func() {
+		defer Recover("[Scheduler] Running ModifyWorkerOverTime")
+		if count, err := s.WorkerService.ModifyWorkerOverTime(ctx); err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyWorkerOverTime", err))
+		} else if count != 0 {
+			log.Printf("[Scheduler] ModifyWorkerOverTime %d requested\n", count)
+		}
+	})
+	if err != nil {
+		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
+	}
 
 	// 프로젝트 정보 업데이트(초기 세팅)::5분
 	_, err = s.cron.AddFunc("0 0/5 * * * *", func() {
@@ -191,7 +256,7 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		return utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err)
 	}
 
-	// 홍채인식기 전체근로자 반영::2분
+	// 홍채인식기 전체근로자 반영::2분 (인식 기록 수신시 바로 반영하므로 누락분 보정용)
 	_, err = s.cron.AddFunc("0 0/2 * * * *", func() {
 		defer Recover("[Scheduler] Running MergeRecdWorker")
 		//log.Println("[Scheduler] Running MergeRecdWorker")
@@ -206,7 +271,7 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
 	}
 
-	// 홍채인식기 현장근로자 반영::2분(정시 1분부터 시작)
+	// 홍채인식기 현장근로자 반영::2분(정시 1분부터 시작) (인식 기록 수신시 바로 반영하므로 누락분 보정용)
 	_, err = s.cron.AddFunc("0 1-59/2 * * * *", func() {
 		defer Recover("[Scheduler] Running MergeRecdDailyWorker")
 		//log.Println("[Scheduler] Running MergeRecdDailyWorker")
@@ -221,6 +286,77 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
 	}
 
+	// 주민번호 암호화 (근로자 등록/수정으로 암호문이 비워진 근로자)::4시 30분 0초
+	_, err = s.cron.AddFunc("0 30 4 * * *", func() {
+		defer Recover("[Scheduler] Running ModifyRegNoEncryption")
+		if count, err := s.WorkerService.ModifyRegNoEncryption(ctx); err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyRegNoEncryption", err))
+		} else if count != 0 {
+			log.Printf("[Scheduler] ModifyRegNoEncryption %d completed\n", count)
+		}
+	})
+	if err != nil {
+		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
+	}
+
+	// 보관 기간 처리 (dry-run 보고 후 RETENTION_EXECUTE=true면 실행)::3시 30분 0초
+	_, err = s.cron.AddFunc("0 30 3 * * *", func() {
+		defer Recover("[Scheduler] Running Retention")
+		report, err := s.RetentionService.GetRetentionReport(ctx)
+		if err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] GetRetentionReport", err))
+			return
+		}
+		logRetentionReport(report)
+		if !s.RetentionExecute {
+			return
+		}
+
+		if report, err = s.RetentionService.ModifyRetention(ctx, report.Token); err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyRetention", err))
+		}
+		logRetentionReport(report)
+	})
+	if err != nil {
+		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
+	}
+
+	// 일일 근로자 비교 자동 대사 (TBM, 퇴직공제 업로드 후 대사하지 않은 프로젝트, 날짜)::10분마다 (3분부터)
+	_, err = s.cron.AddFunc("0 3/10 * * * *", func() {
+		defer Recover("[Scheduler] Running ModifyCompareReconcile")
+		results, err := s.CompareService.ModifyCompareReconcile(ctx)
+		if err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] ModifyCompareReconcile", err))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				log.Printf("[Scheduler] ModifyCompareReconcile jno:%d date:%s failed\n", result.Jno.Int64, result.RecordDate.Time.Format("2006-01-02"))
+				continue
+			}
+			log.Printf("[Scheduler] ModifyCompareReconcile jno:%d date:%s applied:%d exception:%d closed:%d\n",
+				result.Jno.Int64, result.RecordDate.Time.Format("2006-01-02"), result.AppliedCount, result.ExceptionCount, result.ClosedCount)
+		}
+	})
+	if err != nil {
+		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
+	}
+
+	// 만료, 폐기된 로그인 세션 삭제::4시 0분 0초
+	_, err = s.cron.AddFunc("0 0 4 * * *", func() {
+		defer Recover("[Scheduler] Running PurgeSessions")
+		if s.Sessions == nil {
+			return
+		}
+		if count, err := s.Sessions.PurgeSessions(ctx, time.Now().Add(-auth.SessionPurgeAfter)); err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] PurgeSessions", err))
+		} else if count != 0 {
+			log.Printf("[Scheduler] PurgeSessions %d deleted\n", count)
+		}
+	})
+	if err != nil {
+		return entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Scheduler] failed to add cron job", err))
+	}
+
 	// ... 추가 job 등록
 	s.cron.Start()
 
@@ -234,3 +370,12 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	log.Println("[Scheduler] Stop")
 	return nil
 }
+
+// 보관 기간 처리 보고 로그
+func logRetentionReport(report entity.RetentionReport) {
+	for _, class := range report.Classes {
+		log.Printf("[Scheduler] Retention dry-run:%t %s %s cutoff:%s target:%d bytes:%d processed:%d missing:%d skipped:%d\n",
+			report.DryRun, class.Class, class.Action, class.Cutoff.Format("2006-01-02"),
+			class.TargetCount, class.TargetBytes, class.ProcessedCount, class.MissingCount, class.SkippedCount)
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"csm-api/entity"
+	"github.com/guregu/null"
+	"io"
 	"time"
 )
 
@@ -11,12 +13,12 @@ type MenuService interface {
 }
 
 type GetUserValidService interface {
-	GetUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool) (entity.User, error)
-	GetCompanyUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool) (entity.User, error)
+	GetUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool, ip string) (entity.User, error)
+	GetCompanyUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool, ip string) (entity.User, error)
 }
 
 type SiteService interface {
-	GetSiteList(ctx context.Context, targetDate time.Time, isRole bool, isNonUse bool) (*entity.Sites, error)
+	GetSiteList(ctx context.Context, targetDate time.Time, isNonUse bool) (*entity.Sites, error)
 	GetSiteNmList(ctx context.Context, page entity.Page, search entity.Site, nonSite int) (*entity.Sites, error)
 	GetSiteNmCount(ctx context.Context, search entity.Site, nonSite int) (int, error)
 	GetSiteStatsList(ctx context.Context, targetDate time.Time) (*entity.Sites, error)
@@ -48,7 +50,7 @@ type SiteDateService interface {
 type ProjectService interface {
 	GetProjectList(ctx context.Context, sno int64, targetDate time.Time) (*entity.ProjectInfos, error)
 	GetProjectWorkerCountList(ctx context.Context, targetDate time.Time) (*entity.ProjectInfos, error)
-	GetProjectNmList(ctx context.Context, isRole bool) (*entity.ProjectInfos, error)
+	GetProjectNmList(ctx context.Context) (*entity.ProjectInfos, error)
 	GetUsedProjectList(ctx context.Context, page entity.Page, search entity.JobInfo, retry string, includeJno string, snoString string) (*entity.JobInfos, error)
 	GetUsedProjectCount(ctx context.Context, search entity.JobInfo, retry string, includeJno string, snoString string) (int, error)
 	GetAllProjectList(ctx context.Context, page entity.Page, search entity.JobInfo, isAll int, retry string) (*entity.JobInfos, error)
@@ -68,10 +70,13 @@ type ProjectService interface {
 }
 
 type ProjectSettingService interface {
-	GetProjectSetting(ctx context.Context, jno int64, isRole bool) (*entity.ProjectSettings, error)
+	GetProjectSetting(ctx context.Context, jno int64) (*entity.ProjectSettings, error)
 	GetManHourList(ctx context.Context, jno int64) (*entity.ManHours, error)
 	MergeManHours(ctx context.Context, manHours *entity.ManHours) error
+	PreviewManHours(ctx context.Context, jno int64, manHours entity.ManHours) (*entity.ManHourPreview, error)
+	ApplyManHours(ctx context.Context, jno int64, apply entity.ManHourApply) (int64, error)
 	MergeProjectSetting(ctx context.Context, project entity.ProjectSetting) error
+	MergeProjectShifts(ctx context.Context, jno int64, shifts entity.ProjectShifts) error
 	CheckProjectSetting(ctx context.Context) (count int, err error)
 	DeleteManHour(ctx context.Context, mhno int64, manhour entity.ManHour) error
 	AddManHour(ctx context.Context, manhour entity.ManHour) error
@@ -83,7 +88,7 @@ type OrganizationService interface {
 }
 
 type ProjectDailyService interface {
-	GetDailyJobList(ctx context.Context, isRole bool, jno int64, targetDate string) (entity.ProjectDailys, error)
+	GetDailyJobList(ctx context.Context, jno int64, targetDate string) (entity.ProjectDailys, error)
 	AddDailyJob(ctx context.Context, project entity.ProjectDailys) error
 	ModifyDailyJob(ctx context.Context, project entity.ProjectDaily) error
 	RemoveDailyJob(ctx context.Context, idx int64) error
@@ -93,6 +98,7 @@ type UserService interface {
 	GetUserInfoPeList(ctx context.Context, unoList []int) (*entity.UserPeInfos, error)
 	GetUserRole(ctx context.Context, jno int64, uno int64) (string, error)
 	GetAuthorizationList(ctx context.Context, api string) (*entity.RoleList, error)
+	GetUserScope(ctx context.Context, jno int64, uno int64, role string) (entity.UserScope, error)
 }
 
 type CodeService interface {
@@ -105,8 +111,8 @@ type CodeService interface {
 }
 
 type NoticeService interface {
-	GetNoticeList(ctx context.Context, page entity.Page, isRole bool, search entity.Notice) (*entity.Notices, error)
-	GetNoticeListCount(ctx context.Context, isRole bool, search entity.Notice) (int, error)
+	GetNoticeList(ctx context.Context, page entity.Page, search entity.Notice) (*entity.Notices, error)
+	GetNoticeListCount(ctx context.Context, search entity.Notice) (int, error)
 	AddNotice(ctx context.Context, notice entity.Notice) error
 	ModifyNotice(ctx context.Context, notice entity.Notice) error
 	RemoveNotice(ctx context.Context, idx int64) error
@@ -119,26 +125,35 @@ type DeviceService interface {
 	ModifyDevice(ctx context.Context, device entity.Device) error
 	RemoveDevice(ctx context.Context, dno int64) error
 	GetCheckRegisteredDevices(ctx context.Context) ([]string, error)
+	IssueDeviceApiKey(ctx context.Context, dno int64) (string, error)
+}
+
+type RecdService interface {
+	GetAuthorizedDevice(ctx context.Context, deviceSn string, apiKey string) (entity.Device, error)
+	AddRecdLogs(ctx context.Context, device entity.Device, recds []entity.RecdLog) (entity.RecdIngestResult, error)
 }
 
 type WorkerService interface {
-	GetWorkerTotalList(ctx context.Context, page entity.Page, isRole bool, search entity.Worker, retry string) (*entity.Workers, error)
-	GetWorkerTotalCount(ctx context.Context, isRole bool, search entity.Worker, retry string) (int, error)
+	GetWorkerTotalList(ctx context.Context, page entity.Page, search entity.Worker, retry string) (*entity.Workers, entity.CursorPage, error)
+	GetWorkerTotalCount(ctx context.Context, search entity.Worker, retry string, mode entity.CountMode) (entity.PageCount, error)
 	GetAbsentWorkerList(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.Workers, error)
 	GetAbsentWorkerCount(ctx context.Context, search entity.WorkerDaily, retry string) (int, error)
 	GetWorkerDepartList(ctx context.Context, jno int64) ([]string, error)
 	AddWorker(ctx context.Context, worker entity.Worker) error
 	ModifyWorker(ctx context.Context, worker entity.Worker) error
 	RemoveWorker(ctx context.Context, worker entity.Worker) error
-	GetWorkerSiteBaseList(ctx context.Context, page entity.Page, isRole bool, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error)
-	GetWorkerSiteBaseCount(ctx context.Context, isRole bool, search entity.WorkerDaily, retry string) (int, error)
-	GetWorkerSiteBaseListByCompany(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error)
-	GetWorkerSiteBaseByCompanyCount(ctx context.Context, search entity.WorkerDaily, retry string) (int, error)
+	GetWorkerSiteBaseList(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, entity.CursorPage, error)
+	GetWorkerSiteBaseCount(ctx context.Context, search entity.WorkerDaily, retry string, mode entity.CountMode) (entity.PageCount, error)
+	GetWorkerSiteBaseListByCompany(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, entity.CursorPage, error)
+	GetWorkerSiteBaseByCompanyCount(ctx context.Context, search entity.WorkerDaily, retry string, mode entity.CountMode) (entity.PageCount, error)
 	MergeSiteBaseWorker(ctx context.Context, workers entity.WorkerDailys) error
 	ModifyWorkerDeadline(ctx context.Context, workers entity.WorkerDailys) error
 	ModifyWorkerProject(ctx context.Context, workers entity.WorkerDailys) error
 	ModifyWorkerDeadlineInit(ctx context.Context) error
 	ModifyWorkerOverTime(ctx context.Context) (int, error)
+	GetWorkerOverTimeList(ctx context.Context, sno int64, status string) (*entity.WorkerOverTimes, error)
+	ApproveWorkerOverTime(ctx context.Context, request entity.WorkerOverTime) error
+	RejectWorkerOverTime(ctx context.Context, request entity.WorkerOverTime) error
 	RemoveSiteBaseWorkers(ctx context.Context, workers entity.WorkerDailys) error
 	ModifyDeadlineCancel(ctx context.Context, workers entity.WorkerDailys) error
 	GetDailyWorkersByJnoAndDate(ctx context.Context, param entity.RecordDailyWorkerReq) ([]entity.RecordDailyWorkerRes, error)
@@ -147,6 +162,19 @@ type WorkerService interface {
 	MergeRecdDailyWorker(ctx context.Context) error
 	GetHistoryDailyWorkers(ctx context.Context, startDate string, endDate string, sno int64, retry string, userKeys []string) (entity.WorkerDailys, error)
 	GetHistoryDailyWorkerReason(ctx context.Context, cno int64) (string, error)
+
+	AddWorkerCorrections(ctx context.Context, corrections entity.WorkerCorrections, file io.Reader, fileName string) error
+	GetWorkerCorrectionList(ctx context.Context, jno int64, status string, startDate string, endDate string) (entity.WorkerCorrections, error)
+	GetWorkerCorrection(ctx context.Context, jno int64, crno int64) (*entity.WorkerCorrection, error)
+	ApproveWorkerCorrections(ctx context.Context, review entity.WorkerCorrectionReview) (int, error)
+	RejectWorkerCorrections(ctx context.Context, review entity.WorkerCorrectionReview) (int, error)
+	GetHistoryDailyWorkerDiffs(ctx context.Context, startDate string, endDate string, sno int64, userKeys []string) (entity.WorkerHistoryDiffs, error)
+	RestoreHistoryDailyWorker(ctx context.Context, restore entity.WorkerHistoryRestore) error
+	GetWorkerDuplicateList(ctx context.Context, sno int64, minScore int) (entity.WorkerDuplicates, error)
+	GetWorkerMergeList(ctx context.Context, sno int64) ([]entity.WorkerMerge, error)
+	MergeWorkerDuplicate(ctx context.Context, merge entity.WorkerMerge) (int64, error)
+	DismissWorkerDuplicate(ctx context.Context, merge entity.WorkerMerge) error
+	ModifyRegNoEncryption(ctx context.Context) (int, error)
 }
 
 type WorkHourService interface {
@@ -185,7 +213,7 @@ type EquipService interface {
 }
 
 type ScheduleService interface {
-	GetRestScheduleList(ctx context.Context, isRole bool, jno int64, year string, month string) (entity.RestSchedules, error)
+	GetRestScheduleList(ctx context.Context, jno int64, year string, month string) (entity.RestSchedules, error)
 	AddRestSchedule(ctx context.Context, schedule entity.RestSchedules) error
 	ModifyRestSchedule(ctx context.Context, schedule entity.RestSchedule) error
 	RemoveRestSchedule(ctx context.Context, cno int64) error
@@ -203,9 +231,22 @@ type UploadFileService interface {
 	GetUploadFile(ctx context.Context, file entity.UploadFile) (entity.UploadFile, error)
 }
 
+type DailyCloseService interface {
+	GetDailyCloseList(ctx context.Context, jno int64, startDate string, endDate string) (entity.DailyCloses, error)
+	ModifyDailyClose(ctx context.Context, action string, dailyClose entity.DailyClose) error
+	ModifyDailyCloseApproved(ctx context.Context) (count int, err error)
+}
+
 type CompareService interface {
-	GetCompareList(ctx context.Context, compare entity.Compare, isRole bool, retry string, order string) ([]entity.Compare, error)
+	GetCompareList(ctx context.Context, compare entity.Compare, retry string, order string) ([]entity.Compare, error)
 	ModifyWorkerCompareApply(ctx context.Context, workers entity.WorkerDailys) error
+	GetDepartmentAliasList(ctx context.Context, jno null.Int) (entity.DepartmentAliases, error)
+	MergeDepartmentAlias(ctx context.Context, alias entity.DepartmentAlias) error
+	RemoveDepartmentAlias(ctx context.Context, alias entity.DepartmentAlias) error
+	ModifyCompareReconcile(ctx context.Context) ([]entity.CompareReconcileResult, error)
+	GetCompareExceptionList(ctx context.Context, jno int64, startDate string, endDate string, status string, assigneeUno null.Int) (entity.CompareExceptions, error)
+	ModifyCompareExceptionAssignee(ctx context.Context, review entity.CompareExceptionReview) error
+	ModifyCompareExceptionResolve(ctx context.Context, review entity.CompareExceptionReview) error
 }
 
 type UserRoleService interface {
@@ -215,3 +256,14 @@ type UserRoleService interface {
 	RemoveUserRole(ctx context.Context, userRoles []entity.UserRoleMap) error
 	GetUserMenuRoleCheck(ctx context.Context, role string, menuId string) (bool, error)
 }
+
+type PiiService interface {
+	RevealWorkerPii(ctx context.Context, reveal entity.PiiReveal) (entity.PiiRevealResult, error)
+	GetPiiAccessReport(ctx context.Context, startDate string, endDate string) (entity.PiiAccessReports, error)
+	GetPiiAccessLogList(ctx context.Context, startDate string, endDate string, uno null.Int, accessType string) (entity.PiiAccessLogs, error)
+}
+
+type RetentionService interface {
+	GetRetentionReport(ctx context.Context) (entity.RetentionReport, error)
+	ModifyRetention(ctx context.Context, token string) (entity.RetentionReport, error)
+}
@@ -3,29 +3,68 @@ package service
 import (
 	"context"
 	"csm-api/auth"
+	"csm-api/crypto"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
 	"csm-api/utils"
+	"fmt"
+	"github.com/guregu/null"
 	"strconv"
 	"strings"
 )
 
 type ServiceCompare struct {
-	SafeDB  store.Queryer
-	SafeTDB store.Beginner
-	Store   store.CompareStore
+	SafeDB      store.Queryer
+	SafeTDB     store.Beginner
+	Store       store.CompareStore
+	PiiStore    store.PiiStore  // 개인정보 접근 기록 (없으면 기록하지 않음)
+	RegNoCipher *crypto.Keyring // 주민번호 암호화 키 (근로자 주민번호 복호화)
+	UserService UserService     // 예외 프로젝트 권한 확인
 }
 
 // 일일 근로자 비교 리스트
-func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Compare, isRole bool, retry string, order string) ([]entity.Compare, error) {
+func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Compare, retry string, order string) ([]entity.Compare, error) {
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
+	compareList, err := s.compareList(ctx, compare, isRole, uno, retry, order)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	// 개인정보 마스킹 및 접근 기록 (공제 자료만 있는 경우 핸드폰번호를 USER_ID로 내보낸다)
+	mask := newPiiMask(ctx)
+	for i := range compareList {
+		compareList[i].Phone = mask.mask(auth.PiiPhone, compareList[i].Phone)
+		compareList[i].DeductionBirth = mask.mask(auth.PiiBirth, compareList[i].DeductionBirth)
+		if !compareList[i].UserKey.Valid {
+			compareList[i].UserId = mask.mask(auth.PiiPhone, compareList[i].UserId)
+		}
+	}
+	if err = addPiiListAccessLog(ctx, s.SafeTDB, s.PiiStore, entity.PiiTargetCompare, mask, len(compareList)); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	return compareList, nil
+}
+
+// func: 일일 근로자 비교 (근태, TBM, 퇴직공제 매칭)
+// 개인정보를 마스킹하지 않으므로 응답에는 GetCompareList를 사용한다.
+// @param
+// - compare: SNO, JNO, RECORD_DATE
+// - isRole, uno: 조회 권한 (isRole이 아니면 uno가 속한 프로젝트만)
+func (s *ServiceCompare) compareList(ctx context.Context, compare entity.Compare, isRole bool, uno string, retry string, order string) ([]entity.Compare, error) {
 	workerlist, err := s.Store.GetDailyWorkerList(ctx, s.SafeDB, compare, isRole, uno, retry, order)
 	if err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
+	for _, worker := range workerlist {
+		if worker.RegNo, err = decryptRegNoEnc(s.RegNoCipher, worker.RegNoEnc); err != nil {
+			return nil, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", worker.UserKey.String), err)
+		}
+	}
 
 	tbmList, err := s.Store.GetTbmList(ctx, s.SafeDB, compare, retry, order)
 	if err != nil {
@@ -99,9 +138,28 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 		return cleaned
 	}
 
-	// TBM map: 동명이인 고려한 slice map
-	tbmMap := make(map[entity.TbmKey][]entity.Tbm)
-	for _, tbm := range tbmList {
+	// 부서 별칭 (TBM, 공제 자료의 부서명을 근로자 부서명으로)
+	aliases, err := s.Store.GetDepartmentAliasList(ctx, s.SafeDB, null.Int{})
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	matcher := newCompareMatcher(aliases)
+
+	// 사용하지 않은 첫번째 행을 사용 처리
+	popUnused := func(indexes []int, used []bool) (int, bool) {
+		for _, i := range indexes {
+			if !used[i] {
+				used[i] = true
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	// TBM map: 동명이인 고려한 index slice map
+	tbmUsed := make([]bool, len(tbmList))
+	tbmMap := make(map[entity.TbmKey][]int)
+	for i, tbm := range tbmList {
 		key := entity.TbmKey{
 			tbm.Sno.Int64,
 			0,
@@ -109,13 +167,14 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 			tbm.UserNm.String,
 			tbm.Department.String,
 			tbm.TbmDate.Time}
-		tbmMap[key] = append(tbmMap[key], tbm)
+		tbmMap[key] = append(tbmMap[key], i)
 	}
 
 	// 공제 map: 주민번호 기준과 동명이인 기준
-	deductionRegMap := make(map[entity.DeductionRegKey]entity.Deduction)
-	deductionMap := make(map[entity.DeductionKey][]entity.Deduction)
-	for _, d := range deductionList {
+	deductionUsed := make([]bool, len(deductionList))
+	deductionRegMap := make(map[entity.DeductionRegKey]int)
+	deductionMap := make(map[entity.DeductionKey][]int)
+	for i, d := range deductionList {
 		regKey := entity.DeductionRegKey{
 			d.Sno.Int64,
 			0,
@@ -124,7 +183,7 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 			cleanBirthRegNo(d.RegNo.String, d.Gender.String),
 			d.RecordDate.Time,
 		}
-		deductionRegMap[regKey] = d
+		deductionRegMap[regKey] = i
 		key := entity.DeductionKey{
 			d.Sno.Int64,
 			0,
@@ -133,11 +192,42 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 			d.Department.String,
 			d.RecordDate.Time,
 		}
-		deductionMap[key] = append(deductionMap[key], d)
+		deductionMap[key] = append(deductionMap[key], i)
 	}
 	var compareList []entity.Compare
 
-	// 근태 기준 비교
+	// TBM, 공제 매칭 반영
+	applyTbm := func(compare *entity.Compare, tbm entity.Tbm, matchType string, score float64) {
+		compare.IsTbm = utils.ParseNullString("Y")
+		compare.DiscName = tbm.DiscName
+		addCompareMatch(compare, entity.CompareMatch{
+			Source:     entity.CompareSourceTbm,
+			MatchType:  matchType,
+			Score:      score,
+			UserNm:     tbm.UserNm,
+			Department: tbm.Department,
+			RecordDate: tbm.TbmDate,
+			Order:      null.NewString(strconv.FormatInt(tbm.TbmOrder.Int64, 10), tbm.TbmOrder.Valid),
+		})
+	}
+	applyDeduction := func(compare *entity.Compare, d entity.Deduction, matchType string, score float64) {
+		compare.DeductionInTime = d.InRecogTime
+		compare.DeductionOutTime = d.OutRecogTime
+		compare.DeductionBirth = d.RegNo
+		addCompareMatch(compare, entity.CompareMatch{
+			Source:     entity.CompareSourceDeduction,
+			MatchType:  matchType,
+			Score:      score,
+			UserNm:     d.UserNm,
+			Department: d.Department,
+			RecordDate: d.RecordDate,
+			Order:      d.DeductOrder,
+		})
+	}
+
+	// 근태 기준 비교 (일치)
+	var workerCandidates []compareCandidate
+	var workerDeductionCandidates []compareCandidate
 	for _, worker := range workerlist {
 		compareTemp := entity.Compare{
 			Jno:           worker.Jno,
@@ -168,13 +258,10 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 		//if worker.CompareState.String == "S" || worker.CompareState.String == "X" {
 		//	tbmKey.Jno = worker.Jno.Int64
 		//}
-		if tbms, ok := tbmMap[tbmKey]; ok && len(tbms) > 0 {
-			compareTemp.IsTbm = utils.ParseNullString("Y")
-			compareTemp.DiscName = tbms[0].DiscName
-			tbmMap[tbmKey] = tbms[1:] // 하나만 소비
-			if len(tbmMap[tbmKey]) == 0 {
-				delete(tbmMap, tbmKey)
-			}
+		if i, ok := popUnused(tbmMap[tbmKey], tbmUsed); ok {
+			applyTbm(&compareTemp, tbmList[i], entity.CompareMatchExact, 1)
+		} else {
+			workerCandidates = append(workerCandidates, matcher.candidate(len(compareList), worker.Sno.Int64, worker.Jno.Int64, worker.UserNm.String, worker.Department.String, worker.RecordDate.Time, "", ""))
 		}
 
 		// 공제 비교 (RegNo 기준)
@@ -189,77 +276,102 @@ func (s *ServiceCompare) GetCompareList(ctx context.Context, compare entity.Comp
 		//	deductKey.Jno = worker.Jno.Int64
 		//}
 
-		if deduction, ok := deductionRegMap[deductKey]; ok {
-			compareTemp.DeductionInTime = deduction.InRecogTime
-			compareTemp.DeductionOutTime = deduction.OutRecogTime
-			compareTemp.DeductionBirth = deduction.RegNo
-			delete(deductionRegMap, deductKey)
-
-			dk := entity.DeductionKey{deduction.Sno.Int64, deduction.Jno.Int64, deduction.UserNm.String, deduction.Department.String, deduction.RecordDate.Time}
-			if list := deductionMap[dk]; len(list) > 0 {
-				deductionMap[dk] = list[1:]
-				if len(deductionMap[dk]) == 0 {
-					delete(deductionMap, dk)
-				}
-			}
+		if i, ok := deductionRegMap[deductKey]; ok && !deductionUsed[i] {
+			deductionUsed[i] = true
+			applyDeduction(&compareTemp, deductionList[i], entity.CompareMatchRegNo, 1)
+		} else {
+			workerDeductionCandidates = append(workerDeductionCandidates, matcher.candidate(len(compareList), worker.Sno.Int64, worker.Jno.Int64, worker.UserNm.String, worker.Department.String, worker.RecordDate.Time, worker.RegNo.String, compareTemp.Gender.String))
 		}
 
 		compareList = append(compareList, compareTemp)
 	}
 
-	// 근태x 남은 TBM-공제 비교
-	for _, tbmSlice := range tbmMap {
-		for _, tbm := range tbmSlice {
-			compareTemp := entity.Compare{
-				Jno:          tbm.Jno,
-				UserNm:       tbm.UserNm,
-				Department:   tbm.Department,
-				DiscName:     tbm.DiscName,
-				IsTbm:        utils.ParseNullString("Y"),
-				RecordDate:   tbm.TbmDate,
-				CompareState: utils.ParseNullString("C"),
-			}
+	// 근태 기준 비교 (이름/부서 유사): TBM 일치 후 남은 근로자와 TBM
+	var tbmCandidates []compareCandidate
+	for i, tbm := range tbmList {
+		if !tbmUsed[i] {
+			tbmCandidates = append(tbmCandidates, matcher.candidate(i, tbm.Sno.Int64, tbm.Jno.Int64, tbm.UserNm.String, tbm.Department.String, tbm.TbmDate.Time, "", ""))
+		}
+	}
+	for _, match := range matcher.match(workerCandidates, tbmCandidates) {
+		tbmUsed[match.right] = true
+		applyTbm(&compareList[match.left], tbmList[match.right], entity.CompareMatchFuzzy, match.score)
+	}
 
-			deductionKey := entity.DeductionKey{tbm.Sno.Int64, tbm.Jno.Int64, tbm.UserNm.String, tbm.Department.String, tbm.TbmDate.Time}
-			if dList, ok := deductionMap[deductionKey]; ok && len(dList) > 0 {
-				d := dList[0]
-				compareTemp.DeductionInTime = d.InRecogTime
-				compareTemp.DeductionOutTime = d.OutRecogTime
-				compareTemp.Gender = d.Gender
-				compareTemp.DeductionBirth = d.RegNo
-				compareTemp.UserId = d.Phone
-				deductionMap[deductionKey] = dList[1:]
-				if len(deductionMap[deductionKey]) == 0 {
-					delete(deductionMap, deductionKey)
-				}
-
-				// 삭제 동기화 (regMap도)
-				regKey := entity.DeductionRegKey{d.Sno.Int64, d.Jno.Int64, replacer.Replace(d.Phone.String), cleanRegNo(d.RegNo.String), d.RecordDate.Time}
-				delete(deductionRegMap, regKey)
-			}
+	// 근태 기준 비교 (이름/부서 유사, 생년월일/성별 확인): 주민번호 일치 후 남은 근로자와 공제
+	var deductionCandidates []compareCandidate
+	for i, d := range deductionList {
+		if !deductionUsed[i] {
+			deductionCandidates = append(deductionCandidates, matcher.candidate(i, d.Sno.Int64, d.Jno.Int64, d.UserNm.String, d.Department.String, d.RecordDate.Time, d.RegNo.String, d.Gender.String))
+		}
+	}
+	for _, match := range matcher.match(workerDeductionCandidates, deductionCandidates) {
+		deductionUsed[match.right] = true
+		applyDeduction(&compareList[match.left], deductionList[match.right], entity.CompareMatchFuzzy, match.score)
+	}
+
+	// 근태x 남은 TBM-공제 비교 (일치)
+	var tbmOnlyCandidates []compareCandidate
+	for i, tbm := range tbmList {
+		if tbmUsed[i] {
+			continue
+		}
+		compareTemp := entity.Compare{
+			Jno:          tbm.Jno,
+			UserNm:       tbm.UserNm,
+			Department:   tbm.Department,
+			DiscName:     tbm.DiscName,
+			IsTbm:        utils.ParseNullString("Y"),
+			RecordDate:   tbm.TbmDate,
+			CompareState: utils.ParseNullString("C"),
+		}
+
+		deductionKey := entity.DeductionKey{tbm.Sno.Int64, 0, tbm.UserNm.String, tbm.Department.String, tbm.TbmDate.Time}
+		if j, ok := popUnused(deductionMap[deductionKey], deductionUsed); ok {
+			applyDeduction(&compareTemp, deductionList[j], entity.CompareMatchExact, 1)
+			compareTemp.Gender = deductionList[j].Gender
+			compareTemp.UserId = deductionList[j].Phone
+		} else {
+			tbmOnlyCandidates = append(tbmOnlyCandidates, matcher.candidate(len(compareList), tbm.Sno.Int64, tbm.Jno.Int64, tbm.UserNm.String, tbm.Department.String, tbm.TbmDate.Time, "", ""))
+		}
+
+		compareList = append(compareList, compareTemp)
+	}
 
-			compareList = append(compareList, compareTemp)
+	// 근태x 남은 TBM-공제 비교 (이름/부서 유사)
+	deductionCandidates = deductionCandidates[:0]
+	for i, d := range deductionList {
+		if !deductionUsed[i] {
+			deductionCandidates = append(deductionCandidates, matcher.candidate(i, d.Sno.Int64, d.Jno.Int64, d.UserNm.String, d.Department.String, d.RecordDate.Time, d.RegNo.String, d.Gender.String))
 		}
 	}
+	for _, match := range matcher.match(tbmOnlyCandidates, deductionCandidates) {
+		d := deductionList[match.right]
+		deductionUsed[match.right] = true
+		applyDeduction(&compareList[match.left], d, entity.CompareMatchFuzzy, match.score)
+		compareList[match.left].Gender = d.Gender
+		compareList[match.left].UserId = d.Phone
+	}
 
 	// 근태x TBMx 공제 처리
-	for _, dList := range deductionMap {
-		for _, d := range dList {
-			compareTemp := entity.Compare{
-				Jno:              d.Jno,
-				UserId:           d.Phone,
-				UserNm:           d.UserNm,
-				Department:       d.Department,
-				Gender:           d.Gender,
-				IsTbm:            utils.ParseNullString("N"),
-				CompareState:     utils.ParseNullString("C"),
-				RecordDate:       d.RecordDate,
-				DeductionInTime:  d.InRecogTime,
-				DeductionOutTime: d.OutRecogTime,
-				DeductionBirth:   d.RegNo,
-			}
-			compareList = append(compareList, compareTemp)
+	for i, d := range deductionList {
+		if deductionUsed[i] {
+			continue
 		}
+		compareTemp := entity.Compare{
+			Jno:              d.Jno,
+			UserId:           d.Phone,
+			UserNm:           d.UserNm,
+			Department:       d.Department,
+			Gender:           d.Gender,
+			IsTbm:            utils.ParseNullString("N"),
+			CompareState:     utils.ParseNullString("C"),
+			RecordDate:       d.RecordDate,
+			DeductionInTime:  d.InRecogTime,
+			DeductionOutTime: d.OutRecogTime,
+			DeductionBirth:   d.RegNo,
+		}
+		compareList = append(compareList, compareTemp)
 	}
 
 	return compareList, nil
@@ -305,3 +417,74 @@ func (s *ServiceCompare) ModifyWorkerCompareApply(ctx context.Context, workers e
 
 	return
 }
+
+// func: 프로젝트별 부서 별칭 조회
+// @param
+// - jno: 프로젝트pk (없으면 전체)
+func (s *ServiceCompare) GetDepartmentAliasList(ctx context.Context, jno null.Int) (entity.DepartmentAliases, error) {
+	list, err := s.Store.GetDepartmentAliasList(ctx, s.SafeDB, jno)
+	if err != nil {
+		return entity.DepartmentAliases{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 프로젝트별 부서 별칭 저장
+// @param
+// - alias: JNO, ALIAS(TBM, 공제 자료 부서명), DEPARTMENT(근로자 부서명)
+func (s *ServiceCompare) MergeDepartmentAlias(ctx context.Context, alias entity.DepartmentAlias) (err error) {
+	if !alias.Jno.Valid || strings.TrimSpace(alias.Alias.String) == "" || strings.TrimSpace(alias.Department.String) == "" {
+		return utils.CustomErrorf(fmt.Errorf("jno, alias or department is empty"))
+	}
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	alias.RegUno = utils.ParseNullInt(uno)
+	alias.RegUser = utils.ParseNullString(userName)
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.Store.MergeDepartmentAlias(ctx, tx, alias); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 프로젝트별 부서 별칭 삭제
+// @param
+// - alias: JNO, ALIAS
+func (s *ServiceCompare) RemoveDepartmentAlias(ctx context.Context, alias entity.DepartmentAlias) (err error) {
+	if !alias.Jno.Valid || alias.Alias.String == "" {
+		return utils.CustomErrorf(fmt.Errorf("jno or alias is empty"))
+	}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.Store.RemoveDepartmentAlias(ctx, tx, alias); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 매칭한 원본 행 추가
+// 비교 행 매칭 점수는 매칭한 원본 행 중 가장 낮은 점수로 한다.
+func addCompareMatch(compare *entity.Compare, match entity.CompareMatch) {
+	compare.MatchSources = append(compare.MatchSources, match)
+	if !compare.MatchScore.Valid || match.Score < compare.MatchScore.Float64 {
+		compare.MatchScore = null.FloatFrom(match.Score)
+	}
+	if compare.MatchScore.Float64 < entity.CompareWeakMatchScore {
+		compare.IsWeakMatch = utils.ParseNullString("Y")
+	} else {
+		compare.IsWeakMatch = utils.ParseNullString("N")
+	}
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"csm-api/entity"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 일일 근로자 비교 매칭 점수
+// 한글 이름은 짧아 자모 하나만 달라도 다른 사람인 경우가 많다. (김민수, 김민주)
+// 이름은 혼동하기 쉬운 자모 하나(compareNameMaxCost)까지만 다를 수 있고,
+// 양쪽 생년월일, 성별이 일치하면 자모 하나(compareNameMaxCostConfirmed)까지 다를 수 있다.
+// 생년월일이나 성별이 다르면 매칭하지 않는다.
+// 이름(compareNameWeight), 부서(1-compareNameWeight) 가중 점수가 compareMatchMinScore 이상인 후보만 매칭한다.
+const (
+	compareNameWeight           = 0.8
+	compareNameMaxCost          = 0.5
+	compareNameMaxCostConfirmed = 1
+	compareMatchMinScore        = 0.9
+	compareDepartmentPartial    = 0.8 // 한쪽 부서명이 다른 쪽에 포함된 경우 (약칭)
+	compareConfusionCost        = 0.5 // 혼동하기 쉬운 자모 치환 비용
+)
+
+// 한글 자모 (호환 자모, 조합 순서)
+var (
+	hangulChoseong  = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+	hangulJungseong = []rune("ㅏㅐㅑㅒㅓㅔㅕㅖㅗㅘㅙㅚㅛㅜㅝㅞㅟㅠㅡㅢㅣ")
+	hangulJongseong = []rune(" ㄱㄲㄳㄴㄵㄶㄷㄹㄺㄻㄼㄽㄾㄿㅀㅁㅂㅄㅅㅆㅇㅈㅊㅋㅌㅍㅎ") // 0: 받침 없음
+)
+
+// OCR, 입력 오류로 혼동하기 쉬운 자모
+var hangulConfusions = func() map[[2]rune]bool {
+	confusions := make(map[[2]rune]bool)
+	for _, group := range []string{"ㅐㅔ", "ㅒㅖ", "ㅙㅚㅞ", "ㅗㅛ", "ㅜㅠ", "ㅁㅇ", "ㄷㄹ", "ㄱㅋ", "ㄷㅌ", "ㅂㅍ", "ㅈㅊ", "ㅅㅆ"} {
+		jamos := []rune(group)
+		for _, a := range jamos {
+			for _, b := range jamos {
+				if a != b {
+					confusions[[2]rune{a, b}] = true
+				}
+			}
+		}
+	}
+	return confusions
+}()
+
+// 비교 매칭 후보 (정규화한 이름, 부서)
+type compareCandidate struct {
+	index      int // 원본 목록 index
+	sno        int64
+	date       time.Time
+	name       string
+	jamos      []rune
+	department string
+	birth      string // 생년월일 6자리 (없으면 "")
+	gender     string // 남, 여 (없으면 "")
+}
+
+// 매칭 결과
+type compareMatch struct {
+	left      int // 왼쪽 원본 목록 index
+	right     int // 오른쪽 원본 목록 index
+	score     float64
+	confirmed bool // 생년월일, 성별 일치
+}
+
+// 이름/부서 매칭 (프로젝트별 부서 별칭 적용)
+type compareMatcher struct {
+	aliases map[int64]map[string]string // jno -> 정규화한 별칭 -> 정규화한 부서명
+}
+
+func newCompareMatcher(aliases entity.DepartmentAliases) *compareMatcher {
+	m := &compareMatcher{aliases: make(map[int64]map[string]string)}
+	for _, alias := range aliases {
+		jno := alias.Jno.Int64
+		if m.aliases[jno] == nil {
+			m.aliases[jno] = make(map[string]string)
+		}
+		m.aliases[jno][normalizeDepartment(alias.Alias.String)] = normalizeDepartment(alias.Department.String)
+	}
+	return m
+}
+
+// func: 매칭 후보 생성
+// @param
+// - index: 원본 목록 index
+// - jno: 부서 별칭을 찾을 프로젝트
+// - birth: 생년월일 (주민번호 또는 yy-mm-dd, 없으면 "")
+// - gender: 남, 여 (없으면 "")
+func (m *compareMatcher) candidate(index int, sno int64, jno int64, name string, department string, date time.Time, birth string, gender string) compareCandidate {
+	name = normalizeName(name)
+	birth = strings.NewReplacer(" ", "", "-", "").Replace(birth)
+	if len(birth) > 6 {
+		birth = birth[:6]
+	}
+	return compareCandidate{
+		index:      index,
+		sno:        sno,
+		date:       date,
+		name:       name,
+		jamos:      decomposeHangul(name),
+		department: m.department(jno, department),
+		birth:      birth,
+		gender:     gender,
+	}
+}
+
+// func: 부서명 정규화 (별칭이면 부서명으로)
+func (m *compareMatcher) department(jno int64, department string) string {
+	department = normalizeDepartment(department)
+	if canonical, ok := m.aliases[jno][department]; ok {
+		return canonical
+	}
+	return department
+}
+
+// func: 생년월일, 성별 비교 (양쪽 모두 있는 값만 비교)
+// @return
+// - confirmed: 생년월일이 일치하고 성별이 다르지 않음
+// - conflict: 생년월일 또는 성별이 다름
+func compareIdentity(a compareCandidate, b compareCandidate) (confirmed bool, conflict bool) {
+	if a.birth != "" && b.birth != "" && a.birth != b.birth {
+		return false, true
+	}
+	if a.gender != "" && b.gender != "" && a.gender != b.gender {
+		return false, true
+	}
+	return a.birth != "" && a.birth == b.birth, false
+}
+
+// func: 후보 점수 (0 ~ 1)
+// 생년월일이나 성별이 다르거나, 이름이 허용 비용보다 많이 다르면 0
+func (m *compareMatcher) score(a compareCandidate, b compareCandidate) float64 {
+	if a.name == "" || b.name == "" {
+		return 0
+	}
+	confirmed, conflict := compareIdentity(a, b)
+	if conflict {
+		return 0
+	}
+
+	nameScore := 1.0
+	if a.name != b.name {
+		maxCost := compareNameMaxCost
+		if confirmed {
+			maxCost = compareNameMaxCostConfirmed
+		}
+		cost := jamoDistance(a.jamos, b.jamos)
+		if cost > maxCost {
+			return 0
+		}
+		nameScore = 1 - cost/float64(max(len(a.jamos), len(b.jamos)))
+	}
+
+	departmentScore := 0.0
+	switch {
+	case a.department == b.department:
+		departmentScore = 1
+	case a.department == "" || b.department == "":
+	case strings.Contains(a.department, b.department) || strings.Contains(b.department, a.department):
+		departmentScore = compareDepartmentPartial
+	default:
+		departmentScore = jamoSimilarity(decomposeHangul(a.department), decomposeHangul(b.department))
+	}
+	return compareNameWeight*nameScore + (1-compareNameWeight)*departmentScore
+}
+
+// func: 후보 매칭
+// 같은 현장, 같은 날짜 후보끼리 생년월일, 성별이 일치하는 쌍, 점수가 높은 쌍 순으로 매칭하고, 한 후보는 한 번만 매칭한다.
+// @param
+// - lefts, rights: 매칭할 후보
+func (m *compareMatcher) match(lefts []compareCandidate, rights []compareCandidate) []compareMatch {
+	type groupKey struct {
+		sno  int64
+		date time.Time
+	}
+	groups := make(map[groupKey][]compareCandidate)
+	for _, right := range rights {
+		key := groupKey{right.sno, right.date}
+		groups[key] = append(groups[key], right)
+	}
+
+	var pairs []compareMatch
+	for _, left := range lefts {
+		for _, right := range groups[groupKey{left.sno, left.date}] {
+			if score := m.score(left, right); score >= compareMatchMinScore {
+				confirmed, _ := compareIdentity(left, right)
+				pairs = append(pairs, compareMatch{left: left.index, right: right.index, score: score, confirmed: confirmed})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].confirmed != pairs[j].confirmed {
+			return pairs[i].confirmed
+		}
+		if pairs[i].score != pairs[j].score {
+			return pairs[i].score > pairs[j].score
+		}
+		if pairs[i].left != pairs[j].left {
+			return pairs[i].left < pairs[j].left
+		}
+		return pairs[i].right < pairs[j].right
+	})
+
+	leftUsed := make(map[int]bool)
+	rightUsed := make(map[int]bool)
+	matches := make([]compareMatch, 0, len(pairs))
+	for _, pair := range pairs {
+		if leftUsed[pair.left] || rightUsed[pair.right] {
+			continue
+		}
+		leftUsed[pair.left] = true
+		rightUsed[pair.right] = true
+		matches = append(matches, pair)
+	}
+	return matches
+}
+
+// func: 한글 자모 조합
+// 조합되지 않은 자모(ㅎㅗㅇ, 첫가끝 자모)를 음절로 조합한다. ex) ㅎㅗㅇ길동 -> 홍길동
+func composeHangul(s string) string {
+	runes := []rune(s)
+	composed := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		l := choseongIndex(runes[i])
+		if l < 0 || i+1 >= len(runes) {
+			composed = appendJongseong(composed, runes, &i)
+			continue
+		}
+		v := jungseongIndex(runes[i+1])
+		if v < 0 {
+			composed = appendJongseong(composed, runes, &i)
+			continue
+		}
+		i++
+
+		// 받침: 다음 자모가 모음이면 다음 음절의 초성
+		t := 0
+		if i+1 < len(runes) {
+			if jong := jongseongIndex(runes[i+1]); jong > 0 && (i+2 >= len(runes) || jungseongIndex(runes[i+2]) < 0) {
+				t = jong
+				i++
+			}
+		}
+		composed = append(composed, rune(0xAC00+(l*21+v)*28+t))
+	}
+	return string(composed)
+}
+
+// 받침 없는 음절 뒤에 받침 자모가 따로 있으면 합친다. ex) 호ㅇ -> 홍
+func appendJongseong(composed []rune, runes []rune, i *int) []rune {
+	r := runes[*i]
+	if len(composed) > 0 {
+		last := composed[len(composed)-1]
+		if last >= 0xAC00 && last <= 0xD7A3 && (last-0xAC00)%28 == 0 {
+			if jong := jongseongIndex(r); jong > 0 && (*i+1 >= len(runes) || jungseongIndex(runes[*i+1]) < 0) {
+				composed[len(composed)-1] = last + rune(jong)
+				return composed
+			}
+		}
+	}
+	return append(composed, r)
+}
+
+// func: 한글 음절을 자모로 분해 (호환 자모, 그 외 문자는 그대로)
+func decomposeHangul(s string) []rune {
+	jamos := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r < 0xAC00 || r > 0xD7A3 {
+			jamos = append(jamos, r)
+			continue
+		}
+		index := int(r - 0xAC00)
+		jamos = append(jamos, hangulChoseong[index/(21*28)], hangulJungseong[index%(21*28)/28])
+		if t := index % 28; t > 0 {
+			jamos = append(jamos, hangulJongseong[t])
+		}
+	}
+	return jamos
+}
+
+// func: 자모 유사도 (1 - 편집거리/길이)
+func jamoSimilarity(a []rune, b []rune) float64 {
+	maxLen := max(len(a), len(b))
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - jamoDistance(a, b)/float64(maxLen)
+}
+
+// func: 자모 편집거리
+// 혼동하기 쉬운 자모 치환은 compareConfusionCost로 계산한다.
+func jamoDistance(a []rune, b []rune) float64 {
+	prev := make([]float64, len(b)+1)
+	curr := make([]float64, len(b)+1)
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = float64(i)
+		for j := 1; j <= len(b); j++ {
+			cost := 1.0
+			if a[i-1] == b[j-1] {
+				cost = 0
+			} else if hangulConfusions[[2]rune{a[i-1], b[j-1]}] {
+				cost = compareConfusionCost
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// 초성 index (호환 자모, 첫가끝 초성), 없으면 -1
+func choseongIndex(r rune) int {
+	if r >= 0x1100 && r <= 0x1112 {
+		return int(r - 0x1100)
+	}
+	return runeIndex(hangulChoseong, r)
+}
+
+// 중성 index (호환 자모, 첫가끝 중성), 없으면 -1
+func jungseongIndex(r rune) int {
+	if r >= 0x1161 && r <= 0x1175 {
+		return int(r - 0x1161)
+	}
+	return runeIndex(hangulJungseong, r)
+}
+
+// 종성 index (호환 자모, 첫가끝 종성), 없으면 -1
+func jongseongIndex(r rune) int {
+	if r >= 0x11A8 && r <= 0x11C2 {
+		return int(r - 0x11A7)
+	}
+	if r == ' ' {
+		return -1
+	}
+	return runeIndex(hangulJongseong, r)
+}
+
+func runeIndex(runes []rune, r rune) int {
+	for i, candidate := range runes {
+		if candidate == r {
+			return i
+		}
+	}
+	return -1
+}
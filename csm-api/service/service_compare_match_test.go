@@ -0,0 +1,139 @@
+package service
+
+import (
+	"csm-api/entity"
+	"github.com/guregu/null"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComposeHangul(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "조합된 이름", in: "홍길동", want: "홍길동"},
+		{name: "호환 자모", in: "ㅎㅗㅇ길동", want: "홍길동"},
+		{name: "받침만 떨어진 음절", in: "호ㅇ길동", want: "홍길동"},
+		{name: "첫가끝 자모", in: "\u1112\u1169\u11bc길동", want: "홍길동"},
+		{name: "모음 앞 자음은 다음 음절 초성", in: "ㄱㅏㄴㅏ", want: "가나"},
+		{name: "겹받침", in: "ㄷㅏㄺ", want: "닭"},
+		{name: "모음 없는 자음은 그대로", in: "ㅎ", want: "ㅎ"},
+		{name: "한글 아닌 문자는 그대로", in: "Kim민수", want: "Kim민수"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := composeHangul(tt.in); got != tt.want {
+				t.Errorf("composeHangul(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJamoSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want float64
+	}{
+		{name: "같은 이름", a: "홍길동", b: "홍길동", want: 1},
+		{name: "빈 문자열", a: "", b: "", want: 1},
+		{name: "자모 하나 다름", a: "김민수", b: "김민주", want: 0.875},
+		{name: "혼동 자모는 반만 계산", a: "김재민", b: "김제민", want: 0.9375},
+		{name: "받침 누락", a: "홍길동", b: "홍기동", want: 1 - 1.0/9},
+		{name: "전혀 다른 이름", a: "가", b: "호", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jamoSimilarity(decomposeHangul(tt.a), decomposeHangul(tt.b))
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("jamoSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareMatcherScore(t *testing.T) {
+	matcher := newCompareMatcher(entity.DepartmentAliases{
+		{Jno: null.IntFrom(1), Alias: null.StringFrom("대한건설"), Department: null.StringFrom("(주)대한종합건설")},
+	})
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	candidate := func(name string, department string, birth string, gender string) compareCandidate {
+		return matcher.candidate(0, 1, 1, name, department, day, birth, gender)
+	}
+
+	tests := []struct {
+		name      string
+		a         compareCandidate
+		b         compareCandidate
+		wantMatch bool
+	}{
+		{name: "같은 이름, 같은 부서", a: candidate("홍길동", "대한건설", "", ""), b: candidate("홍길동 ", "대한건설", "", ""), wantMatch: true},
+		{name: "부서 별칭", a: candidate("홍길동", "(주)대한종합건설", "", ""), b: candidate("홍길동", "대한건설", "", ""), wantMatch: true},
+		{name: "같은 이름, 다른 부서", a: candidate("홍길동", "대한건설", "", ""), b: candidate("홍길동", "서울전기", "", ""), wantMatch: false},
+		{name: "혼동 자모 하나", a: candidate("김재민", "대한건설", "", ""), b: candidate("김제민", "대한건설", "", ""), wantMatch: true},
+		{name: "자모 하나 다른 이름은 다른 사람", a: candidate("김민수", "대한건설", "", ""), b: candidate("김민주", "대한건설", "", ""), wantMatch: false},
+		{name: "생년월일, 성별이 같으면 자모 하나 허용", a: candidate("김민수", "대한건설", "900101-1234567", "남"), b: candidate("김민주", "대한건설", "90-01-01", "남"), wantMatch: true},
+		{name: "생년월일이 같아도 자모 둘은 다른 사람", a: candidate("김민수", "대한건설", "900101-1234567", "남"), b: candidate("김인주", "대한건설", "90-01-01", "남"), wantMatch: false},
+		{name: "같은 이름, 다른 생년월일", a: candidate("홍길동", "대한건설", "900101-1234567", "남"), b: candidate("홍길동", "대한건설", "85-05-05", "남"), wantMatch: false},
+		{name: "같은 이름, 다른 성별", a: candidate("홍길동", "대한건설", "900101-1234567", "남"), b: candidate("홍길동", "대한건설", "", "여"), wantMatch: false},
+		{name: "빈 이름", a: candidate("", "대한건설", "", ""), b: candidate("", "대한건설", "", ""), wantMatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matcher.score(tt.a, tt.b)
+			if (got >= compareMatchMinScore) != tt.wantMatch {
+				t.Errorf("score() = %v, want match %v", got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestCompareMatcherMatch(t *testing.T) {
+	matcher := newCompareMatcher(nil)
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		lefts  []compareCandidate
+		rights []compareCandidate
+		want   [][2]int
+	}{
+		{
+			name:   "점수가 높은 쌍부터 한 번만 매칭",
+			lefts:  []compareCandidate{matcher.candidate(0, 1, 1, "김재민", "대한건설", day, "", "")},
+			rights: []compareCandidate{matcher.candidate(0, 1, 1, "김제민", "대한건설", day, "", ""), matcher.candidate(1, 1, 1, "김재민", "대한건설", day, "", "")},
+			want:   [][2]int{{0, 1}},
+		},
+		{
+			name:   "생년월일, 성별이 일치하는 후보 우선",
+			lefts:  []compareCandidate{matcher.candidate(0, 1, 1, "홍길동", "대한건설", day, "900101-1234567", "남")},
+			rights: []compareCandidate{matcher.candidate(0, 1, 1, "홍길동", "대한건설", day, "", ""), matcher.candidate(1, 1, 1, "홍길동", "대한건설", day, "90-01-01", "남")},
+			want:   [][2]int{{0, 1}},
+		},
+		{
+			name:   "다른 현장, 다른 날짜는 매칭하지 않음",
+			lefts:  []compareCandidate{matcher.candidate(0, 1, 1, "홍길동", "대한건설", day, "", "")},
+			rights: []compareCandidate{matcher.candidate(0, 2, 1, "홍길동", "대한건설", day, "", ""), matcher.candidate(1, 1, 1, "홍길동", "대한건설", day.AddDate(0, 0, 1), "", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+			for _, match := range matcher.match(tt.lefts, tt.rights) {
+				got = append(got, [2]int{match.left, match.right})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/config"
+	"csm-api/entity"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCompareException          = errors.New("invalid compare exception")   // 처리할 수 없는 예외 (없거나 이미 처리됨, 잘못된 처리 상태)
+	ErrCompareExceptionForbidden = errors.New("compare exception forbidden") // 예외 프로젝트 권한 없음
+)
+
+// func: 일일 근로자 비교 자동 대사 (스케줄러)
+// TBM, 퇴직공제 파일을 업로드한 프로젝트, 날짜마다 근로자 비교를 다시 해서
+// - 근태, TBM(이름+부서), 퇴직공제(핸드폰번호+주민번호)가 모두 일치하고 출퇴근 시간 차이가 허용 범위 안이면 확정(S)으로 반영하고
+// - 나머지는 예외로 등록한다. 이번 대사에서 나오지 않은 처리 대기 예외는 해소 상태로 한다.
+// 이미 확정(S), 제외(X)했거나 마감한 근로자, 다른 프로젝트 근로자는 대사하지 않는다.
+// 대사에 실패한 프로젝트, 날짜는 에러 로그와 대사 기록에 남기고 다음 대상을 대사한다. (다음 대사에서 다시 대상이 된다)
+func (s *ServiceCompare) ModifyCompareReconcile(ctx context.Context) ([]entity.CompareReconcileResult, error) {
+	reconcile, err := config.GetReconcileConfig()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	timeDiff := time.Duration(reconcile.TimeDiffMinutes) * time.Minute
+
+	targets, err := s.Store.GetCompareReconcileTargetList(ctx, s.SafeDB)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	results := make([]entity.CompareReconcileResult, 0, len(targets))
+	for _, target := range targets {
+		result, err := s.reconcileCompare(ctx, target, timeDiff)
+		if err != nil {
+			err = utils.CustomMessageErrorf(fmt.Sprintf("reconcile jno %d date %s", target.Jno.Int64, target.RecordDate.Time.Format("2006-01-02")), err)
+			_ = entity.WriteErrorLog(ctx, err)
+			result.Error = err.Error()
+			if err = s.addCompareReconcileError(ctx, target, result.Error); err != nil {
+				_ = entity.WriteErrorLog(ctx, utils.CustomErrorf(err))
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// func: 자동 대사 실패 기록
+// @param
+// - target: 실패한 프로젝트, 날짜
+// - message: 실패 사유
+func (s *ServiceCompare) addCompareReconcileError(ctx context.Context, target entity.CompareReconcileTarget, message string) (err error) {
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	if err = s.Store.MergeCompareReconcileError(ctx, tx, target, message, time.Now()); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 프로젝트, 날짜 자동 대사
+func (s *ServiceCompare) reconcileCompare(ctx context.Context, target entity.CompareReconcileTarget, timeDiff time.Duration) (result entity.CompareReconcileResult, err error) {
+	result = entity.CompareReconcileResult{CompareReconcileTarget: target}
+	runDate := time.Now()
+
+	compare := entity.Compare{Sno: target.Sno, Jno: target.Jno, RecordDate: target.RecordDate}
+	compareList, err := s.compareList(ctx, compare, true, "", "", "")
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+
+	user := entity.Base{RegUser: utils.ParseNullString("Scheduled"), RegUno: utils.ParseNullInt("0")}
+	var applies entity.WorkerDailys
+	var exceptions []entity.CompareException
+	for _, row := range compareList {
+		apply, rowExceptions := reconcileCompareRow(target, row, timeDiff)
+		if apply != nil {
+			apply.Base = user
+			applies = append(applies, apply)
+		}
+		exceptions = append(exceptions, rowExceptions...)
+	}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	// 자동 반영: ModifyWorkerCompareApply와 같이 근로자 일일 정보, TBM, 퇴직공제, 비교 로그 (근로자 프로젝트는 그대로)
+	if len(applies) > 0 {
+		if err = s.Store.ModifyDailyWorkerCompareApply(ctx, tx, applies); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+		if err = s.Store.ModifyTbmCompareApply(ctx, tx, applies); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+		if err = s.Store.ModifyDeductionCompareApply(ctx, tx, applies); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+		if err = s.Store.AddCompareLog(ctx, tx, applies); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+	}
+	result.AppliedCount = len(applies)
+
+	for _, exception := range exceptions {
+		exception.DetectDate = null.TimeFrom(runDate)
+		if err = s.Store.MergeCompareException(ctx, tx, exception); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+	}
+	result.ExceptionCount = len(exceptions)
+
+	closed, err := s.Store.ModifyCompareExceptionClosed(ctx, tx, target.Jno.Int64, target.RecordDate.Time, runDate)
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+	result.ClosedCount = int(closed)
+
+	if err = s.Store.MergeCompareReconcile(ctx, tx, result, runDate); err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+	return result, nil
+}
+
+// func: 비교 행 대사
+// @return
+// - apply: 자동 반영할 근로자 (예외가 있으면 nil)
+// - exceptions: 예외
+func reconcileCompareRow(target entity.CompareReconcileTarget, row entity.Compare, timeDiff time.Duration) (*entity.WorkerDaily, []entity.CompareException) {
+	newException := func(exceptionType string, detail string) entity.CompareException {
+		key := row.UserKey.String
+		if !row.UserKey.Valid {
+			key = normalizeName(row.UserNm.String) + "|" + normalizeDepartment(row.Department.String)
+		}
+		return entity.CompareException{
+			Sno:              target.Sno,
+			Jno:              target.Jno,
+			RecordDate:       target.RecordDate,
+			ExceptionType:    utils.ParseNullString(exceptionType),
+			ExceptionKey:     utils.ParseNullString(key),
+			UserKey:          row.UserKey,
+			UserNm:           row.UserNm,
+			Department:       row.Department,
+			Detail:           utils.ParseNullString(detail),
+			WorkerInTime:     row.WorkerInTime,
+			WorkerOutTime:    row.WorkerOutTime,
+			DeductionInTime:  row.DeductionInTime,
+			DeductionOutTime: row.DeductionOutTime,
+			MatchScore:       row.MatchScore,
+		}
+	}
+
+	tbm := findCompareMatch(row, entity.CompareSourceTbm)
+	deduction := findCompareMatch(row, entity.CompareSourceDeduction)
+
+	// 근태 기록 없음 (TBM, 퇴직공제만)
+	if !row.UserKey.Valid {
+		sources := []string{}
+		if row.IsTbm.String == "Y" {
+			sources = append(sources, entity.CompareSourceTbm)
+		}
+		if row.DeductionInTime.Valid || row.DeductionOutTime.Valid || row.DeductionBirth.Valid {
+			sources = append(sources, entity.CompareSourceDeduction)
+		}
+		return nil, []entity.CompareException{newException(entity.CompareExceptionNoIris, strings.Join(sources, ","))}
+	}
+
+	if row.Jno.Int64 != target.Jno.Int64 || row.IsDeadline.String == "Y" {
+		return nil, nil
+	}
+	if state := row.CompareState.String; state == "S" || state == "X" {
+		return nil, nil
+	}
+
+	var exceptions []entity.CompareException
+	switch {
+	case tbm == nil:
+		exceptions = append(exceptions, newException(entity.CompareExceptionNoTbm, ""))
+	case tbm.MatchType != entity.CompareMatchExact:
+		detail := fmt.Sprintf("%s %s (%s)", tbm.UserNm.String, tbm.Department.String, strconv.FormatFloat(tbm.Score, 'f', 2, 64))
+		exceptions = append(exceptions, newException(entity.CompareExceptionWeakMatch, detail))
+	}
+	if deduction == nil {
+		exceptions = append(exceptions, newException(entity.CompareExceptionNoDeduction, ""))
+	} else {
+		in, inOk := compareTimeDiff(row.WorkerInTime, row.DeductionInTime)
+		out, outOk := compareTimeDiff(row.WorkerOutTime, row.DeductionOutTime)
+		switch {
+		case !inOk || !outOk:
+			exceptions = append(exceptions, newException(entity.CompareExceptionMissingTime, missingCompareTimes(row)))
+		case in > timeDiff || out > timeDiff:
+			detail := fmt.Sprintf("출근 %d분, 퇴근 %d분", int(in.Minutes()), int(out.Minutes()))
+			exceptions = append(exceptions, newException(entity.CompareExceptionTimeDiff, detail))
+		}
+	}
+	if len(exceptions) > 0 {
+		return nil, exceptions
+	}
+
+	return &entity.WorkerDaily{
+		Sno:         target.Sno,
+		Jno:         row.Jno,
+		UserKey:     row.UserKey,
+		UserId:      row.UserId,
+		UserNm:      row.UserNm,
+		Department:  row.Department,
+		RegNo:       row.DeductionBirth,
+		RecordDate:  row.RecordDate,
+		BeforeState: row.CompareState,
+		AfterState:  utils.ParseNullString("S"),
+	}, nil
+}
+
+// 비교 행에서 원본(TBM, DEDUCTION) 매칭 찾기
+func findCompareMatch(row entity.Compare, source string) *entity.CompareMatch {
+	for i := range row.MatchSources {
+		if row.MatchSources[i].Source == source {
+			return &row.MatchSources[i]
+		}
+	}
+	return nil
+}
+
+// 출퇴근 시간 차이 (한쪽이라도 없으면 false)
+func compareTimeDiff(a null.Time, b null.Time) (time.Duration, bool) {
+	if !a.Valid || !b.Valid {
+		return 0, false
+	}
+	diff := a.Time.Sub(b.Time)
+	if diff < 0 {
+		return -diff, true
+	}
+	return diff, true
+}
+
+// 없는 출퇴근 시간 ex) 근태 퇴근, 공제 출근
+func missingCompareTimes(row entity.Compare) string {
+	var missing []string
+	for _, t := range []struct {
+		name  string
+		value null.Time
+	}{
+		{"근태 출근", row.WorkerInTime},
+		{"근태 퇴근", row.WorkerOutTime},
+		{"공제 출근", row.DeductionInTime},
+		{"공제 퇴근", row.DeductionOutTime},
+	} {
+		if !t.value.Valid {
+			missing = append(missing, t.name)
+		}
+	}
+	return strings.Join(missing, ", ")
+}
+
+// func: 일일 근로자 비교 예외 목록 조회
+// 프로젝트 권한(RoleScopeMiddleware의 IsMember)이 있어야 조회할 수 있다.
+// @param
+// - jno: 프로젝트pk
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+// - status: 처리 상태 (없으면 전체)
+// - assigneeUno: 담당자 (없으면 전체)
+func (s *ServiceCompare) GetCompareExceptionList(ctx context.Context, jno int64, startDate string, endDate string, status string, assigneeUno null.Int) (entity.CompareExceptions, error) {
+	if !auth.GetIsMember(ctx) {
+		return entity.CompareExceptions{}, utils.CustomErrorf(fmt.Errorf("%w: jno %d", ErrCompareExceptionForbidden, jno))
+	}
+
+	list, err := s.Store.GetCompareExceptionList(ctx, s.SafeDB, jno, startDate, endDate, status, assigneeUno)
+	if err != nil {
+		return entity.CompareExceptions{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 근로자 비교 예외 담당자 지정
+// 처리 대기 예외만 지정할 수 있고, 모든 예외의 프로젝트 권한이 있어야 한다.
+// @param
+// - review: EXNOS, ASSIGNEE_UNO, ASSIGNEE_NAME
+func (s *ServiceCompare) ModifyCompareExceptionAssignee(ctx context.Context, review entity.CompareExceptionReview) (err error) {
+	if len(review.Exnos) == 0 || !review.AssigneeUno.Valid {
+		return utils.CustomErrorf(fmt.Errorf("%w: exnos or assignee_uno is empty", ErrCompareException))
+	}
+	if err = s.checkCompareExceptionScope(ctx, review.Exnos); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	user := compareExceptionUser(ctx)
+	for _, exno := range review.Exnos {
+		count, err := s.Store.ModifyCompareExceptionAssignee(ctx, tx, exno, review, user)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if count == 0 {
+			return utils.CustomErrorf(fmt.Errorf("%w: exception %d is not open", ErrCompareException, exno))
+		}
+	}
+	return
+}
+
+// func: 일일 근로자 비교 예외 처리 (처리 완료, 예외 아님)
+// 처리 대기 예외만 처리할 수 있고, 모든 예외의 프로젝트 권한이 있어야 한다. 근로자 비교 반영은 따로 한다. (ModifyWorkerCompareApply)
+// @param
+// - review: EXNOS, STATUS(R, D), NOTE
+func (s *ServiceCompare) ModifyCompareExceptionResolve(ctx context.Context, review entity.CompareExceptionReview) (err error) {
+	if len(review.Exnos) == 0 {
+		return utils.CustomErrorf(fmt.Errorf("%w: exnos is empty", ErrCompareException))
+	}
+	if review.Status != entity.CompareExceptionResolved && review.Status != entity.CompareExceptionDismissed {
+		return utils.CustomErrorf(fmt.Errorf("%w: unknown status %q", ErrCompareException, review.Status))
+	}
+	if review.Status == entity.CompareExceptionDismissed && strings.TrimSpace(review.Note.String) == "" {
+		return utils.CustomErrorf(fmt.Errorf("%w: note is empty", ErrCompareException))
+	}
+	if err = s.checkCompareExceptionScope(ctx, review.Exnos); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	user := compareExceptionUser(ctx)
+	for _, exno := range review.Exnos {
+		count, err := s.Store.ModifyCompareExceptionResolve(ctx, tx, exno, review, user)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if count == 0 {
+			return utils.CustomErrorf(fmt.Errorf("%w: exception %d is not open", ErrCompareException, exno))
+		}
+	}
+	return
+}
+
+// func: 예외 프로젝트 권한 확인
+// 관리자이거나 예외의 프로젝트 권한(UserService.GetUserScope의 IsMember)이 있어야 한다. (협력업체는 없음)
+// @param
+// - exnos: 예외pk
+func (s *ServiceCompare) checkCompareExceptionScope(ctx context.Context, exnos []int64) error {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	uno, _ := strconv.ParseInt(unoString, 10, 64)
+
+	checked := make(map[int64]bool)
+	for _, exno := range exnos {
+		exception, err := s.Store.GetCompareException(ctx, s.SafeDB, exno)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if exception == nil {
+			return fmt.Errorf("%w: exception %d not found", ErrCompareException, exno)
+		}
+		jno := exception.Jno.Int64
+		if checked[jno] {
+			continue
+		}
+		scope, err := s.UserService.GetUserScope(ctx, jno, uno, role)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if !scope.IsMember {
+			return fmt.Errorf("%w: jno %d", ErrCompareExceptionForbidden, jno)
+		}
+		checked[jno] = true
+	}
+	return nil
+}
+
+// 예외 처리자 (context)
+func compareExceptionUser(ctx context.Context) entity.Base {
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	return entity.Base{ModUser: utils.ParseNullString(userName), ModUno: utils.ParseNullInt(uno)}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/store"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"testing"
+	"time"
+)
+
+func TestReconcileCompareRow(t *testing.T) {
+	target := entity.CompareReconcileTarget{Sno: null.IntFrom(1), Jno: null.IntFrom(1), RecordDate: null.TimeFrom(time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local))}
+	at := func(hour int, minute int) null.Time {
+		return null.TimeFrom(time.Date(2025, 3, 3, hour, minute, 0, 0, time.Local))
+	}
+	row := func(workerIn null.Time, workerOut null.Time, deductionIn null.Time, deductionOut null.Time) entity.Compare {
+		return entity.Compare{
+			Jno:              null.IntFrom(1),
+			UserKey:          null.StringFrom("user"),
+			UserNm:           null.StringFrom("홍길동"),
+			RecordDate:       target.RecordDate,
+			WorkerInTime:     workerIn,
+			WorkerOutTime:    workerOut,
+			DeductionInTime:  deductionIn,
+			DeductionOutTime: deductionOut,
+			MatchSources: []entity.CompareMatch{
+				{Source: entity.CompareSourceTbm, MatchType: entity.CompareMatchExact, Score: 1},
+				{Source: entity.CompareSourceDeduction, MatchType: entity.CompareMatchRegNo, Score: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		row       entity.Compare
+		wantApply bool
+		wantType  string
+	}{
+		{name: "시간 차이 허용 범위 안이면 확정", row: row(at(7, 50), at(17, 5), at(8, 0), at(17, 0)), wantApply: true},
+		{name: "시간 차이 허용 범위 초과", row: row(at(7, 0), at(17, 0), at(8, 0), at(17, 0)), wantType: entity.CompareExceptionTimeDiff},
+		{name: "근태 퇴근 없음", row: row(at(8, 0), null.Time{}, at(8, 0), at(17, 0)), wantType: entity.CompareExceptionMissingTime},
+		{name: "공제 출퇴근 없음", row: row(at(8, 0), at(17, 0), null.Time{}, null.Time{}), wantType: entity.CompareExceptionMissingTime},
+		{name: "양쪽 퇴근 없음", row: row(at(8, 0), null.Time{}, at(8, 0), null.Time{}), wantType: entity.CompareExceptionMissingTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apply, exceptions := reconcileCompareRow(target, tt.row, 30*time.Minute)
+			if (apply != nil) != tt.wantApply {
+				t.Fatalf("reconcileCompareRow() apply = %v, want %v", apply != nil, tt.wantApply)
+			}
+			if tt.wantType == "" {
+				if len(exceptions) != 0 {
+					t.Errorf("reconcileCompareRow() exceptions = %+v, want none", exceptions)
+				}
+				return
+			}
+			if len(exceptions) != 1 || exceptions[0].ExceptionType.String != tt.wantType {
+				t.Errorf("reconcileCompareRow() exceptions = %+v, want %s", exceptions, tt.wantType)
+			}
+		})
+	}
+}
+
+// 트랜잭션 시작, 커밋, 롤백만 하는 DB 연결 (쿼리는 fake 저장소에서)
+type txOnlyConnector struct{}
+
+func (c txOnlyConnector) Connect(ctx context.Context) (driver.Conn, error) { return txOnlyConn{}, nil }
+func (c txOnlyConnector) Driver() driver.Driver                            { return c }
+func (c txOnlyConnector) Open(name string) (driver.Conn, error)            { return txOnlyConn{}, nil }
+
+type txOnlyConn struct{}
+
+func (txOnlyConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("query is not supported")
+}
+func (txOnlyConn) Close() error              { return nil }
+func (txOnlyConn) Begin() (driver.Tx, error) { return txOnlyConn{}, nil }
+func (txOnlyConn) Commit() error             { return nil }
+func (txOnlyConn) Rollback() error           { return nil }
+
+// 대사 대상 프로젝트별 근태만 있는 비교 저장소 (저장 내용 기록)
+type reconcileStore struct {
+	store.CompareStore
+	targets []entity.CompareReconcileTarget
+	workers map[int64]entity.WorkerDailys // 프로젝트별 근태 (없으면 조회 실패)
+
+	exceptions []entity.CompareException
+	closedJnos []int64
+	doneJnos   []int64 // 대사 기록
+	errorJnos  []int64 // 실패 기록
+}
+
+func (r *reconcileStore) GetCompareReconcileTargetList(ctx context.Context, db store.Queryer) ([]entity.CompareReconcileTarget, error) {
+	return r.targets, nil
+}
+
+func (r *reconcileStore) GetDailyWorkerList(ctx context.Context, db store.Queryer, compare entity.Compare, isRole bool, uno string, retry string, order string) (entity.WorkerDailys, error) {
+	workers, ok := r.workers[compare.Jno.Int64]
+	if !ok {
+		return nil, errors.New("daily worker list failed")
+	}
+	return workers, nil
+}
+
+func (r *reconcileStore) GetTbmList(ctx context.Context, db store.Queryer, compare entity.Compare, retry string, order string) ([]entity.Tbm, error) {
+	return nil, nil
+}
+
+func (r *reconcileStore) GetDeductionList(ctx context.Context, db store.Queryer, compare entity.Compare, retry string, order string) ([]entity.Deduction, error) {
+	return nil, nil
+}
+
+func (r *reconcileStore) GetDepartmentAliasList(ctx context.Context, db store.Queryer, jno null.Int) (entity.DepartmentAliases, error) {
+	return nil, nil
+}
+
+func (r *reconcileStore) MergeCompareException(ctx context.Context, tx store.Execer, exception entity.CompareException) error {
+	r.exceptions = append(r.exceptions, exception)
+	return nil
+}
+
+func (r *reconcileStore) ModifyCompareExceptionClosed(ctx context.Context, tx store.Execer, jno int64, recordDate time.Time, runDate time.Time) (int64, error) {
+	r.closedJnos = append(r.closedJnos, jno)
+	return 0, nil
+}
+
+func (r *reconcileStore) MergeCompareReconcile(ctx context.Context, tx store.Execer, result entity.CompareReconcileResult, runDate time.Time) error {
+	r.doneJnos = append(r.doneJnos, result.Jno.Int64)
+	return nil
+}
+
+func (r *reconcileStore) MergeCompareReconcileError(ctx context.Context, tx store.Execer, target entity.CompareReconcileTarget, message string, errorDate time.Time) error {
+	r.errorJnos = append(r.errorJnos, target.Jno.Int64)
+	return nil
+}
+
+func TestModifyCompareReconcile(t *testing.T) {
+	t.Setenv("ERR_LOG_PATH", t.TempDir())
+	date := null.TimeFrom(time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local))
+	worker := func(jno int64, userKey string) *entity.WorkerDaily {
+		return &entity.WorkerDaily{
+			Sno:          null.IntFrom(1),
+			Jno:          null.IntFrom(jno),
+			UserKey:      null.StringFrom(userKey),
+			UserNm:       null.StringFrom(userKey),
+			RecordDate:   date,
+			InRecogTime:  null.TimeFrom(date.Time.Add(8 * time.Hour)),
+			OutRecogTime: null.TimeFrom(date.Time.Add(17 * time.Hour)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		targets    []int64                       // 대사 대상 프로젝트 (현장 1, 같은 날짜)
+		workers    map[int64]entity.WorkerDailys // 프로젝트별 근태 (없으면 조회 실패)
+		wantErrors []int64                       // 실패 기록한 프로젝트
+		wantDone   []int64                       // 대사 기록한 프로젝트
+		wantUsers  map[string]bool               // 예외 등록 근로자
+	}{
+		{
+			name:      "대상 프로젝트 근로자만 예외 등록",
+			targets:   []int64{10},
+			workers:   map[int64]entity.WorkerDailys{10: {worker(10, "a"), worker(20, "other")}},
+			wantDone:  []int64{10},
+			wantUsers: map[string]bool{"a": true},
+		},
+		{
+			name:       "실패한 대상 다음 대상 계속 대사",
+			targets:    []int64{10, 20},
+			workers:    map[int64]entity.WorkerDailys{20: {worker(20, "b")}},
+			wantErrors: []int64{10},
+			wantDone:   []int64{20},
+			wantUsers:  map[string]bool{"b": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reconcileStore{workers: tt.workers}
+			for _, jno := range tt.targets {
+				r.targets = append(r.targets, entity.CompareReconcileTarget{Sno: null.IntFrom(1), Jno: null.IntFrom(jno), RecordDate: date})
+			}
+			db := sqlx.NewDb(sql.OpenDB(txOnlyConnector{}), "godror")
+			s := &ServiceCompare{SafeDB: db, SafeTDB: db, Store: r}
+
+			results, err := s.ModifyCompareReconcile(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != len(tt.targets) {
+				t.Fatalf("ModifyCompareReconcile() = %d results, want %d", len(results), len(tt.targets))
+			}
+			for _, result := range results {
+				failed := result.Error != ""
+				if failed != containsJno(tt.wantErrors, result.Jno.Int64) {
+					t.Errorf("result jno %d error = %q", result.Jno.Int64, result.Error)
+				}
+			}
+			if !equalJnos(r.errorJnos, tt.wantErrors) || !equalJnos(r.doneJnos, tt.wantDone) || !equalJnos(r.closedJnos, tt.wantDone) {
+				t.Errorf("errors = %v, done = %v, closed = %v, want errors %v, done %v", r.errorJnos, r.doneJnos, r.closedJnos, tt.wantErrors, tt.wantDone)
+			}
+
+			// 예외는 대상 현장, 프로젝트로만 등록 (다른 프로젝트 근로자 제외)
+			users := map[string]bool{}
+			for _, exception := range r.exceptions {
+				if exception.Sno.Int64 != 1 || !containsJno(tt.wantDone, exception.Jno.Int64) {
+					t.Errorf("exception %s sno %d jno %d, want sno 1 jno in %v", exception.UserKey.String, exception.Sno.Int64, exception.Jno.Int64, tt.wantDone)
+				}
+				users[exception.UserKey.String] = true
+			}
+			if len(users) != len(tt.wantUsers) {
+				t.Errorf("exception users = %v, want %v", users, tt.wantUsers)
+			}
+			for user := range tt.wantUsers {
+				if !users[user] {
+					t.Errorf("exception users = %v, want %v", users, tt.wantUsers)
+				}
+			}
+		})
+	}
+}
+
+func containsJno(jnos []int64, jno int64) bool {
+	for _, j := range jnos {
+		if j == jno {
+			return true
+		}
+	}
+	return false
+}
+
+func equalJnos(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/clock"
+	"csm-api/entity"
+	"csm-api/store"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDailyCloseState     = errors.New("invalid daily close state")          // 현재 상태에서 할 수 없는 처리
+	ErrDailyCloseIssue     = errors.New("unresolved compare state remaining") // 비교 미처리 근로자가 있어 마감 요청 불가
+	ErrDailyCloseForbidden = errors.New("daily close forbidden")              // 처리 권한 없음
+)
+
+type ServiceDailyClose struct {
+	SafeDB    store.Queryer
+	SafeTDB   store.Beginner
+	Store     store.DailyCloseStore
+	UserStore store.UserStore
+	Clock     clock.Clocker
+}
+
+// func: 일일 마감 현황 조회
+// @param
+// - jno: 프로젝트pk
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+func (s *ServiceDailyClose) GetDailyCloseList(ctx context.Context, jno int64, startDate string, endDate string) (entity.DailyCloses, error) {
+	list, err := s.Store.GetDailyCloseList(ctx, s.SafeDB, jno, startDate, endDate)
+	if err != nil {
+		return entity.DailyCloses{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 마감 상태 변경
+// - 마감 요청(SUBMIT): 현장 관리자, 현장소장. 마감 전이고 지난 날짜이며 비교 미처리 근로자가 없어야 한다.
+// - 승인(APPROVE): 현장소장. 마감 요청 상태
+// - 반려(REJECT): 현장소장. 마감 요청, 승인 상태이며 사유 필수
+// - 마감(LOCK): 현장소장. 승인 상태이며 근로자 기록을 마감한다. (승인된 날짜는 매일 밤 스케줄러도 마감)
+// 시스템관리자는 모든 처리 가능
+// @param
+// - action: 처리 (entity.DailyCloseSubmit, DailyCloseApprove, DailyCloseReject, DailyCloseLock)
+// - dailyClose: JNO, RECORD_DATE, REASON
+func (s *ServiceDailyClose) ModifyDailyClose(ctx context.Context, action string, dailyClose entity.DailyClose) (err error) {
+	if !dailyClose.Jno.Valid || !dailyClose.RecordDate.Valid {
+		return utils.CustomErrorf(fmt.Errorf("jno or record_date is empty"))
+	}
+	jno := dailyClose.Jno.Int64
+	recordDate := dailyClose.RecordDate.Time
+
+	// 처리 권한 확인
+	if err = s.checkDailyCloseRole(ctx, action, jno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	current, err := s.Store.GetDailyCloseForUpdate(ctx, tx, tx, jno, recordDate)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	before := current.Status.String
+
+	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	uno := utils.ParseNullInt(unoString)
+	user := entity.Base{ModUser: utils.ParseNullString(userName), ModUno: uno}
+	now := null.TimeFrom(s.Clock.Now())
+
+	after := *current
+	after.Base = user
+	switch action {
+	case entity.DailyCloseSubmit:
+		if before != entity.DailyCloseOpen {
+			return utils.CustomErrorf(fmt.Errorf("%w: %s -> %s", ErrDailyCloseState, before, action))
+		}
+		today := s.Clock.Now()
+		if !recordDate.Before(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())) {
+			return utils.CustomErrorf(fmt.Errorf("%w: record date is not past", ErrDailyCloseState))
+		}
+		issueCount, err := s.Store.GetDailyCloseIssueCount(ctx, tx, jno, recordDate)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if issueCount > 0 {
+			return utils.CustomErrorf(fmt.Errorf("%w: %d workers", ErrDailyCloseIssue, issueCount))
+		}
+		after.Status = utils.ParseNullString(entity.DailyCloseSubmitted)
+		after.SubmitUno = uno
+		after.SubmitUser = user.ModUser
+		after.SubmitDate = now
+		after.Reason = null.String{}
+
+	case entity.DailyCloseApprove:
+		if before != entity.DailyCloseSubmitted {
+			return utils.CustomErrorf(fmt.Errorf("%w: %s -> %s", ErrDailyCloseState, before, action))
+		}
+		after.Status = utils.ParseNullString(entity.DailyCloseApproved)
+		after.ApproveUno = uno
+		after.ApproveUser = user.ModUser
+		after.ApproveDate = now
+
+	case entity.DailyCloseReject:
+		if before != entity.DailyCloseSubmitted && before != entity.DailyCloseApproved {
+			return utils.CustomErrorf(fmt.Errorf("%w: %s -> %s", ErrDailyCloseState, before, action))
+		}
+		if strings.TrimSpace(dailyClose.Reason.String) == "" {
+			return utils.CustomErrorf(fmt.Errorf("reason is empty"))
+		}
+		after.Status = utils.ParseNullString(entity.DailyCloseOpen)
+		after.ApproveUno = null.Int{}
+		after.ApproveUser = null.String{}
+		after.ApproveDate = null.Time{}
+		after.Reason = dailyClose.Reason
+
+	case entity.DailyCloseLock:
+		if before != entity.DailyCloseApproved {
+			return utils.CustomErrorf(fmt.Errorf("%w: %s -> %s", ErrDailyCloseState, before, action))
+		}
+		if _, err = s.Store.ModifyDailyCloseDeadline(ctx, tx, jno, recordDate, user); err != nil {
+			return utils.CustomErrorf(err)
+		}
+		after.Status = utils.ParseNullString(entity.DailyCloseLocked)
+		after.LockDate = now
+
+	default:
+		return utils.CustomErrorf(fmt.Errorf("%w: unknown action %s", ErrDailyCloseState, action))
+	}
+
+	if err = s.Store.ModifyDailyClose(ctx, tx, after); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if err = s.Store.AddDailyCloseLog(ctx, tx, before, after); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 승인된 일일 마감 마감처리 (스케줄러)
+// @return
+// - 마감한 날짜 수
+func (s *ServiceDailyClose) ModifyDailyCloseApproved(ctx context.Context) (count int, err error) {
+	list, err := s.Store.GetDailyCloseApprovedList(ctx, s.SafeDB)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	user := entity.Base{ModUser: utils.ParseNullString("Scheduled"), ModUno: utils.ParseNullInt("0")}
+	for _, approved := range list {
+		if err = s.lockDailyClose(ctx, approved.Jno.Int64, approved.RecordDate.Time, user); err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// func: 승인된 날짜 마감 (다른 곳에서 상태를 바꾼 경우 건너뜀)
+func (s *ServiceDailyClose) lockDailyClose(ctx context.Context, jno int64, recordDate time.Time, user entity.Base) (err error) {
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	current, err := s.Store.GetDailyCloseForUpdate(ctx, tx, tx, jno, recordDate)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if current.Status.String != entity.DailyCloseApproved {
+		return nil
+	}
+
+	if _, err = s.Store.ModifyDailyCloseDeadline(ctx, tx, jno, recordDate, user); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	after := *current
+	after.Base = user
+	after.Status = utils.ParseNullString(entity.DailyCloseLocked)
+	after.LockDate = null.TimeFrom(s.Clock.Now())
+	if err = s.Store.ModifyDailyClose(ctx, tx, after); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if err = s.Store.AddDailyCloseLog(ctx, tx, current.Status.String, after); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 일일 마감 처리 권한 확인
+// 마감 요청은 현장 관리자, 현장소장. 그 외 처리는 현장소장 (시스템관리자는 모두 가능)
+func (s *ServiceDailyClose) checkDailyCloseRole(ctx context.Context, action string, jno int64) error {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	if auth.JWTRole(role) == auth.SystemAdmin {
+		return nil
+	}
+
+	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	uno, _ := strconv.ParseInt(unoString, 10, 64)
+	siteRole, err := s.UserStore.GetSiteRole(ctx, s.SafeDB, jno, uno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	switch auth.JWTRole(siteRole) {
+	case auth.SiteDirector:
+		return nil
+	case auth.SiteManager:
+		if action == entity.DailyCloseSubmit {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrDailyCloseForbidden, action)
+}
@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
 	"csm-api/utils"
 	"database/sql"
 	"encoding/json"
+	"github.com/guregu/null"
+	"strconv"
 )
 
 /**
@@ -154,3 +157,36 @@ func (s *ServiceDevice) GetCheckRegisteredDevices(ctx context.Context) ([]string
 
 	return respond, nil
 }
+
+// func: 근태인식기 api key 발급
+// 기존 key는 즉시 사용할 수 없게 되며, 발급한 key 원문은 저장하지 않으므로 이 응답에서만 확인할 수 있다.
+// @param
+// - dno: 근태인식기 고유번호
+func (s *ServiceDevice) IssueDeviceApiKey(ctx context.Context, dno int64) (apiKey string, err error) {
+	strUno, _ := auth.GetContext(ctx, auth.Uno{})
+	uno, _ := strconv.ParseInt(strUno, 10, 64)
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+
+	apiKey, hash, err := auth.NewDeviceApiKey()
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+
+	device := entity.Device{
+		Dno:        null.IntFrom(dno),
+		ApiKeyHash: null.StringFrom(hash),
+	}
+	device.ModUser = null.StringFrom(userName)
+	device.ModUno = null.IntFrom(uno)
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.Store.ModifyDeviceApiKey(ctx, tx, device); err != nil {
+		return "", utils.CustomErrorf(err)
+	}
+	return apiKey, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"csm-api/crypto"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
@@ -20,6 +21,7 @@ type ServiceExcel struct {
 	Store       store.ExcelStore
 	WorkerStore store.WorkerStore
 	FileStore   store.UploadFileStore
+	RegNoCipher *crypto.Keyring // 주민번호 암호화 키 (근로자 업로드)
 }
 
 func mustGet(f *excelize.File, sheet, cell string) string {
@@ -315,14 +317,17 @@ func (s *ServiceExcel) ImportAddDailyWorker(ctx context.Context, path string, wo
 			},
 		}
 
-		var userKey string
-		if userKey, err = s.WorkerStore.GetDailyWorkerUserKey(ctx, s.SafeDB, temp); err != nil {
+		var userKey null.String
+		if userKey, err = s.getDailyWorkerUserKey(ctx, temp); err != nil {
+			return list, utils.CustomErrorf(err)
+		}
+		if !userKey.Valid {
 			// 조회된 전체근로자가 없는 경우
 			temp.FailReason = utils.ParseNullString("해당 근로자가 등록되어 있지 않습니다")
 			nonWorkers = append(nonWorkers, &temp)
 		} else {
 			// 조회된 전체근로자가 있는 경우
-			temp.UserKey = utils.ParseNullString(userKey)
+			temp.UserKey = userKey
 			workers = append(workers, &temp)
 		}
 	}
@@ -412,6 +417,11 @@ func (s *ServiceExcel) ImportAddWorker(ctx context.Context, path string, worker
 			normalizedPhone = "0" + normalizedPhone
 		}
 
+		// 마스킹된 개인정보(목록 내려받기 등)는 원래 값을 덮어쓰므로 업로드하지 않는다.
+		if crypto.IsMaskedRegNo(identityNumberRaw) || crypto.IsMaskedPhone(normalizedId) || crypto.IsMaskedPhone(normalizedPhone) {
+			failReason = "마스킹된 주민등록번호, 아이디, 핸드폰번호는 업로드할 수 없습니다."
+		}
+
 		// G: 공종
 		discName, _ := f.GetCellValue(sheet, fmt.Sprintf("G%d", row))
 
@@ -455,18 +465,30 @@ func (s *ServiceExcel) ImportAddWorker(ctx context.Context, path string, worker
 		row++
 	}
 
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
-	if err != nil {
-		return list, utils.CustomErrorf(err)
+	// 주민번호 암호화
+	for _, excelWorker := range excels {
+		if excelWorker.FailReason.Valid {
+			continue
+		}
+		if excelWorker.RegNoEnc, err = encryptRegNo(s.RegNoCipher, excelWorker.RegNo); err != nil {
+			return list, utils.CustomErrorf(err)
+		}
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return list, utils.CustomMessageErrorf("begin tx", err)
+	}
+	defer txutil.DeferTxx(tx, &err)
 
-	// 업로드 데이터 추가/수정
+	// 업로드 데이터 추가/수정 (아이디, 이름, 주민번호가 같은 근로자는 수정)
 	var count int64
 	for _, excelWorker := range excels {
 
 		if !excelWorker.FailReason.Valid {
+			if excelWorker.UserKey, err = findWorkerUserKey(ctx, tx, s.WorkerStore, s.RegNoCipher, *excelWorker); err != nil {
+				return list, utils.CustomErrorf(err)
+			}
 			count, err = s.WorkerStore.MergeWorker(ctx, tx, *excelWorker)
 			if err != nil {
 				return list, utils.CustomErrorf(err)
@@ -481,3 +503,25 @@ func (s *ServiceExcel) ImportAddWorker(ctx context.Context, path string, worker
 
 	return
 }
+
+// func: 현장근로자 업로드 근로자 키 조회
+// 핸드폰번호, 이름, 현장, 프로젝트가 같은 근로자 중 생년월일(주민번호 앞 6자리)이 같은 근로자. 없으면 NULL
+// @param
+// - worker: SNO, JNO, USER_NM, PHONE, REG_NO(생년월일)
+func (s *ServiceExcel) getDailyWorkerUserKey(ctx context.Context, worker entity.WorkerDaily) (null.String, error) {
+	list, err := s.WorkerStore.GetDailyWorkerUserKeyList(ctx, s.SafeDB, worker)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+
+	for _, candidate := range list {
+		regNo, err := decryptRegNoEnc(s.RegNoCipher, candidate.RegNoEnc)
+		if err != nil {
+			return null.String{}, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", candidate.UserKey.String), err)
+		}
+		if regNo.Valid && regNoBirth(regNo.String) == worker.RegNo.String {
+			return candidate.UserKey, nil
+		}
+	}
+	return null.String{}, nil
+}
@@ -19,10 +19,11 @@ type ServiceNotice struct {
 // func: 공지사항 전체 조회
 // @param
 // - page entity.PageSql : 현재 페이지번호, 리스트 목록 개수
-func (s *ServiceNotice) GetNoticeList(ctx context.Context, page entity.Page, isRole bool, search entity.Notice) (*entity.Notices, error) {
+func (s *ServiceNotice) GetNoticeList(ctx context.Context, page entity.Page, search entity.Notice) (*entity.Notices, error) {
 
 	// 사용자 정보 가져오기
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	// 페이지 변환
 	pageSql := entity.PageSql{}
@@ -43,9 +44,10 @@ func (s *ServiceNotice) GetNoticeList(ctx context.Context, page entity.Page, isR
 // func: 공지사항 전체 개수 조회
 // @param
 // -
-func (s *ServiceNotice) GetNoticeListCount(ctx context.Context, isRole bool, search entity.Notice) (int, error) {
+func (s *ServiceNotice) GetNoticeListCount(ctx context.Context, search entity.Notice) (int, error) {
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	count, err := s.Store.GetNoticeListCount(ctx, s.SafeDB, uno, isRole, search)
 	if err != nil {
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/crypto"
+	"csm-api/entity"
+	"csm-api/store"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"sort"
+	"strings"
+)
+
+var ErrPiiReveal = errors.New("invalid pii reveal request") // 조회할 수 없는 근로자, 항목이거나 사유 없음
+
+type ServicePii struct {
+	SafeDB      store.Queryer
+	SafeTDB     store.Beginner
+	Store       store.PiiStore
+	RegNoCipher *crypto.Keyring
+}
+
+// func: 근로자 개인정보 단건 조회 (reveal)
+// 마스킹하지 않은 값을 반환하고 조회자, 항목, 사유를 기록한다.
+// @param
+// - reveal: USER_KEY, 항목(phone, reg_no, birth), 사유
+func (s *ServicePii) RevealWorkerPii(ctx context.Context, reveal entity.PiiReveal) (result entity.PiiRevealResult, err error) {
+	switch reveal.Field {
+	case auth.PiiPhone, auth.PiiRegNo, auth.PiiBirth:
+	default:
+		return result, utils.CustomErrorf(fmt.Errorf("%w: unknown field %q", ErrPiiReveal, reveal.Field))
+	}
+	if reveal.UserKey.String == "" {
+		return result, utils.CustomErrorf(fmt.Errorf("%w: user_key is empty", ErrPiiReveal))
+	}
+	if strings.TrimSpace(reveal.Reason.String) == "" {
+		return result, utils.CustomErrorf(fmt.Errorf("%w: reason is empty", ErrPiiReveal))
+	}
+
+	worker, err := s.Store.GetWorkerPii(ctx, s.SafeDB, reveal.UserKey.String)
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+	if worker == nil {
+		return result, utils.CustomErrorf(fmt.Errorf("%w: worker not found", ErrPiiReveal))
+	}
+	if err = decryptRegNo(s.RegNoCipher, worker); err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+
+	result = entity.PiiRevealResult{UserKey: reveal.UserKey.String, Field: reveal.Field}
+	switch reveal.Field {
+	case auth.PiiPhone:
+		result.Value = worker.Phone.String
+	case auth.PiiRegNo:
+		result.Value = worker.RegNo.String
+	case auth.PiiBirth:
+		regNo := strings.NewReplacer(" ", "", "-", "").Replace(worker.RegNo.String)
+		if len(regNo) >= 6 {
+			result.Value = regNo[:6]
+		}
+	}
+
+	accessLog := entity.PiiAccessLog{
+		AccessType: utils.ParseNullString(entity.PiiAccessReveal),
+		Target:     utils.ParseNullString(entity.PiiTargetWorker),
+		UserKey:    reveal.UserKey,
+		Fields:     utils.ParseNullString(reveal.Field),
+		RowCount:   null.IntFrom(1),
+		Reason:     reveal.Reason,
+	}
+	if err = addPiiAccessLog(ctx, s.SafeTDB, s.Store, accessLog); err != nil {
+		return entity.PiiRevealResult{}, utils.CustomErrorf(err)
+	}
+	return result, nil
+}
+
+// func: 사용자별 개인정보 접근 현황 조회
+// @param
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+func (s *ServicePii) GetPiiAccessReport(ctx context.Context, startDate string, endDate string) (entity.PiiAccessReports, error) {
+	list, err := s.Store.GetPiiAccessReport(ctx, s.SafeDB, startDate, endDate)
+	if err != nil {
+		return entity.PiiAccessReports{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 개인정보 접근 기록 조회
+// @param
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+// - uno: 접근자 (없으면 전체)
+// - accessType: 구분 (L: 목록 조회, R: 단건 조회, 없으면 전체)
+func (s *ServicePii) GetPiiAccessLogList(ctx context.Context, startDate string, endDate string, uno null.Int, accessType string) (entity.PiiAccessLogs, error) {
+	list, err := s.Store.GetPiiAccessLogList(ctx, s.SafeDB, startDate, endDate, uno, accessType)
+	if err != nil {
+		return entity.PiiAccessLogs{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// 응답 개인정보 마스킹 (조회자 역할 기준, auth.JWTRole.CanViewPii)
+// 마스킹하지 않고 내보낸 항목을 모아 접근 기록에 남긴다.
+type piiMask struct {
+	role      auth.JWTRole
+	disclosed map[string]bool
+}
+
+func newPiiMask(ctx context.Context) *piiMask {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	return &piiMask{role: auth.JWTRole(role), disclosed: map[string]bool{}}
+}
+
+// func: 개인정보 항목 마스킹
+// @param
+// - field: 개인정보 항목 (auth.PiiPhone, PiiRegNo, PiiBirth)
+// - value: 값
+func (m *piiMask) mask(field string, value null.String) null.String {
+	if value.String == "" {
+		return value
+	}
+	if m.role.CanViewPii(field) {
+		m.disclosed[field] = true
+		return value
+	}
+
+	switch field {
+	case auth.PiiPhone:
+		return utils.ParseNullString(crypto.MaskPhone(value.String))
+	case auth.PiiRegNo:
+		return utils.ParseNullString(crypto.MaskRegNo(value.String))
+	case auth.PiiBirth:
+		return utils.ParseNullString(crypto.MaskBirth(value.String))
+	}
+	return value
+}
+
+// 마스킹하지 않고 내보낸 항목 (',' 구분)
+func (m *piiMask) fields() string {
+	fields := make([]string, 0, len(m.disclosed))
+	for field := range m.disclosed {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
+
+// func: 목록 조회 개인정보 접근 기록
+// 마스킹하지 않고 내보낸 항목이 있는 경우만 기록한다.
+// @param
+// - target: 조회 목록 (entity.PiiTargetWorkerTotal 등)
+// - mask: 목록에 사용한 마스킹
+// - rowCount: 내보낸 행 수
+func addPiiListAccessLog(ctx context.Context, db store.Beginner, piiStore store.PiiStore, target string, mask *piiMask, rowCount int) error {
+	if piiStore == nil || rowCount == 0 || len(mask.disclosed) == 0 {
+		return nil
+	}
+
+	accessLog := entity.PiiAccessLog{
+		AccessType: utils.ParseNullString(entity.PiiAccessList),
+		Target:     utils.ParseNullString(target),
+		Fields:     utils.ParseNullString(mask.fields()),
+		RowCount:   null.IntFrom(int64(rowCount)),
+	}
+	return addPiiAccessLog(ctx, db, piiStore, accessLog)
+}
+
+// func: 개인정보 접근 기록 저장 (조회자는 context에서)
+func addPiiAccessLog(ctx context.Context, db store.Beginner, piiStore store.PiiStore, accessLog entity.PiiAccessLog) (err error) {
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	accessLog.Uno = utils.ParseNullInt(uno)
+	accessLog.UserName = utils.ParseNullString(userName)
+	accessLog.Role = utils.ParseNullString(role)
+
+	tx, err := txutil.BeginTxWithMode(ctx, db, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = piiStore.AddPiiAccessLog(ctx, tx, accessLog); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
@@ -108,8 +108,9 @@ func (p *ServiceProject) GetProjectWorkerCountList(ctx context.Context, targetDa
 // func: 프로젝트 조회(이름)
 // @param
 // -
-func (p *ServiceProject) GetProjectNmList(ctx context.Context, isRole bool) (*entity.ProjectInfos, error) {
+func (p *ServiceProject) GetProjectNmList(ctx context.Context) (*entity.ProjectInfos, error) {
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	nmList, err := p.Store.GetProjectNmList(ctx, p.SafeDB, isRole, uno)
 	if err != nil {
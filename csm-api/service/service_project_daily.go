@@ -16,9 +16,10 @@ type ServiceProjectDaily struct {
 }
 
 // 작업내용 조회
-func (s *ServiceProjectDaily) GetDailyJobList(ctx context.Context, isRole bool, jno int64, targetDate string) (entity.ProjectDailys, error) {
+func (s *ServiceProjectDaily) GetDailyJobList(ctx context.Context, jno int64, targetDate string) (entity.ProjectDailys, error) {
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	list, err := s.Store.GetDailyJobList(ctx, s.SafeDB, isRole, jno, uno, targetDate)
 	if err != nil {
@@ -2,21 +2,38 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"csm-api/auth"
+	"csm-api/clock"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
 	"csm-api/utils"
+	"csm-api/worktime"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// 공수 단계 변경 미리보기 이후 공수 단계나 근로자 공수가 바뀐 경우
+var ErrStalePreview = errors.New("stale preview")
+
 type ServiceProjectSetting struct {
-	SafeDB        store.Queryer
-	SafeTDB       store.Beginner
-	Store         store.ProjectSettingStore
-	WorkHourStore store.WorkHourStore
+	SafeDB             store.Queryer
+	SafeTDB            store.Beginner
+	Store              store.ProjectSettingStore
+	WorkHourStore      store.WorkHourStore
+	WorkerStore        store.WorkerStore // 공수 단계 변경 적용 이력
+	RestDateApiService RestDateApiService
+	Clock              clock.Clocker
+	holidays           holidayCache // 공휴일 API 조회 결과
 }
 
 // func: 프로젝트에 설정된 공수 조회
@@ -46,12 +63,14 @@ func (s *ServiceProjectSetting) MergeManHours(ctx context.Context, manHours *ent
 		return utils.CustomErrorf(err)
 	}
 	// jno에 해당하는 공수 찾기
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
 
 	// jno에 해당하는 공수 모두 삭제
 	if deleteManhours != nil && len(*deleteManhours) > 0 {
@@ -75,7 +94,7 @@ func (s *ServiceProjectSetting) MergeManHours(ctx context.Context, manHours *ent
 			// 공수 삭제 시 근로자 업데이트
 			jno = deleteManhour.Jno.Int64
 			user = deleteManhour.Base
-			if err = s.WorkHourStore.ModifyWorkHourByJno(ctx, tx, jno, user, nil); err != nil {
+			if err = modifyWorkHour(ctx, tx, tx, s.WorkHourStore, holidays, jno, user, nil); err != nil {
 				return utils.CustomErrorf(err)
 			}
 
@@ -110,28 +129,260 @@ func (s *ServiceProjectSetting) MergeManHours(ctx context.Context, manHours *ent
 	}
 
 	// 공수에 맞춰 근로자 업데이트
-	if err = s.WorkHourStore.ModifyWorkHourByJno(ctx, tx, jno, user, nil); err != nil {
+	if err = modifyWorkHour(ctx, tx, tx, s.WorkHourStore, holidays, jno, user, nil); err != nil {
 		return utils.CustomErrorf(err)
 	}
 
 	return
 }
 
+// func: 공수 단계 변경 미리보기
+// 변경할 공수 단계로 마감되지 않고 공수가 입력되지 않은 근로자 공수를 계산한다. (저장하지 않음)
+// 공수 단계 변경(MergeManHours)과 같이 이미 공수가 입력된 근로자는 다시 계산하지 않는다.
+// @param
+// - jno: 프로젝트pk
+// - manHours: 변경할 공수 단계
+func (s *ServiceProjectSetting) PreviewManHours(ctx context.Context, jno int64, manHours entity.ManHours) (*entity.ManHourPreview, error) {
+	preview, err := s.previewManHours(ctx, s.SafeDB, s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now()), jno, manHours)
+	if err != nil {
+		return &entity.ManHourPreview{}, utils.CustomErrorf(err)
+	}
+	return preview, nil
+}
+
+// func: 공수 단계 변경 적용
+// 미리보기와 같은 내용으로 다시 계산되는 경우에만 공수 단계를 바꾸고 공수가 바뀌는 근로자만 저장한다. 변경 전/후는 변경 이력에 남긴다.
+// 미리보기 이후 근로자 공수나 출퇴근이 바뀌었으면 ErrStalePreview
+// @param
+// - jno: 프로젝트pk
+// - apply: 미리보기 token, 공수 단계, 수정자 (handler에서 로그인 사용자로 설정)
+// @return
+// - 공수가 변경된 근로자 수
+func (s *ServiceProjectSetting) ApplyManHours(ctx context.Context, jno int64, apply entity.ManHourApply) (count int64, err error) {
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	preview, err := s.previewManHours(ctx, tx, holidays, jno, apply.ManHours)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	if preview.Token != apply.Token {
+		return 0, utils.CustomErrorf(ErrStalePreview)
+	}
+
+	user := apply.Base
+
+	// 기존 공수 단계 삭제
+	before, err := s.Store.GetManHourList(ctx, tx, jno)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	if before != nil {
+		for _, manHour := range *before {
+			if manHour == nil {
+				continue
+			}
+			if err = s.Store.DeleteManHour(ctx, tx, manHour.Mhno.Int64); err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+			manHour.Message = utils.ParseNullString(fmt.Sprintf(`[DELETE] mhno:[before:%d, after: N/A]|work_hour:[before: %d, after: N/A]|man_hour:[before:%.2f, after: N/A]|jno:[before:%d, after: N/A]|etc:[before:%s, after: N/A]`, manHour.Mhno.Int64, manHour.WorkHour.Int64, manHour.ManHour.Float64, manHour.Jno.Int64, manHour.Etc.String))
+			manHour.Base = apply.Base
+			if err = s.Store.ManHourLog(ctx, tx, *manHour); err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+		}
+	}
+
+	// 미리보기한 공수 단계 추가
+	for _, manHour := range apply.ManHours {
+		if manHour == nil {
+			continue
+		}
+		manHour.Jno = null.IntFrom(jno)
+		manHour.Base = apply.Base
+		if err = s.Store.AddManHour(ctx, tx, *manHour); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		manHour.Message = utils.ParseNullString(fmt.Sprintf("[ADD] jno:[before:N/A, after:%d]|work_hour:[before:N/A, after:%d]|man_hour:[before:N/A, after:%f]|etc:[before:N/A, after:%s]", jno, manHour.WorkHour.Int64, manHour.ManHour.Float64, manHour.Etc.String))
+		if err = s.Store.ManHourLog(ctx, tx, *manHour); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+	}
+
+	// 공수가 바뀌는 근로자만 저장
+	changed := make(entity.ManHourPreviewRows, 0, preview.Changed)
+	workers := make(entity.WorkerDailys, 0, preview.Changed)
+	for _, row := range preview.Rows {
+		if row.IsChanged {
+			changed = append(changed, row)
+			worker := &entity.WorkerDaily{Sno: row.Sno, Jno: row.Jno, UserKey: row.UserKey, RecordDate: row.RecordDate}
+			worker.ReasonType = utils.ParseNullString(entity.ReasonTypeManHourApply)
+			worker.Reason = utils.ParseNullString("공수 단계 변경")
+			workers = append(workers, worker)
+		}
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	// 변경전 데이터 조회
+	beforeList, err := s.WorkerStore.GetDailyWorkerBeforeList(ctx, tx, workers)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	count, err = s.WorkHourStore.ModifyWorkHourPreviewList(ctx, tx, changed, user)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	// 조회 이후 다른 곳에서 공수를 바꾸거나 마감한 경우
+	if count != int64(len(changed)) {
+		return 0, utils.CustomErrorf(ErrStalePreview)
+	}
+
+	// 변경이력 저장
+	regDate := null.NewTime(time.Now(), true)
+	afterList := make(entity.WorkerDailys, 0, len(beforeList))
+	for i, before := range beforeList {
+		before.HisStatus = utils.ParseNullString("BEFORE")
+		before.RegDate = regDate
+		before.ModUser = user.ModUser
+		before.ModUno = user.ModUno
+
+		after := *before
+		after.HisStatus = utils.ParseNullString("AFTER")
+		after.WorkHour = changed[i].AfterWorkHour
+		afterList = append(afterList, &after)
+	}
+	if err = s.WorkerStore.AddHistoryDailyWorkers(ctx, tx, beforeList); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	if err = s.WorkerStore.AddHistoryDailyWorkers(ctx, tx, afterList); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
+
+// func: 공수 단계 변경 미리보기 계산
+// token은 프로젝트, 공수 단계, 공수가 바뀌는 근로자의 변경 전/후 공수로 만든다.
+// @param
+// - db: 적용할 때는 같은 트랜잭션
+// - holidays: 공휴일 (트랜잭션 시작 전에 조회)
+func (s *ServiceProjectSetting) previewManHours(ctx context.Context, db store.Queryer, holidays holidaySet, jno int64, manHours entity.ManHours) (*entity.ManHourPreview, error) {
+	for _, manHour := range manHours {
+		if manHour == nil || !manHour.WorkHour.Valid || !manHour.ManHour.Valid || manHour.WorkHour.Int64 < 0 || manHour.ManHour.Float64 < 0 {
+			return nil, utils.CustomErrorf(fmt.Errorf("%w: man hour", worktime.ErrInvalidRule))
+		}
+	}
+
+	targets, err := s.WorkHourStore.GetWorkHourOpenList(ctx, db, jno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	calc := newWorkHourCalculator(db, s.WorkHourStore, holidays)
+	ws, err := calc.setting(ctx, jno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	ws.manHours = ProjectManHours(&manHours)
+
+	steps := make([]worktime.ManHour, len(ws.manHours))
+	copy(steps, ws.manHours)
+	sort.Slice(steps, func(i, j int) bool {
+		if steps[i].WorkHour != steps[j].WorkHour {
+			return steps[i].WorkHour < steps[j].WorkHour
+		}
+		return steps[i].ManHour < steps[j].ManHour
+	})
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%d", jno)
+	for _, step := range steps {
+		_, _ = fmt.Fprintf(hash, "|%d:%g", step.WorkHour, step.ManHour)
+	}
+
+	preview := &entity.ManHourPreview{
+		Jno:  null.IntFrom(jno),
+		Rows: make(entity.ManHourPreviewRows, 0, len(targets)),
+	}
+	for _, target := range targets {
+		result := calc.calculate(ws, target)
+		// 퇴근이 출근보다 빠른 기록은 공수를 계산하지 않음
+		if result.Reversed {
+			continue
+		}
+
+		row := &entity.ManHourPreviewRow{
+			Sno:            target.Sno,
+			Jno:            target.Jno,
+			UserKey:        target.UserKey,
+			UserNm:         target.UserNm,
+			RecordDate:     target.RecordDate,
+			InRecogTime:    target.InRecogTime,
+			OutRecogTime:   target.OutRecogTime,
+			BeforeWorkHour: target.WorkHour,
+			AfterWorkHour:  null.FloatFrom(result.ManHour),
+		}
+		row.IsChanged = !row.BeforeWorkHour.Valid || row.BeforeWorkHour.Float64 != row.AfterWorkHour.Float64
+
+		preview.Count++
+		preview.BeforeTotal += row.BeforeWorkHour.Float64
+		preview.AfterTotal += row.AfterWorkHour.Float64
+		if row.IsChanged {
+			preview.Changed++
+			before := "N/A"
+			if row.BeforeWorkHour.Valid {
+				before = strconv.FormatFloat(row.BeforeWorkHour.Float64, 'g', -1, 64)
+			}
+			_, _ = fmt.Fprintf(hash, "|%d,%s,%s,%s,%g", row.Sno.Int64, row.UserKey.String, row.RecordDate.Time.Format(time.RFC3339), before, row.AfterWorkHour.Float64)
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+	preview.BeforeTotal = math.Round(preview.BeforeTotal*100) / 100
+	preview.AfterTotal = math.Round(preview.AfterTotal*100) / 100
+	preview.Token = hex.EncodeToString(hash.Sum(nil))
+
+	return preview, nil
+}
+
 // func: 프로젝트 설정 정보 추가 및 수정
 // @param
 // - ProjectSetting
 func (s *ServiceProjectSetting) MergeProjectSetting(ctx context.Context, project entity.ProjectSetting) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
 
 	if !project.Message.Valid {
 		return
 	}
 
+	// 출퇴근 분류 규칙, 공수 계산 설정 확인
+	if _, err = ProjectScanRule(&project); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if err = ProjectWorkSetting(&project).Validate(); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	// 변경 전 공수 배율, 반일 기준 (로그용)
+	before, err := s.WorkHourStore.GetWorkHourSetting(ctx, tx, project.Jno.Int64)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
 	count, err := s.Store.MergeProjectSetting(ctx, tx, project)
 	if err != nil {
 		return utils.CustomErrorf(err)
@@ -140,10 +391,26 @@ func (s *ServiceProjectSetting) MergeProjectSetting(ctx context.Context, project
 	if count <= 0 {
 		return
 	} else {
+		// 공수 배율, 반일 기준 변경 로그
+		if message := formatWorkRateChanges(*before, project); message != "" {
+			log := project
+			log.Message = utils.ParseNullString(fmt.Sprintf("[MODIFY] jno:%d|%s", project.Jno.Int64, message))
+			if err = s.Store.ProjectSettingLog(ctx, tx, log); err != nil {
+				return utils.CustomErrorf(err)
+			}
+		}
+
+		// 휴게 시간 변경 (요청에 있는 경우만)
+		if project.Breaks != nil {
+			if err = s.mergeProjectBreaks(ctx, tx, project); err != nil {
+				return utils.CustomErrorf(err)
+			}
+		}
+
 		// 프로젝트 재설정 시 근로자 업데이트
 		jno := project.Jno.Int64
 		user := project.Base
-		if err = s.WorkHourStore.ModifyWorkHourByJno(ctx, tx, jno, user, nil); err != nil {
+		if err = modifyWorkHour(ctx, tx, tx, s.WorkHourStore, holidays, jno, user, nil); err != nil {
 			return utils.CustomErrorf(err)
 		}
 	}
@@ -155,6 +422,81 @@ func (s *ServiceProjectSetting) MergeProjectSetting(ctx context.Context, project
 	return
 }
 
+// func: 휴게 시간 수정 (기존 휴게 시간 삭제 후 새로 넣는 방식)
+// 변경 전/후 휴게 시간은 프로젝트 설정 로그에 남긴다.
+// @param
+// - project: Jno, Breaks, Base
+func (s *ServiceProjectSetting) mergeProjectBreaks(ctx context.Context, tx *sqlx.Tx, project entity.ProjectSetting) error {
+	jno := project.Jno.Int64
+
+	before, err := s.Store.GetProjectBreakList(ctx, tx, jno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	if err = s.Store.DeleteProjectBreak(ctx, tx, jno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	for _, brk := range *project.Breaks {
+		if brk == nil {
+			continue
+		}
+		brk.Jno = project.Jno
+		brk.Base = project.Base
+		if err = s.Store.AddProjectBreak(ctx, tx, *brk); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
+	log := project
+	log.Message = utils.ParseNullString(fmt.Sprintf("[MODIFY] jno:%d|breaks:[before:%s, after:%s]", jno, formatBreaks(before), formatBreaks(project.Breaks)))
+	if err = s.Store.ProjectSettingLog(ctx, tx, log); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 휴게 시간 로그 형식 (HH:mm~HH:mm,...)
+func formatBreaks(breaks *entity.ProjectBreaks) string {
+	if breaks == nil || len(*breaks) == 0 {
+		return "N/A"
+	}
+	list := make([]string, 0, len(*breaks))
+	for _, brk := range *breaks {
+		if brk == nil {
+			continue
+		}
+		list = append(list, fmt.Sprintf("%s~%s", formatShiftTime(brk.StartTime), formatShiftTime(brk.EndTime)))
+	}
+	if len(list) == 0 {
+		return "N/A"
+	}
+	return strings.Join(list, ",")
+}
+
+// func: 공수 배율, 반일 기준 변경 로그 형식 (바뀐 항목만, 없으면 "")
+func formatWorkRateChanges(before entity.ProjectSetting, after entity.ProjectSetting) string {
+	var list []string
+	if !sameNullFloat(before.WeekendRate, after.WeekendRate) {
+		list = append(list, fmt.Sprintf("weekend_rate:[before:%s, after:%s]", formatNullFloat(before.WeekendRate), formatNullFloat(after.WeekendRate)))
+	}
+	if !sameNullFloat(before.HolidayRate, after.HolidayRate) {
+		list = append(list, fmt.Sprintf("holiday_rate:[before:%s, after:%s]", formatNullFloat(before.HolidayRate), formatNullFloat(after.HolidayRate)))
+	}
+	if before.HalfDayHour.Valid != after.HalfDayHour.Valid || before.HalfDayHour.Int64 != after.HalfDayHour.Int64 {
+		list = append(list, fmt.Sprintf("half_day_hour:[before:%s, after:%s]", formatNullInt(before.HalfDayHour), formatNullInt(after.HalfDayHour)))
+	}
+	return strings.Join(list, "|")
+}
+
+func formatNullInt(i null.Int) string {
+	if !i.Valid {
+		return "N/A"
+	}
+	return strconv.FormatInt(i.Int64, 10)
+}
+
 // func: 프로젝트 미설정 정보 업데이트(스케줄러)
 // @param
 // -
@@ -209,9 +551,10 @@ func (s *ServiceProjectSetting) CheckProjectSetting(ctx context.Context) (count
 // func: 프로젝트 설정 정보 가져오기
 // @param
 // - jno: 프로젝트PK
-func (s *ServiceProjectSetting) GetProjectSetting(ctx context.Context, jno int64, isRole bool) (*entity.ProjectSettings, error) {
+func (s *ServiceProjectSetting) GetProjectSetting(ctx context.Context, jno int64) (*entity.ProjectSettings, error) {
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	setting, err := s.Store.GetProjectSetting(ctx, s.SafeDB, isRole, uno, jno)
 	if err != nil {
@@ -223,8 +566,20 @@ func (s *ServiceProjectSetting) GetProjectSetting(ctx context.Context, jno int64
 		return &entity.ProjectSettings{}, utils.CustomErrorf(err)
 	}
 
+	shifts, err := s.Store.GetProjectShiftList(ctx, s.SafeDB, jno)
+	if err != nil {
+		return &entity.ProjectSettings{}, utils.CustomErrorf(err)
+	}
+
+	breaks, err := s.Store.GetProjectBreakList(ctx, s.SafeDB, jno)
+	if err != nil {
+		return &entity.ProjectSettings{}, utils.CustomErrorf(err)
+	}
+
 	if len(*setting) > 0 {
 		(*setting)[0].ManHours = manHours
+		(*setting)[0].Shifts = shifts
+		(*setting)[0].Breaks = breaks
 	}
 
 	return setting, nil
@@ -235,12 +590,14 @@ func (s *ServiceProjectSetting) GetProjectSetting(ctx context.Context, jno int64
 // @param
 // - mhno: 공수pk
 func (s *ServiceProjectSetting) DeleteManHour(ctx context.Context, mhno int64, manhour entity.ManHour) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
 
 	// 공수 삭제
 	if err = s.Store.DeleteManHour(ctx, tx, mhno); err != nil {
@@ -250,7 +607,7 @@ func (s *ServiceProjectSetting) DeleteManHour(ctx context.Context, mhno int64, m
 	// 공수 삭제 시 근로자 업데이트
 	jno := manhour.Jno.Int64
 	user := manhour.Base
-	if err = s.WorkHourStore.ModifyWorkHourByJno(ctx, tx, jno, user, nil); err != nil {
+	if err = modifyWorkHour(ctx, tx, tx, s.WorkHourStore, holidays, jno, user, nil); err != nil {
 		return utils.CustomErrorf(err)
 	}
 
@@ -285,3 +642,163 @@ func (s *ServiceProjectSetting) AddManHour(ctx context.Context, manhour entity.M
 
 	return
 }
+
+// func: 근무 시간대 수정 (기존 근무 시간대 삭제 후 새로 넣는 방식)
+// 빈 목록이면 근무 시간대를 모두 삭제하고 기본 규칙(15시 기준)으로 분류한다.
+// @param
+// - jno: 프로젝트pk
+// - shifts: 근무 시간대 배열
+func (s *ServiceProjectSetting) MergeProjectShifts(ctx context.Context, jno int64, shifts entity.ProjectShifts) (err error) {
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+
+	setting, err := s.Store.GetProjectScanRule(ctx, s.SafeDB, jno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	before := setting.Shifts
+	setting.Shifts = &shifts
+
+	// 출퇴근 분류 규칙 확인
+	if _, err = ProjectScanRule(setting); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.Store.DeleteProjectShift(ctx, tx, jno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	// 삭제 로그
+	for _, shift := range *before {
+		shift.Message = utils.ParseNullString(fmt.Sprintf("[DELETE] shno:[before:%d, after: N/A]|shift_name:[before:%s, after: N/A]|start_time:[before:%s, after: N/A]|end_time:[before:%s, after: N/A]|in_until_time:[before:%s, after: N/A]", shift.Shno.Int64, shift.ShiftName.String, formatShiftTime(shift.StartTime), formatShiftTime(shift.EndTime), formatShiftTime(shift.InUntilTime)))
+		shift.RegUno = utils.ParseNullInt(uno)
+		shift.RegUser = utils.ParseNullString(userName)
+		if err = s.Store.ProjectShiftLog(ctx, tx, *shift); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
+	for _, shift := range shifts {
+		shift.Jno = null.IntFrom(jno)
+		shift.RegUno = utils.ParseNullInt(uno)
+		shift.RegUser = utils.ParseNullString(userName)
+		if err = s.Store.AddProjectShift(ctx, tx, *shift); err != nil {
+			return utils.CustomErrorf(err)
+		}
+
+		shift.Message = utils.ParseNullString(fmt.Sprintf("[ADD] jno:[before:N/A, after:%d]|shift_name:[before:N/A, after:%s]|start_time:[before:N/A, after:%s]|end_time:[before:N/A, after:%s]|in_until_time:[before:N/A, after:%s]", jno, shift.ShiftName.String, formatShiftTime(shift.StartTime), formatShiftTime(shift.EndTime), formatShiftTime(shift.InUntilTime)))
+		if err = s.Store.ProjectShiftLog(ctx, tx, *shift); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
+	return
+}
+
+// func: 프로젝트 설정을 출퇴근 분류 규칙으로 변환
+// 근무 시간대가 없으면 기본 규칙(15시 이전 첫 인식 출근, 마지막 인식 퇴근)
+// @param
+// - setting: 프로젝트 설정 (IN_TIME, SCAN_POLICY, MIN_SCAN_GAP, 근무 시간대)
+func ProjectScanRule(setting *entity.ProjectSetting) (worktime.Rule, error) {
+	rule := worktime.DefaultRule
+	if setting == nil {
+		return rule, nil
+	}
+
+	if setting.ScanPolicy.Valid && setting.ScanPolicy.String != "" {
+		rule.Policy = worktime.ScanPolicy(setting.ScanPolicy.String)
+	}
+	if setting.MinScanGap.Valid {
+		rule.MinGap = time.Duration(setting.MinScanGap.Int64) * time.Minute
+	}
+	rule.DayStart = timeOfDay(setting.InTime)
+
+	if setting.Shifts != nil && len(*setting.Shifts) > 0 {
+		rule.Shifts = make([]worktime.Shift, 0, len(*setting.Shifts))
+		for _, shift := range *setting.Shifts {
+			if shift == nil || !shift.StartTime.Valid || !shift.EndTime.Valid {
+				return rule, fmt.Errorf("%w: shift start_time, end_time required", worktime.ErrInvalidRule)
+			}
+			rule.Shifts = append(rule.Shifts, worktime.Shift{
+				Name:    shift.ShiftName.String,
+				Start:   timeOfDay(shift.StartTime),
+				End:     timeOfDay(shift.EndTime),
+				InUntil: timeOfDay(shift.InUntilTime),
+			})
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+// func: 시간(IN_TIME 등 날짜가 의미 없는 DATE)의 자정부터 시간. 없으면 0
+func timeOfDay(t null.Time) time.Duration {
+	if !t.Valid {
+		return 0
+	}
+	return time.Duration(t.Time.Hour())*time.Hour + time.Duration(t.Time.Minute())*time.Minute
+}
+
+// func: 근무 시간 로그 형식 (HH:mm)
+func formatShiftTime(t null.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format("15:04")
+}
+
+// func: 프로젝트 설정을 공수 계산 설정으로 변환
+// @param
+// - setting: 프로젝트 설정 (IN_TIME, OUT_TIME, RESPITE_TIME, WEEKEND_RATE, HOLIDAY_RATE, HALF_DAY_HOUR, 휴게 시간)
+func ProjectWorkSetting(setting *entity.ProjectSetting) worktime.WorkSetting {
+	ws := worktime.WorkSetting{}
+	if setting == nil {
+		return ws
+	}
+	ws.InTime = timeOfDay(setting.InTime)
+	ws.OutTime = timeOfDay(setting.OutTime)
+	ws.Respite = time.Duration(setting.RespiteTime.Int64) * time.Minute
+	ws.WeekendRate = setting.WeekendRate.Float64
+	ws.HolidayRate = setting.HolidayRate.Float64
+	ws.HalfDayHour = setting.HalfDayHour.Int64
+
+	if setting.Breaks != nil {
+		for _, brk := range *setting.Breaks {
+			if brk == nil {
+				continue
+			}
+			// 종료 00:00은 자정(24:00)
+			end := timeOfDay(brk.EndTime)
+			if end == 0 {
+				end = 24 * time.Hour
+			}
+			ws.Breaks = append(ws.Breaks, worktime.Break{Start: timeOfDay(brk.StartTime), End: end})
+		}
+	}
+	return ws
+}
+
+// func: 공수 정보를 공수 단계로 변환
+func ProjectManHours(manHours *entity.ManHours) []worktime.ManHour {
+	if manHours == nil {
+		return nil
+	}
+	steps := make([]worktime.ManHour, 0, len(*manHours))
+	for _, manHour := range *manHours {
+		if manHour == nil || !manHour.WorkHour.Valid || !manHour.ManHour.Valid {
+			continue
+		}
+		steps = append(steps, worktime.ManHour{WorkHour: manHour.WorkHour.Int64, ManHour: manHour.ManHour.Float64})
+	}
+	return steps
+}
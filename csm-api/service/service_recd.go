@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/clock"
+	"csm-api/entity"
+	"csm-api/store"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrDeviceUnauthorized = errors.New("device unauthorized")
+
+// 한번에 받을 수 있는 인식 기록 수
+const MaxRecdIngestSize = 500
+
+// 인식 시간이 서버 시간보다 늦어도 허용하는 시간 (장치 시계 오차)
+const recdFutureTolerance = 10 * time.Minute
+
+// struct: 홍채인식기 인식 기록 수신 서비스
+type ServiceRecd struct {
+	SafeDB        store.Queryer
+	SafeTDB       store.Beginner
+	Store         store.RecdStore
+	DeviceStore   store.DeviceStore
+	WorkerService WorkerService
+	Clock         clock.Clocker
+}
+
+// func: 근태인식기 인증
+// 사용중인 장치이고 api key가 발급된 장치만 인증한다.
+// @param
+// - deviceSn: 근태인식기 시리얼번호
+// - apiKey: 근태인식기 api key
+func (s *ServiceRecd) GetAuthorizedDevice(ctx context.Context, deviceSn string, apiKey string) (entity.Device, error) {
+	if deviceSn == "" || apiKey == "" {
+		return entity.Device{}, ErrDeviceUnauthorized
+	}
+
+	device, err := s.DeviceStore.GetDeviceBySn(ctx, s.SafeDB, deviceSn)
+	if err != nil {
+		return entity.Device{}, utils.CustomErrorf(err)
+	}
+	if !device.Dno.Valid || !auth.VerifyDeviceApiKey(apiKey, device.ApiKeyHash.String) {
+		return entity.Device{}, ErrDeviceUnauthorized
+	}
+	return device, nil
+}
+
+// func: 인식 기록 저장 후 근로자 테이블에 바로 반영
+// transactionID 기준으로 한번만 저장하므로 장치가 같은 기록을 다시 보내도 된다.
+// 반영(MergeRecdWorker, MergeRecdDailyWorker) 실패시 기록은 저장된 상태로 두고 스케줄러에서 다시 반영한다.
+// @param
+// - device: 인증된 근태인식기
+// - recds: 인식 기록
+func (s *ServiceRecd) AddRecdLogs(ctx context.Context, device entity.Device, recds []entity.RecdLog) (entity.RecdIngestResult, error) {
+	result, err := s.addRecdLogs(ctx, device, recds)
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+
+	if result.Inserted > 0 {
+		s.mergeRecd(ctx)
+	}
+	return result, nil
+}
+
+func (s *ServiceRecd) addRecdLogs(ctx context.Context, device entity.Device, recds []entity.RecdLog) (result entity.RecdIngestResult, err error) {
+	result.Received = len(recds)
+	result.Rejected = []entity.RecdIngestReject{}
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return result, utils.CustomErrorf(err)
+	}
+	defer txutil.DeferTx(tx, &err)
+
+	now := s.Clock.Now()
+	for _, recd := range recds {
+		if reason := validateRecd(device, recd, now); reason != "" {
+			result.Rejected = append(result.Rejected, entity.RecdIngestReject{TransactionID: recd.TransactionID, Reason: reason})
+			continue
+		}
+
+		recd.DeviceSN = device.DeviceSn
+
+		var irisData []byte
+		if irisData, err = json.Marshal(recd); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+
+		var inserted bool
+		inserted, err = s.Store.AddRecdLog(ctx, tx, device, recd, string(irisData))
+		if err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+		if !inserted {
+			result.Duplicated++
+			continue
+		}
+
+		if err = s.Store.AddRecdSet(ctx, tx, device, recd); err != nil {
+			return result, utils.CustomErrorf(err)
+		}
+		result.Inserted++
+	}
+
+	return result, nil
+}
+
+// func: 인식 기록 확인 (저장하지 않는 사유 반환)
+// 인식 시간이 없는 기록은 수신 시간으로 대신하지 않고 거부한다. (지연 전송된 기록이 다른 날짜로 반영되지 않도록)
+// @param
+// - now: 수신 시간
+func validateRecd(device entity.Device, recd entity.RecdLog, now time.Time) string {
+	if !recd.TransactionID.Valid {
+		return "transactionID is required"
+	}
+	if recd.DeviceSN.Valid && recd.DeviceSN.String != device.DeviceSn.String {
+		return "deviceSN does not match the authenticated device"
+	}
+	if recd.UserID.String == "" {
+		return "userID is required"
+	}
+	if !recd.RecogTime.Valid || recd.RecogTime.Time.IsZero() {
+		return "recogTime is required"
+	}
+	if recd.RecogTime.Time.After(now.Add(recdFutureTolerance)) {
+		return "recogTime is in the future"
+	}
+	return ""
+}
+
+// func: 수신한 인식 기록 근로자 테이블에 반영
+func (s *ServiceRecd) mergeRecd(ctx context.Context) {
+	if err := s.WorkerService.MergeRecdWorker(ctx); err != nil {
+		_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Recd] MergeRecdWorker", err))
+		return
+	}
+	if err := s.WorkerService.MergeRecdDailyWorker(ctx); err != nil {
+		_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("[Recd] MergeRecdDailyWorker", err))
+	}
+}
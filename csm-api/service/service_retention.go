@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"csm-api/config"
+	"csm-api/entity"
+	"csm-api/store"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type ServiceRetention struct {
+	SafeDB          store.Queryer
+	SafeTDB         store.Beginner
+	WorkerStore     store.WorkerStore
+	UploadFileStore store.UploadFileStore
+	Config          *config.Config
+}
+
+// 업로드 파일 종류별 보관 기간
+type retentionFileClass struct {
+	fileType string
+	days     int
+}
+
+func retentionFileClasses(retention *config.RetentionConfig) []retentionFileClass {
+	return []retentionFileClass{
+		{fileType: "TBM", days: retention.TbmFileDays},
+		{fileType: "DEDUCTION", days: retention.DeductionFileDays},
+		{fileType: "ADD_WORKER", days: retention.WorkerFileDays},
+		{fileType: "ADD_DAILY_WORKER", days: retention.WorkerFileDays},
+		{fileType: entity.UploadFileCorrection, days: retention.CorrectionDays},
+	}
+}
+
+// func: 보관 기간 처리 dry-run 보고
+// 보관 기간이 지난 퇴직 근로자, 업로드 파일 수를 조회만 한다.
+// 실행(ModifyRetention)에는 보고의 token이 필요하다.
+func (s *ServiceRetention) GetRetentionReport(ctx context.Context) (entity.RetentionReport, error) {
+	report, err := s.runRetention(ctx, true)
+	if err != nil {
+		return report, utils.CustomErrorf(err)
+	}
+	return report, nil
+}
+
+// func: 보관 기간 처리
+// 퇴직 근로자 개인정보를 익명화하고 업로드 파일을 보관 경로로 옮기거나 삭제한다.
+// dry-run 보고 이후 대상이 바뀌었으면(token이 다르면) 실행하지 않고 ErrStalePreview
+// @param
+// - token: dry-run 보고(GetRetentionReport)의 token
+func (s *ServiceRetention) ModifyRetention(ctx context.Context, token string) (entity.RetentionReport, error) {
+	dryRun, err := s.runRetention(ctx, true)
+	if err != nil {
+		return dryRun, utils.CustomErrorf(err)
+	}
+	if token == "" || dryRun.Token != token {
+		return dryRun, utils.CustomErrorf(ErrStalePreview)
+	}
+
+	report, err := s.runRetention(ctx, false)
+	if err != nil {
+		return report, utils.CustomErrorf(err)
+	}
+	return report, nil
+}
+
+func (s *ServiceRetention) runRetention(ctx context.Context, dryRun bool) (entity.RetentionReport, error) {
+	retention, err := config.GetRetentionConfig()
+	if err != nil {
+		return entity.RetentionReport{}, utils.CustomErrorf(err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	report := entity.RetentionReport{DryRun: dryRun, RunDate: now}
+
+	// token: 대상별 기준일, 대상 수, 대상 파일
+	hash := sha256.New()
+
+	if retention.RetiredWorkerDays > 0 {
+		class, err := s.anonymizeRetiredWorkers(ctx, dryRun, retention.RetiredWorkerDays, today.AddDate(0, 0, -retention.RetiredWorkerDays))
+		if err != nil {
+			return report, utils.CustomErrorf(err)
+		}
+		report.Classes = append(report.Classes, class)
+		_, _ = fmt.Fprintf(hash, "|%s,%s,%d", class.Class, class.Cutoff.Format("2006-01-02"), class.TargetCount)
+	}
+
+	for _, fileClass := range retentionFileClasses(retention) {
+		if fileClass.days <= 0 {
+			continue
+		}
+		class, err := s.purgeUploadFiles(ctx, dryRun, fileClass, today.AddDate(0, 0, -fileClass.days), retention.ArchivePath, hash)
+		if err != nil {
+			return report, utils.CustomErrorf(err)
+		}
+		report.Classes = append(report.Classes, class)
+	}
+	report.Token = hex.EncodeToString(hash.Sum(nil))
+	return report, nil
+}
+
+// func: 퇴직 근로자 개인정보 익명화
+func (s *ServiceRetention) anonymizeRetiredWorkers(ctx context.Context, dryRun bool, days int, cutoff time.Time) (class entity.RetentionClass, err error) {
+	class = entity.RetentionClass{
+		Class:  entity.RetentionRetiredWorker,
+		Action: entity.RetentionAnonymize,
+		Days:   days,
+		Cutoff: cutoff,
+	}
+
+	if class.TargetCount, err = s.WorkerStore.GetRetiredWorkerAnonymizeCount(ctx, s.SafeDB, cutoff); err != nil {
+		return class, utils.CustomErrorf(err)
+	}
+	if dryRun || class.TargetCount == 0 {
+		return class, nil
+	}
+
+	workers, err := s.WorkerStore.GetRetiredWorkerAnonymizeList(ctx, s.SafeDB, cutoff)
+	if err != nil {
+		return class, utils.CustomErrorf(err)
+	}
+
+	// 근로자와 관련 기록(공제, TBM, 홍채인식기, 변경 이력)을 한 트랜잭션에서 익명화
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return class, utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	user := entity.Base{ModUser: utils.ParseNullString("SYSTEM_RETENTION"), ModUno: utils.ParseNullInt("0")}
+	count, err := s.WorkerStore.ModifyRetiredWorkerAnonymize(ctx, tx, workers, cutoff, user)
+	if err != nil {
+		return class, utils.CustomErrorf(err)
+	}
+	class.ProcessedCount = int(count)
+	return class, nil
+}
+
+// func: 보관 기간이 지난 업로드 파일 삭제 또는 이동
+// 업로드 경로(Config.UploadPath) 밖의 파일(FILE_NAME의 상대 경로 포함)은 처리하지 않는다.
+// @param
+// - hash: 보고 token (대상 파일을 기록)
+func (s *ServiceRetention) purgeUploadFiles(ctx context.Context, dryRun bool, fileClass retentionFileClass, cutoff time.Time, archivePath string, hash io.Writer) (entity.RetentionClass, error) {
+	class := entity.RetentionClass{
+		Class:  fileClass.fileType,
+		Action: entity.RetentionPurge,
+		Days:   fileClass.days,
+		Cutoff: cutoff,
+	}
+	if archivePath != "" {
+		class.Action = entity.RetentionArchive
+	}
+
+	files, err := s.UploadFileStore.GetExpiredUploadFileList(ctx, s.SafeDB, fileClass.fileType, cutoff)
+	if err != nil {
+		return class, utils.CustomErrorf(err)
+	}
+	class.TargetCount = len(files)
+	_, _ = fmt.Fprintf(hash, "|%s,%s,%d", class.Class, class.Cutoff.Format("2006-01-02"), class.TargetCount)
+
+	for _, file := range files {
+		_, _ = fmt.Fprintf(hash, "|%s,%s", file.FilePath.String, file.FileName.String)
+
+		path := filepath.Join(file.FilePath.String, file.FileName.String)
+		rel, ok := uploadRelPath(s.Config.UploadPath, path)
+		if !ok {
+			log.Printf("[Retention] skip file outside upload path: %s", path)
+			class.SkippedCount++
+			continue
+		}
+
+		info, err := os.Stat(path)
+		missing := errors.Is(err, fs.ErrNotExist)
+		if err != nil && !missing {
+			return class, utils.CustomErrorf(err)
+		}
+		if missing {
+			class.MissingCount++
+		} else {
+			class.TargetBytes += info.Size()
+		}
+		if dryRun {
+			continue
+		}
+
+		archived := null.String{}
+		if !missing && archivePath != "" {
+			dst := filepath.Join(archivePath, rel)
+			if err = moveFile(path, dst); err != nil {
+				return class, utils.CustomErrorf(err)
+			}
+			archived = utils.ParseNullString(dst)
+		} else if !missing {
+			if err = os.Remove(path); err != nil {
+				return class, utils.CustomErrorf(err)
+			}
+		}
+
+		if err = s.modifyUploadFilePurged(ctx, file, archived); err != nil {
+			return class, utils.CustomErrorf(err)
+		}
+		class.ProcessedCount++
+	}
+	return class, nil
+}
+
+// func: 업로드 경로 기준 상대 경로
+// 파일 이름이 비어있거나 경로가 업로드 경로 밖이면 false
+// @param
+// - uploadPath: 업로드 경로 (Config.UploadPath)
+// - path: 파일 경로 (FILE_PATH + FILE_NAME)
+func uploadRelPath(uploadPath string, path string) (string, bool) {
+	root, err := filepath.Abs(uploadPath)
+	if err != nil {
+		return "", false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// func: 업로드 파일 삭제/이동 기록
+func (s *ServiceRetention) modifyUploadFilePurged(ctx context.Context, file entity.UploadFile, archivePath null.String) (err error) {
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.UploadFileStore.ModifyUploadFilePurged(ctx, tx, file, archivePath); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 파일 이동 (다른 디스크면 복사 후 삭제)
+func moveFile(src string, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	_ = in.Close()
+	return os.Remove(src)
+}
@@ -18,9 +18,10 @@ type ServiceSchedule struct {
 // func: 휴무일 조회
 // @param
 // -
-func (s *ServiceSchedule) GetRestScheduleList(ctx context.Context, isRole bool, jno int64, year string, month string) (entity.RestSchedules, error) {
+func (s *ServiceSchedule) GetRestScheduleList(ctx context.Context, jno int64, year string, month string) (entity.RestSchedules, error) {
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	list, err := s.Store.GetRestScheduleList(ctx, s.SafeDB, isRole, jno, uno, year, month)
 	if err != nil {
@@ -42,9 +42,10 @@ type ServiceSite struct {
 // func: 현장 관리 리스트 조회
 // @param
 // - targetDate: 현재시간
-func (s *ServiceSite) GetSiteList(ctx context.Context, targetDate time.Time, isRole bool, isNonUse bool) (*entity.Sites, error) {
+func (s *ServiceSite) GetSiteList(ctx context.Context, targetDate time.Time, isNonUse bool) (*entity.Sites, error) {
 
 	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	var roleInt int
 	if isRole { // 권한이 있는 경우
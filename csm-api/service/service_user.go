@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/utils"
@@ -67,3 +68,43 @@ func (u *ServiceUser) GetAuthorizationList(ctx context.Context, api string) (*en
 	return list, nil
 
 }
+
+// 조회 범위 권한 확인
+// 관리자 역할만 전체 프로젝트 조회 권한(IsGlobal)이 있고,
+// 해당 프로젝트에 부여된 권한(IRIS_USER_ROLE_MAP, 현장소장/현장관리자, 안전관리자/관리감독자)은 그 프로젝트 권한(IsMember)으로만 본다.
+//
+// @param
+// - jno: 프로젝트PK (0인 경우 역할만 확인)
+// - uno: 유저PK
+// - role: jwt 역할
+func (u *ServiceUser) GetUserScope(ctx context.Context, jno int64, uno int64, role string) (entity.UserScope, error) {
+	if auth.JWTRole(role).IsAdmin() {
+		return entity.UserScope{IsGlobal: true, IsMember: true}, nil
+	}
+	if jno == 0 || auth.JWTRole(role) == auth.CoUser || auth.JWTRole(role) == auth.CoManager {
+		return entity.UserScope{}, nil
+	}
+
+	roleMaps, err := u.Store.GetProjectRoleMapList(ctx, u.SafeDB, jno, uno)
+	if err != nil {
+		return entity.UserScope{}, utils.CustomErrorf(err)
+	}
+	if len(roleMaps) > 0 {
+		return entity.UserScope{IsMember: true}, nil
+	}
+
+	siteRole, err := u.Store.GetSiteRole(ctx, u.SafeDB, jno, uno)
+	if err != nil {
+		return entity.UserScope{}, utils.CustomErrorf(err)
+	}
+	if siteRole != "" {
+		return entity.UserScope{IsMember: true}, nil
+	}
+
+	operationalRole, err := u.Store.GetOperationalRole(ctx, u.SafeDB, jno, uno)
+	if err != nil {
+		return entity.UserScope{}, utils.CustomErrorf(err)
+	}
+
+	return entity.UserScope{IsMember: operationalRole != ""}, nil
+}
@@ -2,29 +2,40 @@ package service
 
 import (
 	"context"
+	"csm-api/clock"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
 	"csm-api/utils"
+	"csm-api/worktime"
+	"fmt"
+	"github.com/guregu/null"
+	"strconv"
+	"sync"
+	"time"
 )
 
 type ServiceWorkHour struct {
-	SafeDB  store.Queryer
-	SafeTDB store.Beginner
-	Store   store.WorkHourStore
+	SafeDB             store.Queryer
+	SafeTDB            store.Beginner
+	Store              store.WorkHourStore
+	RestDateApiService RestDateApiService
+	Clock              clock.Clocker
+	holidays           holidayCache // 공휴일 API 조회 결과
 }
 
 // 특정 프로젝트 및 근로자의 공수 계산: jno는 필수, uuids는 없으면 jno의 모든 근로자 계산 있으면 해당 id의 근로자만 계산
 func (s *ServiceWorkHour) ModifyWorkHourByJno(ctx context.Context, jno int64, user entity.Base, uuids []string) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
 
-	err = s.Store.ModifyWorkHourByJno(ctx, tx, jno, user, uuids)
-	if err != nil {
+	if err = modifyWorkHour(ctx, tx, tx, s.Store, holidays, jno, user, uuids); err != nil {
 		return utils.CustomErrorf(err)
 	}
 	return
@@ -32,16 +43,205 @@ func (s *ServiceWorkHour) ModifyWorkHourByJno(ctx context.Context, jno int64, us
 
 // 출퇴근이 둘다 있는 모든 근로자의 공수 계산
 func (s *ServiceWorkHour) ModifyWorkHour(ctx context.Context, user entity.Base) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	holidays := s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
 
-	err = s.Store.ModifyWorkHour(ctx, tx, user)
-	if err != nil {
+	if err = modifyWorkHour(ctx, tx, tx, s.Store, holidays, 0, user, nil); err != nil {
 		return utils.CustomErrorf(err)
 	}
 	return
 }
+
+// func: 공수 계산 및 저장
+// 계산 대상과 프로젝트 설정을 조회해서 worktime.Calculate로 계산한 공수를 저장한다.
+// 같은 트랜잭션에서 변경한 공수 단계로 계산해야 하는 경우 db, tx에 같은 트랜잭션을 넘긴다.
+// @param
+// - holidays: 공휴일 (트랜잭션 시작 전에 holidayCache.load로 조회)
+// - jno: 프로젝트pk (0이면 모든 프로젝트)
+// - uuids: 근로자 키 (없으면 프로젝트의 모든 근로자)
+func modifyWorkHour(ctx context.Context, db store.Queryer, tx store.Execer, workHourStore store.WorkHourStore, holidays holidaySet, jno int64, user entity.Base, uuids []string) error {
+	targets, err := workHourStore.GetWorkHourTargetList(ctx, db, jno, uuids)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	calc := newWorkHourCalculator(db, workHourStore, holidays)
+	list := make(entity.WorkerDailys, 0, len(targets))
+	for _, target := range targets {
+		ws, err := calc.setting(ctx, target.Jno.Int64)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		result := calc.calculate(ws, target)
+		// 퇴근이 출근보다 빠른 기록은 공수를 비워두고 기록만 남김 (출퇴근 수정 후 다시 계산)
+		if result.Reversed {
+			_ = entity.WriteErrorLog(ctx, utils.CustomErrorf(fmt.Errorf("out before in: jno %d, user_key %s, record_date %s", target.Jno.Int64, target.UserKey.String, target.RecordDate.Time.Format("2006-01-02"))))
+			continue
+		}
+		target.WorkHour = null.FloatFrom(result.ManHour)
+		list = append(list, target)
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	if err = workHourStore.ModifyWorkHourList(ctx, tx, list, user); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 프로젝트 공수 계산 설정 (공수 단계 포함)
+type workHourSetting struct {
+	setting  worktime.WorkSetting
+	manHours []worktime.ManHour
+}
+
+// 공수 계산기
+// 프로젝트 설정을 한번만 조회하도록 캐시한다. 공휴일은 트랜잭션 시작 전에 holidayCache.load로 조회해서 넘긴다.
+type workHourCalculator struct {
+	db       store.Queryer
+	store    store.WorkHourStore
+	holidays holidaySet
+	settings map[int64]workHourSetting
+}
+
+func newWorkHourCalculator(db store.Queryer, workHourStore store.WorkHourStore, holidays holidaySet) *workHourCalculator {
+	return &workHourCalculator{
+		db:       db,
+		store:    workHourStore,
+		holidays: holidays,
+		settings: make(map[int64]workHourSetting),
+	}
+}
+
+// func: 프로젝트 공수 계산 설정 조회
+func (c *workHourCalculator) setting(ctx context.Context, jno int64) (workHourSetting, error) {
+	if ws, ok := c.settings[jno]; ok {
+		return ws, nil
+	}
+	setting, err := c.store.GetWorkHourSetting(ctx, c.db, jno)
+	if err != nil {
+		return workHourSetting{}, utils.CustomErrorf(err)
+	}
+	ws := workHourSetting{
+		setting:  ProjectWorkSetting(setting),
+		manHours: ProjectManHours(setting.ManHours),
+	}
+	c.settings[jno] = ws
+	return ws, nil
+}
+
+// func: 근로자 하루 공수 계산
+// @param
+// - ws: 공수 계산 설정
+// - target: RECORD_DATE, IN_RECOG_TIME, OUT_RECOG_TIME, IS_OVERTIME
+func (c *workHourCalculator) calculate(ws workHourSetting, target *entity.WorkerDaily) worktime.WorkResult {
+	holiday := ws.setting.HolidayRate > 0 && c.holidays.contains(target.RecordDate.Time)
+	return worktime.Calculate(ws.setting, ws.manHours, target.RecordDate.Time, target.InRecogTime.Time, target.OutRecogTime.Time, target.IsOvertime.String == "Y", holiday)
+}
+
+// 공휴일 (연도별 yyyyMMdd)
+type holidaySet map[int]map[string]struct{}
+
+// func: 공휴일 여부
+// 조회하지 않은 연도는 양력 고정 공휴일로 판단한다.
+func (h holidaySet) contains(date time.Time) bool {
+	dates, ok := h[date.Year()]
+	if !ok {
+		dates = fixedHolidays(date.Year())
+	}
+	_, holiday := dates[date.Format("20060102")]
+	return holiday
+}
+
+// 공휴일 API 조회 결과 캐시 유지 시간
+const holidayCacheTTL = 24 * time.Hour
+
+// 공휴일 API 조회 결과 캐시 (연도별)
+// 공휴일을 조회하는 서비스에 필드로 두고, zero value로 사용한다.
+type holidayCache struct {
+	mu    sync.Mutex
+	years map[int]cachedHolidays
+}
+
+type cachedHolidays struct {
+	dates     map[string]struct{}
+	fetchDate time.Time
+}
+
+// func: 공휴일 조회 (작년, 올해)
+// 공수 계산 트랜잭션 안에서 외부 API를 호출하지 않도록 트랜잭션 시작 전에 조회한다.
+// API 결과는 holidayCacheTTL 동안 캐시하고, API 조회에 실패하면 이전 조회 결과, 없으면 양력 고정 공휴일을 사용한다.
+// @param
+// - restDate: 공휴일 API (없으면 양력 고정 공휴일)
+// - now: 현재 시간 (서비스의 Clock)
+func (c *holidayCache) load(ctx context.Context, restDate RestDateApiService, now time.Time) holidaySet {
+	holidays := make(holidaySet, 2)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.years == nil {
+		c.years = make(map[int]cachedHolidays)
+	}
+
+	for _, year := range []int{now.Year() - 1, now.Year()} {
+		cached, ok := c.years[year]
+		if ok && now.Sub(cached.fetchDate) < holidayCacheTTL {
+			holidays[year] = cached.dates
+			continue
+		}
+
+		dates, err := getHolidays(restDate, year)
+		if err != nil {
+			_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf(fmt.Sprintf("holidays %d", year), err))
+			if ok {
+				holidays[year] = cached.dates
+			} else {
+				holidays[year] = fixedHolidays(year)
+			}
+			continue
+		}
+		c.years[year] = cachedHolidays{dates: dates, fetchDate: now}
+		holidays[year] = dates
+	}
+	return holidays
+}
+
+// func: 양력 고정 공휴일 (공휴일 API를 사용할 수 없을 때)
+// 신정, 삼일절, 어린이날, 현충일, 광복절, 개천절, 한글날, 성탄절 (음력 공휴일, 대체공휴일 제외)
+func fixedHolidays(year int) map[string]struct{} {
+	holidays := make(map[string]struct{})
+	for _, day := range []string{"0101", "0301", "0505", "0606", "0815", "1003", "1009", "1225"} {
+		holidays[strconv.Itoa(year)+day] = struct{}{}
+	}
+	return holidays
+}
+
+// func: 연도의 공휴일 조회
+// @return
+// - 공휴일 (yyyyMMdd)
+func getHolidays(restDate RestDateApiService, year int) (map[string]struct{}, error) {
+	if restDate == nil {
+		return nil, utils.CustomErrorf(fmt.Errorf("rest date service not configured"))
+	}
+	restDates, err := restDate.GetRestDelDates(strconv.Itoa(year), "")
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	holidays := make(map[string]struct{}, len(restDates))
+	for _, rest := range restDates {
+		holidays[strconv.FormatInt(rest.RestDate, 10)] = struct{}{}
+	}
+	return holidays, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"csm-api/entity"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// 조회 횟수를 세는 공휴일 API (연도별 설날 하루)
+type countingRestDate struct {
+	calls map[string]int
+}
+
+func (r *countingRestDate) GetRestDelDates(year string, month string) (entity.RestDates, error) {
+	r.calls[year]++
+	date, _ := strconv.ParseInt(year+"0129", 10, 64)
+	return entity.RestDates{{Reason: "설날", RestDate: date}}, nil
+}
+
+func TestHolidayCacheLoad(t *testing.T) {
+	start := time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantCalls map[string]int
+	}{
+		{name: "처음 조회", now: start, wantCalls: map[string]int{"2024": 1, "2025": 1}},
+		{name: "캐시 유지 시간 안", now: start.Add(holidayCacheTTL - time.Second), wantCalls: map[string]int{"2024": 1, "2025": 1}},
+		{name: "캐시 유지 시간 이후 다시 조회 (연도 변경)", now: start.Add(holidayCacheTTL), wantCalls: map[string]int{"2024": 1, "2025": 2, "2026": 1}},
+	}
+
+	// 같은 캐시로 시간 순서대로 조회
+	var cache holidayCache
+	restDate := &countingRestDate{calls: make(map[string]int)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays := cache.load(context.Background(), restDate, tt.now)
+			if !holidays.contains(time.Date(tt.now.Year(), 1, 29, 0, 0, 0, 0, time.Local)) {
+				t.Errorf("load() does not contain %d-01-29", tt.now.Year())
+			}
+			for year, want := range tt.wantCalls {
+				if got := restDate.calls[year]; got != want {
+					t.Errorf("GetRestDelDates(%s) calls = %d, want %d", year, got, want)
+				}
+			}
+		})
+	}
+}
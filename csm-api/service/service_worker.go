@@ -3,13 +3,21 @@ package service
 import (
 	"context"
 	"csm-api/auth"
+	"csm-api/clock"
 	"csm-api/config"
+	"csm-api/crypto"
 	"csm-api/entity"
 	"csm-api/store"
 	"csm-api/txutil"
 	"csm-api/utils"
+	"csm-api/worktime"
+	"errors"
 	"fmt"
 	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,10 +31,34 @@ import (
  */
 
 type ServiceWorker struct {
-	SafeDB  store.Queryer
-	SafeTDB store.Beginner
-	Store   store.WorkerStore
-	Config  *config.Config
+	SafeDB              store.Queryer
+	SafeTDB             store.Beginner
+	Store               store.WorkerStore
+	ProjectSettingStore store.ProjectSettingStore
+	UserStore           store.UserStore
+	Config              *config.Config
+	RegNoCipher         *crypto.Keyring // 주민번호 암호화 키 (전체 근로자 조회, 재암호화 배치)
+	PiiStore            store.PiiStore  // 개인정보 접근 기록 (없으면 기록하지 않음)
+	DailyCloseStore     store.DailyCloseStore
+	UploadFileStore     store.UploadFileStore
+	WorkHourStore       store.WorkHourStore
+	RestDateApiService  RestDateApiService
+	Clock               clock.Clocker
+	holidays            holidayCache // 공휴일 API 조회 결과
+}
+
+var (
+	ErrOverTimeState     = errors.New("invalid overtime state")    // 처리할 수 없는 철야 승인 요청 (이미 처리됨, 기록 변경, 마감)
+	ErrOverTimeForbidden = errors.New("overtime review forbidden") // 승인/반려 권한 없음
+)
+
+// 마감취소할 수 없는 기록이 있는 경우 (거절된 기록과 사유)
+type DeadlineCancelError struct {
+	Rejects entity.DeadlineCancelRejects
+}
+
+func (e *DeadlineCancelError) Error() string {
+	return fmt.Sprintf("deadline cancel rejected: %d rows", len(e.Rejects))
 }
 
 // func: 전체 근로자 조회
@@ -34,36 +66,66 @@ type ServiceWorker struct {
 // - page entity.PageSql: 정렬, 리스트 수
 // - search entity.WorkerSql: 검색 단어
 // - retry string: 통합검색 텍스트
-func (s *ServiceWorker) GetWorkerTotalList(ctx context.Context, page entity.Page, isRole bool, search entity.Worker, retry string) (*entity.Workers, error) {
+func (s *ServiceWorker) GetWorkerTotalList(ctx context.Context, page entity.Page, search entity.Worker, retry string) (*entity.Workers, entity.CursorPage, error) {
 	// regular type ->  sql type 변환
 	pageSql := entity.PageSql{}
 	pageSql, err := pageSql.OfPageSql(page)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	// 조회
 	list, err := s.Store.GetWorkerTotalList(ctx, s.SafeDB, pageSql, isRole, uno, search, retry)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
-	return list, nil
+	rows, cursor := *list, entity.CursorPage{}
+	if pageSql.UseCursor {
+		// keyset 페이징: 1건 더 조회한 행으로 다음 페이지 여부 확인
+		rows, cursor, err = entity.NextCursorPage(*list, pageSql.Limit, (*entity.Worker).CursorValues)
+		if err != nil {
+			return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+		}
+	}
+
+	// 개인정보 복호화, 마스킹 및 접근 기록
+	mask := newPiiMask(ctx)
+	for _, worker := range rows {
+		if err = decryptRegNo(s.RegNoCipher, worker); err != nil {
+			return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+		}
+		worker.UserId = mask.mask(auth.PiiPhone, worker.UserId) // 아이디는 핸드폰번호
+		worker.Phone = mask.mask(auth.PiiPhone, worker.Phone)
+		worker.RegNo = mask.mask(auth.PiiRegNo, worker.RegNo)
+	}
+	if err = addPiiListAccessLog(ctx, s.SafeTDB, s.PiiStore, entity.PiiTargetWorkerTotal, mask, len(rows)); err != nil {
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+	}
+
+	return &rows, cursor, nil
 }
 
 // func: 전체 근로자 개수 조회
 // @param
 // - searchTime string: 조회 날짜
 // - retry string: 통합검색 텍스트
-func (s *ServiceWorker) GetWorkerTotalCount(ctx context.Context, isRole bool, search entity.Worker, retry string) (int, error) {
+// - mode entity.CountMode: 개수 조회 방식 (exact, estimate, none)
+func (s *ServiceWorker) GetWorkerTotalCount(ctx context.Context, search entity.Worker, retry string, mode entity.CountMode) (entity.PageCount, error) {
+	if mode == entity.CountNone {
+		return entity.PageCount{}, nil
+	}
+
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
-	count, err := s.Store.GetWorkerTotalCount(ctx, s.SafeDB, isRole, uno, search, retry)
+	isRole := auth.GetIsRole(ctx)
+	count, err := s.Store.GetWorkerTotalCount(ctx, s.SafeDB, isRole, uno, search, retry, mode.Limit())
 	if err != nil {
-		return 0, utils.CustomErrorf(err)
+		return entity.PageCount{}, utils.CustomErrorf(err)
 	}
-	return count, nil
+	return mode.Result(count), nil
 }
 
 // func: 미출근 근로자 검색(현장근로자 추가시 사용)
@@ -107,15 +169,26 @@ func (s *ServiceWorker) GetWorkerDepartList(ctx context.Context, jno int64) ([]s
 }
 
 // func: 근로자 추가
+// 아이디, 이름, 주민번호가 같은 근로자가 있으면 추가하지 않는다.
 // @param
 // -
 func (s *ServiceWorker) AddWorker(ctx context.Context, worker entity.Worker) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
-	if err != nil {
+	if worker.RegNoEnc, err = encryptRegNo(s.RegNoCipher, worker.RegNo); err != nil {
 		return utils.CustomErrorf(err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+	defer txutil.DeferTxx(tx, &err)
+
+	userKey, err := findWorkerUserKey(ctx, tx, s.Store, s.RegNoCipher, worker)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	} else if userKey.Valid {
+		return utils.CustomErrorf(fmt.Errorf("중복데이터 존재"))
+	}
 
 	var count int64
 	count, err = s.Store.AddWorker(ctx, tx, worker)
@@ -131,6 +204,13 @@ func (s *ServiceWorker) AddWorker(ctx context.Context, worker entity.Worker) (er
 // @param
 // -
 func (s *ServiceWorker) ModifyWorker(ctx context.Context, worker entity.Worker) (err error) {
+	// 마스킹된 주민번호는 변경하지 않는다. (store에서 기존 값 유지)
+	if !crypto.IsMaskedRegNo(worker.RegNo.String) {
+		if worker.RegNoEnc, err = encryptRegNo(s.RegNoCipher, worker.RegNo); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
 	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
 	if err != nil {
 		return utils.CustomErrorf(err)
@@ -164,48 +244,69 @@ func (s *ServiceWorker) RemoveWorker(ctx context.Context, worker entity.Worker)
 // @param
 // - page entity.PageSql: 정렬, 리스트 수
 // - search entity.WorkerSql: 검색 단어
-func (s *ServiceWorker) GetWorkerSiteBaseList(ctx context.Context, page entity.Page, isRole bool, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error) {
+func (s *ServiceWorker) GetWorkerSiteBaseList(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, entity.CursorPage, error) {
 	// regular type ->  sql type 변환
 	pageSql := entity.PageSql{}
 	pageSql, err := pageSql.OfPageSql(page)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
 	// 조회
 	list, err := s.Store.GetWorkerSiteBaseList(ctx, s.SafeDB, pageSql, isRole, uno, search, retry)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
-	return list, nil
+	rows, cursor := *list, entity.CursorPage{}
+	if pageSql.UseCursor {
+		// keyset 페이징: 1건 더 조회한 행으로 다음 페이지 여부 확인
+		rows, cursor, err = entity.NextCursorPage(*list, pageSql.Limit, (*entity.WorkerDaily).CursorValues)
+		if err != nil {
+			return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+		}
+	}
+
+	// 개인정보 마스킹 및 접근 기록
+	if err = s.maskWorkerDailyPii(ctx, rows); err != nil {
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+	}
+
+	return &rows, cursor, nil
 }
 
 // func: 현장 근로자 개수 조회
 // @param
 // - searchTime string: 조회 날짜
-func (s *ServiceWorker) GetWorkerSiteBaseCount(ctx context.Context, isRole bool, search entity.WorkerDaily, retry string) (int, error) {
+// - mode entity.CountMode: 개수 조회 방식 (exact, estimate, none)
+func (s *ServiceWorker) GetWorkerSiteBaseCount(ctx context.Context, search entity.WorkerDaily, retry string, mode entity.CountMode) (entity.PageCount, error) {
+	if mode == entity.CountNone {
+		return entity.PageCount{}, nil
+	}
+
 	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	isRole := auth.GetIsRole(ctx)
 
-	count, err := s.Store.GetWorkerSiteBaseCount(ctx, s.SafeDB, isRole, uno, search, retry)
+	count, err := s.Store.GetWorkerSiteBaseCount(ctx, s.SafeDB, isRole, uno, search, retry, mode.Limit())
 	if err != nil {
-		return 0, utils.CustomErrorf(err)
+		return entity.PageCount{}, utils.CustomErrorf(err)
 	}
-	return count, nil
+	return mode.Result(count), nil
 }
 
 // func: 현장 근로자 조회 - 협력업체
 // @param
 // - page entity.PageSql: 정렬, 리스트 수
 // - search entity.WorkerSql: 검색 단어
-func (s *ServiceWorker) GetWorkerSiteBaseListByCompany(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error) {
+func (s *ServiceWorker) GetWorkerSiteBaseListByCompany(ctx context.Context, page entity.Page, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, entity.CursorPage, error) {
 	// regular type ->  sql type 변환
 	pageSql := entity.PageSql{}
 	pageSql, err := pageSql.OfPageSql(page)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
 	id, _ := auth.GetContext(ctx, auth.Uno{})
@@ -213,23 +314,58 @@ func (s *ServiceWorker) GetWorkerSiteBaseListByCompany(ctx context.Context, page
 	// 조회
 	list, err := s.Store.GetWorkerSiteBaseListByCompany(ctx, s.SafeDB, pageSql, id, search, retry)
 	if err != nil {
-		return nil, utils.CustomErrorf(err)
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
 	}
 
-	return list, nil
+	rows, cursor := *list, entity.CursorPage{}
+	if pageSql.UseCursor {
+		// keyset 페이징: 1건 더 조회한 행으로 다음 페이지 여부 확인
+		rows, cursor, err = entity.NextCursorPage(*list, pageSql.Limit, (*entity.WorkerDaily).CursorValues)
+		if err != nil {
+			return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+		}
+	}
+
+	// 개인정보 마스킹 및 접근 기록
+	if err = s.maskWorkerDailyPii(ctx, rows); err != nil {
+		return nil, entity.CursorPage{}, utils.CustomErrorf(err)
+	}
+
+	return &rows, cursor, nil
+}
+
+// func: 현장 근로자 개인정보 마스킹 및 접근 기록
+// @param
+// - rows: 응답할 현장 근로자
+func (s *ServiceWorker) maskWorkerDailyPii(ctx context.Context, rows entity.WorkerDailys) error {
+	mask := newPiiMask(ctx)
+	for _, worker := range rows {
+		worker.UserId = mask.mask(auth.PiiPhone, worker.UserId) // 아이디는 핸드폰번호
+		worker.Phone = mask.mask(auth.PiiPhone, worker.Phone)
+		worker.RegNo = mask.mask(auth.PiiRegNo, worker.RegNo)
+	}
+	if err := addPiiListAccessLog(ctx, s.SafeTDB, s.PiiStore, entity.PiiTargetWorkerSiteBase, mask, len(rows)); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
 }
 
 // func: 현장 근로자 개수 조회 - 협력업체
 // @param
 // - searchTime string: 조회 날짜
-func (s *ServiceWorker) GetWorkerSiteBaseByCompanyCount(ctx context.Context, search entity.WorkerDaily, retry string) (int, error) {
+// - mode entity.CountMode: 개수 조회 방식 (exact, estimate, none)
+func (s *ServiceWorker) GetWorkerSiteBaseByCompanyCount(ctx context.Context, search entity.WorkerDaily, retry string, mode entity.CountMode) (entity.PageCount, error) {
+	if mode == entity.CountNone {
+		return entity.PageCount{}, nil
+	}
+
 	id, _ := auth.GetContext(ctx, auth.Uno{})
 
-	count, err := s.Store.GetWorkerSiteBaseByCompanyCount(ctx, s.SafeDB, id, search, retry)
+	count, err := s.Store.GetWorkerSiteBaseByCompanyCount(ctx, s.SafeDB, id, search, retry, mode.Limit())
 	if err != nil {
-		return 0, utils.CustomErrorf(err)
+		return entity.PageCount{}, utils.CustomErrorf(err)
 	}
-	return count, nil
+	return mode.Result(count), nil
 }
 
 // func: 현장 근로자 추가/수정
@@ -282,21 +418,32 @@ func (s *ServiceWorker) MergeSiteBaseWorker(ctx context.Context, workers entity.
 }
 
 // func: 현장 근로자 일괄마감
+// 같은 트랜잭션에서 미마감 근로자가 남지 않은 프로젝트, 날짜는 일일 마감(IRIS_DAILY_CLOSE)도 마감으로 바꾼다.
+// 마감 요청/승인을 거치지 않도록 승인(또는 마감)된 날짜만 마감할 수 있다. (시스템관리자는 모두 가능)
 // @param
 // -
 func (s *ServiceWorker) ModifyWorkerDeadline(ctx context.Context, workers entity.WorkerDailys) (err error) {
-	// 변경전 데이터 조회
-	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, s.SafeDB, workers)
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	defer txutil.DeferTxx(tx, &err)
+
+	// 일일 마감 잠금 (마감 요청/승인 처리와 동시에 바뀌지 않도록 근로자 기록보다 먼저)
+	days, err := s.lockDailyCloseDays(ctx, tx, workers)
 	if err != nil {
 		return utils.CustomErrorf(err)
 	}
+	if err = checkDeadlineDays(ctx, days); err != nil {
+		return utils.CustomErrorf(err)
+	}
 
-	defer txutil.DeferTx(tx, &err)
+	// 변경전 데이터 조회
+	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, tx, workers)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
 
 	// 마감처리
 	if err = s.Store.ModifyWorkerDeadline(ctx, tx, workers); err != nil {
@@ -308,8 +455,15 @@ func (s *ServiceWorker) ModifyWorkerDeadline(ctx context.Context, workers entity
 		return utils.CustomErrorf(err)
 	}
 
+	// 일일 마감 상태 변경
+	if len(workers) > 0 {
+		if err = s.modifyDailyCloseDays(ctx, tx, days, entity.DailyCloseLocked, workers[0].Base, null.String{}); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
 	// 변경이력 저장
-	regDate := null.NewTime(time.Now(), true)
+	regDate := null.NewTime(s.Clock.Now(), true)
 	// 변경전
 	for i := range beforeList {
 		beforeList[i].HisStatus = utils.ParseNullString("BEFORE")
@@ -330,6 +484,95 @@ func (s *ServiceWorker) ModifyWorkerDeadline(ctx context.Context, workers entity
 	return
 }
 
+// func: 근로자 기록 마감 가능 여부 확인
+// 승인되지 않은 날짜(마감 전, 마감 요청)가 있으면 ErrDailyCloseState (시스템관리자 제외)
+// @param
+// - days: lockDailyCloseDays로 잠근 일일 마감
+func checkDeadlineDays(ctx context.Context, days entity.DailyCloses) error {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	if auth.JWTRole(role) == auth.SystemAdmin {
+		return nil
+	}
+	for _, day := range days {
+		if day.Status.String != entity.DailyCloseApproved && day.Status.String != entity.DailyCloseLocked {
+			return fmt.Errorf("%w: %d %s %s -> %s", ErrDailyCloseState, day.Jno.Int64, day.RecordDate.Time.Format("2006-01-02"), day.Status.String, entity.DailyCloseLock)
+		}
+	}
+	return nil
+}
+
+// func: 근로자 기록의 프로젝트, 날짜별 일일 마감 잠금
+// 일일 마감 기록이 없으면 마감 전으로 만든다.
+// @param
+// - workers: JNO, RECORD_DATE
+func (s *ServiceWorker) lockDailyCloseDays(ctx context.Context, tx *sqlx.Tx, workers entity.WorkerDailys) (entity.DailyCloses, error) {
+	seen := make(map[string]struct{})
+	var days entity.DailyCloses
+	for _, worker := range workers {
+		if !worker.Jno.Valid || !worker.RecordDate.Valid {
+			continue
+		}
+		recordDate := worker.RecordDate.Time
+		key := fmt.Sprintf("%d|%s", worker.Jno.Int64, recordDate.Format("20060102"))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		day, err := s.DailyCloseStore.GetDailyCloseForUpdate(ctx, tx, tx, worker.Jno.Int64, recordDate)
+		if err != nil {
+			return nil, utils.CustomErrorf(err)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// func: 일일 마감 상태 변경 (근로자 기록 마감, 마감취소와 같은 트랜잭션)
+// 마감(L)은 미마감 근로자가 남지 않은 날짜만 마감 시간을 기록하고, 마감 전(O)은 승인, 마감 시간을 지운다.
+// 이미 같은 상태면 바꾸지 않는다.
+// @param
+// - days: lockDailyCloseDays로 잠근 일일 마감
+// - status: 변경할 상태 (entity.DailyCloseLocked, entity.DailyCloseOpen)
+// - user: 처리자
+// - reason: 사유 (로그)
+func (s *ServiceWorker) modifyDailyCloseDays(ctx context.Context, tx *sqlx.Tx, days entity.DailyCloses, status string, user entity.Base, reason null.String) error {
+	now := null.TimeFrom(s.Clock.Now())
+	for _, day := range days {
+		if day.Status.String == status {
+			continue
+		}
+		if status == entity.DailyCloseLocked {
+			count, err := s.DailyCloseStore.GetDailyCloseOpenCount(ctx, tx, day.Jno.Int64, day.RecordDate.Time)
+			if err != nil {
+				return utils.CustomErrorf(err)
+			}
+			if count > 0 {
+				continue
+			}
+		}
+		after := *day
+		after.Base = user
+		after.Status = utils.ParseNullString(status)
+		after.Reason = reason
+		if status == entity.DailyCloseLocked {
+			after.LockDate = now
+		} else {
+			after.ApproveUno = null.Int{}
+			after.ApproveUser = null.String{}
+			after.ApproveDate = null.Time{}
+			after.LockDate = null.Time{}
+		}
+		if err := s.DailyCloseStore.ModifyDailyClose(ctx, tx, after); err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if err := s.DailyCloseStore.AddDailyCloseLog(ctx, tx, day.Status.String, after); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+	return nil
+}
+
 // func: 현장 근로자 프로젝트 변경
 // @param
 // -
@@ -386,56 +629,267 @@ func (s *ServiceWorker) ModifyWorkerProject(ctx context.Context, workers entity.
 }
 
 // func: 현장 근로자 일일 마감처리
+// 같은 트랜잭션에서 미마감 근로자가 남지 않은 프로젝트, 날짜는 일일 마감(IRIS_DAILY_CLOSE)도 마감으로 바꾼다.
+// 마감 요청(S)된 날짜는 마감하지 않는다.
 // @param
 // -
 func (s *ServiceWorker) ModifyWorkerDeadlineInit(ctx context.Context) (err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return utils.CustomMessageErrorf("begin tx", err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	defer txutil.DeferTxx(tx, &err)
+
+	// 마감할 프로젝트, 날짜
+	targets, err := s.Store.GetWorkerDeadlineInitDays(ctx, tx)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if len(targets) == 0 {
+		return
+	}
+	workers := make(entity.WorkerDailys, 0, len(targets))
+	for _, target := range targets {
+		workers = append(workers, &entity.WorkerDaily{Jno: target.Jno, RecordDate: target.RecordDate})
+	}
+	locked, err := s.lockDailyCloseDays(ctx, tx, workers)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	// 마감 요청된 날짜는 승인/반려를 기다린다. (조회 이후 마감 요청된 경우)
+	days := make(entity.DailyCloses, 0, len(locked))
+	for _, day := range locked {
+		if day.Status.String != entity.DailyCloseSubmitted {
+			days = append(days, day)
+		}
+	}
 
 	if err = s.Store.ModifyWorkerDeadlineInit(ctx, tx); err != nil {
 		return utils.CustomErrorf(err)
 	}
+
+	user := entity.Base{ModUser: utils.ParseNullString("Scheduled"), ModUno: utils.ParseNullInt("0")}
+	if err = s.modifyDailyCloseDays(ctx, tx, days, entity.DailyCloseLocked, user, null.String{}); err != nil {
+		return utils.CustomErrorf(err)
+	}
 	return
 }
 
-// func: 현장 근로자 철야 처리 (scheduler: 현재 수동으로 마감하기로 하여 사용하지 않음)
+// func: 현장 근로자 철야 승인 요청 생성 (scheduler)
+// 출근만 있는 날과 다음날 기록 쌍 중 다음날 첫 인식이 프로젝트 출근시간 이전인 경우 승인 요청으로 등록한다.
+// 기록은 현장 관리자가 승인(ApproveWorkerOverTime)해야 합쳐진다.
 // @param
 // -
 func (s *ServiceWorker) ModifyWorkerOverTime(ctx context.Context) (count int, err error) {
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	// 철야 후보 조회
+	workerOverTimes, err := s.Store.GetWorkerOverTime(ctx, s.SafeDB)
 	if err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
+	if len(*workerOverTimes) == 0 {
+		return 0, nil
+	}
 
-	// 철야 근로자 존재 여부 확인
-	workerOverTimes := &entity.WorkerOverTimes{}
-	workerOverTimes, err = s.Store.GetWorkerOverTime(ctx, s.SafeDB)
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
 	if err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
-	count = len(*workerOverTimes)
 
 	defer txutil.DeferTx(tx, &err)
 
+	rules := make(map[int64]worktime.Rule)
 	for _, workerOverTime := range *workerOverTimes {
+		rule, ok := rules[workerOverTime.Jno.Int64]
+		if !ok {
+			if rule, err = s.getProjectScanRule(ctx, workerOverTime.Jno.Int64); err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+			rules[workerOverTime.Jno.Int64] = rule
+		}
 
-		// 철야 근로자 철야 표시 및 퇴근시간 합치기.
-		if err = s.Store.ModifyWorkerOverTime(ctx, tx, *workerOverTime); err != nil {
-			return 0, utils.CustomErrorf(err)
+		// 다음날 출근시간 이후 인식은 철야가 아님
+		if !rule.IsOvernight(workerOverTime.InRecogTime.Time, workerOverTime.OutRecogTime.Time) {
+			continue
 		}
 
-		// 다음날 퇴근 표시 삭제
-		if err = s.Store.DeleteWorkerOverTime(ctx, tx, (*workerOverTime).AfterCno); err != nil {
+		if err = s.Store.AddWorkerOverTime(ctx, tx, *workerOverTime); err != nil {
 			return 0, utils.CustomErrorf(err)
 		}
+		count++
 	}
 	return
 }
 
+// func: 프로젝트 인식 규칙 조회
+func (s *ServiceWorker) getProjectScanRule(ctx context.Context, jno int64) (worktime.Rule, error) {
+	setting, err := s.ProjectSettingStore.GetProjectScanRule(ctx, s.SafeDB, jno)
+	if err != nil {
+		return worktime.Rule{}, utils.CustomErrorf(err)
+	}
+	return ProjectScanRule(setting)
+}
+
+// func: 철야 승인 요청 목록 조회
+// @param
+// - sno: 현장pk
+// - status: 처리 상태 (P: 승인 대기, A: 승인, R: 반려, 없으면 전체)
+func (s *ServiceWorker) GetWorkerOverTimeList(ctx context.Context, sno int64, status string) (*entity.WorkerOverTimes, error) {
+	list, err := s.Store.GetWorkerOverTimeList(ctx, s.SafeDB, sno, status)
+	if err != nil {
+		return &entity.WorkerOverTimes{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 철야 승인
+// 출근한 날 기록에 다음날 첫 인식을 퇴근으로 합치고, 다음날 기록은 남은 인식이 없으면 삭제한다. 변경 전/후는 변경 이력에 남긴다.
+// @param
+// - request: Ono, Reason
+func (s *ServiceWorker) ApproveWorkerOverTime(ctx context.Context, request entity.WorkerOverTime) (err error) {
+	return s.processWorkerOverTime(ctx, request, entity.OverTimeApproved)
+}
+
+// func: 철야 반려
+// @param
+// - request: Ono, Reason
+func (s *ServiceWorker) RejectWorkerOverTime(ctx context.Context, request entity.WorkerOverTime) (err error) {
+	return s.processWorkerOverTime(ctx, request, entity.OverTimeRejected)
+}
+
+// func: 철야 승인 요청 처리
+// 승인/반려는 현장 관리자만 할 수 있다. 승인할 때는 현재 기록을 프로젝트 인식 규칙으로 다시 확인한다.
+func (s *ServiceWorker) processWorkerOverTime(ctx context.Context, request entity.WorkerOverTime, status string) (err error) {
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+	defer txutil.DeferTxx(tx, &err)
+
+	workerOverTime, err := s.Store.GetWorkerOverTimeForUpdate(ctx, tx, request.Ono.Int64)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if !workerOverTime.Ono.Valid {
+		return utils.CustomErrorf(fmt.Errorf("overtime request not found: %d", request.Ono.Int64))
+	}
+	if workerOverTime.Status.String != entity.OverTimePending {
+		return utils.CustomErrorf(fmt.Errorf("%w: already processed %d", ErrOverTimeState, request.Ono.Int64))
+	}
+
+	// 처리 권한 확인
+	manager, err := s.isSiteManager(ctx, workerOverTime.Jno.Int64)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if !manager {
+		return utils.CustomErrorf(fmt.Errorf("%w: jno %d", ErrOverTimeForbidden, workerOverTime.Jno.Int64))
+	}
+
+	workerOverTime.Status = utils.ParseNullString(status)
+	workerOverTime.Reason = request.Reason
+	workerOverTime.ModUser = utils.ParseNullString(userName)
+	workerOverTime.ModUno = utils.ParseNullInt(uno)
+	if err = s.Store.ModifyWorkerOverTimeStatus(ctx, tx, workerOverTime); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	if status != entity.OverTimeApproved {
+		return
+	}
+
+	// 변경전 데이터 조회 (출근한 날, 다음날)
+	workers := entity.WorkerDailys{
+		{Sno: workerOverTime.Sno, Jno: workerOverTime.Jno, UserKey: workerOverTime.UserKey, RecordDate: workerOverTime.RecordDate},
+		{Sno: workerOverTime.Sno, Jno: workerOverTime.Jno, UserKey: workerOverTime.UserKey, RecordDate: null.TimeFrom(workerOverTime.RecordDate.Time.AddDate(0, 0, 1))},
+	}
+	for i := range workers {
+		workers[i].ReasonType = utils.ParseNullString("10")
+		workers[i].Reason = request.Reason
+	}
+	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, tx, workers)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	before, beforeNext := beforeList[0], beforeList[1]
+	if !before.RecordDate.Valid || !beforeNext.RecordDate.Valid || before.IsDeadline.String == "Y" || beforeNext.IsDeadline.String == "Y" {
+		return utils.CustomErrorf(fmt.Errorf("%w: worker not found or deadline %d", ErrOverTimeState, request.Ono.Int64))
+	}
+
+	// 요청 이후 기록이 바뀌었으면 승인하지 않음
+	rule, err := s.getProjectScanRule(ctx, workerOverTime.Jno.Int64)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	prev, next, ok := rule.SplitOvernight(
+		worktime.Attendance{In: before.InRecogTime.Time, Out: before.OutRecogTime.Time},
+		worktime.Attendance{In: beforeNext.InRecogTime.Time, Out: beforeNext.OutRecogTime.Time},
+	)
+	if !ok || !prev.In.Equal(workerOverTime.InRecogTime.Time) || !prev.Out.Equal(workerOverTime.OutRecogTime.Time) {
+		return utils.CustomErrorf(fmt.Errorf("%w: worker changed since request %d", ErrOverTimeState, request.Ono.Int64))
+	}
+
+	// 철야 표시 및 퇴근시간 합치기
+	if err = s.Store.ModifyWorkerOverTime(ctx, tx, workerOverTime); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	// 다음날 기록: 남은 인식이 없으면 삭제, 있으면 철야 퇴근으로 옮긴 시간만 뺌
+	afterNext := *beforeNext
+	afterNext.InRecogTime = null.NewTime(next.In, !next.In.IsZero())
+	afterNext.OutRecogTime = null.NewTime(next.Out, !next.Out.IsZero())
+	if !afterNext.InRecogTime.Valid && !afterNext.OutRecogTime.Valid {
+		if err = s.Store.DeleteWorkerOverTime(ctx, tx, workerOverTime.AfterCno); err != nil {
+			return utils.CustomErrorf(err)
+		}
+		afterNext.Sno = null.NewInt(0, false)
+		afterNext.Jno = null.NewInt(0, false)
+		afterNext.RecordDate = null.NewTime(time.Time{}, false)
+		afterNext.IsDeadline = null.NewString("", false)
+		afterNext.WorkState = null.NewString("", false)
+		afterNext.IsOvertime = null.NewString("", false)
+		afterNext.WorkHour = null.NewFloat(0, false)
+	} else {
+		afterNext.Cno = workerOverTime.AfterCno
+		afterNext.ModUser = workerOverTime.ModUser
+		afterNext.ModUno = workerOverTime.ModUno
+		if err = s.Store.ModifyWorkerOverTimeAfter(ctx, tx, afterNext); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
+	// 변경이력 저장
+	regDate := null.NewTime(s.Clock.Now(), true)
+	// 변경전
+	for i := range beforeList {
+		beforeList[i].HisStatus = utils.ParseNullString("BEFORE")
+		beforeList[i].RegDate = regDate
+		beforeList[i].ModUser = workerOverTime.ModUser
+		beforeList[i].ModUno = workerOverTime.ModUno
+	}
+	if err = s.Store.AddHistoryDailyWorkers(ctx, tx, beforeList); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	// 변경후: 출근한 날은 합친 기록, 다음날은 남은 기록
+	after := *before
+	after.HisStatus = utils.ParseNullString("AFTER")
+	after.OutRecogTime = workerOverTime.OutRecogTime
+	after.IsOvertime = utils.ParseNullString("Y")
+	after.WorkState = utils.ParseNullString("02")
+	afterNext.HisStatus = utils.ParseNullString("AFTER")
+	afterNext.RegDate = regDate
+	afterNext.ModUser = workerOverTime.ModUser
+	afterNext.ModUno = workerOverTime.ModUno
+	if err = s.Store.AddHistoryDailyWorkers(ctx, tx, entity.WorkerDailys{&after, &afterNext}); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	return
+}
+
 // 현장 근로자 삭제
 func (s *ServiceWorker) RemoveSiteBaseWorkers(ctx context.Context, workers entity.WorkerDailys) (err error) {
 	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
@@ -486,19 +940,33 @@ func (s *ServiceWorker) RemoveSiteBaseWorkers(ctx context.Context, workers entit
 }
 
 // 마감 취소
+// 프로젝트 마감취소 가능 기한(CANCEL_DAY)이 지난 기록은 현장소장, 시스템관리자만 사유를 입력해서 취소할 수 있다.
+// 취소할 수 없는 기록이 하나라도 있으면 아무것도 바꾸지 않고 DeadlineCancelError
+// 취소한 프로젝트, 날짜의 일일 마감(IRIS_DAILY_CLOSE)은 같은 트랜잭션에서 마감 전으로 되돌린다.
 func (s *ServiceWorker) ModifyDeadlineCancel(ctx context.Context, workers entity.WorkerDailys) (err error) {
-	// 변경전 데이터 조회
-	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, s.SafeDB, workers)
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	// 일일 마감 잠금
+	days, err := s.lockDailyCloseDays(ctx, tx, workers)
 	if err != nil {
 		return utils.CustomErrorf(err)
 	}
 
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	// 변경전 데이터 조회
+	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, tx, workers)
 	if err != nil {
 		return utils.CustomErrorf(err)
 	}
 
-	defer txutil.DeferTx(tx, &err)
+	// 마감취소 가능 기한 확인
+	if err = s.checkDeadlineCancel(ctx, tx, workers, beforeList); err != nil {
+		return utils.CustomErrorf(err)
+	}
 
 	// 마감 취소
 	if err = s.Store.ModifyDeadlineCancel(ctx, tx, workers); err != nil {
@@ -510,8 +978,15 @@ func (s *ServiceWorker) ModifyDeadlineCancel(ctx context.Context, workers entity
 		return utils.CustomErrorf(err)
 	}
 
+	// 일일 마감 상태 변경 (마감 전)
+	if len(workers) > 0 {
+		if err = s.modifyDailyCloseDays(ctx, tx, days, entity.DailyCloseOpen, workers[0].Base, utils.ParseNullString("마감취소")); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
 	// 변경이력 저장
-	regDate := null.NewTime(time.Now(), true)
+	regDate := null.NewTime(s.Clock.Now(), true)
 	// 변경전
 	for i := range beforeList {
 		beforeList[i].HisStatus = utils.ParseNullString("BEFORE")
@@ -532,6 +1007,122 @@ func (s *ServiceWorker) ModifyDeadlineCancel(ctx context.Context, workers entity
 	return
 }
 
+// func: 마감취소 가능 여부 확인
+// 마감취소 가능 기한은 마감한 시간(IRIS_DAILY_CLOSE.LOCK_DATE)부터 CANCEL_DAY일까지 (설정이 없거나 0이면 기한 없음)
+// 일일 마감 기록이 없는 기록(일일 마감 이전에 마감한 기록)은 근무일(RECORD_DATE)부터 CANCEL_DAY일까지
+// 기한이 지난 기록은 시스템관리자 또는 해당 프로젝트 현장소장이 사유를 입력한 경우만 취소하고 사유 구분을 기한초과로 기록한다.
+// @param
+// - db: 마감취소와 같은 트랜잭션
+// - workers: 마감취소 요청 (Reason: 기한초과 사유)
+// - beforeList: workers와 같은 순서의 현재 기록
+func (s *ServiceWorker) checkDeadlineCancel(ctx context.Context, db store.Queryer, workers entity.WorkerDailys, beforeList entity.WorkerDailys) error {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	uno, _ := strconv.ParseInt(unoString, 10, 64)
+
+	now := s.Clock.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	cancelDays := make(map[int64]null.Int)
+	lockDates := make(map[string]null.Time)
+	directors := make(map[int64]bool)
+	var rejects entity.DeadlineCancelRejects
+
+	for i, worker := range workers {
+		before := beforeList[i]
+		jno := worker.Jno
+		if before.Jno.Valid {
+			jno = before.Jno
+		}
+		reject := &entity.DeadlineCancelReject{
+			Sno:        worker.Sno,
+			Jno:        jno,
+			UserKey:    worker.UserKey,
+			RecordDate: worker.RecordDate,
+		}
+
+		if !before.RecordDate.Valid {
+			reject.Code = entity.DeadlineCancelNotFound
+			reject.Message = "근로자 기록이 없습니다."
+			rejects = append(rejects, reject)
+			continue
+		}
+		if before.IsDeadline.String != "Y" {
+			reject.Code = entity.DeadlineCancelNotDeadline
+			reject.Message = "마감되지 않은 기록입니다."
+			rejects = append(rejects, reject)
+			continue
+		}
+
+		cancelDay, ok := cancelDays[jno.Int64]
+		if !ok {
+			var err error
+			if cancelDay, err = s.ProjectSettingStore.GetProjectCancelDay(ctx, db, jno.Int64); err != nil {
+				return utils.CustomErrorf(err)
+			}
+			cancelDays[jno.Int64] = cancelDay
+		}
+		if !cancelDay.Valid || cancelDay.Int64 <= 0 {
+			continue
+		}
+
+		recordDate := before.RecordDate.Time.In(now.Location())
+		key := fmt.Sprintf("%d|%s", jno.Int64, recordDate.Format("20060102"))
+		lockDate, ok := lockDates[key]
+		if !ok {
+			var err error
+			if lockDate, err = s.DailyCloseStore.GetDailyCloseLockDate(ctx, db, jno.Int64, recordDate); err != nil {
+				return utils.CustomErrorf(err)
+			}
+			lockDates[key] = lockDate
+		}
+		if lockDate.Valid {
+			if !now.After(lockDate.Time.AddDate(0, 0, int(cancelDay.Int64))) {
+				continue
+			}
+		} else {
+			limit := time.Date(recordDate.Year(), recordDate.Month(), recordDate.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, int(cancelDay.Int64))
+			if !today.After(limit) {
+				continue
+			}
+		}
+
+		// 기한 초과: 시스템관리자, 현장소장만 가능
+		director := auth.JWTRole(role) == auth.SystemAdmin
+		if !director {
+			if director, ok = directors[jno.Int64]; !ok {
+				siteRole, err := s.UserStore.GetSiteRole(ctx, db, jno.Int64, uno)
+				if err != nil {
+					return utils.CustomErrorf(err)
+				}
+				director = auth.JWTRole(siteRole) == auth.SiteDirector
+				directors[jno.Int64] = director
+			}
+		}
+		reject.CancelDay = cancelDay
+		if !director {
+			reject.Code = entity.DeadlineCancelExpired
+			reject.Message = fmt.Sprintf("마감취소 가능 기한(%d일)이 지났습니다.", cancelDay.Int64)
+			rejects = append(rejects, reject)
+			continue
+		}
+		if strings.TrimSpace(worker.Reason.String) == "" {
+			reject.Code = entity.DeadlineCancelReasonRequired
+			reject.Message = "마감취소 가능 기한이 지난 기록은 사유를 입력해야 합니다."
+			rejects = append(rejects, reject)
+			continue
+		}
+		worker.ReasonType = utils.ParseNullString(entity.ReasonTypeDeadlineCancelOverride)
+		before.ReasonType = worker.ReasonType
+		before.Reason = worker.Reason
+	}
+
+	if len(rejects) > 0 {
+		return &DeadlineCancelError{Rejects: rejects}
+	}
+	return nil
+}
+
 // 프로젝트, 기간내 모든 현장근로자 근태정보 조회
 func (s *ServiceWorker) GetDailyWorkersByJnoAndDate(ctx context.Context, param entity.RecordDailyWorkerReq) ([]entity.RecordDailyWorkerRes, error) {
 	list, err := s.Store.GetDailyWorkersByJnoAndDate(ctx, s.SafeDB, param)
@@ -561,8 +1152,21 @@ func (s *ServiceWorker) ModifyWorkHours(ctx context.Context, workers entity.Work
 		return utils.CustomErrorf(err)
 	}
 
-	// 공수 변경 로그 저장
-	if err = s.Store.MergeSiteBaseWorkerLog(ctx, tx, workers); err != nil {
+	// 공수 변경 로그, 변경이력 저장
+	if err = s.addDailyWorkerHistory(ctx, tx, beforeList, workers); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	return
+}
+
+// func: 현장 근로자 변경 로그, 변경이력(변경전/변경후) 저장
+// @param
+// - beforeList: 변경전 기록 (GetDailyWorkerBeforeList)
+// - workers: 변경후 기록
+func (s *ServiceWorker) addDailyWorkerHistory(ctx context.Context, tx store.Execer, beforeList entity.WorkerDailys, workers entity.WorkerDailys) error {
+	// 변경 로그 저장
+	if err := s.Store.MergeSiteBaseWorkerLog(ctx, tx, workers); err != nil {
 		return utils.CustomErrorf(err)
 	}
 
@@ -573,7 +1177,7 @@ func (s *ServiceWorker) ModifyWorkHours(ctx context.Context, workers entity.Work
 		beforeList[i].HisStatus = utils.ParseNullString("BEFORE")
 		beforeList[i].RegDate = regDate
 	}
-	if err = s.Store.AddHistoryDailyWorkers(ctx, tx, beforeList); err != nil {
+	if err := s.Store.AddHistoryDailyWorkers(ctx, tx, beforeList); err != nil {
 		return utils.CustomErrorf(err)
 	}
 	// 변경후
@@ -581,15 +1185,48 @@ func (s *ServiceWorker) ModifyWorkHours(ctx context.Context, workers entity.Work
 		workers[i].HisStatus = utils.ParseNullString("AFTER")
 		workers[i].RegDate = regDate
 	}
-	if err = s.Store.AddHistoryDailyWorkers(ctx, tx, workers); err != nil {
+	if err := s.Store.AddHistoryDailyWorkers(ctx, tx, workers); err != nil {
 		return utils.CustomErrorf(err)
 	}
+	return nil
+}
 
-	return
+// func: 홍채인식기 데이터 근로자 키 조회 및 생성
+// 아이디, 이름이 같은 근로자 중 주민번호가 같은(둘 다 없는 경우 포함) 최근 등록 근로자. 없으면 새 근로자 키
+// @param
+// - db: 조회 DB
+// - worker: USER_ID, USER_NM, REG_NO
+func (s *ServiceWorker) getRecdWorkerUserKey(ctx context.Context, db store.Queryer, worker entity.Worker) (null.String, error) {
+	list, err := s.Store.GetRecdWorkerUserKeyList(ctx, db, worker)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+
+	for _, candidate := range list {
+		regNo, err := decryptRegNoEnc(s.RegNoCipher, candidate.RegNoEnc)
+		if err != nil {
+			return null.String{}, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", candidate.UserKey.String), err)
+		}
+		if regNo.String == worker.RegNo.String {
+			return candidate.UserKey, nil
+		}
+	}
+
+	userKey, err := s.Store.GetNewWorkerUserKey(ctx, db)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+	return utils.ParseNullString(userKey), nil
 }
 
-// 홍채인식기데이터 전체근로자 테이블(IRIS_WORKER_SET)에 반영::스케줄 용도
+// 홍채인식기 데이터 반영은 스케줄러와 인식 기록 수신(RecdService)에서 동시에 호출될 수 있으므로 한번에 하나씩 실행한다.
+var recdMergeMu sync.Mutex
+
+// 홍채인식기데이터 전체근로자 테이블(IRIS_WORKER_SET)에 반영::스케줄, 인식 기록 수신 용도
 func (s *ServiceWorker) MergeRecdWorker(ctx context.Context) (err error) {
+	recdMergeMu.Lock()
+	defer recdMergeMu.Unlock()
+
 	// 미반영 데이터 조회
 	recdList, err := s.Store.GetRecdWorkerList(ctx, s.SafeDB)
 	if err != nil {
@@ -600,13 +1237,14 @@ func (s *ServiceWorker) MergeRecdWorker(ctx context.Context) (err error) {
 		return
 	}
 
-	// 근로자 키 조회 및 생성
+	// 근로자 키 조회 및 생성, 주민번호 암호화
 	for i := range recdList {
-		userKey, err := s.Store.GetRecdWorkerUserKey(ctx, s.SafeDB, recdList[i])
-		if err != nil {
+		if recdList[i].UserKey, err = s.getRecdWorkerUserKey(ctx, s.SafeDB, recdList[i]); err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if recdList[i].RegNoEnc, err = encryptRegNo(s.RegNoCipher, recdList[i].RegNo); err != nil {
 			return utils.CustomErrorf(err)
 		}
-		recdList[i].UserKey = utils.ParseNullString(userKey)
 	}
 
 	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
@@ -624,71 +1262,225 @@ func (s *ServiceWorker) MergeRecdWorker(ctx context.Context) (err error) {
 	return
 }
 
-// 홍채인식기데이터 현장근로자 테이블(IRIS_WORKER_DAILY_SET)에 반영::스케줄 용도
-func (s *ServiceWorker) MergeRecdDailyWorker(ctx context.Context) (err error) {
-	// 미방영 데이터 조회
-	recdList, err := s.Store.GetRecdDailyWorkerList(ctx, s.SafeDB)
+const (
+	// 현장근로자 반영 작업 이름 (IRIS_RECD_WATERMARK.JOB_NAME)
+	recdDailyWorkerJob = "RECD_DAILY_WORKER"
+	// 한번에 반영하는 인식 기록 건수 (IN 절 1000건 제한 이하)
+	recdDailyWorkerBatchSize = 500
+	// 한번 실행에서 반영하는 최대 배치 수. 남은 기록은 다음 실행에서 반영한다.
+	recdDailyWorkerMaxBatch = 20
+	// 반영 위치보다 먼저 채번됐지만 늦게 커밋된 기록을 반영할 저장 후 경과 시간(분)
+	recdDailyWorkerLagMinutes = 5
+)
+
+// func: 홍채인식기 데이터 현장근로자 반영
+// 미반영 기록을 인식 시간 순서로 배치 단위로 반영한다.
+// 배치마다 하나의 트랜잭션에서 반영 위치를 잠그고 반영, 완료 처리까지 하므로 중간에 실패하거나
+// 여러 서버에서 동시에 실행해도 같은 기록이 두번 반영되지 않는다.
+func (s *ServiceWorker) MergeRecdDailyWorker(ctx context.Context) error {
+	recdMergeMu.Lock()
+	defer recdMergeMu.Unlock()
+
+	for i := 0; i < recdDailyWorkerMaxBatch; i++ {
+		count, err := s.mergeRecdDailyWorkerBatch(ctx)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if count < recdDailyWorkerBatchSize {
+			break
+		}
+	}
+	return nil
+}
+
+// func: 홍채인식기 데이터 현장근로자 배치 반영
+// @return
+// - int: 조회한 인식 기록 건수
+func (s *ServiceWorker) mergeRecdDailyWorkerBatch(ctx context.Context) (count int, err error) {
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
 	if err != nil {
-		return utils.CustomErrorf(err)
+		return 0, utils.CustomMessageErrorf("begin tx", err)
 	}
+	defer txutil.DeferTxx(tx, &err)
 
+	// 반영 위치 잠금: 다른 서버의 반영 작업은 커밋될 때까지 대기
+	watermark, err := s.Store.LockRecdWatermark(ctx, tx, tx, recdDailyWorkerJob)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 반영 위치 이후 기록 조회
+	recdList, err := s.Store.GetRecdDailyWorkerBatch(ctx, tx, watermark.LastIrisNo, recdDailyWorkerLagMinutes, recdDailyWorkerBatchSize)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
 	if len(recdList) == 0 {
-		return
+		return 0, nil
 	}
 
+	// 근로자 키가 없는 경우 조회 및 생성 (같은 근로자는 한번만)
+	userKeys := make(map[string]null.String)
 	for i := range recdList {
-		// 근로자 키 조회 및 생성
-		temp := entity.Worker{
-			UserId: recdList[i].UserId,
-			UserNm: recdList[i].UserNm,
-			RegNo:  recdList[i].RegNo,
+		if recdList[i].UserKey.Valid {
+			continue
 		}
-		userKey, err := s.Store.GetRecdWorkerUserKey(ctx, s.SafeDB, temp)
-		if err != nil {
-			return utils.CustomErrorf(err)
+		key := fmt.Sprintf("%s|%s|%s", recdList[i].UserId.String, recdList[i].UserNm.String, recdList[i].RegNo.String)
+		userKey, ok := userKeys[key]
+		if !ok {
+			temp := entity.Worker{
+				UserId: recdList[i].UserId,
+				UserNm: recdList[i].UserNm,
+				RegNo:  recdList[i].RegNo,
+			}
+			if userKey, err = s.getRecdWorkerUserKey(ctx, tx, temp); err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+			userKeys[key] = userKey
+		}
+		recdList[i].UserKey = userKey
+	}
+
+	dailyList, err := s.classifyRecdDailyWorker(ctx, tx, recdList)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 현장근로자 테이블에 반영
+	if err = s.Store.MergeRecdDailyWorker(ctx, tx, dailyList); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 반영 완료 처리 (시간 기록이 없는 기록 포함)
+	irisNos := make([]int64, 0, len(recdList))
+	for _, recd := range recdList {
+		irisNos = append(irisNos, recd.IrisNo.Int64)
+	}
+	if err = s.Store.ModifyRecdDailyWorkerDone(ctx, tx, irisNos); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 반영 위치 갱신 (반영한 가장 큰 IRIS_NO)
+	for _, recd := range recdList {
+		if recd.IrisNo.Valid && (!watermark.LastIrisNo.Valid || recd.IrisNo.Int64 > watermark.LastIrisNo.Int64) {
+			watermark.LastIrisNo = recd.IrisNo
+			watermark.LastRecogTime = recd.RecordDate
 		}
-		recdList[i].UserKey = utils.ParseNullString(userKey)
+	}
+	if err = s.Store.ModifyRecdWatermark(ctx, tx, watermark); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	return len(recdList), nil
+}
 
-		// 출퇴근 시간
-		if !recdList[i].RecordDate.Valid { // 시간 기록이 없는 경우 패스
+// func: 인식 기록을 근로자/현장/근무일별 출퇴근 기록으로 분류
+// 근로자/현장별로 기존 출퇴근 기록과 새 인식 기록을 모아 프로젝트 출퇴근 분류 규칙으로 다시 분류한다.
+// 새 인식 기록이 포함된 근무만 반영 대상으로 돌려준다.
+// @param
+// - recdList: 인식 시간 순서로 정렬된 인식 기록 (RECORD_DATE: 인식 시간)
+func (s *ServiceWorker) classifyRecdDailyWorker(ctx context.Context, db store.Queryer, recdList []entity.WorkerDaily) ([]entity.WorkerDaily, error) {
+	type group struct {
+		jno   int64
+		scans []worktime.Scan
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	userKeySet := make(map[string]struct{})
+	var userKeys []string
+	var startDate, endDate time.Time
+
+	for i, recd := range recdList {
+		// 시간 기록이나 근로자 키가 없는 경우 패스
+		if !recd.RecordDate.Valid || !recd.UserKey.Valid {
 			continue
-		} else {
-			// 출근 기록 확인
-			isChk, err := s.Store.GetRecdDailyWorkerChk(ctx, s.SafeDB, userKey, recdList[i].RecordDate)
+		}
+		key := fmt.Sprintf("%s|%d", recd.UserKey.String, recd.Sno.Int64)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{jno: recd.Jno.Int64}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.scans = append(g.scans, worktime.Scan{Time: recd.RecordDate.Time, Ref: i})
+
+		if _, ok := userKeySet[recd.UserKey.String]; !ok {
+			userKeySet[recd.UserKey.String] = struct{}{}
+			userKeys = append(userKeys, recd.UserKey.String)
+		}
+		if startDate.IsZero() || recd.RecordDate.Time.Before(startDate) {
+			startDate = recd.RecordDate.Time
+		}
+		if recd.RecordDate.Time.After(endDate) {
+			endDate = recd.RecordDate.Time
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	// 기존 출퇴근 기록 (전날 시작한 야간 근무, 다음날 기록 포함)
+	existList, err := s.Store.GetRecdDailyWorkerExistList(ctx, db, userKeys, startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	for _, exist := range existList {
+		g, ok := groups[fmt.Sprintf("%s|%d", exist.UserKey.String, exist.Sno.Int64)]
+		if !ok {
+			continue
+		}
+		if exist.InRecogTime.Valid {
+			g.scans = append(g.scans, worktime.Scan{Time: exist.InRecogTime.Time, Ref: -1})
+		}
+		if exist.OutRecogTime.Valid {
+			g.scans = append(g.scans, worktime.Scan{Time: exist.OutRecogTime.Time, Ref: -1})
+		}
+	}
+
+	rules := make(map[int64]worktime.Rule)
+	var list []entity.WorkerDaily
+	for _, key := range keys {
+		g := groups[key]
+
+		rule, ok := rules[g.jno]
+		if !ok {
+			setting, err := s.ProjectSettingStore.GetProjectScanRule(ctx, db, g.jno)
 			if err != nil {
-				return utils.CustomErrorf(err)
+				return nil, utils.CustomErrorf(err)
+			}
+			if rule, err = ProjectScanRule(setting); err != nil {
+				// 잘못 저장된 규칙으로 반영이 멈추지 않도록 기본 규칙 사용
+				_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf(fmt.Sprintf("jno: %d", g.jno), err))
+				rule = worktime.DefaultRule
 			}
-			if isChk { // 출근을 한 경우
-				recdList[i].OutRecogTime = recdList[i].RecordDate
-			} else { // 출근이 없는 경우
-				cmp := time.Date(recdList[i].RecordDate.Time.Year(), recdList[i].RecordDate.Time.Month(), recdList[i].RecordDate.Time.Day(), 15, 0, 0, 0, time.Local)
-				if recdList[i].RecordDate.Time.Before(cmp) {
-					recdList[i].InRecogTime = recdList[i].RecordDate
-				} else {
-					recdList[i].OutRecogTime = recdList[i].RecordDate
+			rules[g.jno] = rule
+		}
+
+		for _, att := range rule.Classify(g.scans) {
+			// 새 인식 기록이 없는 근무는 반영하지 않음
+			last := -1
+			for _, ref := range att.Refs {
+				if ref > last {
+					last = ref
 				}
 			}
+			if last < 0 {
+				continue
+			}
 
-			// 출퇴근 상태
-			if recdList[i].InRecogTime.Valid {
-				recdList[i].WorkState = utils.ParseNullString("01")
+			daily := recdList[last]
+			daily.RecordDate = null.TimeFrom(att.WorkDate)
+			daily.InRecogTime = null.NewTime(att.In, !att.In.IsZero())
+			daily.OutRecogTime = null.NewTime(att.Out, !att.Out.IsZero())
+			if daily.OutRecogTime.Valid {
+				daily.WorkState = utils.ParseNullString("02")
 			} else {
-				recdList[i].WorkState = utils.ParseNullString("02")
+				daily.WorkState = utils.ParseNullString("01")
 			}
+			list = append(list, daily)
 		}
 	}
-
-	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
-	if err != nil {
-		return utils.CustomErrorf(err)
-	}
-	defer txutil.DeferTx(tx, &err)
-
-	// 현장근로자 테이블에 반영
-	if err = s.Store.MergeRecdDailyWorker(ctx, tx, recdList); err != nil {
-		return utils.CustomErrorf(err)
-	}
-	return
+	return list, nil
 }
 
 // 변경 이력 조회
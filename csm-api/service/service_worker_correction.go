@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCorrectionState     = errors.New("invalid correction state")    // 처리할 수 없는 정정 요청 (마감, 기록 변경, 이미 처리됨)
+	ErrCorrectionForbidden = errors.New("correction review forbidden") // 승인/반려 권한 없음
+)
+
+// func: 출퇴근 정정 요청 (협력업체)
+// 협력업체 현장 근로자 조회에서 보이는 미마감 기록만 요청할 수 있고, 같은 기록에 승인 대기 중인 요청이 있으면 요청할 수 없다.
+// 요청 당시 출퇴근 시간, 공수를 함께 저장해서 승인할 때 기록이 바뀌었는지 확인한다.
+// 첨부파일은 요청을 모두 확인한 후 저장하고 업로드 파일(CORRECTION)로 등록한다. 저장 후 실패하면 파일을 지운다.
+// @param
+// - corrections: JNO, USER_KEY, RECORD_DATE, 정정 내용(IN_RECOG_TIME, OUT_RECOG_TIME, WORK_HOUR), REASON
+// - file: 첨부파일 (없으면 nil)
+// - fileName: 첨부파일 이름
+func (s *ServiceWorker) AddWorkerCorrections(ctx context.Context, corrections entity.WorkerCorrections, file io.Reader, fileName string) (err error) {
+	if len(corrections) == 0 {
+		return utils.CustomErrorf(fmt.Errorf("corrections is empty"))
+	}
+	for _, correction := range corrections {
+		if !correction.Jno.Valid || !correction.UserKey.Valid || !correction.RecordDate.Valid {
+			return utils.CustomErrorf(fmt.Errorf("jno, user_key or record_date is empty"))
+		}
+		if !correction.InRecogTime.Valid && !correction.OutRecogTime.Valid && !correction.WorkHour.Valid {
+			return utils.CustomErrorf(fmt.Errorf("correction is empty: %s", correction.UserKey.String))
+		}
+		if strings.TrimSpace(correction.Reason.String) == "" {
+			return utils.CustomErrorf(fmt.Errorf("reason is empty"))
+		}
+	}
+
+	id, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+
+	// 저장한 첨부파일 (트랜잭션이 롤백되면 삭제)
+	var savedPath string
+	defer func() {
+		if err != nil && savedPath != "" {
+			if removeErr := os.Remove(savedPath); removeErr != nil {
+				_ = entity.WriteErrorLog(ctx, utils.CustomMessageErrorf("remove correction file", removeErr))
+			}
+		}
+	}()
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	for _, correction := range corrections {
+		target, err := s.Store.GetWorkerCorrectionTarget(ctx, tx, id, *correction)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if target == nil {
+			return utils.CustomErrorf(fmt.Errorf("%w: worker not found %s", ErrCorrectionState, correction.UserKey.String))
+		}
+		if target.IsDeadline.String == "Y" {
+			return utils.CustomErrorf(fmt.Errorf("%w: deadline worker %s", ErrCorrectionState, correction.UserKey.String))
+		}
+
+		count, err := s.Store.GetWorkerCorrectionPendingCount(ctx, tx, *correction)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if count > 0 {
+			return utils.CustomErrorf(fmt.Errorf("%w: pending request exists %s", ErrCorrectionState, correction.UserKey.String))
+		}
+
+		correction.Sno = target.Sno
+		correction.RecordDate = target.RecordDate
+		correction.BeforeInRecogTime = target.InRecogTime
+		correction.BeforeOutRecogTime = target.OutRecogTime
+		correction.BeforeWorkHour = target.WorkHour
+		correction.RegUser = utils.ParseNullString(userName)
+		correction.RegUno = utils.ParseNullInt(id)
+	}
+
+	// 첨부파일 저장 및 업로드 파일 등록
+	if file != nil {
+		uploadFile, err := s.saveCorrectionFile(corrections[0].Jno.Int64, file, fileName)
+		if uploadFile.FilePath.Valid {
+			savedPath = filepath.Join(uploadFile.FilePath.String, uploadFile.FileName.String)
+		}
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		uploadFile.RegUser = utils.ParseNullString(userName)
+		uploadFile.RegUno = utils.ParseNullInt(id)
+		if err = s.UploadFileStore.AddUploadFile(ctx, tx, uploadFile); err != nil {
+			return utils.CustomErrorf(err)
+		}
+		for _, correction := range corrections {
+			correction.FilePath = utils.ParseNullString(savedPath)
+			correction.FileName = utils.ParseNullString(filepath.Base(fileName))
+		}
+	}
+
+	for _, correction := range corrections {
+		if err = s.Store.AddWorkerCorrection(ctx, tx, *correction); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+	return
+}
+
+// func: 출퇴근 정정 요청 첨부파일 저장
+// 업로드 경로/correction/YYYY/MM/DD/jno 에 "시간_파일명"으로 저장한다.
+// @param
+// - jno: 프로젝트pk
+// - file: 첨부파일
+// - fileName: 첨부파일 이름
+// @return
+// - 업로드 파일 정보 (FILE_PATH: 저장 폴더, FILE_NAME: 저장한 파일 이름). 파일을 만든 후 실패해도 경로를 돌려준다.
+func (s *ServiceWorker) saveCorrectionFile(jno int64, file io.Reader, fileName string) (entity.UploadFile, error) {
+	now := s.Clock.Now()
+	dir := filepath.Join(s.Config.UploadPath, "correction", now.Format("2006"), now.Format("01"), now.Format("02"), strconv.FormatInt(jno, 10))
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return entity.UploadFile{}, utils.CustomMessageErrorf("create upload directory", err)
+	}
+
+	savedName := fmt.Sprintf("%d_%s", now.UnixNano(), filepath.Base(fileName))
+	uploadFile := entity.UploadFile{
+		FileType: utils.ParseNullString(entity.UploadFileCorrection),
+		FilePath: utils.ParseNullString(dir),
+		FileName: utils.ParseNullString(savedName),
+		WorkDate: null.TimeFrom(now),
+		Jno:      null.IntFrom(jno),
+	}
+
+	outFile, err := os.Create(filepath.Join(dir, savedName))
+	if err != nil {
+		return entity.UploadFile{}, utils.CustomMessageErrorf("create file", err)
+	}
+	_, err = io.Copy(outFile, file)
+	if closeErr := outFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return uploadFile, utils.CustomMessageErrorf("save uploaded file", err)
+	}
+	return uploadFile, nil
+}
+
+// func: 출퇴근 정정 요청 목록 조회
+// 협력업체는 본인이 요청한 건만 조회하고, 그 외에는 프로젝트 권한(RoleScopeMiddleware의 IsMember)이 있어야 조회할 수 있다.
+// @param
+// - jno: 프로젝트pk
+// - status: 처리 상태 (P: 승인 대기, A: 승인, R: 반려, 없으면 전체)
+// - startDate, endDate: 근무일 조회 기간 (YYYY-MM-DD)
+func (s *ServiceWorker) GetWorkerCorrectionList(ctx context.Context, jno int64, status string, startDate string, endDate string) (entity.WorkerCorrections, error) {
+	regUno := null.Int{}
+	if isCoRole(ctx) {
+		uno, _ := auth.GetContext(ctx, auth.Uno{})
+		regUno = utils.ParseNullInt(uno)
+	} else if !auth.GetIsMember(ctx) {
+		return entity.WorkerCorrections{}, utils.CustomErrorf(fmt.Errorf("%w: jno %d", ErrCorrectionForbidden, jno))
+	}
+
+	list, err := s.Store.GetWorkerCorrectionList(ctx, s.SafeDB, jno, status, startDate, endDate, regUno)
+	if err != nil {
+		return entity.WorkerCorrections{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 출퇴근 정정 요청 조회 (첨부파일 다운로드용)
+// 목록 조회와 같이 협력업체는 본인이 요청한 건만, 그 외에는 요청의 프로젝트 권한(IsMember)이 있어야 조회할 수 있다.
+// 요청 jno가 정정 요청의 프로젝트와 다르면 현장 관리자, 현장소장인지 확인한다.
+// @param
+// - jno: 요청 프로젝트pk (RoleScopeMiddleware의 IsMember 기준, 없으면 0)
+// - crno: 출퇴근 정정 요청pk
+func (s *ServiceWorker) GetWorkerCorrection(ctx context.Context, jno int64, crno int64) (*entity.WorkerCorrection, error) {
+	correction, err := s.Store.GetWorkerCorrection(ctx, s.SafeDB, crno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if correction == nil {
+		return nil, utils.CustomErrorf(fmt.Errorf("%w: correction not found %d", ErrCorrectionState, crno))
+	}
+	if isCoRole(ctx) {
+		uno, _ := auth.GetContext(ctx, auth.Uno{})
+		if strconv.FormatInt(correction.RegUno.Int64, 10) != uno {
+			return nil, utils.CustomErrorf(fmt.Errorf("%w: %d", ErrCorrectionForbidden, crno))
+		}
+		return correction, nil
+	}
+	if correction.Jno.Int64 == jno && auth.GetIsMember(ctx) {
+		return correction, nil
+	}
+	manager, err := s.isSiteManager(ctx, correction.Jno.Int64)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if !manager {
+		return nil, utils.CustomErrorf(fmt.Errorf("%w: jno %d", ErrCorrectionForbidden, correction.Jno.Int64))
+	}
+	return correction, nil
+}
+
+// func: 출퇴근 정정 요청 일괄 승인
+// 현장 근로자 기록에 정정 내용을 반영하고 변경 이력(사유구분: 정정요청)을 남긴다.
+// 출퇴근 시간만 정정한 경우 공수는 프로젝트 설정으로 다시 계산한다 (퇴근이 없으면 비움, 퇴근이 출근보다 빠르면 승인하지 않음).
+// 요청 후 기록이 바뀌었거나 마감된 경우, 이미 처리된 요청이 있으면 아무것도 승인하지 않는다.
+// @param
+// - review: Crnos
+// @return
+// - 승인한 요청 수
+func (s *ServiceWorker) ApproveWorkerCorrections(ctx context.Context, review entity.WorkerCorrectionReview) (int, error) {
+	return s.reviewWorkerCorrections(ctx, review, entity.CorrectionApproved)
+}
+
+// func: 출퇴근 정정 요청 일괄 반려
+// @param
+// - review: Crnos, Reason(반려 사유)
+// @return
+// - 반려한 요청 수
+func (s *ServiceWorker) RejectWorkerCorrections(ctx context.Context, review entity.WorkerCorrectionReview) (int, error) {
+	if strings.TrimSpace(review.Reason.String) == "" {
+		return 0, utils.CustomErrorf(fmt.Errorf("reason is empty"))
+	}
+	return s.reviewWorkerCorrections(ctx, review, entity.CorrectionRejected)
+}
+
+// func: 출퇴근 정정 요청 승인/반려 처리
+func (s *ServiceWorker) reviewWorkerCorrections(ctx context.Context, review entity.WorkerCorrectionReview, status string) (count int, err error) {
+	if len(review.Crnos) == 0 {
+		return 0, utils.CustomErrorf(fmt.Errorf("crnos is empty"))
+	}
+
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	reviewUno := utils.ParseNullInt(uno)
+	reviewUser := utils.ParseNullString(userName)
+
+	// 공수 재계산용 공휴일 (트랜잭션 시작 전에 조회)
+	var holidays holidaySet
+	if status == entity.CorrectionApproved {
+		holidays = s.holidays.load(ctx, s.RestDateApiService, s.Clock.Now())
+	}
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	var calc *workHourCalculator
+	if status == entity.CorrectionApproved {
+		calc = newWorkHourCalculator(tx, s.WorkHourStore, holidays)
+	}
+	reviewers := make(map[int64]bool)
+	var beforeList, afterList entity.WorkerDailys
+	for _, crno := range review.Crnos {
+		correction, err := s.Store.GetWorkerCorrectionForUpdate(ctx, tx, crno)
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		if correction == nil || correction.Status.String != entity.CorrectionPending {
+			return 0, utils.CustomErrorf(fmt.Errorf("%w: correction not pending %d", ErrCorrectionState, crno))
+		}
+
+		// 처리 권한 확인
+		jno := correction.Jno.Int64
+		reviewer, ok := reviewers[jno]
+		if !ok {
+			if reviewer, err = s.isSiteManager(ctx, jno); err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+			reviewers[jno] = reviewer
+		}
+		if !reviewer {
+			return 0, utils.CustomErrorf(fmt.Errorf("%w: jno %d", ErrCorrectionForbidden, jno))
+		}
+
+		correction.Status = utils.ParseNullString(status)
+		correction.ReviewUno = reviewUno
+		correction.ReviewUser = reviewUser
+		if status == entity.CorrectionRejected {
+			correction.ReviewReason = review.Reason
+		}
+		if err = s.Store.ModifyWorkerCorrectionStatus(ctx, tx, *correction); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		count++
+
+		if status != entity.CorrectionApproved {
+			continue
+		}
+
+		// 변경전 데이터 조회: 요청 당시와 다르거나 마감된 기록은 승인하지 않음
+		worker := &entity.WorkerDaily{Sno: correction.Sno, Jno: correction.Jno, UserKey: correction.UserKey, RecordDate: correction.RecordDate}
+		worker.ReasonType = utils.ParseNullString(entity.ReasonTypeCorrection)
+		worker.Reason = correction.Reason
+		befores, err := s.Store.GetDailyWorkerBeforeList(ctx, tx, entity.WorkerDailys{worker})
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		before := befores[0]
+		if !before.RecordDate.Valid || before.IsDeadline.String == "Y" ||
+			!sameNullTime(before.InRecogTime, correction.BeforeInRecogTime) ||
+			!sameNullTime(before.OutRecogTime, correction.BeforeOutRecogTime) ||
+			!sameNullFloat(before.WorkHour, correction.BeforeWorkHour) {
+			return 0, utils.CustomErrorf(fmt.Errorf("%w: worker changed since request %d", ErrCorrectionState, crno))
+		}
+
+		// 변경후: 정정 요청한 항목만 바꿈
+		after := *before
+		if correction.InRecogTime.Valid {
+			after.InRecogTime = correction.InRecogTime
+		}
+		if correction.OutRecogTime.Valid {
+			after.OutRecogTime = correction.OutRecogTime
+		}
+		if correction.WorkHour.Valid {
+			after.WorkHour = correction.WorkHour
+		} else if !after.InRecogTime.Valid || !after.OutRecogTime.Valid {
+			after.WorkHour = null.Float{}
+		} else {
+			ws, err := calc.setting(ctx, jno)
+			if err != nil {
+				return 0, utils.CustomErrorf(err)
+			}
+			result := calc.calculate(ws, &after)
+			if result.Reversed {
+				return 0, utils.CustomErrorf(fmt.Errorf("%w: out before in %d", ErrCorrectionState, crno))
+			}
+			after.WorkHour = null.FloatFrom(result.ManHour)
+		}
+		if after.OutRecogTime.Valid {
+			after.WorkState = utils.ParseNullString("02")
+		} else {
+			after.WorkState = utils.ParseNullString("01")
+		}
+		after.ModUser = reviewUser
+		after.ModUno = reviewUno
+		after.Message = utils.ParseNullString(fmt.Sprintf("[MODIFY] crno:%d|in_recog_time:[before:%s, after:%s]|out_recog_time:[before:%s, after:%s]|work_hour:[before:%s, after:%s]",
+			crno, formatNullTime(before.InRecogTime), formatNullTime(after.InRecogTime),
+			formatNullTime(before.OutRecogTime), formatNullTime(after.OutRecogTime),
+			formatNullFloat(before.WorkHour), formatNullFloat(after.WorkHour)))
+
+		before.ModUser = reviewUser
+		before.ModUno = reviewUno
+		beforeList = append(beforeList, before)
+		afterList = append(afterList, &after)
+	}
+
+	if len(afterList) == 0 {
+		return
+	}
+
+	// 현장 근로자 기록 변경
+	if err = s.Store.MergeSiteBaseWorker(ctx, tx, afterList); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 변경 로그, 변경이력 저장
+	if err = s.addDailyWorkerHistory(ctx, tx, beforeList, afterList); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 현장 관리 권한 확인 (출퇴근 정정, 철야 승인/반려)
+// 시스템관리자, 현장소장, 현장 관리자
+func (s *ServiceWorker) isSiteManager(ctx context.Context, jno int64) (bool, error) {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	if auth.JWTRole(role) == auth.SystemAdmin {
+		return true, nil
+	}
+
+	unoString, _ := auth.GetContext(ctx, auth.Uno{})
+	uno, _ := strconv.ParseInt(unoString, 10, 64)
+	siteRole, err := s.UserStore.GetSiteRole(ctx, s.SafeDB, jno, uno)
+	if err != nil {
+		return false, utils.CustomErrorf(err)
+	}
+	switch auth.JWTRole(siteRole) {
+	case auth.SiteDirector, auth.SiteManager:
+		return true, nil
+	}
+	return false, nil
+}
+
+// func: 협력업체 사용자 여부
+func isCoRole(ctx context.Context) bool {
+	role, _ := auth.GetContext(ctx, auth.Role{})
+	switch auth.JWTRole(role) {
+	case auth.CoUser, auth.CoManager:
+		return true
+	}
+	return false
+}
+
+func sameNullTime(a null.Time, b null.Time) bool {
+	if a.Valid != b.Valid {
+		return false
+	}
+	return !a.Valid || a.Time.Equal(b.Time)
+}
+
+func sameNullFloat(a null.Float, b null.Float) bool {
+	if a.Valid != b.Valid {
+		return false
+	}
+	return !a.Valid || a.Float64 == b.Float64
+}
+
+func formatNullTime(t null.Time) string {
+	if !t.Valid {
+		return "N/A"
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+func formatNullFloat(f null.Float) string {
+	if !f.Valid {
+		return "N/A"
+	}
+	return strconv.FormatFloat(f.Float64, 'f', 2, 64)
+}
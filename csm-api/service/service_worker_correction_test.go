@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"csm-api/store"
+	"errors"
+	"github.com/guregu/null"
+	"testing"
+)
+
+// 정정 요청 하나만 조회하는 근로자 저장소
+type correctionStore struct {
+	store.WorkerStore
+	correction entity.WorkerCorrection
+}
+
+func (c correctionStore) GetWorkerCorrection(ctx context.Context, db store.Queryer, crno int64) (*entity.WorkerCorrection, error) {
+	correction := c.correction
+	return &correction, nil
+}
+
+// 프로젝트별 현장 역할만 조회하는 사용자 저장소
+type siteRoleStore struct {
+	store.UserStore
+	roles map[int64]string
+}
+
+func (s siteRoleStore) GetSiteRole(ctx context.Context, db store.Queryer, jno int64, uno int64) (string, error) {
+	return s.roles[jno], nil
+}
+
+func TestGetWorkerCorrectionScope(t *testing.T) {
+	correction := entity.WorkerCorrection{Crno: null.IntFrom(1), Jno: null.IntFrom(10)}
+	correction.RegUno = null.IntFrom(100)
+
+	tests := []struct {
+		name     string
+		role     auth.JWTRole
+		uno      string
+		jno      int64
+		isMember bool
+		siteRole string // 정정 요청 프로젝트(10)의 현장 역할
+		wantErr  error
+	}{
+		{name: "요청한 협력업체", role: auth.CoManager, uno: "100"},
+		{name: "다른 협력업체", role: auth.CoManager, uno: "200", wantErr: ErrCorrectionForbidden},
+		{name: "프로젝트 권한이 있는 직원", role: auth.User, uno: "300", jno: 10, isMember: true},
+		{name: "다른 프로젝트 권한으로 요청", role: auth.User, uno: "300", jno: 20, isMember: true, wantErr: ErrCorrectionForbidden},
+		{name: "프로젝트 권한 없음", role: auth.User, uno: "300", jno: 10, wantErr: ErrCorrectionForbidden},
+		{name: "jno 없이 요청한 현장 관리자", role: auth.User, uno: "400", siteRole: string(auth.SiteManager)},
+		{name: "jno 없이 요청한 안전관리자", role: auth.User, uno: "400", siteRole: string(auth.SafetyManager), wantErr: ErrCorrectionForbidden},
+		{name: "시스템관리자", role: auth.SystemAdmin, uno: "500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.SetContext(context.Background(), auth.Role{}, string(tt.role))
+			ctx = auth.SetContext(ctx, auth.Uno{}, tt.uno)
+			if tt.isMember {
+				ctx = auth.SetContext(ctx, auth.IsMember{}, "true")
+			}
+			s := &ServiceWorker{
+				Store:     correctionStore{correction: correction},
+				UserStore: siteRoleStore{roles: map[int64]string{10: tt.siteRole}},
+			}
+
+			if _, err := s.GetWorkerCorrection(ctx, tt.jno, 1); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetWorkerCorrection() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var ErrWorkerMerge = errors.New("workers cannot be merged") // 병합할 수 없는 근로자 (없는 근로자, 같은 날 기록 중복)
+
+// 중복 근로자 점수
+// 이름, 전화번호, 생년월일 중 하나 이상 같은 근로자 쌍만 비교한다.
+const (
+	duplicateScoreName       = 40
+	duplicateScorePhone      = 30
+	duplicateScoreBirth      = 20
+	duplicateScoreDepartment = 10
+	duplicatePenaltyBirth    = -30 // 생년월일이 모두 있는데 다른 경우
+	DefaultDuplicateMinScore = 50
+)
+
+// func: 중복 근로자 후보 조회
+// 현장 근로자 쌍의 이름, 전화번호, 생년월일, 소속을 비교해서 점수가 minScore 이상인 후보를 점수 순으로 조회한다.
+// 병합, 중복 아님으로 처리한 쌍은 제외한다.
+// @param
+// - sno: 현장pk
+// - minScore: 최소 점수 (0이면 DefaultDuplicateMinScore)
+func (s *ServiceWorker) GetWorkerDuplicateList(ctx context.Context, sno int64, minScore int) (entity.WorkerDuplicates, error) {
+	if minScore <= 0 {
+		minScore = DefaultDuplicateMinScore
+	}
+
+	workers, err := s.Store.GetWorkerIdentityList(ctx, s.SafeDB, sno)
+	if err != nil {
+		return entity.WorkerDuplicates{}, utils.CustomErrorf(err)
+	}
+	// 생년월일: 주민번호 앞 6자리
+	for _, worker := range workers {
+		regNo, err := decryptRegNoEnc(s.RegNoCipher, worker.RegNoEnc)
+		if err != nil {
+			return entity.WorkerDuplicates{}, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", worker.UserKey.String), err)
+		}
+		if regNo.Valid {
+			worker.Birth = utils.ParseNullString(regNoBirth(regNo.String))
+		}
+		worker.RegNoEnc = null.String{}
+	}
+	merges, err := s.Store.GetWorkerMergeList(ctx, s.SafeDB, sno)
+	if err != nil {
+		return entity.WorkerDuplicates{}, utils.CustomErrorf(err)
+	}
+	reviewed := make(map[string]struct{}, len(merges))
+	for _, merge := range merges {
+		reviewed[duplicatePairKey(merge.UserKey.String, merge.MergedUserKey.String)] = struct{}{}
+	}
+
+	// 이름, 전화번호, 생년월일이 같은 근로자끼리 묶음
+	buckets := make(map[string][]int)
+	for i, worker := range workers {
+		if name := normalizeName(worker.UserNm.String); name != "" {
+			buckets["name:"+name] = append(buckets["name:"+name], i)
+		}
+		if phone := normalizePhone(worker.Phone.String); phone != "" {
+			buckets["phone:"+phone] = append(buckets["phone:"+phone], i)
+		}
+		if birth := strings.TrimSpace(worker.Birth.String); birth != "" {
+			buckets["birth:"+birth] = append(buckets["birth:"+birth], i)
+		}
+	}
+
+	list := entity.WorkerDuplicates{}
+	compared := make(map[string]struct{})
+	for _, bucket := range buckets {
+		for a := 0; a < len(bucket); a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				worker, other := workers[bucket[a]], workers[bucket[b]]
+				key := duplicatePairKey(worker.UserKey.String, other.UserKey.String)
+				if _, ok := compared[key]; ok {
+					continue
+				}
+				compared[key] = struct{}{}
+				if _, ok := reviewed[key]; ok {
+					continue
+				}
+
+				score, reasons := scoreWorkerDuplicate(worker, other)
+				if score < minScore {
+					continue
+				}
+				// 출퇴근 기록이 많은 근로자를 남길 근로자로
+				if other.DailyCount.Int64 > worker.DailyCount.Int64 {
+					worker, other = other, worker
+				}
+				list = append(list, &entity.WorkerDuplicate{Score: score, Reasons: reasons, Worker: worker, Duplicate: other})
+			}
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+		return list[i].Worker.UserKey.String < list[j].Worker.UserKey.String
+	})
+	return list, nil
+}
+
+// func: 중복 근로자 점수 계산
+func scoreWorkerDuplicate(a *entity.WorkerIdentity, b *entity.WorkerIdentity) (int, []string) {
+	score := 0
+	reasons := []string{}
+
+	if name := normalizeName(a.UserNm.String); name != "" && name == normalizeName(b.UserNm.String) {
+		score += duplicateScoreName
+		reasons = append(reasons, "name")
+	}
+	if phone := normalizePhone(a.Phone.String); phone != "" && phone == normalizePhone(b.Phone.String) {
+		score += duplicateScorePhone
+		reasons = append(reasons, "phone")
+	}
+	birthA, birthB := strings.TrimSpace(a.Birth.String), strings.TrimSpace(b.Birth.String)
+	if birthA != "" && birthB != "" {
+		if birthA == birthB {
+			score += duplicateScoreBirth
+			reasons = append(reasons, "birth")
+		} else {
+			score += duplicatePenaltyBirth
+		}
+	}
+	if department := normalizeDepartment(a.Department.String); department != "" && department == normalizeDepartment(b.Department.String) {
+		score += duplicateScoreDepartment
+		reasons = append(reasons, "department")
+	}
+	return score, reasons
+}
+
+// func: 중복 근로자 병합
+// 병합할 근로자의 모든 현장 출퇴근 기록을 남길 근로자로 옮기고 변경 이력(사유구분: 근로자병합)을 남긴 후
+// 근로자 키를 쓰는 테이블(변경 이력, 철야, 정정 요청, 비교 예외 등)을 같은 트랜잭션에서 남길 근로자로 바꾸고 병합할 근로자를 삭제한다.
+// 두 근로자가 같은 현장, 같은 날 출퇴근 기록이 있으면 병합하지 않는다. (기록 정리 후 병합)
+// @param
+// - merge: SNO, USER_KEY(남길 근로자), MERGED_USER_KEY(병합할 근로자), REASON
+// @return
+// - 옮긴 출퇴근 기록 수
+func (s *ServiceWorker) MergeWorkerDuplicate(ctx context.Context, merge entity.WorkerMerge) (count int64, err error) {
+	if err = validateWorkerMerge(merge); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	sno := merge.Sno.Int64
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	for _, userKey := range []string{merge.UserKey.String, merge.MergedUserKey.String} {
+		worker, err := s.Store.GetWorkerForUpdate(ctx, tx, sno, userKey)
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		if worker == nil {
+			return 0, utils.CustomErrorf(fmt.Errorf("%w: worker not found %s", ErrWorkerMerge, userKey))
+		}
+	}
+
+	dates, err := s.Store.GetWorkerMergeConflictDates(ctx, tx, merge.UserKey.String, merge.MergedUserKey.String)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	if len(dates) > 0 {
+		return 0, utils.CustomErrorf(fmt.Errorf("%w: same record date %s", ErrWorkerMerge, strings.Join(dates, ", ")))
+	}
+
+	// 변경전 데이터 조회
+	beforeList, err := s.Store.GetDailyWorkerListByUserKey(ctx, tx, merge.MergedUserKey.String)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	merge.Status = utils.ParseNullString(entity.WorkerMergeMerged)
+	merge.RegUser = utils.ParseNullString(userName)
+	merge.RegUno = utils.ParseNullInt(uno)
+
+	if count, err = s.Store.ModifyDailyWorkerUserKey(ctx, tx, merge); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	// 변경 로그, 변경이력 저장
+	if len(beforeList) > 0 {
+		afterList := make(entity.WorkerDailys, 0, len(beforeList))
+		for _, before := range beforeList {
+			before.ReasonType = utils.ParseNullString(entity.ReasonTypeWorkerMerge)
+			before.Reason = merge.Reason
+			before.ModUser = merge.RegUser
+			before.ModUno = merge.RegUno
+
+			after := *before
+			after.UserKey = merge.UserKey
+			after.Message = utils.ParseNullString(fmt.Sprintf("[MERGE] user_key:[before:%s, after:%s]", merge.MergedUserKey.String, merge.UserKey.String))
+			afterList = append(afterList, &after)
+		}
+		if err = s.addDailyWorkerHistory(ctx, tx, beforeList, afterList); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+	}
+
+	// 근로자 키를 쓰는 테이블 변경 (방금 남긴 변경이력 포함), 병합된 근로자 삭제
+	if err = s.Store.ModifyWorkerMergeUserKey(ctx, tx, merge); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	if err = s.Store.RemoveMergedWorker(ctx, tx, merge); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	merge.DailyCount = null.IntFrom(count)
+	if err = s.Store.AddWorkerMerge(ctx, tx, merge); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 중복 근로자 아님 처리
+// 중복 근로자 후보에서 제외한다.
+// @param
+// - merge: SNO, USER_KEY, MERGED_USER_KEY, REASON
+func (s *ServiceWorker) DismissWorkerDuplicate(ctx context.Context, merge entity.WorkerMerge) (err error) {
+	if err = validateWorkerMerge(merge); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	merge.Status = utils.ParseNullString(entity.WorkerMergeDismissed)
+	merge.DailyCount = null.IntFrom(0)
+	merge.RegUser = utils.ParseNullString(userName)
+	merge.RegUno = utils.ParseNullInt(uno)
+
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	if err = s.Store.AddWorkerMerge(ctx, tx, merge); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 중복 근로자 처리 내역 조회
+// @param
+// - sno: 현장pk
+func (s *ServiceWorker) GetWorkerMergeList(ctx context.Context, sno int64) ([]entity.WorkerMerge, error) {
+	list, err := s.Store.GetWorkerMergeList(ctx, s.SafeDB, sno)
+	if err != nil {
+		return []entity.WorkerMerge{}, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+func validateWorkerMerge(merge entity.WorkerMerge) error {
+	if !merge.Sno.Valid || merge.UserKey.String == "" || merge.MergedUserKey.String == "" {
+		return fmt.Errorf("sno, user_key or merged_user_key is empty")
+	}
+	if merge.UserKey.String == merge.MergedUserKey.String {
+		return fmt.Errorf("%w: same user_key %s", ErrWorkerMerge, merge.UserKey.String)
+	}
+	if strings.TrimSpace(merge.Reason.String) == "" {
+		return fmt.Errorf("reason is empty")
+	}
+	return nil
+}
+
+// 근로자 쌍 키 (순서 무관)
+func duplicatePairKey(a string, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+// 이름 비교용: 공백 제거, 한글 자모 조합
+func normalizeName(name string) string {
+	return composeHangul(strings.Join(strings.Fields(name), ""))
+}
+
+// 전화번호 비교용: 숫자만
+func normalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// 소속 비교용: 공백, '주식회사', '(주)' 제거
+func normalizeDepartment(department string) string {
+	department = strings.ReplaceAll(department, "주식회사", "")
+	department = strings.ReplaceAll(department, "(주)", "")
+	return strings.ToUpper(strings.Join(strings.Fields(department), ""))
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrHistoryRestore   = errors.New("history cannot be restored") // 복원할 수 없는 이력 (삭제 이력, 마감된 기록)
+	ErrHistoryForbidden = errors.New("history restore forbidden")  // 복원 권한 없음
+)
+
+// func: 변경 이력 비교 조회
+// 같은 변경(USER_KEY, REG_DATE)에서 순번(HIS_SEQ)이 같은 변경전/변경후를 한 쌍으로 묶어 바뀐 항목(출퇴근 시간, 공수, 프로젝트, 마감 여부)을 계산한다.
+// 순번이 없는 이전 이력은 저장 순서(CNO)대로 짝을 맞춘다.
+// @param
+// - startDate, endDate: 근무일 조회 기간 (YYYY-MM-DD)
+// - sno: 현장pk
+// - userKeys: 근로자 (없으면 전체)
+func (s *ServiceWorker) GetHistoryDailyWorkerDiffs(ctx context.Context, startDate string, endDate string, sno int64, userKeys []string) (entity.WorkerHistoryDiffs, error) {
+	list, err := s.Store.GetHistoryDailyWorkerList(ctx, s.SafeDB, startDate, endDate, sno, userKeys)
+	if err != nil {
+		return entity.WorkerHistoryDiffs{}, utils.CustomErrorf(err)
+	}
+
+	diffs := entity.WorkerHistoryDiffs{}
+	for start := 0; start < len(list); {
+		// 같은 변경 묶음 (REG_DATE, USER_KEY 순으로 조회됨)
+		end := start + 1
+		for end < len(list) && list[end].UserKey == list[start].UserKey && list[end].RegDate.Equal(list[start].RegDate) {
+			end++
+		}
+
+		diffs = append(diffs, pairWorkerHistories(list[start:end])...)
+		start = end
+	}
+	return diffs, nil
+}
+
+// func: 같은 변경의 변경전/변경후 이력 짝 맞추기
+// 순번(HIS_SEQ)이 같은 변경전/변경후를 짝으로 하고, 순번이 없는 이력은 변경전, 변경후 저장 순서(CNO)대로 짝을 맞춘다.
+// 짝이 없는 이력(추가, 삭제)은 한쪽만 비교한다.
+func pairWorkerHistories(histories entity.WorkerHistories) entity.WorkerHistoryDiffs {
+	diffs := entity.WorkerHistoryDiffs{}
+
+	afterBySeq := make(map[int64]*entity.WorkerHistory)
+	for _, history := range histories {
+		if history.HisStatus.String != "BEFORE" && history.HisSeq.Valid {
+			afterBySeq[history.HisSeq.Int64] = history
+		}
+	}
+
+	var befores, afters entity.WorkerHistories
+	for _, history := range histories {
+		if history.HisStatus.String == "BEFORE" {
+			if !history.HisSeq.Valid {
+				befores = append(befores, history)
+				continue
+			}
+			after := afterBySeq[history.HisSeq.Int64]
+			delete(afterBySeq, history.HisSeq.Int64)
+			diffs = append(diffs, diffWorkerHistory(history, after))
+		} else if !history.HisSeq.Valid {
+			afters = append(afters, history)
+		}
+	}
+	// 변경전이 없는 변경후 (추가)
+	for _, history := range histories {
+		if history.HisSeq.Valid && afterBySeq[history.HisSeq.Int64] == history {
+			diffs = append(diffs, diffWorkerHistory(nil, history))
+		}
+	}
+
+	for i := 0; i < len(befores) || i < len(afters); i++ {
+		var before, after *entity.WorkerHistory
+		if i < len(befores) {
+			before = befores[i]
+		}
+		if i < len(afters) {
+			after = afters[i]
+		}
+		diffs = append(diffs, diffWorkerHistory(before, after))
+	}
+	return diffs
+}
+
+// func: 변경전/변경후 이력 비교
+func diffWorkerHistory(before *entity.WorkerHistory, after *entity.WorkerHistory) *entity.WorkerHistoryDiff {
+	if before == nil {
+		before = &entity.WorkerHistory{}
+	}
+	if after == nil {
+		after = &entity.WorkerHistory{}
+	}
+	base := after
+	if !base.Cno.Valid {
+		base = before
+	}
+
+	diff := &entity.WorkerHistoryDiff{
+		BeforeCno:  before.Cno,
+		AfterCno:   after.Cno,
+		Sno:        base.Sno,
+		UserKey:    base.UserKey,
+		UserId:     base.UserId,
+		UserNm:     base.UserNm,
+		Department: base.Department,
+		RecordDate: base.RecordDate,
+		ReasonType: base.ReasonType,
+		ReasonName: base.ReasonName,
+		Reason:     base.Reason,
+		RegDate:    base.RegDate,
+		RegUser:    base.RegUser,
+		RegUno:     base.RegUno,
+		Changes:    entity.WorkerHistoryChanges{},
+	}
+	if !diff.Reason.Valid {
+		diff.Reason = before.Reason
+	}
+
+	if !before.InRecogTime.Equal(after.InRecogTime) {
+		diff.Changes = append(diff.Changes, entity.WorkerHistoryChange{Field: entity.HistoryFieldInRecogTime, Before: before.InRecogTime, After: after.InRecogTime})
+	}
+	if !before.OutRecogTime.Equal(after.OutRecogTime) {
+		diff.Changes = append(diff.Changes, entity.WorkerHistoryChange{Field: entity.HistoryFieldOutRecogTime, Before: before.OutRecogTime, After: after.OutRecogTime})
+	}
+	if !before.WorkHour.Equal(after.WorkHour) {
+		diff.Changes = append(diff.Changes, entity.WorkerHistoryChange{Field: entity.HistoryFieldWorkHour, Before: before.WorkHour, After: after.WorkHour})
+	}
+	if !before.Jno.Equal(after.Jno) {
+		diff.Changes = append(diff.Changes, entity.WorkerHistoryChange{Field: entity.HistoryFieldJno, Before: before.Jno, After: after.Jno})
+	}
+	if !before.IsDeadline.Equal(after.IsDeadline) {
+		diff.Changes = append(diff.Changes, entity.WorkerHistoryChange{Field: entity.HistoryFieldIsDeadline, Before: before.IsDeadline, After: after.IsDeadline})
+	}
+	return diff
+}
+
+// func: 변경 이력 버전으로 복원
+// 선택한 이력의 프로젝트, 출퇴근 시간, 공수, 근무 상태, 철야 여부로 현재 기록을 바꾸고 변경전/변경후 이력(사유구분: 이력복원)을 새로 남긴다.
+// 기존 이력은 지우지 않는다. 삭제된 기록은 다시 추가하고, 마감된 기록과 삭제 이력으로는 복원할 수 없다. (마감 여부는 복원하지 않음)
+// 복원할 이력과 현재 기록의 프로젝트 모두 현장 관리 권한(시스템관리자, 현장소장, 현장 관리자)이 있어야 한다.
+// @param
+// - restore: Cno(복원할 이력pk), Reason
+func (s *ServiceWorker) RestoreHistoryDailyWorker(ctx context.Context, restore entity.WorkerHistoryRestore) (err error) {
+	if !restore.Cno.Valid {
+		return utils.CustomErrorf(fmt.Errorf("cno is empty"))
+	}
+	if strings.TrimSpace(restore.Reason.String) == "" {
+		return utils.CustomErrorf(fmt.Errorf("reason is empty"))
+	}
+
+	uno, _ := auth.GetContext(ctx, auth.Uno{})
+	userName, _ := auth.GetContext(ctx, auth.UserName{})
+	modUno := utils.ParseNullInt(uno)
+	modUser := utils.ParseNullString(userName)
+
+	tx, err := s.SafeTDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return utils.CustomMessageErrorf("begin tx", err)
+	}
+
+	defer txutil.DeferTxx(tx, &err)
+
+	version, err := s.Store.GetHistoryDailyWorker(ctx, tx, restore.Cno.Int64)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if version == nil {
+		return utils.CustomErrorf(fmt.Errorf("%w: history not found %d", ErrHistoryRestore, restore.Cno.Int64))
+	}
+	if !version.Jno.Valid || !version.RecordDate.Valid {
+		return utils.CustomErrorf(fmt.Errorf("%w: removed version %d", ErrHistoryRestore, restore.Cno.Int64))
+	}
+	if err = s.checkHistoryRestoreRole(ctx, version.Jno.Int64); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	// 변경전 데이터 조회
+	worker := &entity.WorkerDaily{Sno: version.Sno, Jno: version.Jno, UserKey: version.UserKey, RecordDate: version.RecordDate}
+	worker.ReasonType = utils.ParseNullString(entity.ReasonTypeHistoryRestore)
+	worker.Reason = restore.Reason
+	beforeList, err := s.Store.GetDailyWorkerBeforeList(ctx, tx, entity.WorkerDailys{worker})
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	before := beforeList[0]
+	if before.IsDeadline.String == "Y" {
+		return utils.CustomErrorf(fmt.Errorf("%w: deadline worker %s", ErrHistoryRestore, version.UserKey.String))
+	}
+	if before.Jno.Valid && before.Jno.Int64 != version.Jno.Int64 {
+		if err = s.checkHistoryRestoreRole(ctx, before.Jno.Int64); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+	before.ModUser = modUser
+	before.ModUno = modUno
+
+	// 변경후: 선택한 이력 값
+	after := *version
+	after.IsDeadline = utils.ParseNullString("N")
+	after.ReasonType = worker.ReasonType
+	after.Reason = worker.Reason
+	after.ModUser = modUser
+	after.ModUno = modUno
+	after.Message = utils.ParseNullString(fmt.Sprintf("[RESTORE] cno:%d|jno:[before:%d, after:%d]|in_recog_time:[before:%s, after:%s]|out_recog_time:[before:%s, after:%s]|work_hour:[before:%s, after:%s]",
+		restore.Cno.Int64, before.Jno.Int64, after.Jno.Int64,
+		formatNullTime(before.InRecogTime), formatNullTime(after.InRecogTime),
+		formatNullTime(before.OutRecogTime), formatNullTime(after.OutRecogTime),
+		formatNullFloat(before.WorkHour), formatNullFloat(after.WorkHour)))
+
+	if before.RecordDate.Valid {
+		// 현재 기록 변경 (현장은 현재 기록 기준)
+		after.Sno = before.Sno
+		after.RecordDate = before.RecordDate
+		count, err := s.Store.ModifyDailyWorkerRestore(ctx, tx, after)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		if count <= 0 {
+			return utils.CustomErrorf(fmt.Errorf("%w: worker changed %s", ErrHistoryRestore, version.UserKey.String))
+		}
+	} else {
+		// 삭제된 기록 다시 추가
+		if err = s.Store.MergeSiteBaseWorker(ctx, tx, entity.WorkerDailys{&after}); err != nil {
+			return utils.CustomErrorf(err)
+		}
+	}
+
+	// 변경 로그, 변경이력 저장
+	if err = s.addDailyWorkerHistory(ctx, tx, entity.WorkerDailys{before}, entity.WorkerDailys{&after}); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return
+}
+
+// func: 이력 복원 권한 확인
+// @param
+// - jno: 프로젝트pk
+func (s *ServiceWorker) checkHistoryRestoreRole(ctx context.Context, jno int64) error {
+	manager, err := s.isSiteManager(ctx, jno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if !manager {
+		return fmt.Errorf("%w: jno %d", ErrHistoryForbidden, jno)
+	}
+	return nil
+}
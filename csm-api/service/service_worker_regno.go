@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"csm-api/crypto"
+	"csm-api/entity"
+	"csm-api/store"
+	"csm-api/txutil"
+	"csm-api/utils"
+	"fmt"
+	"github.com/guregu/null"
+	"strings"
+	"time"
+)
+
+// 재암호화 배치 1회 조회 건수
+const regNoEncryptionBatchSize = 500
+
+// func: 주민번호 재암호화 (배치)
+// 1. 이전 방식(REG_NO)으로만 저장된 주민번호를 현재 키로 암호화해서 옮기고 REG_NO는 비운다.
+// 2. 현재 키로 암호화되지 않은 암호문(REG_NO_ENC)을 이전 키로 복호화해서 현재 키로 다시 암호화한다.
+// 키 목록 파일에 새 키를 등록한 후 실행하면 이전 키의 암호문이 모두 교체된다.
+// @return
+// - 암호화한 근로자 수
+func (s *ServiceWorker) ModifyRegNoEncryption(ctx context.Context) (count int, err error) {
+	if s.RegNoCipher == nil {
+		return 0, utils.CustomErrorf(fmt.Errorf("regno keyring is not set"))
+	}
+	activeKeyID := s.RegNoCipher.ActiveKey(time.Now()).ID
+
+	var lastSno int64
+	lastUserKey := ""
+	for {
+		list, err := s.Store.GetRegNoLegacyList(ctx, s.SafeDB, lastSno, lastUserKey, regNoEncryptionBatchSize)
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		if len(list) == 0 {
+			break
+		}
+
+		modified, err := s.modifyRegNoEncryption(ctx, list, s.Store.ModifyRegNoLegacy)
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		count += modified
+		lastSno, lastUserKey = list[len(list)-1].Sno.Int64, list[len(list)-1].UserKey.String
+	}
+
+	lastSno, lastUserKey = 0, ""
+	for {
+		list, err := s.Store.GetRegNoEncryptionList(ctx, s.SafeDB, activeKeyID, lastSno, lastUserKey, regNoEncryptionBatchSize)
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		if len(list) == 0 {
+			return count, nil
+		}
+
+		// 이전 키로 복호화
+		for _, worker := range list {
+			regNo, err := s.RegNoCipher.Decrypt(worker.RegNoEnc.String)
+			if err != nil {
+				return count, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", worker.UserKey.String), err)
+			}
+			worker.RegNo = utils.ParseNullString(regNo)
+		}
+
+		modified, err := s.modifyRegNoEncryption(ctx, list, s.Store.ModifyRegNoEncryption)
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		count += modified
+		lastSno, lastUserKey = list[len(list)-1].Sno.Int64, list[len(list)-1].UserKey.String
+	}
+}
+
+// func: 조회한 근로자 주민번호 암호화 저장
+// @param
+// - list: USER_KEY, SNO, REG_NO(평문), REG_NO_ENC(조회한 암호문)
+// - modify: 암호문 저장 (조회 후 바뀐 근로자는 건너뜀)
+func (s *ServiceWorker) modifyRegNoEncryption(ctx context.Context, list entity.Workers, modify func(context.Context, store.Execer, entity.Worker, string) (int64, error)) (count int, err error) {
+	tx, err := txutil.BeginTxWithMode(ctx, s.SafeTDB, false)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+
+	defer txutil.DeferTx(tx, &err)
+
+	for _, worker := range list {
+		regNoEnc, err := s.RegNoCipher.Encrypt(worker.RegNo.String)
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+
+		// 조회 후 변경된 근로자는 다음 배치에서 처리
+		modified, err := modify(ctx, tx, *worker, regNoEnc)
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		count += int(modified)
+	}
+	return count, nil
+}
+
+// func: 주민번호 암호화
+// 주민번호가 없으면 NULL
+// @param
+// - cipher: 주민번호 암호화 키
+// - regNo: 주민번호
+func encryptRegNo(cipher *crypto.Keyring, regNo null.String) (null.String, error) {
+	if strings.TrimSpace(regNo.String) == "" {
+		return null.String{}, nil
+	}
+	if cipher == nil {
+		return null.String{}, utils.CustomErrorf(fmt.Errorf("regno keyring is not set"))
+	}
+
+	regNoEnc, err := cipher.Encrypt(regNo.String)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+	return utils.ParseNullString(regNoEnc), nil
+}
+
+// func: 주민번호 암호문 복호화
+// 암호문이 없으면 NULL
+// @param
+// - cipher: 주민번호 암호화 키
+// - regNoEnc: 주민번호 암호문 (REG_NO_ENC)
+func decryptRegNoEnc(cipher *crypto.Keyring, regNoEnc null.String) (null.String, error) {
+	if !regNoEnc.Valid || regNoEnc.String == "" {
+		return null.String{}, nil
+	}
+	if cipher == nil {
+		return null.String{}, utils.CustomErrorf(fmt.Errorf("regno keyring is not set"))
+	}
+
+	regNo, err := cipher.Decrypt(regNoEnc.String)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+	return utils.ParseNullString(regNo), nil
+}
+
+// func: 근로자 주민번호 복호화
+// 암호문(REG_NO_ENC)을 복호화하여 REG_NO에 넣는다. (암호문이 없으면 주민번호 없음)
+// @param
+// - cipher: 주민번호 암호화 키
+// - worker: 조회한 근로자 (REG_NO_ENC)
+func decryptRegNo(cipher *crypto.Keyring, worker *entity.Worker) error {
+	regNo, err := decryptRegNoEnc(cipher, worker.RegNoEnc)
+	if err != nil {
+		return utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", worker.UserKey.String), err)
+	}
+	worker.RegNo = regNo
+	worker.RegNoEnc = null.String{}
+	return nil
+}
+
+// func: 같은 근로자 키 조회
+// 아이디, 이름이 같은 근로자 중 주민번호가 같은(둘 다 없는 경우 포함) 최근 등록 근로자. 없으면 NULL
+// @param
+// - db, workerStore: 조회 DB, 근로자 저장소
+// - cipher: 주민번호 암호화 키
+// - worker: USER_ID, USER_NM, REG_NO
+func findWorkerUserKey(ctx context.Context, db store.Queryer, workerStore store.WorkerStore, cipher *crypto.Keyring, worker entity.Worker) (null.String, error) {
+	list, err := workerStore.GetWorkerRegNoList(ctx, db, worker.UserId.String, worker.UserNm.String)
+	if err != nil {
+		return null.String{}, utils.CustomErrorf(err)
+	}
+
+	for _, candidate := range list {
+		regNo, err := decryptRegNoEnc(cipher, candidate.RegNoEnc)
+		if err != nil {
+			return null.String{}, utils.CustomMessageErrorf(fmt.Sprintf("user_key %s", candidate.UserKey.String), err)
+		}
+		if regNo.String == worker.RegNo.String {
+			return candidate.UserKey, nil
+		}
+	}
+	return null.String{}, nil
+}
+
+// func: 주민번호 생년월일 (앞 6자리)
+// @param
+// - regNo: 주민번호
+func regNoBirth(regNo string) string {
+	if len(regNo) < 6 {
+		return regNo
+	}
+	return regNo[:6]
+}
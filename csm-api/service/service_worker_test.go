@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/entity"
+	"errors"
+	"github.com/guregu/null"
+	"testing"
+	"time"
+)
+
+func TestCheckDeadlineDays(t *testing.T) {
+	day := func(status string) *entity.DailyClose {
+		return &entity.DailyClose{Jno: null.IntFrom(1), RecordDate: null.TimeFrom(time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)), Status: null.StringFrom(status)}
+	}
+
+	tests := []struct {
+		name    string
+		role    auth.JWTRole
+		days    entity.DailyCloses
+		wantErr error
+	}{
+		{name: "승인된 날짜", role: auth.SiteDirector, days: entity.DailyCloses{day(entity.DailyCloseApproved)}},
+		{name: "이미 마감된 날짜", role: auth.SiteDirector, days: entity.DailyCloses{day(entity.DailyCloseLocked)}},
+		{name: "마감 전 날짜", role: auth.SiteDirector, days: entity.DailyCloses{day(entity.DailyCloseApproved), day(entity.DailyCloseOpen)}, wantErr: ErrDailyCloseState},
+		{name: "마감 요청된 날짜", role: auth.SiteManager, days: entity.DailyCloses{day(entity.DailyCloseSubmitted)}, wantErr: ErrDailyCloseState},
+		{name: "시스템관리자는 승인 전 날짜도 마감", role: auth.SystemAdmin, days: entity.DailyCloses{day(entity.DailyCloseOpen)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.SetContext(context.Background(), auth.Role{}, string(tt.role))
+			if err := checkDeadlineDays(ctx, tt.days); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkDeadlineDays() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,30 +2,51 @@ package service
 
 import (
 	"context"
-	"crypto/md5"
 	"csm-api/api"
 	"csm-api/auth"
 	"csm-api/entity"
 	"csm-api/store"
+	"csm-api/txutil"
 	"csm-api/utils"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/guregu/null"
 	"strconv"
 )
 
+// 로그인 시도 제한
+const (
+	loginMaxFailCount   = 5  // 계정별 최대 실패 횟수
+	loginIpMaxFailCount = 20 // IP별 최대 실패 횟수
+	loginLockMinutes    = 15 // 실패 횟수 집계 및 잠금 시간(분)
+)
+
+var (
+	ErrLoginLocked         = errors.New("login locked: too many failed attempts")
+	ErrInvalidPassword     = errors.New("invalid user id or password")
+	ErrCompanyUserNotFound = errors.New("service.GetCompanyUserValid: Cno not valid")
+	ErrDuplicateCompany    = errors.New("service.GetCompanyUserValid: user id matches several companies")
+)
+
 type UserValid struct {
 	DB          store.Queryer
+	TDB         store.Beginner
 	Store       store.GetUserValidStore
 	UserService UserService
+	// 직원 비밀번호 검증 (V_BIZ_USER_INFO.USER_PWD, 레거시 MD5)
+	Verifier *auth.CredentialVerifier
+	// 협력업체 비밀번호 검증 (JOB_SUBCON_INFO.PW, 레거시 평문)
+	CompanyVerifier *auth.CredentialVerifier
 }
 
 // 직원 로그인
-func (g *UserValid) GetUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool) (entity.User, error) {
-	// 비밀번호 암호화.
-	hash := md5.Sum([]byte(userPwd))
-	pwMd5 := hex.EncodeToString(hash[:])
-	user, err := entity.User{}, error(nil)
+func (g *UserValid) GetUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool, ip string) (user entity.User, err error) {
+	// 로그인 잠금 확인 및 기록
+	if err = g.checkLoginLock(ctx, userId, "N", ip); err != nil {
+		return entity.User{}, err
+	}
+	defer func() { g.addLoginLog(ctx, userId, "N", ip, err) }()
 
 	if userPwd == "rltnfdusrnth" && isAdmin { // -> 기술연구소
 		user, err = g.Store.GetUserInfo(ctx, g.DB, userId)
@@ -33,8 +54,18 @@ func (g *UserValid) GetUserValid(ctx context.Context, userId string, userPwd str
 			return entity.User{}, utils.CustomErrorf(err)
 		}
 	} else {
-		// 유저 db에서 확인
-		user, err = g.Store.GetUserValid(ctx, g.DB, userId, pwMd5)
+		// 유저 db에서 비밀번호 확인
+		// V_BIZ_USER_INFO는 공통 사용자 뷰로 수정할 수 없어 재해시하지 않음
+		var stored string
+		stored, err = g.Store.GetUserPassword(ctx, g.DB, userId)
+		if err != nil {
+			return entity.User{}, utils.CustomMessageErrorf(ErrInvalidPassword.Error(), err)
+		}
+		if ok, _ := g.Verifier.Verify(stored, userPwd); !ok {
+			return entity.User{}, utils.CustomErrorf(ErrInvalidPassword)
+		}
+
+		user, err = g.Store.GetUserInfo(ctx, g.DB, userId)
 		if err != nil {
 			return entity.User{}, utils.CustomErrorf(err)
 		}
@@ -67,25 +98,56 @@ func (g *UserValid) GetUserValid(ctx context.Context, userId string, userPwd str
 }
 
 // 협력업체 로그인
-func (g *UserValid) GetCompanyUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool) (entity.User, error) {
-	user := entity.User{}
+func (g *UserValid) GetCompanyUserValid(ctx context.Context, userId string, userPwd string, isAdmin bool, ip string) (user entity.User, err error) {
+	// 로그인 잠금 확인 및 기록
+	if err = g.checkLoginLock(ctx, userId, "Y", ip); err != nil {
+		return entity.User{}, err
+	}
+	defer func() { g.addLoginLog(ctx, userId, "Y", ip, err) }()
 
-	company, err := entity.CompanyInfo{}, error(nil)
-	if userPwd == "rltnfdusrnth" && isAdmin { // -> 기술연구소
-		company, err = g.Store.GetCompanyUser(ctx, g.DB, userId)
-		if err != nil {
-			return entity.User{}, utils.CustomErrorf(err)
+	companies, err := g.Store.GetCompanyUserList(ctx, g.DB, userId)
+	if err != nil {
+		return entity.User{}, utils.CustomMessageErrorf(ErrInvalidPassword.Error(), err)
+	}
+
+	// 비밀번호가 일치하는 업체관리자 계정 (같은 아이디가 여러 업체에 있으면 비밀번호로 구분)
+	var (
+		company entity.CompanyInfo
+		rehash  bool
+		found   int
+	)
+	isDeveloper := userPwd == "rltnfdusrnth" && isAdmin // -> 기술연구소
+	for _, candidate := range companies {
+		// 해당 업체관리자가 없는 경우
+		if !candidate.Cno.Valid {
+			continue
 		}
-	} else {
-		company, err = g.Store.GetCompanyUserValid(ctx, g.DB, userId, userPwd)
-		if err != nil {
-			return entity.User{}, utils.CustomErrorf(err)
+		if isDeveloper {
+			company = candidate
+			found++
+			continue
+		}
+		if ok, needRehash := g.CompanyVerifier.Verify(candidate.Pw.String, userPwd); ok {
+			company, rehash = candidate, needRehash
+			found++
 		}
 	}
 
-	// 해당 업체관리자가 없는 경우
-	if !company.Cno.Valid {
-		return entity.User{}, utils.CustomErrorf(fmt.Errorf("service.GetCompanyUserValid: Cno not valid"))
+	switch {
+	case found == 0 && !isDeveloper:
+		return entity.User{}, utils.CustomErrorf(ErrInvalidPassword)
+	case found == 0:
+		return entity.User{}, utils.CustomErrorf(ErrCompanyUserNotFound)
+	case found > 1:
+		// 어느 업체 계정인지 구분할 수 없는 경우
+		return entity.User{}, utils.CustomErrorf(ErrDuplicateCompany)
+	}
+
+	// 평문으로 저장된 비밀번호는 해시로 전환
+	if rehash {
+		if err = g.modifyCompanyUserPassword(ctx, company, userPwd); err != nil {
+			return entity.User{}, utils.CustomErrorf(err)
+		}
 	}
 
 	// 있는 경우
@@ -119,3 +181,83 @@ func (g *UserValid) GetCompanyUserValid(ctx context.Context, userId string, user
 
 	return user, nil
 }
+
+// 협력업체 비밀번호 해시 저장
+func (g *UserValid) modifyCompanyUserPassword(ctx context.Context, company entity.CompanyInfo, userPwd string) (err error) {
+	hashed, err := g.CompanyVerifier.Hash(userPwd)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	company.Pw = null.StringFrom(hashed)
+
+	tx, err := txutil.BeginTxWithMode(ctx, g.TDB, false)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	defer txutil.DeferTx(tx, &err)
+
+	if err = g.Store.ModifyCompanyUserPassword(ctx, tx, company); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 로그인 잠금 확인 (계정별, IP별 최근 실패 횟수)
+// 잠금된 경우 기록을 남기고 ErrLoginLocked 반환
+// @param
+// - isCompany: 협력업체 여부(Y/N)
+func (g *UserValid) checkLoginLock(ctx context.Context, userId string, isCompany string, ip string) error {
+	accountFail, err := g.Store.GetLoginFailCount(ctx, g.DB, userId, isCompany, loginLockMinutes)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	ipFail, err := g.Store.GetLoginFailCountByIp(ctx, g.DB, ip, loginLockMinutes)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	if isLoginLocked(accountFail, ipFail) {
+		g.addLoginLog(ctx, userId, isCompany, ip, ErrLoginLocked)
+		return utils.CustomErrorf(ErrLoginLocked)
+	}
+	return nil
+}
+
+// 최근 실패 횟수가 계정별 또는 IP별 최대 실패 횟수에 도달했는지 확인
+func isLoginLocked(accountFail entity.LoginFailCount, ipFail entity.LoginFailCount) bool {
+	return accountFail.FailCount >= loginMaxFailCount || ipFail.FailCount >= loginIpMaxFailCount
+}
+
+// func: 로그인 기록 추가
+// 기록 실패는 로그인 결과에 영향을 주지 않도록 에러 로그만 남김
+// @param
+// - loginErr: 로그인 결과 에러 (nil이면 성공)
+func (g *UserValid) addLoginLog(ctx context.Context, userId string, isCompany string, ip string, loginErr error) {
+	loginLog := entity.LoginLog{
+		UserId:    null.StringFrom(userId),
+		IsCompany: null.StringFrom(isCompany),
+		Ip:        null.StringFrom(ip),
+		Result:    null.StringFrom(entity.LoginSuccess),
+	}
+	if errors.Is(loginErr, ErrLoginLocked) {
+		loginLog.Result = null.StringFrom(entity.LoginLocked)
+		loginLog.Reason = null.StringFrom(loginErr.Error())
+	} else if loginErr != nil {
+		loginLog.Result = null.StringFrom(entity.LoginFail)
+		loginLog.Reason = null.StringFrom(loginErr.Error())
+	}
+
+	err := func() (err error) {
+		tx, err := txutil.BeginTxWithMode(ctx, g.TDB, false)
+		if err != nil {
+			return utils.CustomErrorf(err)
+		}
+		defer txutil.DeferTx(tx, &err)
+
+		return g.Store.AddLoginLog(ctx, tx, loginLog)
+	}()
+	if err != nil {
+		_ = entity.WriteErrorLog(ctx, utils.CustomErrorf(err))
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"csm-api/entity"
+	"testing"
+)
+
+func TestIsLoginLocked(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountFail int
+		ipFail      int
+		want        bool
+	}{
+		{name: "실패 없음", accountFail: 0, ipFail: 0, want: false},
+		{name: "계정 실패 횟수 제한 직전", accountFail: loginMaxFailCount - 1, ipFail: 0, want: false},
+		{name: "계정 실패 횟수 제한 도달", accountFail: loginMaxFailCount, ipFail: 0, want: true},
+		{name: "IP 실패 횟수 제한 직전", accountFail: 0, ipFail: loginIpMaxFailCount - 1, want: false},
+		{name: "IP 실패 횟수 제한 도달", accountFail: 0, ipFail: loginIpMaxFailCount, want: true},
+		{name: "둘 다 제한 초과", accountFail: loginMaxFailCount + 1, ipFail: loginIpMaxFailCount + 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isLoginLocked(entity.LoginFailCount{FailCount: tt.accountFail}, entity.LoginFailCount{FailCount: tt.ipFail})
+			if got != tt.want {
+				t.Errorf("isLoginLocked(%d, %d) = %v, want %v", tt.accountFail, tt.ipFail, got, tt.want)
+			}
+		})
+	}
+}
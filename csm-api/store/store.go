@@ -15,9 +15,12 @@ type MenuStore interface {
 
 type GetUserValidStore interface {
 	GetUserInfo(ctx context.Context, db Queryer, userId string) (entity.User, error)
-	GetUserValid(ctx context.Context, db Queryer, userId string, userPwd string) (entity.User, error)
-	GetCompanyUserValid(ctx context.Context, db Queryer, userId string, userPwd string) (entity.CompanyInfo, error)
-	GetCompanyUser(ctx context.Context, db Queryer, userId string) (entity.CompanyInfo, error)
+	GetUserPassword(ctx context.Context, db Queryer, userId string) (string, error)
+	GetCompanyUserList(ctx context.Context, db Queryer, userId string) ([]entity.CompanyInfo, error)
+	ModifyCompanyUserPassword(ctx context.Context, tx Execer, company entity.CompanyInfo) error
+	AddLoginLog(ctx context.Context, tx Execer, loginLog entity.LoginLog) error
+	GetLoginFailCount(ctx context.Context, db Queryer, userId string, isCompany string, minutes int) (entity.LoginFailCount, error)
+	GetLoginFailCountByIp(ctx context.Context, db Queryer, ip string, minutes int) (entity.LoginFailCount, error)
 }
 
 type SiteStore interface {
@@ -87,6 +90,15 @@ type ProjectSettingStore interface {
 	GetProjectSetting(ctx context.Context, db Queryer, isRole bool, uno string, jno int64) (*entity.ProjectSettings, error)
 	DeleteManHour(ctx context.Context, tx Execer, mhno int64) error
 	ProjectSettingLog(ctx context.Context, tx Execer, setting entity.ProjectSetting) error
+	GetProjectScanRule(ctx context.Context, db Queryer, jno int64) (*entity.ProjectSetting, error)
+	GetProjectCancelDay(ctx context.Context, db Queryer, jno int64) (null.Int, error)
+	GetProjectShiftList(ctx context.Context, db Queryer, jno int64) (*entity.ProjectShifts, error)
+	AddProjectShift(ctx context.Context, tx Execer, shift entity.ProjectShift) error
+	DeleteProjectShift(ctx context.Context, tx Execer, jno int64) error
+	ProjectShiftLog(ctx context.Context, tx Execer, shift entity.ProjectShift) error
+	GetProjectBreakList(ctx context.Context, db Queryer, jno int64) (*entity.ProjectBreaks, error)
+	AddProjectBreak(ctx context.Context, tx Execer, brk entity.ProjectBreak) error
+	DeleteProjectBreak(ctx context.Context, tx Execer, jno int64) error
 	ManHourLog(ctx context.Context, tx Execer, manhour entity.ManHour) error
 }
 type OrganizationStore interface {
@@ -109,6 +121,7 @@ type UserStore interface {
 	GetOperationalRole(ctx context.Context, db Queryer, jno int64, uno int64) (string, error)
 	GetAuthorizationList(ctx context.Context, db Queryer, api string) (*entity.RoleList, error)
 	GetSupervisorRole(ctx context.Context, db Queryer, uno int64) (string, error)
+	GetProjectRoleMapList(ctx context.Context, db Queryer, jno int64, uno int64) ([]string, error)
 }
 
 type CodeStore interface {
@@ -136,11 +149,18 @@ type DeviceStore interface {
 	RemoveDevice(ctx context.Context, tx Execer, dno sql.NullInt64) error
 	GetDeviceLog(ctx context.Context, db Queryer) (*entity.RecdLogOrigins, error)
 	GetCheckRegistered(ctx context.Context, db Queryer, deviceName string) (int, error)
+	GetDeviceBySn(ctx context.Context, db Queryer, deviceSn string) (entity.Device, error)
+	ModifyDeviceApiKey(ctx context.Context, tx Execer, device entity.Device) error
+}
+
+type RecdStore interface {
+	AddRecdLog(ctx context.Context, tx Execer, device entity.Device, recd entity.RecdLog, irisData string) (bool, error)
+	AddRecdSet(ctx context.Context, tx Execer, device entity.Device, recd entity.RecdLog) error
 }
 
 type WorkerStore interface {
 	GetWorkerTotalList(ctx context.Context, db Queryer, page entity.PageSql, isRole bool, uno string, search entity.Worker, retry string) (*entity.Workers, error)
-	GetWorkerTotalCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.Worker, retry string) (int, error)
+	GetWorkerTotalCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.Worker, retry string, limit int) (int, error)
 	GetAbsentWorkerList(ctx context.Context, db Queryer, page entity.PageSql, search entity.WorkerDaily, retry string) (*entity.Workers, error)
 	GetAbsentWorkerCount(ctx context.Context, db Queryer, search entity.WorkerDaily, retry string) (int, error)
 	GetWorkerDepartList(ctx context.Context, db Queryer, jno int64) ([]string, error)
@@ -149,39 +169,92 @@ type WorkerStore interface {
 	ModifyWorker(ctx context.Context, tx Execer, worker entity.Worker) error
 	RemoveWorker(ctx context.Context, tx Execer, worker entity.Worker) error
 	GetWorkerSiteBaseList(ctx context.Context, db Queryer, page entity.PageSql, isRole bool, uno string, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error)
-	GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.WorkerDaily, retry string) (int, error)
+	GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.WorkerDaily, retry string, limit int) (int, error)
 	GetWorkerSiteBaseListByCompany(ctx context.Context, db Queryer, page entity.PageSql, id string, search entity.WorkerDaily, retry string) (*entity.WorkerDailys, error)
-	GetWorkerSiteBaseByCompanyCount(ctx context.Context, db Queryer, id string, search entity.WorkerDaily, retry string) (int, error)
+	GetWorkerSiteBaseByCompanyCount(ctx context.Context, db Queryer, id string, search entity.WorkerDaily, retry string, limit int) (int, error)
 	MergeSiteBaseWorker(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	MergeSiteBaseWorkerLog(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	ModifyWorkerDeadline(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	ModifyWorkerProject(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	ModifyWorkerDefaultProject(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
+	GetWorkerDeadlineInitDays(ctx context.Context, db Queryer) (entity.DailyCloses, error)
 	ModifyWorkerDeadlineInit(ctx context.Context, tx Execer) error
 	GetWorkerOverTime(ctx context.Context, db Queryer) (*entity.WorkerOverTimes, error)
+	AddWorkerOverTime(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error
+	GetWorkerOverTimeList(ctx context.Context, db Queryer, sno int64, status string) (*entity.WorkerOverTimes, error)
+	GetWorkerOverTimeForUpdate(ctx context.Context, db Queryer, ono int64) (entity.WorkerOverTime, error)
+	ModifyWorkerOverTimeStatus(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error
 	ModifyWorkerOverTime(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error
 	DeleteWorkerOverTime(ctx context.Context, tx Execer, cno null.Int) error
+	ModifyWorkerOverTimeAfter(ctx context.Context, tx Execer, worker entity.WorkerDaily) error
 	RemoveSiteBaseWorkers(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	ModifyDeadlineCancel(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
-	GetDailyWorkerUserKey(ctx context.Context, db Queryer, worker entity.WorkerDaily) (string, error)
+	GetDailyWorkerUserKeyList(ctx context.Context, db Queryer, worker entity.WorkerDaily) (entity.Workers, error)
 	AddDailyWorkers(ctx context.Context, db Queryer, tx Execer, workers entity.WorkerDailys) (entity.WorkerDailys, error)
 	GetDailyWorkersByJnoAndDate(ctx context.Context, db Queryer, param entity.RecordDailyWorkerReq) ([]entity.RecordDailyWorkerRes, error)
 	ModifyWorkHours(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	GetRecdWorkerList(ctx context.Context, db Queryer) ([]entity.Worker, error)
-	GetRecdWorkerUserKey(ctx context.Context, db Queryer, worker entity.Worker) (string, error)
+	GetRecdWorkerUserKeyList(ctx context.Context, db Queryer, worker entity.Worker) (entity.Workers, error)
+	GetNewWorkerUserKey(ctx context.Context, db Queryer) (string, error)
 	MergeRecdWorker(ctx context.Context, tx Execer, worker []entity.Worker) error
-	GetRecdDailyWorkerList(ctx context.Context, db Queryer) ([]entity.WorkerDaily, error)
-	GetRecdDailyWorkerChk(ctx context.Context, db Queryer, userKey string, date null.Time) (bool, error)
+	LockRecdWatermark(ctx context.Context, db Queryer, tx Execer, jobName string) (entity.RecdWatermark, error)
+	ModifyRecdWatermark(ctx context.Context, tx Execer, watermark entity.RecdWatermark) error
+	GetRecdDailyWorkerBatch(ctx context.Context, db Queryer, lastIrisNo null.Int, lagMinutes int, limit int) ([]entity.WorkerDaily, error)
+	GetRecdDailyWorkerExistList(ctx context.Context, db Queryer, userKeys []string, startDate time.Time, endDate time.Time) ([]entity.WorkerDaily, error)
 	MergeRecdDailyWorker(ctx context.Context, tx Execer, worker []entity.WorkerDaily) error
+	ModifyRecdDailyWorkerDone(ctx context.Context, tx Execer, irisNos []int64) error
 	GetDailyWorkerBeforeList(ctx context.Context, db Queryer, workers entity.WorkerDailys) (entity.WorkerDailys, error)
 	AddHistoryDailyWorkers(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	GetHistoryDailyWorkers(ctx context.Context, db Queryer, startDate string, endDate string, sno int64, retry string, userKeys []string) (entity.WorkerDailys, error)
 	GetHistoryDailyWorkerReason(ctx context.Context, db Queryer, cno int64) (string, error)
+
+	GetWorkerCorrectionTarget(ctx context.Context, db Queryer, id string, correction entity.WorkerCorrection) (*entity.WorkerDaily, error)
+	GetWorkerCorrectionPendingCount(ctx context.Context, db Queryer, correction entity.WorkerCorrection) (int, error)
+	AddWorkerCorrection(ctx context.Context, tx Execer, correction entity.WorkerCorrection) error
+	GetWorkerCorrectionList(ctx context.Context, db Queryer, jno int64, status string, startDate string, endDate string, regUno null.Int) (entity.WorkerCorrections, error)
+	GetWorkerCorrection(ctx context.Context, db Queryer, crno int64) (*entity.WorkerCorrection, error)
+	GetWorkerCorrectionForUpdate(ctx context.Context, db Queryer, crno int64) (*entity.WorkerCorrection, error)
+	ModifyWorkerCorrectionStatus(ctx context.Context, tx Execer, correction entity.WorkerCorrection) error
+	GetHistoryDailyWorkerList(ctx context.Context, db Queryer, startDate string, endDate string, sno int64, userKeys []string) (entity.WorkerHistories, error)
+	GetHistoryDailyWorker(ctx context.Context, db Queryer, cno int64) (*entity.WorkerDaily, error)
+	ModifyDailyWorkerRestore(ctx context.Context, tx Execer, worker entity.WorkerDaily) (int64, error)
+	GetWorkerIdentityList(ctx context.Context, db Queryer, sno int64) (entity.WorkerIdentities, error)
+	GetWorkerMergeList(ctx context.Context, db Queryer, sno int64) ([]entity.WorkerMerge, error)
+	GetWorkerForUpdate(ctx context.Context, db Queryer, sno int64, userKey string) (*entity.Worker, error)
+	GetWorkerMergeConflictDates(ctx context.Context, db Queryer, userKey string, mergedUserKey string) ([]string, error)
+	GetDailyWorkerListByUserKey(ctx context.Context, db Queryer, userKey string) (entity.WorkerDailys, error)
+	ModifyDailyWorkerUserKey(ctx context.Context, tx Execer, merge entity.WorkerMerge) (int64, error)
+	RemoveMergedWorker(ctx context.Context, tx Execer, merge entity.WorkerMerge) error
+	ModifyWorkerMergeUserKey(ctx context.Context, tx Execer, merge entity.WorkerMerge) error
+	AddWorkerMerge(ctx context.Context, tx Execer, merge entity.WorkerMerge) error
+	GetRegNoLegacyList(ctx context.Context, db Queryer, lastSno int64, lastUserKey string, limit int) (entity.Workers, error)
+	ModifyRegNoLegacy(ctx context.Context, tx Execer, worker entity.Worker, regNoEnc string) (int64, error)
+	GetRegNoEncryptionList(ctx context.Context, db Queryer, activeKeyID string, lastSno int64, lastUserKey string, limit int) (entity.Workers, error)
+	ModifyRegNoEncryption(ctx context.Context, tx Execer, worker entity.Worker, regNoEnc string) (int64, error)
+	GetWorkerRegNoList(ctx context.Context, db Queryer, userId string, userNm string) (entity.Workers, error)
+	GetRetiredWorkerAnonymizeCount(ctx context.Context, db Queryer, cutoff time.Time) (int, error)
+	GetRetiredWorkerAnonymizeList(ctx context.Context, db Queryer, cutoff time.Time) (entity.Workers, error)
+	ModifyRetiredWorkerAnonymize(ctx context.Context, tx Execer, workers entity.Workers, cutoff time.Time, user entity.Base) (int64, error)
+}
+
+type DailyCloseStore interface {
+	GetDailyCloseList(ctx context.Context, db Queryer, jno int64, startDate string, endDate string) (entity.DailyCloses, error)
+	GetDailyCloseIssueCount(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (int, error)
+	GetDailyCloseForUpdate(ctx context.Context, db Queryer, tx Execer, jno int64, recordDate time.Time) (*entity.DailyClose, error)
+	ModifyDailyClose(ctx context.Context, tx Execer, dailyClose entity.DailyClose) error
+	AddDailyCloseLog(ctx context.Context, tx Execer, before string, after entity.DailyClose) error
+	ModifyDailyCloseDeadline(ctx context.Context, tx Execer, jno int64, recordDate time.Time, user entity.Base) (int64, error)
+	GetDailyCloseApprovedList(ctx context.Context, db Queryer) (entity.DailyCloses, error)
+	GetDailyCloseLockDate(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (null.Time, error)
+	GetDailyCloseOpenCount(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (int, error)
 }
 
 type WorkHourStore interface {
-	ModifyWorkHour(ctx context.Context, tx Execer, user entity.Base) error
-	ModifyWorkHourByJno(ctx context.Context, tx Execer, jno int64, user entity.Base, uuids []string) error
+	GetWorkHourTargetList(ctx context.Context, db Queryer, jno int64, uuids []string) (entity.WorkerDailys, error)
+	GetWorkHourSetting(ctx context.Context, db Queryer, jno int64) (*entity.ProjectSetting, error)
+	ModifyWorkHourList(ctx context.Context, tx Execer, workers entity.WorkerDailys, user entity.Base) error
+	GetWorkHourOpenList(ctx context.Context, db Queryer, jno int64) (entity.WorkerDailys, error)
+	ModifyWorkHourPreviewList(ctx context.Context, tx Execer, rows entity.ManHourPreviewRows, user entity.Base) (int64, error)
 }
 
 type CompanyStore interface {
@@ -213,6 +286,8 @@ type UploadFileStore interface {
 	GetUploadFileList(ctx context.Context, db Queryer, file entity.UploadFile) ([]entity.UploadFile, error)
 	GetUploadFile(ctx context.Context, db Queryer, file entity.UploadFile) (entity.UploadFile, error)
 	AddUploadFile(ctx context.Context, tx Execer, file entity.UploadFile) error
+	GetExpiredUploadFileList(ctx context.Context, db Queryer, fileType string, cutoff time.Time) ([]entity.UploadFile, error)
+	ModifyUploadFilePurged(ctx context.Context, tx Execer, file entity.UploadFile, archivePath null.String) error
 }
 
 type CompareStore interface {
@@ -224,6 +299,18 @@ type CompareStore interface {
 	ModifyTbmCompareApply(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	ModifyDeductionCompareApply(ctx context.Context, tx Execer, workers entity.WorkerDailys) error
 	AddCompareLog(ctx context.Context, tx Execer, logs entity.WorkerDailys) error
+	GetDepartmentAliasList(ctx context.Context, db Queryer, jno null.Int) (entity.DepartmentAliases, error)
+	MergeDepartmentAlias(ctx context.Context, tx Execer, alias entity.DepartmentAlias) error
+	RemoveDepartmentAlias(ctx context.Context, tx Execer, alias entity.DepartmentAlias) error
+	GetCompareReconcileTargetList(ctx context.Context, db Queryer) ([]entity.CompareReconcileTarget, error)
+	MergeCompareReconcile(ctx context.Context, tx Execer, result entity.CompareReconcileResult, runDate time.Time) error
+	MergeCompareReconcileError(ctx context.Context, tx Execer, target entity.CompareReconcileTarget, message string, errorDate time.Time) error
+	MergeCompareException(ctx context.Context, tx Execer, exception entity.CompareException) error
+	ModifyCompareExceptionClosed(ctx context.Context, tx Execer, jno int64, recordDate time.Time, runDate time.Time) (int64, error)
+	GetCompareException(ctx context.Context, db Queryer, exno int64) (*entity.CompareException, error)
+	GetCompareExceptionList(ctx context.Context, db Queryer, jno int64, startDate string, endDate string, status string, assigneeUno null.Int) (entity.CompareExceptions, error)
+	ModifyCompareExceptionAssignee(ctx context.Context, tx Execer, exno int64, review entity.CompareExceptionReview, user entity.Base) (int64, error)
+	ModifyCompareExceptionResolve(ctx context.Context, tx Execer, exno int64, review entity.CompareExceptionReview, user entity.Base) (int64, error)
 }
 
 type ExcelStore interface {
@@ -246,3 +333,10 @@ type UserRoleStore interface {
 	RemoveUserRole(ctx context.Context, tx Execer, userRoles []entity.UserRoleMap) error
 	GetUserMenuRoleCheck(ctx context.Context, db Queryer, role string, menuId string) (bool, error)
 }
+
+type PiiStore interface {
+	AddPiiAccessLog(ctx context.Context, tx Execer, accessLog entity.PiiAccessLog) error
+	GetPiiAccessReport(ctx context.Context, db Queryer, startDate string, endDate string) (entity.PiiAccessReports, error)
+	GetPiiAccessLogList(ctx context.Context, db Queryer, startDate string, endDate string, uno null.Int, accessType string) (entity.PiiAccessLogs, error)
+	GetWorkerPii(ctx context.Context, db Queryer, userKey string) (*entity.Worker, error)
+}
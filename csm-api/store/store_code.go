@@ -4,7 +4,6 @@ import (
 	"context"
 	"csm-api/entity"
 	"csm-api/utils"
-	"fmt"
 )
 
 func (r *Repository) GetCodeList(ctx context.Context, db Queryer, pCode string) (*entity.Codes, error) {
@@ -32,7 +31,7 @@ func (r *Repository) GetCodeList(ctx context.Context, db Queryer, pCode string)
 func (r *Repository) GetCodeTree(ctx context.Context, db Queryer, pCode string) (*entity.Codes, error) {
 	codes := entity.Codes{}
 
-	query := fmt.Sprintf(`
+	query := `
 			SELECT 
 			    LEVEL, 
 			    C.IDX, 
@@ -50,12 +49,12 @@ func (r *Repository) GetCodeTree(ctx context.Context, db Queryer, pCode string)
 			    C.ETC			    
 			FROM IRIS_CODE_SET C
 			WHERE DEL_YN = 'N'
-			START WITH P_CODE = '%s'
+			START WITH P_CODE = :1
 			CONNECT BY PRIOR CODE = P_CODE
 			ORDER SIBLINGS BY "ORDER" ASC
-		`, pCode)
+		`
 
-	if err := db.SelectContext(ctx, &codes, query); err != nil {
+	if err := db.SelectContext(ctx, &codes, query, pCode); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -217,7 +217,6 @@ func (r *Repository) GetCompanyInfoList(ctx context.Context, db Queryer, jno sql
 				SELECT S.JNO, 
 					 S.CNO, 
 					 S.ID, 
-					 S.PW, 
 					 S.CELLPHONE, 
 					 S.EMAIL, 
 					 U.USER_NAME, 
@@ -5,6 +5,24 @@ import (
 	"csm-api/entity"
 	"csm-api/utils"
 	"fmt"
+	"github.com/guregu/null"
+)
+
+// 일일 근로자 비교 목록 정렬 가능 필드
+var compareDailyOrderFields = entity.OrderColumns(
+	"USER_KEY", "SNO", "JNO", "USER_ID", "USER_NM", "PHONE", "DEPARTMENT", "DISC_NAME", "IN_RECOG_TIME",
+	"OUT_RECOG_TIME", "RECORD_DATE", "COMPARE_STATE", "IS_DEADLINE", "DEVICE_NM",
+)
+
+// TBM 비교 목록 정렬 가능 필드
+var compareTbmOrderFields = entity.OrderColumns(
+	"SNO", "JNO", "DEPARTMENT", "DISC_NAME", "USER_NM", "TBM_DATE", "TBM_ORDER",
+)
+
+// 퇴직공제 비교 목록 정렬 가능 필드
+var compareDeductionOrderFields = entity.OrderColumns(
+	"SNO", "JNO", "USER_NM", "GENDER", "PHONE", "DEPARTMENT", "IN_RECOG_TIME", "OUT_RECOG_TIME", "RECORD_DATE",
+	"DEDUCT_ORDER",
 )
 
 // 일일 근로자 비교 - 근로자 리스트
@@ -23,10 +41,14 @@ func (r *Repository) GetDailyWorkerList(ctx context.Context, db Queryer, compare
 	columns = append(columns, "T2.DEPARTMENT")
 	columns = append(columns, "T2.USER_ID")
 	columns = append(columns, "T3.DEVICE_NM")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	where := utils.NewWhereBuilder(compare.RecordDate, compare.Sno, compare.Jno, compare.Jno)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var orderBy string
-	if order == "" {
+	orderBy, err := compareDailyOrderFields.Parse(order)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if orderBy == "" {
 		orderBy = `
 			ORDER BY
 				CASE COMPARE_STATE
@@ -43,7 +65,7 @@ func (r *Repository) GetDailyWorkerList(ctx context.Context, db Queryer, compare
 				END
 				DESC NULLS LAST`
 	} else {
-		orderBy = fmt.Sprintf(`ORDER BY %s`, order)
+		orderBy = "ORDER BY " + orderBy
 	}
 
 	query := fmt.Sprintf(`
@@ -59,7 +81,7 @@ func (r *Repository) GetDailyWorkerList(ctx context.Context, db Queryer, compare
 			T2.USER_ID,
 			T2.USER_NM,
 			T2.PHONE,
-			COMMON.FUNC_DECODE(T2.REG_NO) AS REG_NO,
+			T2.REG_NO_ENC,
 			CASE
 				WHEN INSTR(T2.DEPARTMENT, ' ', -1) > 0 THEN SUBSTR(T2.DEPARTMENT, 1, INSTR(T2.DEPARTMENT, ' ', -1) - 1)
 				ELSE T2.DEPARTMENT
@@ -87,7 +109,7 @@ func (r *Repository) GetDailyWorkerList(ctx context.Context, db Queryer, compare
 			%s
 			%s`, roleCondition, retryCondition, orderBy)
 
-	if err := db.SelectContext(ctx, &list, query, compare.RecordDate, compare.Sno, compare.Jno, compare.Jno); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return list, utils.CustomErrorf(err)
 	}
 	return list, nil
@@ -100,13 +122,17 @@ func (r *Repository) GetTbmList(ctx context.Context, db Queryer, compare entity.
 	var columns []string
 	columns = append(columns, "A.USER_NM")
 	columns = append(columns, "A.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	where := utils.NewWhereBuilder(compare.Sno, compare.RecordDate, compare.Sno, compare.RecordDate, compare.Jno)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var orderBy string
-	if order == "" {
+	orderBy, err := compareTbmOrderFields.Parse(order)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if orderBy == "" {
 		orderBy = `ORDER BY TBM_DATE DESC NULLS LAST`
 	} else {
-		orderBy = fmt.Sprintf(`ORDER BY %s`, order)
+		orderBy = "ORDER BY " + orderBy
 	}
 
 	query := fmt.Sprintf(`
@@ -140,7 +166,7 @@ func (r *Repository) GetTbmList(ctx context.Context, db Queryer, compare entity.
 		%s
 		%s`, retryCondition, orderBy)
 
-	if err := db.SelectContext(ctx, &list, query, compare.Sno, compare.RecordDate, compare.Sno, compare.RecordDate, compare.Jno); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return list, utils.CustomErrorf(err)
 	}
 	return list, nil
@@ -153,10 +179,14 @@ func (r *Repository) GetDeductionList(ctx context.Context, db Queryer, compare e
 	var columns []string
 	columns = append(columns, "A.USER_NM")
 	columns = append(columns, "A.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	where := utils.NewWhereBuilder(compare.Sno, compare.RecordDate, compare.Sno, compare.RecordDate, compare.Jno)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var orderBy string
-	if order == "" {
+	orderBy, err := compareDeductionOrderFields.Parse(order)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if orderBy == "" {
 		orderBy = `
 			ORDER BY (
 				CASE 
@@ -166,7 +196,7 @@ func (r *Repository) GetDeductionList(ctx context.Context, db Queryer, compare e
 				END
 			) DESC NULLS LAST`
 	} else {
-		orderBy = fmt.Sprintf(`ORDER BY %s`, order)
+		orderBy = "ORDER BY " + orderBy
 	}
 
 	query := fmt.Sprintf(`
@@ -206,7 +236,7 @@ func (r *Repository) GetDeductionList(ctx context.Context, db Queryer, compare e
 		%s
 		%s`, retryCondition, orderBy)
 
-	if err := db.SelectContext(ctx, &list, query, compare.Sno, compare.RecordDate, compare.Sno, compare.RecordDate, compare.Jno); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return list, utils.CustomErrorf(err)
 	}
 	return list, nil
@@ -290,7 +320,7 @@ func (r *Repository) ModifyTbmCompareApply(ctx context.Context, tx Execer, worke
 		)`
 
 	for _, worker := range workers {
-		if _, err := tx.ExecContext(ctx, query, worker.Jno, worker.RegUser, worker.RegNo, agent, worker.Sno, worker.UserNm, worker.Department, worker.RecordDate); err != nil {
+		if _, err := tx.ExecContext(ctx, query, worker.Jno, worker.RegUser, worker.RegUno, agent, worker.Sno, worker.UserNm, worker.Department, worker.RecordDate); err != nil {
 			return utils.CustomErrorf(err)
 		}
 	}
@@ -346,3 +376,70 @@ func (r *Repository) AddCompareLog(ctx context.Context, tx Execer, logs entity.W
 	}
 	return nil
 }
+
+// func: 프로젝트별 부서 별칭 조회
+// @param
+// - jno: 프로젝트pk (없으면 전체)
+func (r *Repository) GetDepartmentAliasList(ctx context.Context, db Queryer, jno null.Int) (entity.DepartmentAliases, error) {
+	list := entity.DepartmentAliases{}
+
+	query := `
+		SELECT
+			JNO, ALIAS, DEPARTMENT, REG_USER, REG_UNO, REG_DATE
+		FROM IRIS_DEPARTMENT_ALIAS
+		WHERE (:1 IS NULL OR JNO = :2)
+		ORDER BY JNO, DEPARTMENT, ALIAS`
+
+	if err := db.SelectContext(ctx, &list, query, jno, jno); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 프로젝트별 부서 별칭 저장 (같은 별칭이 있으면 부서명 수정)
+// @param
+// - alias: JNO, ALIAS, DEPARTMENT
+func (r *Repository) MergeDepartmentAlias(ctx context.Context, tx Execer, alias entity.DepartmentAlias) error {
+	agent := utils.GetAgent()
+
+	query := `
+		MERGE INTO IRIS_DEPARTMENT_ALIAS t1
+		USING (
+			SELECT :1 AS JNO, :2 AS ALIAS FROM DUAL
+		) t2
+		ON (t1.JNO = t2.JNO AND t1.ALIAS = t2.ALIAS)
+		WHEN MATCHED THEN
+			UPDATE SET
+				DEPARTMENT = :3,
+				MOD_DATE = SYSDATE,
+				MOD_AGENT = :4,
+				MOD_USER = :5,
+				MOD_UNO = :6
+		WHEN NOT MATCHED THEN
+			INSERT (JNO, ALIAS, DEPARTMENT, REG_DATE, REG_AGENT, REG_USER, REG_UNO)
+			VALUES (t2.JNO, t2.ALIAS, :7, SYSDATE, :8, :9, :10)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		alias.Jno, alias.Alias,
+		alias.Department, agent, alias.RegUser, alias.RegUno,
+		alias.Department, agent, alias.RegUser, alias.RegUno,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 프로젝트별 부서 별칭 삭제
+// @param
+// - alias: JNO, ALIAS
+func (r *Repository) RemoveDepartmentAlias(ctx context.Context, tx Execer, alias entity.DepartmentAlias) error {
+	query := `
+		DELETE FROM IRIS_DEPARTMENT_ALIAS
+		WHERE JNO = :1
+		AND ALIAS = :2`
+
+	if _, err := tx.ExecContext(ctx, query, alias.Jno, alias.Alias); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"time"
+)
+
+// func: 일일 근로자 비교 자동 대사 대상 조회
+// TBM, 퇴직공제 파일을 마지막 대사 이후 업로드한 프로젝트, 날짜
+func (r *Repository) GetCompareReconcileTargetList(ctx context.Context, db Queryer) ([]entity.CompareReconcileTarget, error) {
+	var list []entity.CompareReconcileTarget
+
+	query := `
+		SELECT
+			J.SNO,
+			F.JNO,
+			TRUNC(F.WORK_DATE) AS RECORD_DATE
+		FROM IRIS_UPLOADED_FILES F
+		JOIN IRIS_SITE_JOB J ON J.JNO = F.JNO
+		LEFT JOIN IRIS_COMPARE_RECONCILE R ON R.JNO = F.JNO AND R.RECORD_DATE = TRUNC(F.WORK_DATE)
+		WHERE F.FILE_TYPE IN ('TBM', 'DEDUCTION')
+		AND F.PURGE_DATE IS NULL
+		GROUP BY J.SNO, F.JNO, TRUNC(F.WORK_DATE)
+		HAVING MAX(F.REG_DATE) > NVL(MAX(R.RUN_DATE), DATE '1900-01-01')
+		ORDER BY RECORD_DATE, JNO`
+
+	if err := db.SelectContext(ctx, &list, query); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 근로자 비교 자동 대사 기록
+// @param
+// - target: JNO, RECORD_DATE
+// - runDate: 대사 시작 시간 (이후 업로드한 파일은 다음 대사 대상)
+func (r *Repository) MergeCompareReconcile(ctx context.Context, tx Execer, result entity.CompareReconcileResult, runDate time.Time) error {
+	query := `
+		MERGE INTO IRIS_COMPARE_RECONCILE t1
+		USING (
+			SELECT :1 AS JNO, TRUNC(:2) AS RECORD_DATE FROM DUAL
+		) t2
+		ON (t1.JNO = t2.JNO AND t1.RECORD_DATE = t2.RECORD_DATE)
+		WHEN MATCHED THEN
+			UPDATE SET
+				RUN_DATE = :3,
+				APPLIED_COUNT = :4,
+				EXCEPTION_COUNT = :5,
+				ERROR_MESSAGE = NULL,
+				ERROR_DATE = NULL
+		WHEN NOT MATCHED THEN
+			INSERT (JNO, RECORD_DATE, RUN_DATE, APPLIED_COUNT, EXCEPTION_COUNT)
+			VALUES (t2.JNO, t2.RECORD_DATE, :6, :7, :8)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		result.Jno, result.RecordDate,
+		runDate, result.AppliedCount, result.ExceptionCount,
+		runDate, result.AppliedCount, result.ExceptionCount,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 일일 근로자 비교 자동 대사 실패 기록
+// 대사 시간(RUN_DATE)은 바꾸지 않으므로 다음 대사에서 다시 대상이 된다.
+// @param
+// - target: JNO, RECORD_DATE
+// - message: 실패 사유
+// - errorDate: 대사 시작 시간
+func (r *Repository) MergeCompareReconcileError(ctx context.Context, tx Execer, target entity.CompareReconcileTarget, message string, errorDate time.Time) error {
+	query := `
+		MERGE INTO IRIS_COMPARE_RECONCILE t1
+		USING (
+			SELECT :1 AS JNO, TRUNC(:2) AS RECORD_DATE FROM DUAL
+		) t2
+		ON (t1.JNO = t2.JNO AND t1.RECORD_DATE = t2.RECORD_DATE)
+		WHEN MATCHED THEN
+			UPDATE SET
+				ERROR_MESSAGE = SUBSTRB(:3, 1, 4000),
+				ERROR_DATE = :4
+		WHEN NOT MATCHED THEN
+			INSERT (JNO, RECORD_DATE, ERROR_MESSAGE, ERROR_DATE)
+			VALUES (t2.JNO, t2.RECORD_DATE, SUBSTRB(:5, 1, 4000), :6)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		target.Jno, target.RecordDate,
+		message, errorDate,
+		message, errorDate,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 일일 근로자 비교 예외 등록
+// 같은 예외(프로젝트, 날짜, 구분, EXCEPTION_KEY)가 처리 대기, 해소 상태면 내용을 갱신하고 처리 대기로 한다.
+// 처리 완료, 예외 아님으로 처리한 예외는 그대로 둔다.
+// @param
+// - exception: SNO, JNO, RECORD_DATE, EXCEPTION_TYPE, EXCEPTION_KEY, 근로자, 내용, DETECT_DATE
+func (r *Repository) MergeCompareException(ctx context.Context, tx Execer, exception entity.CompareException) error {
+	agent := utils.GetAgent()
+
+	query := `
+		MERGE INTO IRIS_COMPARE_EXCEPTION t1
+		USING (
+			SELECT :1 AS JNO, TRUNC(:2) AS RECORD_DATE, :3 AS EXCEPTION_TYPE, :4 AS EXCEPTION_KEY FROM DUAL
+		) t2
+		ON (t1.JNO = t2.JNO AND t1.RECORD_DATE = t2.RECORD_DATE AND t1.EXCEPTION_TYPE = t2.EXCEPTION_TYPE AND t1.EXCEPTION_KEY = t2.EXCEPTION_KEY)
+		WHEN MATCHED THEN
+			UPDATE SET
+				DETAIL = :5,
+				WORKER_IN_TIME = :6,
+				WORKER_OUT_TIME = :7,
+				DEDUCTION_IN_TIME = :8,
+				DEDUCTION_OUT_TIME = :9,
+				MATCH_SCORE = :10,
+				STATUS = 'O',
+				DETECT_DATE = :11,
+				MOD_DATE = SYSDATE,
+				MOD_AGENT = :12
+			WHERE t1.STATUS IN ('O', 'C')
+		WHEN NOT MATCHED THEN
+			INSERT (
+				EXNO, SNO, JNO, RECORD_DATE, EXCEPTION_TYPE, EXCEPTION_KEY,
+				USER_KEY, USER_NM, DEPARTMENT, DETAIL, WORKER_IN_TIME, WORKER_OUT_TIME,
+				DEDUCTION_IN_TIME, DEDUCTION_OUT_TIME, MATCH_SCORE, STATUS, DETECT_DATE, REG_DATE, REG_AGENT
+			) VALUES (
+				SEQ_IRIS_COMPARE_EXCEPTION.NEXTVAL, :13, t2.JNO, t2.RECORD_DATE, t2.EXCEPTION_TYPE, t2.EXCEPTION_KEY,
+				:14, :15, :16, :17, :18, :19,
+				:20, :21, :22, 'O', :23, SYSDATE, :24
+			)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		exception.Jno, exception.RecordDate, exception.ExceptionType, exception.ExceptionKey,
+		exception.Detail, exception.WorkerInTime, exception.WorkerOutTime, exception.DeductionInTime, exception.DeductionOutTime,
+		exception.MatchScore, exception.DetectDate, agent,
+		exception.Sno,
+		exception.UserKey, exception.UserNm, exception.Department, exception.Detail, exception.WorkerInTime, exception.WorkerOutTime,
+		exception.DeductionInTime, exception.DeductionOutTime, exception.MatchScore, exception.DetectDate, agent,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 해소된 일일 근로자 비교 예외 처리
+// 이번 대사에서 나오지 않은 처리 대기 예외를 해소 상태로 한다.
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+// - runDate: 대사 시작 시간
+func (r *Repository) ModifyCompareExceptionClosed(ctx context.Context, tx Execer, jno int64, recordDate time.Time, runDate time.Time) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_COMPARE_EXCEPTION
+		SET
+			STATUS = 'C',
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :1
+		WHERE JNO = :2
+		AND RECORD_DATE = TRUNC(:3)
+		AND STATUS = 'O'
+		AND DETECT_DATE < :4`
+
+	result, err := tx.ExecContext(ctx, query, agent, jno, recordDate, runDate)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 일일 근로자 비교 예외 조회 (권한 확인용)
+// 예외가 없으면 nil
+// @param
+// - exno: 예외pk
+func (r *Repository) GetCompareException(ctx context.Context, db Queryer, exno int64) (*entity.CompareException, error) {
+	exception := entity.CompareException{}
+
+	query := `
+		SELECT EXNO, SNO, JNO, RECORD_DATE, STATUS
+		FROM IRIS_COMPARE_EXCEPTION
+		WHERE EXNO = :1`
+
+	if err := db.GetContext(ctx, &exception, query, exno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &exception, nil
+}
+
+// func: 일일 근로자 비교 예외 목록 조회
+// @param
+// - jno: 프로젝트pk
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+// - status: 처리 상태 (없으면 전체)
+// - assigneeUno: 담당자 (없으면 전체)
+func (r *Repository) GetCompareExceptionList(ctx context.Context, db Queryer, jno int64, startDate string, endDate string, status string, assigneeUno null.Int) (entity.CompareExceptions, error) {
+	list := entity.CompareExceptions{}
+
+	query := `
+		SELECT
+			EXNO, SNO, JNO, RECORD_DATE, EXCEPTION_TYPE, EXCEPTION_KEY,
+			USER_KEY, USER_NM, DEPARTMENT, DETAIL, WORKER_IN_TIME, WORKER_OUT_TIME,
+			DEDUCTION_IN_TIME, DEDUCTION_OUT_TIME, MATCH_SCORE, STATUS,
+			ASSIGNEE_UNO, ASSIGNEE_NAME, RESOLVE_UNO, RESOLVE_USER, RESOLVE_DATE, RESOLVE_NOTE,
+			DETECT_DATE, REG_DATE, MOD_DATE, MOD_USER, MOD_UNO
+		FROM IRIS_COMPARE_EXCEPTION
+		WHERE JNO = :1
+		AND RECORD_DATE >= TO_DATE(:2, 'YYYY-MM-DD')
+		AND RECORD_DATE < TO_DATE(:3, 'YYYY-MM-DD') + 1
+		AND (:4 IS NULL OR STATUS = :5)
+		AND (:6 IS NULL OR ASSIGNEE_UNO = :7)
+		ORDER BY RECORD_DATE DESC, EXCEPTION_TYPE, USER_NM, EXNO`
+
+	statusBind := utils.ParseNullString(status)
+	if err := db.SelectContext(ctx, &list, query, jno, startDate, endDate, statusBind, statusBind, assigneeUno, assigneeUno); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 근로자 비교 예외 담당자 지정
+// 처리 대기 예외만 지정한다.
+// @param
+// - exno: 예외pk
+// - review: ASSIGNEE_UNO, ASSIGNEE_NAME
+// - user: 처리자
+func (r *Repository) ModifyCompareExceptionAssignee(ctx context.Context, tx Execer, exno int64, review entity.CompareExceptionReview, user entity.Base) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_COMPARE_EXCEPTION
+		SET
+			ASSIGNEE_UNO = :1,
+			ASSIGNEE_NAME = :2,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :3,
+			MOD_USER = :4,
+			MOD_UNO = :5
+		WHERE EXNO = :6
+		AND STATUS = 'O'`
+
+	result, err := tx.ExecContext(ctx, query, review.AssigneeUno, review.AssigneeName, agent, user.ModUser, user.ModUno, exno)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 일일 근로자 비교 예외 처리 (처리 완료, 예외 아님)
+// 처리 대기 예외만 처리한다.
+// @param
+// - exno: 예외pk
+// - review: STATUS, NOTE
+// - user: 처리자
+func (r *Repository) ModifyCompareExceptionResolve(ctx context.Context, tx Execer, exno int64, review entity.CompareExceptionReview, user entity.Base) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_COMPARE_EXCEPTION
+		SET
+			STATUS = :1,
+			RESOLVE_UNO = :2,
+			RESOLVE_USER = :3,
+			RESOLVE_DATE = SYSDATE,
+			RESOLVE_NOTE = :4,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :5,
+			MOD_USER = :6,
+			MOD_UNO = :7
+		WHERE EXNO = :8
+		AND STATUS = 'O'`
+
+	result, err := tx.ExecContext(ctx, query, review.Status, user.ModUno, user.ModUser, review.Note, agent, user.ModUser, user.ModUno, exno)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
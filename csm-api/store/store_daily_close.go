@@ -0,0 +1,272 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"time"
+)
+
+// func: 일일 마감 현황 조회
+// 근로자 기록이 있는 날짜별 마감 상태, 근로자 수, 비교 미처리 근로자 수 (마감 기록이 없으면 마감 전)
+// @param
+// - jno: 프로젝트pk
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+func (r *Repository) GetDailyCloseList(ctx context.Context, db Queryer, jno int64, startDate string, endDate string) (entity.DailyCloses, error) {
+	list := entity.DailyCloses{}
+
+	query := `
+		WITH DAYS AS (
+			SELECT
+				JNO,
+				TRUNC(RECORD_DATE) AS RECORD_DATE,
+				COUNT(*) AS WORKER_COUNT,
+				SUM(CASE WHEN NVL(COMPARE_STATE, 'N') NOT IN ('S', 'X') THEN 1 ELSE 0 END) AS ISSUE_COUNT
+			FROM IRIS_WORKER_DAILY_SET
+			WHERE JNO = :1
+			AND RECORD_DATE >= TO_DATE(:2, 'YYYY-MM-DD')
+			AND RECORD_DATE < TO_DATE(:3, 'YYYY-MM-DD') + 1
+			GROUP BY JNO, TRUNC(RECORD_DATE)
+		)
+		SELECT
+			D.JNO,
+			D.RECORD_DATE,
+			NVL(C.STATUS, 'O') AS STATUS,
+			D.WORKER_COUNT,
+			D.ISSUE_COUNT,
+			C.SUBMIT_UNO,
+			C.SUBMIT_USER,
+			C.SUBMIT_DATE,
+			C.APPROVE_UNO,
+			C.APPROVE_USER,
+			C.APPROVE_DATE,
+			C.LOCK_DATE,
+			C.REASON,
+			C.MOD_DATE,
+			C.MOD_USER,
+			C.MOD_UNO
+		FROM DAYS D
+		LEFT JOIN IRIS_DAILY_CLOSE C ON C.JNO = D.JNO AND C.RECORD_DATE = D.RECORD_DATE
+		ORDER BY D.RECORD_DATE DESC`
+
+	if err := db.SelectContext(ctx, &list, query, jno, startDate, endDate); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 마감 비교 미처리 근로자 수 조회
+// 비교 상태(COMPARE_STATE)가 확정(S)이나 제외(X)가 아닌 근로자
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+func (r *Repository) GetDailyCloseIssueCount(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM IRIS_WORKER_DAILY_SET
+		WHERE JNO = :1
+		AND TRUNC(RECORD_DATE) = TRUNC(:2)
+		AND NVL(COMPARE_STATE, 'N') NOT IN ('S', 'X')`
+
+	if err := db.GetContext(ctx, &count, query, jno, recordDate); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
+
+// func: 일일 마감 잠금 조회
+// 마감 기록이 없으면 마감 전으로 만든 후 FOR UPDATE로 조회한다.
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+func (r *Repository) GetDailyCloseForUpdate(ctx context.Context, db Queryer, tx Execer, jno int64, recordDate time.Time) (*entity.DailyClose, error) {
+	mergeQuery := `
+		MERGE INTO IRIS_DAILY_CLOSE C
+		USING (
+			SELECT :1 AS JNO, TRUNC(:2) AS RECORD_DATE FROM DUAL
+		) S
+		ON (C.JNO = S.JNO AND C.RECORD_DATE = S.RECORD_DATE)
+		WHEN NOT MATCHED THEN
+			INSERT (JNO, RECORD_DATE, STATUS, REG_DATE)
+			VALUES (S.JNO, S.RECORD_DATE, 'O', SYSDATE)`
+
+	if _, err := tx.ExecContext(ctx, mergeQuery, jno, recordDate); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	dailyClose := entity.DailyClose{}
+	query := `
+		SELECT
+			JNO,
+			RECORD_DATE,
+			STATUS,
+			SUBMIT_UNO,
+			SUBMIT_USER,
+			SUBMIT_DATE,
+			APPROVE_UNO,
+			APPROVE_USER,
+			APPROVE_DATE,
+			LOCK_DATE,
+			REASON
+		FROM IRIS_DAILY_CLOSE
+		WHERE JNO = :1
+		AND RECORD_DATE = TRUNC(:2)
+		FOR UPDATE`
+
+	if err := db.GetContext(ctx, &dailyClose, query, jno, recordDate); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return &dailyClose, nil
+}
+
+// func: 일일 마감 상태 변경
+// @param
+// - dailyClose: 변경할 상태와 요청자, 승인자, 마감일, 반려 사유
+func (r *Repository) ModifyDailyClose(ctx context.Context, tx Execer, dailyClose entity.DailyClose) error {
+	query := `
+		UPDATE IRIS_DAILY_CLOSE
+		SET
+			STATUS = :1,
+			SUBMIT_UNO = :2,
+			SUBMIT_USER = :3,
+			SUBMIT_DATE = :4,
+			APPROVE_UNO = :5,
+			APPROVE_USER = :6,
+			APPROVE_DATE = :7,
+			LOCK_DATE = :8,
+			REASON = :9,
+			MOD_DATE = SYSDATE,
+			MOD_USER = :10,
+			MOD_UNO = :11
+		WHERE JNO = :12
+		AND RECORD_DATE = TRUNC(:13)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		dailyClose.Status, dailyClose.SubmitUno, dailyClose.SubmitUser, dailyClose.SubmitDate,
+		dailyClose.ApproveUno, dailyClose.ApproveUser, dailyClose.ApproveDate, dailyClose.LockDate,
+		dailyClose.Reason, dailyClose.ModUser, dailyClose.ModUno, dailyClose.Jno, dailyClose.RecordDate,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 일일 마감 상태 변경 로그 저장
+// @param
+// - before: 변경 전 상태
+// - after: 변경 후 (STATUS, REASON, MOD_USER, MOD_UNO)
+func (r *Repository) AddDailyCloseLog(ctx context.Context, tx Execer, before string, after entity.DailyClose) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_DAILY_CLOSE_LOG(JNO, RECORD_DATE, BEFORE_STATUS, AFTER_STATUS, REASON, REG_DATE, REG_USER, REG_UNO, REG_AGENT)
+		VALUES(:1, TRUNC(:2), :3, :4, :5, SYSDATE, :6, :7, :8)`
+
+	if _, err := tx.ExecContext(ctx, query, after.Jno, after.RecordDate, before, after.Status, after.Reason, after.ModUser, after.ModUno, agent); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 일일 마감 근로자 마감처리
+// 비교 확정(S)된 미마감 근로자를 마감한다. (철야 승인 대기 중인 기록 제외)
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+// @return
+// - 마감된 근로자 수
+func (r *Repository) ModifyDailyCloseDeadline(ctx context.Context, tx Execer, jno int64, recordDate time.Time, user entity.Base) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			IS_DEADLINE = 'Y',
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :1,
+			MOD_USER = :2,
+			MOD_UNO = :3
+		WHERE JNO = :4
+		AND TRUNC(RECORD_DATE) = TRUNC(:5)
+		AND IS_DEADLINE = 'N'
+		AND COMPARE_STATE = 'S'
+		AND CNO NOT IN (
+			SELECT AFTER_CNO
+			FROM IRIS_WORKER_OVERTIME
+			WHERE STATUS = 'P'
+		)`
+
+	result, err := tx.ExecContext(ctx, query, agent, user.ModUser, user.ModUno, jno, recordDate)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 승인된 일일 마감 조회 (스케줄러)
+// @param
+// -
+func (r *Repository) GetDailyCloseApprovedList(ctx context.Context, db Queryer) (entity.DailyCloses, error) {
+	list := entity.DailyCloses{}
+
+	query := `
+		SELECT JNO, RECORD_DATE, STATUS
+		FROM IRIS_DAILY_CLOSE
+		WHERE STATUS = 'A'
+		ORDER BY RECORD_DATE, JNO`
+
+	if err := db.SelectContext(ctx, &list, query); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 일일 마감 시간 조회 (마감취소 기한 기준)
+// 마감 상태가 아니거나 일일 마감 기록이 없으면 null
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+func (r *Repository) GetDailyCloseLockDate(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (null.Time, error) {
+	var lockDate null.Time
+
+	query := `
+		SELECT LOCK_DATE
+		FROM IRIS_DAILY_CLOSE
+		WHERE JNO = :1
+		AND RECORD_DATE = TRUNC(:2)
+		AND STATUS = 'L'`
+
+	if err := db.GetContext(ctx, &lockDate, query, jno, recordDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return null.Time{}, nil
+		}
+		return null.Time{}, utils.CustomErrorf(err)
+	}
+	return lockDate, nil
+}
+
+// func: 일일 마감 미마감 근로자 수 조회
+// @param
+// - jno: 프로젝트pk
+// - recordDate: 근무일
+func (r *Repository) GetDailyCloseOpenCount(ctx context.Context, db Queryer, jno int64, recordDate time.Time) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM IRIS_WORKER_DAILY_SET
+		WHERE JNO = :1
+		AND TRUNC(RECORD_DATE) = TRUNC(:2)
+		AND IS_DEADLINE = 'N'`
+
+	if err := db.GetContext(ctx, &count, query, jno, recordDate); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
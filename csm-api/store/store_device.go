@@ -7,7 +7,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
+)
+
+// 근태인식기 목록 정렬 가능 필드
+var deviceOrderFields = entity.OrderColumns(
+	"DNO", "SNO", "JNO", "JOB_NAME", "SITE_NM", "DEVICE_SN", "DEVICE_NM", "ETC", "IS_USE", "REG_DATE", "MOD_DATE",
 )
 
 /**
@@ -25,46 +29,24 @@ import (
 func (r *Repository) GetDeviceList(ctx context.Context, db Queryer, page entity.PageSql, search entity.Device, retry string) (*entity.Devices, error) {
 	list := entity.Devices{}
 
+	where := utils.NewWhereBuilder()
 	condition := "AND 1=1"
-	if search.DeviceNm.Valid {
-		trimDeviceNm := strings.TrimSpace(search.DeviceNm.String)
-
-		if trimDeviceNm != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.DEVICE_NM) LIKE LOWER('%%%s%%')`, trimDeviceNm)
-		}
-	}
-	if search.DeviceSn.Valid {
-		trimDeviceSn := strings.TrimSpace(search.DeviceSn.String)
-
-		if trimDeviceSn != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.DEVICE_SN) LIKE LOWER('%%%s%%')`, trimDeviceSn)
-		}
-	}
-	if search.SiteNm.Valid {
-		trimSiteNm := strings.TrimSpace(search.SiteNm.String)
-
-		if trimSiteNm != "" {
-			condition += fmt.Sprintf(` AND LOWER(t2.SITE_NM) LIKE LOWER('%%%s%%')`, trimSiteNm)
-		}
-	}
-	if search.Etc.Valid {
-		trimEtc := strings.TrimSpace(search.Etc.String)
-
-		if trimEtc != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.ETC) LIKE LOWER('%%%s%%')`, trimEtc)
-		}
-	}
+	condition = where.StringWhereConvert(condition, search.DeviceNm.NullString, "t1.DEVICE_NM")
+	condition = where.StringWhereConvert(condition, search.DeviceSn.NullString, "t1.DEVICE_SN")
+	condition = where.StringWhereConvert(condition, search.SiteNm.NullString, "t2.SITE_NM")
+	condition = where.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
 
 	var columns []string
 	columns = append(columns, "t2.SITE_NM")
 	columns = append(columns, "t1.DEVICE_SN")
 	columns = append(columns, "t1.DEVICE_NM")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(deviceOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "NULL"
 	}
 
@@ -97,11 +79,11 @@ func (r *Repository) GetDeviceList(ctx context.Context, db Queryer, page entity.
 							%s %s
 						ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :1
+					WHERE ROWNUM <= %s
 				)
-				WHERE RNUM > :2`, condition, retryCondition, order)
+				WHERE RNUM > %s`, condition, retryCondition, order, where.Bind(page.EndNum), where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -114,41 +96,18 @@ func (r *Repository) GetDeviceList(ctx context.Context, db Queryer, page entity.
 func (r *Repository) GetDeviceListCount(ctx context.Context, db Queryer, search entity.Device, retry string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder()
 	condition := "AND 1=1"
-	if search.DeviceNm.Valid {
-		trimDeviceNm := strings.TrimSpace(search.DeviceNm.String)
-
-		if trimDeviceNm != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.DEVICE_NM) LIKE LOWER('%%%s%%')`, trimDeviceNm)
-		}
-	}
-	if search.DeviceSn.Valid {
-		trimDeviceSn := strings.TrimSpace(search.DeviceSn.String)
-
-		if trimDeviceSn != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.DEVICE_SN) LIKE LOWER('%%%s%%')`, trimDeviceSn)
-		}
-	}
-	if search.SiteNm.Valid {
-		trimSiteNm := strings.TrimSpace(search.SiteNm.String)
-
-		if trimSiteNm != "" {
-			condition += fmt.Sprintf(` AND LOWER(t2.SITE_NM) LIKE LOWER('%%%s%%')`, trimSiteNm)
-		}
-	}
-	if search.Etc.Valid {
-		trimEtc := strings.TrimSpace(search.Etc.String)
-
-		if trimEtc != "" {
-			condition += fmt.Sprintf(` AND LOWER(t1.ETC) LIKE LOWER('%%%s%%')`, trimEtc)
-		}
-	}
+	condition = where.StringWhereConvert(condition, search.DeviceNm.NullString, "t1.DEVICE_NM")
+	condition = where.StringWhereConvert(condition, search.DeviceSn.NullString, "t1.DEVICE_SN")
+	condition = where.StringWhereConvert(condition, search.SiteNm.NullString, "t2.SITE_NM")
+	condition = where.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
 
 	var columns []string
 	columns = append(columns, "t2.SITE_NM")
 	columns = append(columns, "t1.DEVICE_SN")
 	columns = append(columns, "t1.DEVICE_NM")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 				SELECT 
@@ -164,7 +123,7 @@ func (r *Repository) GetDeviceListCount(ctx context.Context, db Queryer, search
 				  	AND t1.SNO >= 100
 					%s %s`, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -291,3 +250,64 @@ func (r *Repository) GetCheckRegistered(ctx context.Context, db Queryer, deviceN
 	return count, nil
 
 }
+
+// func: 근태인식기 조회 (시리얼번호)
+// 사용중인 장치만 조회하며, 없으면 빈 값(DNO 없음)을 반환한다.
+// @param
+// - deviceSn: 근태인식기 시리얼번호
+func (r *Repository) GetDeviceBySn(ctx context.Context, db Queryer, deviceSn string) (entity.Device, error) {
+	device := entity.Device{}
+
+	query := `
+			SELECT 
+				DNO,
+				SNO,
+				JNO,
+				DEVICE_SN,
+				DEVICE_NM,
+				IS_USE,
+				API_KEY_HASH
+			FROM
+			    IRIS_DEVICE_SET
+			WHERE
+			    IS_USE = 'Y'
+				AND DEVICE_SN = :1`
+
+	if err := db.GetContext(ctx, &device, query, deviceSn); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return device, nil
+		}
+		return device, utils.CustomErrorf(err)
+	}
+
+	return device, nil
+}
+
+// func: 근태인식기 api key 변경
+// @param
+// - device entity.Device: DNO, API_KEY_HASH, MOD_USER, MOD_UNO
+func (r *Repository) ModifyDeviceApiKey(ctx context.Context, tx Execer, device entity.Device) error {
+	agent := utils.GetAgent()
+
+	query := `
+			UPDATE IRIS_DEVICE_SET
+			SET 
+				API_KEY_HASH = :1,
+				MOD_DATE = SYSDATE,
+				MOD_AGENT = :2,
+				MOD_USER = :3,
+				MOD_UNO = :4
+			WHERE
+			    DNO = :5
+				AND IS_USE = 'Y'`
+
+	result, err := tx.ExecContext(ctx, query, device.ApiKeyHash, agent, device.ModUser, device.ModUno, device.Dno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return utils.CustomErrorf(fmt.Errorf("device not found: dno %d", device.Dno.Int64))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+)
+
+// func: 로그인 기록 추가
+// @param
+// - loginLog: 로그인 아이디, 협력업체 여부, IP, 결과, 사유
+func (r *Repository) AddLoginLog(ctx context.Context, tx Execer, loginLog entity.LoginLog) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_LOGIN_LOG(USER_ID, IS_COMPANY, IP, RESULT, REASON, REG_DATE, REG_AGENT)
+		VALUES (:1, :2, :3, :4, :5, SYSDATE, :6)`
+
+	if _, err := tx.ExecContext(ctx, query, loginLog.UserId, loginLog.IsCompany, loginLog.Ip, loginLog.Result, loginLog.Reason, agent); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 계정별 최근 로그인 실패 횟수 조회 (마지막 성공 이후, 최근 minutes분 이내)
+// @param
+// - userId: 로그인 아이디
+// - isCompany: 협력업체 여부(Y/N)
+// - minutes: 조회 기간(분)
+func (r *Repository) GetLoginFailCount(ctx context.Context, db Queryer, userId string, isCompany string, minutes int) (entity.LoginFailCount, error) {
+	count := entity.LoginFailCount{}
+
+	query := `
+		SELECT
+			COUNT(*) AS FAIL_COUNT,
+			MAX(L.REG_DATE) AS LAST_FAIL_DATE
+		FROM IRIS_LOGIN_LOG L
+		WHERE L.USER_ID = :1
+		AND L.IS_COMPANY = :2
+		AND L.RESULT = 'F'
+		AND L.REG_DATE > SYSDATE - :3 / 1440
+		AND L.REG_DATE > NVL((
+			SELECT MAX(S.REG_DATE)
+			FROM IRIS_LOGIN_LOG S
+			WHERE S.USER_ID = :4
+			AND S.IS_COMPANY = :5
+			AND S.RESULT = 'S'
+		), L.REG_DATE - 1)`
+
+	if err := db.GetContext(ctx, &count, query, userId, isCompany, minutes, userId, isCompany); err != nil {
+		return count, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
+
+// func: IP별 최근 로그인 실패 횟수 조회 (최근 minutes분 이내)
+// @param
+// - ip: 요청 IP
+// - minutes: 조회 기간(분)
+func (r *Repository) GetLoginFailCountByIp(ctx context.Context, db Queryer, ip string, minutes int) (entity.LoginFailCount, error) {
+	count := entity.LoginFailCount{}
+
+	query := `
+		SELECT
+			COUNT(*) AS FAIL_COUNT,
+			MAX(REG_DATE) AS LAST_FAIL_DATE
+		FROM IRIS_LOGIN_LOG
+		WHERE IP = :1
+		AND RESULT = 'F'
+		AND REG_DATE > SYSDATE - :2 / 1440`
+
+	if err := db.GetContext(ctx, &count, query, ip, minutes); err != nil {
+		return count, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
@@ -11,6 +11,12 @@ import (
 	"strings"
 )
 
+// 공지사항 목록 정렬 가능 필드
+var noticeOrderFields = entity.OrderColumns(
+	"IDX", "JNO", "JOB_NAME", "JOB_LOC_NAME", "TITLE", "SHOW_YN", "REG_USER", "REG_DATE", "DUTY_NAME", "USER_INFO",
+	"MOD_USER", "MOD_DATE", "POSTING_START_DATE", "POSTING_END_DATE", "IS_IMPORTANT",
+)
+
 /**
  * @author 작성자: 정지영
  * @created 작성일: 2025-02-12
@@ -35,17 +41,19 @@ func (r *Repository) GetNoticeList(ctx context.Context, db Queryer, uno string,
 	}
 
 	// 조건
+	where := utils.NewWhereBuilder()
 	condition := "1=1"
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "JNO")
-	condition = utils.StringWhereConvert(condition, search.JobLocName.NullString, "JOB_LOC_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.Title.NullString, "TITLE")
-	condition = utils.StringWhereConvert(condition, search.UserInfo.NullString, "USER_INFO")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "JNO")
+	condition = where.StringWhereConvert(condition, search.JobLocName.NullString, "JOB_LOC_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.Title.NullString, "TITLE")
+	condition = where.StringWhereConvert(condition, search.UserInfo.NullString, "USER_INFO")
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(noticeOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "NULL"
 	}
 
@@ -111,12 +119,12 @@ func (r *Repository) GetNoticeList(ctx context.Context, db Queryer, uno string,
 							END,
 							POSTING_START_DATE DESC
 						) sorted_data
-					WHERE ROWNUM <= :1
+					WHERE ROWNUM <= %s
 			  	)
-			  	WHERE RNUM > :2`,
-		roleCondition, condition, order)
+			  	WHERE RNUM > %s`,
+		roleCondition, condition, order, where.Bind(page.EndNum), where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &notices, query, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &notices, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 	return &notices, nil
@@ -136,12 +144,13 @@ func (r *Repository) GetNoticeListCount(ctx context.Context, db Queryer, uno str
 		roleCondition = fmt.Sprintf("AND (M.UNO = %s OR S.ID = %s)", uno, uno)
 	}
 
+	where := utils.NewWhereBuilder()
 	condition := "1=1"
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "JNO")
-	condition = utils.StringWhereConvert(condition, search.JobLocName.NullString, "JOB_LOC_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.Title.NullString, "TITLE")
-	condition = utils.StringWhereConvert(condition, search.UserInfo.NullString, "USER_INFO")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "JNO")
+	condition = where.StringWhereConvert(condition, search.JobLocName.NullString, "JOB_LOC_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.Title.NullString, "TITLE")
+	condition = where.StringWhereConvert(condition, search.UserInfo.NullString, "USER_INFO")
 
 	query := fmt.Sprintf(`
 			WITH USER_IN_JNO AS (
@@ -188,7 +197,7 @@ func (r *Repository) GetNoticeListCount(ctx context.Context, db Queryer, uno str
 			WHERE
 				%s`, roleCondition, condition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
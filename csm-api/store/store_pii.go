@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+)
+
+// func: 개인정보 접근 기록 저장
+// @param
+// - accessLog: 접근자(UNO, USER_NAME, ROLE), 구분, 대상, 마스킹하지 않은 항목, 건수, 사유
+func (r *Repository) AddPiiAccessLog(ctx context.Context, tx Execer, accessLog entity.PiiAccessLog) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_PII_ACCESS_LOG(
+			LNO, UNO, USER_NAME, ROLE, ACCESS_TYPE, TARGET, USER_KEY, FIELDS, ROW_COUNT, REASON, REG_DATE, REG_AGENT
+		) VALUES (
+			SEQ_IRIS_PII_ACCESS_LOG.NEXTVAL, :1, :2, :3, :4, :5, :6, :7, :8, :9, SYSDATE, :10
+		)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		accessLog.Uno, accessLog.UserName, accessLog.Role, accessLog.AccessType, accessLog.Target,
+		accessLog.UserKey, accessLog.Fields, accessLog.RowCount, accessLog.Reason, agent,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 사용자별 개인정보 접근 현황 조회
+// @param
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+func (r *Repository) GetPiiAccessReport(ctx context.Context, db Queryer, startDate string, endDate string) (entity.PiiAccessReports, error) {
+	list := entity.PiiAccessReports{}
+
+	query := `
+		SELECT
+			L.UNO,
+			MAX(L.USER_NAME) KEEP (DENSE_RANK LAST ORDER BY L.REG_DATE) AS USER_NAME,
+			MAX(L.ROLE) KEEP (DENSE_RANK LAST ORDER BY L.REG_DATE) AS ROLE,
+			SUM(CASE WHEN L.ACCESS_TYPE = 'L' THEN 1 ELSE 0 END) AS LIST_COUNT,
+			SUM(CASE WHEN L.ACCESS_TYPE = 'L' THEN L.ROW_COUNT ELSE 0 END) AS LIST_ROWS,
+			SUM(CASE WHEN L.ACCESS_TYPE = 'R' THEN 1 ELSE 0 END) AS REVEAL_COUNT,
+			COUNT(DISTINCT CASE WHEN L.ACCESS_TYPE = 'R' THEN NVL(M.USER_KEY, L.USER_KEY) END) AS REVEAL_USERS,
+			MIN(L.REG_DATE) AS FIRST_DATE,
+			MAX(L.REG_DATE) AS LAST_DATE
+		FROM IRIS_PII_ACCESS_LOG L
+		LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = L.USER_KEY AND M.STATUS = 'M'
+		WHERE L.REG_DATE >= TO_DATE(:1, 'YYYY-MM-DD')
+		AND L.REG_DATE < TO_DATE(:2, 'YYYY-MM-DD') + 1
+		GROUP BY L.UNO
+		ORDER BY REVEAL_COUNT DESC, LIST_ROWS DESC, L.UNO`
+
+	if err := db.SelectContext(ctx, &list, query, startDate, endDate); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 개인정보 접근 기록 조회
+// @param
+// - startDate, endDate: 조회 기간 (YYYY-MM-DD)
+// - uno: 접근자 (없으면 전체)
+// - accessType: 구분 (L: 목록 조회, R: 단건 조회, 없으면 전체)
+func (r *Repository) GetPiiAccessLogList(ctx context.Context, db Queryer, startDate string, endDate string, uno null.Int, accessType string) (entity.PiiAccessLogs, error) {
+	list := entity.PiiAccessLogs{}
+
+	query := `
+		SELECT
+			L.LNO, L.UNO, L.USER_NAME, L.ROLE, L.ACCESS_TYPE, L.TARGET, NVL(M.USER_KEY, L.USER_KEY) AS USER_KEY, L.FIELDS, L.ROW_COUNT, L.REASON, L.REG_DATE, L.REG_AGENT
+		FROM IRIS_PII_ACCESS_LOG L
+		LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = L.USER_KEY AND M.STATUS = 'M'
+		WHERE L.REG_DATE >= TO_DATE(:1, 'YYYY-MM-DD')
+		AND L.REG_DATE < TO_DATE(:2, 'YYYY-MM-DD') + 1
+		AND (:3 IS NULL OR L.UNO = :4)
+		AND (:5 IS NULL OR L.ACCESS_TYPE = :6)
+		ORDER BY L.REG_DATE DESC, L.LNO DESC`
+
+	accessTypeBind := utils.ParseNullString(accessType)
+	if err := db.SelectContext(ctx, &list, query, startDate, endDate, uno, uno, accessTypeBind, accessTypeBind); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 근로자 개인정보 조회 (단건 조회용)
+// 주민번호는 암호문(REG_NO_ENC)으로 조회하고 서비스에서 복호화한다.
+// @param
+// - userKey: 근로자
+func (r *Repository) GetWorkerPii(ctx context.Context, db Queryer, userKey string) (*entity.Worker, error) {
+	worker := entity.Worker{}
+
+	query := `
+		SELECT
+			USER_KEY,
+			SNO,
+			USER_NM,
+			PHONE,
+			REG_NO_ENC
+		FROM IRIS_WORKER_SET
+		WHERE USER_KEY = :1
+		AND IS_DEL = 'N'`
+
+	if err := db.GetContext(ctx, &worker, query, userKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &worker, nil
+}
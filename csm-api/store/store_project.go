@@ -11,6 +11,17 @@ import (
 	"time"
 )
 
+// 프로젝트 목록 정렬 가능 필드
+var jobInfoOrderFields = entity.OrderColumns(
+	"JNO", "SNO", "JOB_NAME", "JOB_NO", "JOB_SD", "JOB_ED", "COMP_NAME", "ORDER_COMP_NAME", "JOB_PM_NAME", "CD_NM",
+)
+
+// 미사용 프로젝트 목록 정렬 가능 필드
+var nonUsedProjectOrderFields = entity.OrderColumns(
+	"JNO", "JOB_NO", "JOB_NAME", "JOB_YEAR", "JOB_SD", "JOB_ED", "COMP_NAME", "ORDER_COMP_NAME", "JOB_PM_NM",
+	"DUTY_NAME", "CD_NM",
+)
+
 // func: 현장 프로젝트 조회
 // @param
 // - sno int64 현장 번호, targetDate time.Time: 현재시간
@@ -391,26 +402,28 @@ func (r *Repository) GetProjectNmList(ctx context.Context, db Queryer, isRole bo
 func (r *Repository) GetUsedProjectList(ctx context.Context, db Queryer, pageSql entity.PageSql, search entity.JobInfo, retry string, includeJno string, snoString string) (*entity.JobInfos, error) {
 	list := entity.JobInfos{}
 
+	where := utils.NewWhereBuilder()
 	condition := ""
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t2.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.CompName.NullString, "t2.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.OrderCompName.NullString, "t2.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobPmName.NullString, "t2.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t2.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t2.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.CdNm.NullString, "t5.CD_NM")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t2.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.CompName.NullString, "t2.COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.OrderCompName.NullString, "t2.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.JobPmName.NullString, "t2.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t2.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t2.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.CdNm.NullString, "t5.CD_NM")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t2.JOB_NO")
 	columns = append(columns, "t2.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if pageSql.Order.Valid {
-		order = pageSql.Order.String
-	} else {
+	order, err := pageSql.OrderBy(jobInfoOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "JNO DESC, JOB_NO ASC"
 	}
 
@@ -458,11 +471,11 @@ func (r *Repository) GetUsedProjectList(ctx context.Context, db Queryer, pageSql
 						%s %s %s %s
 						ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :1
+					WHERE ROWNUM <= %s
 				)
-				WHERE RNUM > :2`, jnoCondition, snoCondition, condition, retryCondition, order)
+				WHERE RNUM > %s`, jnoCondition, snoCondition, condition, retryCondition, order, where.Bind(pageSql.EndNum), where.Bind(pageSql.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, pageSql.EndNum, pageSql.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -475,21 +488,22 @@ func (r *Repository) GetUsedProjectList(ctx context.Context, db Queryer, pageSql
 func (r *Repository) GetUsedProjectCount(ctx context.Context, db Queryer, search entity.JobInfo, retry string, includeJno string, snoString string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder()
 	condition := ""
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t2.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.CompName.NullString, "t2.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.OrderCompName.NullString, "t2.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobPmName.NullString, "t2.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t2.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t2.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.CdNm.NullString, "t5.CD_NM")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t2.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.CompName.NullString, "t2.COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.OrderCompName.NullString, "t2.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.JobPmName.NullString, "t2.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t2.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t2.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.CdNm.NullString, "t5.CD_NM")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t2.JOB_NO")
 	columns = append(columns, "t2.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	var jnoCondition string
 	if includeJno != "undefined" && includeJno != "" {
@@ -521,7 +535,7 @@ func (r *Repository) GetUsedProjectCount(ctx context.Context, db Queryer, search
 				AND t1.IS_USE = 'Y'
 				%s %s %s %s`, jnoCondition, snoCondition, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -537,26 +551,28 @@ func (r *Repository) GetUsedProjectCount(ctx context.Context, db Queryer, search
 func (r *Repository) GetAllProjectList(ctx context.Context, db Queryer, pageSql entity.PageSql, search entity.JobInfo, isAll int, retry string) (*entity.JobInfos, error) {
 	list := entity.JobInfos{}
 
+	where := utils.NewWhereBuilder(isAll)
 	condition := "1 = 1"
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "J.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.CompName.NullString, "J.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.OrderCompName.NullString, "J.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "J.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobPmName.NullString, "J.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "J.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "J.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.CdNm.NullString, "SC.CD_NM")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "J.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.CompName.NullString, "J.COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.OrderCompName.NullString, "J.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "J.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.JobPmName.NullString, "J.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "J.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "J.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.CdNm.NullString, "SC.CD_NM")
 
 	var columns []string
 	columns = append(columns, "J.JNO")
 	columns = append(columns, "J.JOB_NO")
 	columns = append(columns, "J.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if pageSql.Order.Valid {
-		order = pageSql.Order.String
-	} else {
+	order, err := pageSql.OrderBy(jobInfoOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "JNO DESC"
 	}
 
@@ -609,11 +625,11 @@ func (r *Repository) GetAllProjectList(ctx context.Context, db Queryer, pageSql
 						WHERE %s %s
 						ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :2
+					WHERE ROWNUM <= %s
 				)
-				WHERE RNUM > :3`, condition, retryCondition, order)
+				WHERE RNUM > %s`, condition, retryCondition, order, where.Bind(pageSql.EndNum), where.Bind(pageSql.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, isAll, pageSql.EndNum, pageSql.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -626,21 +642,22 @@ func (r *Repository) GetAllProjectList(ctx context.Context, db Queryer, pageSql
 func (r *Repository) GetAllProjectCount(ctx context.Context, db Queryer, search entity.JobInfo, retry string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder()
 	condition := "1 = 1"
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "J.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.CompName.NullString, "J.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.OrderCompName.NullString, "J.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "J.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobPmName.NullString, "J.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "J.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "J.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.CdNm.NullString, "SC.CD_NM")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "J.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.CompName.NullString, "J.COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.OrderCompName.NullString, "J.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "J.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.JobPmName.NullString, "J.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "J.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "J.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.CdNm.NullString, "SC.CD_NM")
 
 	var columns []string
 	columns = append(columns, "J.JNO")
 	columns = append(columns, "J.JOB_NO")
 	columns = append(columns, "J.JOB_PM_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 				SELECT 
@@ -658,7 +675,7 @@ func (r *Repository) GetAllProjectCount(ctx context.Context, db Queryer, search
 					AND SC.MAJOR_CD = 'JOB_STATE' 
 				WHERE %s %s`, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
 
@@ -672,26 +689,28 @@ func (r *Repository) GetStaffProjectList(ctx context.Context, db Queryer, pageSq
 
 	list := entity.JobInfos{}
 
+	where := utils.NewWhereBuilder(uno)
 	condition := "1=1"
-	condition = utils.StringWhereConvert(condition, searchSql.JobNo.NullString, "J.JOB_NO")
-	condition = utils.StringWhereConvert(condition, searchSql.CompName.NullString, "J.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.OrderCompName.NullString, "J.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobName.NullString, "J.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobPmName.NullString, "J.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobSd.NullString, "J.JOB_SD")
-	condition = utils.StringWhereConvert(condition, searchSql.JobEd.NullString, "J.JOB_ED")
-	condition = utils.StringWhereConvert(condition, searchSql.CdNm.NullString, "SC.CD_NM")
+	condition = where.StringWhereConvert(condition, searchSql.JobNo.NullString, "J.JOB_NO")
+	condition = where.StringWhereConvert(condition, searchSql.CompName.NullString, "J.COMP_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.OrderCompName.NullString, "J.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobName.NullString, "J.JOB_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobPmName.NullString, "J.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobSd.NullString, "J.JOB_SD")
+	condition = where.StringWhereConvert(condition, searchSql.JobEd.NullString, "J.JOB_ED")
+	condition = where.StringWhereConvert(condition, searchSql.CdNm.NullString, "SC.CD_NM")
 
 	var columns []string
 	columns = append(columns, "J.JNO")
 	columns = append(columns, "J.JOB_NO")
 	columns = append(columns, "J.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if pageSql.Order.Valid {
-		order = pageSql.Order.String
-	} else {
+	order, err := pageSql.OrderBy(jobInfoOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "JNO DESC"
 	}
 
@@ -729,11 +748,11 @@ func (r *Repository) GetStaffProjectList(ctx context.Context, db Queryer, pageSq
 					WHERE %s %s
 					ORDER BY %s
 					) sorted_data
-				WHERE ROWNUM <= :2
+				WHERE ROWNUM <= %s
 			) 
-			WHERE RNUM > :3`, condition, retryCondition, order)
+			WHERE RNUM > %s`, condition, retryCondition, order, where.Bind(pageSql.EndNum), where.Bind(pageSql.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, uno, pageSql.EndNum, pageSql.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -746,21 +765,22 @@ func (r *Repository) GetStaffProjectList(ctx context.Context, db Queryer, pageSq
 func (r *Repository) GetStaffProjectCount(ctx context.Context, db Queryer, searchSql entity.JobInfo, uno sql.NullInt64, retry string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder(uno)
 	condition := "1=1"
-	condition = utils.StringWhereConvert(condition, searchSql.JobNo.NullString, "J.JOB_NO")
-	condition = utils.StringWhereConvert(condition, searchSql.CompName.NullString, "J.COMP_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.OrderCompName.NullString, "J.ORDER_COMP_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobName.NullString, "J.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobPmName.NullString, "J.JOB_PM_NAME")
-	condition = utils.StringWhereConvert(condition, searchSql.JobSd.NullString, "J.JOB_SD")
-	condition = utils.StringWhereConvert(condition, searchSql.JobEd.NullString, "J.JOB_ED")
-	condition = utils.StringWhereConvert(condition, searchSql.CdNm.NullString, "SC.CD_NM")
+	condition = where.StringWhereConvert(condition, searchSql.JobNo.NullString, "J.JOB_NO")
+	condition = where.StringWhereConvert(condition, searchSql.CompName.NullString, "J.COMP_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.OrderCompName.NullString, "J.ORDER_COMP_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobName.NullString, "J.JOB_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobPmName.NullString, "J.JOB_PM_NAME")
+	condition = where.StringWhereConvert(condition, searchSql.JobSd.NullString, "J.JOB_SD")
+	condition = where.StringWhereConvert(condition, searchSql.JobEd.NullString, "J.JOB_ED")
+	condition = where.StringWhereConvert(condition, searchSql.CdNm.NullString, "SC.CD_NM")
 
 	var columns []string
 	columns = append(columns, "J.JNO")
 	columns = append(columns, "J.JOB_NO")
 	columns = append(columns, "J.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 				SELECT 
@@ -777,7 +797,7 @@ func (r *Repository) GetStaffProjectCount(ctx context.Context, db Queryer, searc
 					AND SC.MAJOR_CD = 'JOB_STATE'
 				WHERE %s %s`, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query, uno); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
 
@@ -817,26 +837,28 @@ func (r *Repository) GetProjectNmUnoList(ctx context.Context, db Queryer, uno sq
 func (r *Repository) GetNonUsedProjectList(ctx context.Context, db Queryer, page entity.PageSql, search entity.NonUsedProject, retry string) (*entity.NonUsedProjects, error) {
 	nonProjects := entity.NonUsedProjects{}
 
+	where := utils.NewWhereBuilder()
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
-	condition = utils.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
+	condition = where.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t1.JOB_NO")
 	columns = append(columns, "t1.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(nonUsedProjectOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = `JNO DESC,
 				CASE 
 					WHEN t1.REG_DATE IS NULL THEN t1.MOD_DATE 
@@ -874,11 +896,11 @@ func (r *Repository) GetNonUsedProjectList(ctx context.Context, db Queryer, page
 										%s %s
 										ORDER BY %s 
 									) sorted_data
-									WHERE ROWNUM <= :1
+									WHERE ROWNUM <= %s
 								)
-								WHERE RNUM > :2`, condition, retryCondition, order)
+								WHERE RNUM > %s`, condition, retryCondition, order, where.Bind(page.EndNum), where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &nonProjects, query, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &nonProjects, query, where.Args()...); err != nil {
 		return &nonProjects, utils.CustomErrorf(err)
 	}
 
@@ -891,21 +913,22 @@ func (r *Repository) GetNonUsedProjectList(ctx context.Context, db Queryer, page
 func (r *Repository) GetNonUsedProjectCount(ctx context.Context, db Queryer, search entity.NonUsedProject, retry string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder()
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
-	condition = utils.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
+	condition = where.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t1.JOB_NO")
 	columns = append(columns, "t1.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 								SELECT 
@@ -920,7 +943,7 @@ func (r *Repository) GetNonUsedProjectCount(ctx context.Context, db Queryer, sea
 								)
 								%s %s`, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -936,26 +959,29 @@ func (r *Repository) GetNonUsedProjectCount(ctx context.Context, db Queryer, sea
 func (r *Repository) GetNonUsedProjectListByType(ctx context.Context, db Queryer, page entity.PageSql, search entity.NonUsedProject, retry string, typeString string) (*entity.NonUsedProjects, error) {
 	nonProjects := entity.NonUsedProjects{}
 
+	// JOB_NO 두번째 구간에 typeString 문자가 포함된 프로젝트 (정규식은 바인드 변수로 전달)
+	where := utils.NewWhereBuilder(fmt.Sprintf(`^[^-]+-[^ -]*[%s][^ -]*-[^-]+-[^-]+$`, typeString))
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
-	condition = utils.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
+	condition = where.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t1.JOB_NO")
 	columns = append(columns, "t1.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(nonUsedProjectOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = `JNO DESC,
 				CASE 
 					WHEN t1.REG_DATE IS NULL THEN t1.MOD_DATE 
@@ -989,15 +1015,15 @@ func (r *Repository) GetNonUsedProjectListByType(ctx context.Context, db Queryer
 											SELECT JNO
 											FROM IRIS_SITE_JOB
 											WHERE IS_USE = 'Y'
-										) AND REGEXP_LIKE(job_no, '^[^-]+-[^ -]*[%s][^ -]*-[^-]+-[^-]+$')
+										) AND REGEXP_LIKE(job_no, :1)
 										%s %s
 										ORDER BY %s 
 									) sorted_data
-									WHERE ROWNUM <= :1
+									WHERE ROWNUM <= %s
 								)
-								WHERE RNUM > :2`, typeString, condition, retryCondition, order)
+								WHERE RNUM > %s`, condition, retryCondition, order, where.Bind(page.EndNum), where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &nonProjects, query, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &nonProjects, query, where.Args()...); err != nil {
 		return &nonProjects, utils.CustomErrorf(err)
 	}
 
@@ -1010,21 +1036,23 @@ func (r *Repository) GetNonUsedProjectListByType(ctx context.Context, db Queryer
 func (r *Repository) GetNonUsedProjectCountByType(ctx context.Context, db Queryer, search entity.NonUsedProject, retry string, typeString string) (int, error) {
 	var count int
 
+	// JOB_NO 두번째 구간에 typeString 문자가 포함된 프로젝트 (정규식은 바인드 변수로 전달)
+	where := utils.NewWhereBuilder(fmt.Sprintf(`^[^-]+-[^ -]*[%s][^ -]*-[^-]+-[^-]+$`, typeString))
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
-	condition = utils.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
-	condition = utils.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
-	condition = utils.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
-	condition = utils.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
-	condition = utils.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
+	condition = where.Int64WhereConvert(condition, search.Jno.NullInt64, "t1.JNO")
+	condition = where.StringWhereConvert(condition, search.JobNo.NullString, "t1.JOB_NO")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t1.JOB_NAME")
+	condition = where.Int64WhereConvert(condition, search.JobYear.NullInt64, "t1.JOB_YEAR")
+	condition = where.StringWhereConvert(condition, search.JobSd.NullString, "t1.JOB_SD")
+	condition = where.StringWhereConvert(condition, search.JobEd.NullString, "t1.JOB_ED")
+	condition = where.StringWhereConvert(condition, search.JobPmNm.NullString, "t2.USER_NAME")
 
 	var columns []string
 	columns = append(columns, "t1.JNO")
 	columns = append(columns, "t1.JOB_NO")
 	columns = append(columns, "t1.JOB_NAME")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 								SELECT 
@@ -1036,10 +1064,10 @@ func (r *Repository) GetNonUsedProjectCountByType(ctx context.Context, db Querye
 									SELECT JNO
 									FROM IRIS_SITE_JOB
 									WHERE IS_USE = 'Y'
-								) AND REGEXP_LIKE(job_no, '^[^-]+-[^ -]*[%s][^ -]*-[^-]+-[^-]+$')
-								%s %s`, typeString, condition, retryCondition)
+								) AND REGEXP_LIKE(job_no, :1)
+								%s %s`, condition, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"csm-api/entity"
 	"csm-api/utils"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/guregu/null"
 )
 
 // func: 프로젝트에 설정된 공수 조회
@@ -115,8 +118,14 @@ func (r *Repository) MergeProjectSetting(ctx context.Context, tx Execer, project
 						:3 AS OUT_TIME,
 						:4 AS RESPITE_TIME,
 						:5 AS CANCEL_CODE,
-						:6 AS UNO,	
-						:7 AS USER_NAME
+						:6 AS SCAN_POLICY,
+						:7 AS MIN_SCAN_GAP,
+						:8 AS WEEKEND_RATE,
+						:9 AS HOLIDAY_RATE,
+						:10 AS HALF_DAY_HOUR,
+						NVL(:11, 'Y') AS AUTO_CLOSE,
+						:12 AS UNO,	
+						:13 AS USER_NAME
 					FROM DUAL
 				) J2
 				ON (
@@ -127,22 +136,34 @@ func (r *Repository) MergeProjectSetting(ctx context.Context, tx Execer, project
 						J1.OUT_TIME = J2.OUT_TIME,
 						J1.RESPITE_TIME = J2.RESPITE_TIME,
 						J1.CANCEL_CODE = J2.CANCEL_CODE,
+						J1.SCAN_POLICY = J2.SCAN_POLICY,
+						J1.MIN_SCAN_GAP = J2.MIN_SCAN_GAP,
+						J1.WEEKEND_RATE = J2.WEEKEND_RATE,
+						J1.HOLIDAY_RATE = J2.HOLIDAY_RATE,
+						J1.HALF_DAY_HOUR = J2.HALF_DAY_HOUR,
+						J1.AUTO_CLOSE = J2.AUTO_CLOSE,
 						J1.MOD_UNO = J2.UNO,	
 						J1.MOD_USER = J2.USER_NAME,
 						J1.MOD_DATE = SYSDATE
 				WHEN NOT MATCHED THEN
-					INSERT ( JNO, IN_TIME, OUT_TIME, RESPITE_TIME, CANCEL_CODE, REG_UNO, REG_USER, REG_DATE )
+					INSERT ( JNO, IN_TIME, OUT_TIME, RESPITE_TIME, CANCEL_CODE, SCAN_POLICY, MIN_SCAN_GAP, WEEKEND_RATE, HOLIDAY_RATE, HALF_DAY_HOUR, AUTO_CLOSE, REG_UNO, REG_USER, REG_DATE )
 					VALUES (
 						J2.JNO,
 						J2.IN_TIME,
 						J2.OUT_TIME,
 						J2.RESPITE_TIME,
 						J2.CANCEL_CODE,
+						J2.SCAN_POLICY,
+						J2.MIN_SCAN_GAP,
+						J2.WEEKEND_RATE,
+						J2.HOLIDAY_RATE,
+						J2.HALF_DAY_HOUR,
+						J2.AUTO_CLOSE,
 						J2.UNO,	
 						J2.USER_NAME,
 						SYSDATE		
 			)`
-	result, err := tx.ExecContext(ctx, query, project.Jno, project.InTime, project.OutTime, project.RespiteTime, project.CancelCode, project.RegUno, project.RegUser)
+	result, err := tx.ExecContext(ctx, query, project.Jno, project.InTime, project.OutTime, project.RespiteTime, project.CancelCode, project.ScanPolicy, project.MinScanGap, project.WeekendRate, project.HolidayRate, project.HalfDayHour, project.AutoClose, project.RegUno, project.RegUser)
 	if err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
@@ -223,6 +244,12 @@ func (r *Repository) GetProjectSetting(ctx context.Context, db Queryer, isRole b
 				J.RESPITE_TIME,
 				J.CANCEL_CODE,
 				C.UDF_VAL_03 AS CANCEL_DAY,
+				J.SCAN_POLICY,
+				J.MIN_SCAN_GAP,
+				J.WEEKEND_RATE,
+				J.HOLIDAY_RATE,
+				J.HALF_DAY_HOUR,
+				NVL(J.AUTO_CLOSE, 'Y') AS AUTO_CLOSE,
 				J.REG_DATE,
 				J.REG_UNO,
 				J.REG_USER,
@@ -294,3 +321,180 @@ func (r *Repository) ManHourLog(ctx context.Context, tx Execer, manhour entity.M
 
 	return nil
 }
+
+// func: 프로젝트 마감취소 가능 기한 조회 (마감취소 가능기한 CODE의 일수)
+// 설정이 없으면 null
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetProjectCancelDay(ctx context.Context, db Queryer, jno int64) (null.Int, error) {
+	var cancelDay null.Int
+
+	query := `
+			SELECT C.UDF_VAL_03 AS CANCEL_DAY
+			FROM IRIS_JOB_SET J
+			INNER JOIN IRIS_CODE_SET C ON J.CANCEL_CODE = C.CODE
+			WHERE J.JNO = :1`
+
+	if err := db.GetContext(ctx, &cancelDay, query, jno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return null.Int{}, nil
+		}
+		return null.Int{}, utils.CustomErrorf(err)
+	}
+	return cancelDay, nil
+}
+
+// func: 프로젝트 출퇴근 분류 규칙 조회 (홍채인식 기록 반영용)
+// 설정이 없으면 Jno만 있는 빈 설정
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetProjectScanRule(ctx context.Context, db Queryer, jno int64) (*entity.ProjectSetting, error) {
+	setting := entity.ProjectSetting{}
+
+	query := `
+			SELECT
+				JNO,
+				IN_TIME,
+				SCAN_POLICY,
+				MIN_SCAN_GAP
+			FROM IRIS_JOB_SET
+			WHERE JNO = :1`
+
+	if err := db.GetContext(ctx, &setting, query, jno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			setting.Jno = null.IntFrom(jno)
+		} else {
+			return nil, utils.CustomErrorf(err)
+		}
+	}
+
+	shifts, err := r.GetProjectShiftList(ctx, db, jno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	setting.Shifts = shifts
+
+	return &setting, nil
+}
+
+// func: 프로젝트 근무 시간대 조회
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetProjectShiftList(ctx context.Context, db Queryer, jno int64) (*entity.ProjectShifts, error) {
+	shifts := entity.ProjectShifts{}
+
+	query := `
+			SELECT
+				SHNO,
+				JNO,
+				SHIFT_NAME,
+				START_TIME,
+				END_TIME,
+				IN_UNTIL_TIME
+			FROM IRIS_JOB_SHIFT
+			WHERE JNO = :1
+			ORDER BY START_TIME, SHNO`
+
+	if err := db.SelectContext(ctx, &shifts, query, jno); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	return &shifts, nil
+}
+
+// func: 프로젝트 근무 시간대 추가
+// @param
+// - shift: 근무 시간대
+func (r *Repository) AddProjectShift(ctx context.Context, tx Execer, shift entity.ProjectShift) error {
+	query := `
+			INSERT INTO IRIS_JOB_SHIFT ( JNO, SHIFT_NAME, START_TIME, END_TIME, IN_UNTIL_TIME, REG_UNO, REG_USER, REG_DATE )
+			VALUES ( :1, :2, :3, :4, :5, :6, :7, SYSDATE )`
+
+	if _, err := tx.ExecContext(ctx, query, shift.Jno, shift.ShiftName, shift.StartTime, shift.EndTime, shift.InUntilTime, shift.RegUno, shift.RegUser); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 프로젝트 근무 시간대 전체 삭제
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) DeleteProjectShift(ctx context.Context, tx Execer, jno int64) error {
+	query := `
+			DELETE 
+			FROM IRIS_JOB_SHIFT
+			WHERE JNO = :1`
+
+	if _, err := tx.ExecContext(ctx, query, jno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 근무 시간대 설정 저장 로그
+func (r *Repository) ProjectShiftLog(ctx context.Context, tx Execer, shift entity.ProjectShift) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_JOB_MAN_HOUR_LOG( JNO, CHANGE_SETTING, MESSAGE, REG_DATE, REG_USER, REG_UNO, REG_AGENT)
+		VALUES (:1, 'IRIS_JOB_SHIFT', :2, SYSDATE, :3, :4, :5)`
+
+	if _, err := tx.ExecContext(ctx, query, shift.Jno, shift.Message, shift.RegUser, shift.RegUno, agent); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	return nil
+}
+
+// func: 프로젝트 휴게 시간 조회
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetProjectBreakList(ctx context.Context, db Queryer, jno int64) (*entity.ProjectBreaks, error) {
+	breaks := entity.ProjectBreaks{}
+
+	query := `
+			SELECT
+				BRNO,
+				JNO,
+				BREAK_NAME,
+				START_TIME,
+				END_TIME
+			FROM IRIS_JOB_BREAK
+			WHERE JNO = :1
+			ORDER BY START_TIME, BRNO`
+
+	if err := db.SelectContext(ctx, &breaks, query, jno); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	return &breaks, nil
+}
+
+// func: 프로젝트 휴게 시간 추가
+// @param
+// - brk: 휴게 시간
+func (r *Repository) AddProjectBreak(ctx context.Context, tx Execer, brk entity.ProjectBreak) error {
+	query := `
+			INSERT INTO IRIS_JOB_BREAK ( JNO, BREAK_NAME, START_TIME, END_TIME, REG_UNO, REG_USER, REG_DATE )
+			VALUES ( :1, :2, :3, :4, :5, :6, SYSDATE )`
+
+	if _, err := tx.ExecContext(ctx, query, brk.Jno, brk.BreakName, brk.StartTime, brk.EndTime, brk.RegUno, brk.RegUser); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 프로젝트 휴게 시간 전체 삭제
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) DeleteProjectBreak(ctx context.Context, tx Execer, jno int64) error {
+	query := `
+			DELETE 
+			FROM IRIS_JOB_BREAK
+			WHERE JNO = :1`
+
+	if _, err := tx.ExecContext(ctx, query, jno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"strings"
+)
+
+// func: 홍채인식기 인식 기록 원문 저장 (IRIS_RECD_LOG)
+// 장치 시리얼번호 + transactionID 기준으로 한번만 저장한다. (같은 기록을 다시 보내도 무시)
+// @param
+// - device: 인증된 근태인식기
+// - recd: 인식 기록
+// - irisData: 인식 기록 원문(json)
+// @return
+// - bool: 새로 저장했는지 여부 (false: 이미 받은 기록)
+func (r *Repository) AddRecdLog(ctx context.Context, tx Execer, device entity.Device, recd entity.RecdLog, irisData string) (bool, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		MERGE INTO IRIS_RECD_LOG t1
+		USING (
+			SELECT 
+				:1 AS DEVICE_SN,
+				:2 AS TRANS_ID
+			FROM DUAL
+		) t2
+		ON (
+			t1.DEVICE_SN = t2.DEVICE_SN
+			AND t1.TRANS_ID = t2.TRANS_ID
+		)
+		WHEN NOT MATCHED THEN
+			INSERT (
+				DEVICE_SN, TRANS_ID, IRIS_DATA, REG_DATE, REG_AGENT
+			) VALUES (
+				t2.DEVICE_SN, t2.TRANS_ID, :3, SYSDATE, :4
+			)`
+
+	result, err := tx.ExecContext(ctx, query, device.DeviceSn, recd.TransactionID, irisData, agent)
+	if err != nil {
+		return false, utils.CustomErrorf(err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, utils.CustomErrorf(err)
+	}
+	return count > 0, nil
+}
+
+// func: 홍채인식기 인식 기록 반영 대기 목록에 추가 (IRIS_RECD_SET)
+// IS_WORKER, IS_DAILY_WORKER = 'N'으로 저장하면 MergeRecdWorker, MergeRecdDailyWorker에서 근로자 테이블에 반영한다.
+// @param
+// - device: 인증된 근태인식기 (현장, 프로젝트)
+// - recd: 인식 기록
+func (r *Repository) AddRecdSet(ctx context.Context, tx Execer, device entity.Device, recd entity.RecdLog) error {
+	agent := utils.GetAgent()
+
+	// 이름: 성 + 이름
+	userNm := strings.TrimSpace(recd.LastName.String + recd.FirstName.String)
+
+	query := `
+		INSERT INTO IRIS_RECD_SET(
+			IRIS_NO, DNO, SNO, JNO, TRANS_ID,
+			USER_ID, USER_NM, DEPARTMENT, DISC_NAME, RECOG_TIME,
+			IS_WORKER, IS_DAILY_WORKER, REG_DATE, REG_USER, REG_AGENT
+		) VALUES (
+			SEQ_IRIS_RECD_SET.NEXTVAL, :1, :2, :3, :4,
+			:5, :6, :7, :8, :9,
+			'N', 'N', SYSDATE, :10, :11
+		)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		device.Dno, device.Sno, device.Jno, recd.TransactionID,
+		recd.UserID, userNm, recd.Department, recd.Role, recd.RecogTime,
+		device.DeviceSn, agent,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"github.com/guregu/null"
+	"time"
+)
+
+// 개인정보 익명화 대상 근로자 조건 (:1 ~ :4 기준일)
+// 퇴직일이 기준일 이전이고 기준일 이후 출퇴근 기록이 없는 근로자의 모든 현장 근로자와, 그 근로자에 병합된 근로자
+const retiredWorkerAnonymizeCondition = `
+		W.ANONYMIZE_DATE IS NULL
+		AND (
+			W.USER_KEY IN (
+				SELECT R.USER_KEY
+				FROM IRIS_WORKER_SET R
+				WHERE R.IS_RETIRE = 'Y'
+				AND R.RETIRE_DATE < TRUNC(:1)
+				AND R.ANONYMIZE_DATE IS NULL
+				AND NOT EXISTS (
+					SELECT 1
+					FROM IRIS_WORKER_DAILY_SET D
+					WHERE D.USER_KEY = R.USER_KEY
+					AND D.RECORD_DATE >= TRUNC(:2)
+				)
+			)
+			OR W.USER_KEY IN (
+				SELECT M.MERGED_USER_KEY
+				FROM IRIS_WORKER_MERGE M
+				JOIN IRIS_WORKER_SET R ON R.USER_KEY = M.USER_KEY
+				WHERE M.STATUS = 'M'
+				AND R.IS_RETIRE = 'Y'
+				AND R.RETIRE_DATE < TRUNC(:3)
+				AND R.ANONYMIZE_DATE IS NULL
+				AND NOT EXISTS (
+					SELECT 1
+					FROM IRIS_WORKER_DAILY_SET D
+					WHERE D.USER_KEY = R.USER_KEY
+					AND D.RECORD_DATE >= TRUNC(:4)
+				)
+			)
+		)`
+
+// func: 개인정보 익명화 대상 근로자 수 조회
+// 퇴직일이 기준일 이전이고 기준일 이후 출퇴근 기록이 없는 근로자 (다른 현장, 병합된 근로자 포함)
+// @param
+// - cutoff: 기준일
+func (r *Repository) GetRetiredWorkerAnonymizeCount(ctx context.Context, db Queryer, cutoff time.Time) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM IRIS_WORKER_SET W
+		WHERE` + retiredWorkerAnonymizeCondition
+
+	if err := db.GetContext(ctx, &count, query, cutoff, cutoff, cutoff, cutoff); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
+
+// func: 개인정보 익명화 대상 근로자 조회
+// GetRetiredWorkerAnonymizeCount와 같은 조건
+// @param
+// - cutoff: 기준일
+func (r *Repository) GetRetiredWorkerAnonymizeList(ctx context.Context, db Queryer, cutoff time.Time) (entity.Workers, error) {
+	list := entity.Workers{}
+
+	query := `
+		SELECT W.USER_KEY, W.SNO, W.USER_ID, W.USER_NM, W.DEPARTMENT
+		FROM IRIS_WORKER_SET W
+		WHERE` + retiredWorkerAnonymizeCondition + `
+		ORDER BY W.USER_KEY, W.SNO`
+
+	if err := db.SelectContext(ctx, &list, query, cutoff, cutoff, cutoff, cutoff); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 퇴직 근로자 개인정보 익명화
+// 근로자의 이름, 아이디, 핸드폰번호, 주민번호를 지우고 USER_KEY, 현장, 프로젝트, 소속은 남긴다. (출퇴근 기록, 공수 집계 유지)
+// 근로자 정보로 찾은 공제, TBM, 홍채인식기 기록의 개인정보와 변경 이력의 사유(직접 입력)도 지운다.
+// TBM은 이름, 소속으로만 찾으므로 기준일 이전 기록만 지운다.
+// 홍채인식기 기록으로 다시 덮어쓰지 않도록 TRG_EDITABLE_YN = 'N'
+// @param
+// - workers: 익명화 대상 근로자 (GetRetiredWorkerAnonymizeList)
+// - cutoff: 기준일
+// @return
+// - 익명화한 근로자 수
+func (r *Repository) ModifyRetiredWorkerAnonymize(ctx context.Context, tx Execer, workers entity.Workers, cutoff time.Time, user entity.Base) (int64, error) {
+	agent := utils.GetAgent()
+
+	historyQuery := `
+		UPDATE IRIS_WORKER_DAILY_HIS
+		SET REASON = NULL
+		WHERE USER_KEY = :1
+		AND REASON IS NOT NULL`
+
+	deductionQuery := `
+		UPDATE IRIS_DEDUCTION_SET
+		SET
+			USER_NM = '익명',
+			REG_NO = NULL,
+			PHONE = NULL
+		WHERE SNO = :1
+		AND USER_NM = :2
+		AND REPLACE(PHONE, '-', '') = REPLACE(:3, '-', '')`
+
+	tbmQuery := `
+		UPDATE IRIS_TBM_SET
+		SET USER_NM = '익명'
+		WHERE SNO = :1
+		AND USER_NM = :2
+		AND DEPARTMENT = :3
+		AND TBM_DATE < TRUNC(:4)`
+
+	recdQuery := `
+		UPDATE IRIS_RECD_SET
+		SET
+			USER_ID = :1,
+			USER_NM = '익명',
+			REG_NO = NULL
+		WHERE SNO = :2
+		AND USER_ID = :3
+		AND USER_NM = :4`
+
+	workerQuery := `
+		UPDATE IRIS_WORKER_SET
+		SET
+			USER_ID = USER_KEY,
+			USER_NM = '익명',
+			PHONE = NULL,
+			REG_NO = NULL,
+			REG_NO_ENC = NULL,
+			TRG_EDITABLE_YN = 'N',
+			ANONYMIZE_DATE = SYSDATE,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :1,
+			MOD_USER = :2,
+			MOD_UNO = :3
+		WHERE USER_KEY = :4
+		AND SNO = :5
+		AND ANONYMIZE_DATE IS NULL`
+
+	var count int64
+	for _, w := range workers {
+		if _, err := tx.ExecContext(ctx, historyQuery, w.UserKey); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		if _, err := tx.ExecContext(ctx, deductionQuery, w.Sno, w.UserNm, w.UserId); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		if _, err := tx.ExecContext(ctx, tbmQuery, w.Sno, w.UserNm, w.Department, cutoff); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		if _, err := tx.ExecContext(ctx, recdQuery, w.UserKey, w.Sno, w.UserId, w.UserNm); err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+
+		result, err := tx.ExecContext(ctx, workerQuery, agent, user.ModUser, user.ModUno, w.UserKey, w.Sno)
+		if err != nil {
+			return 0, utils.CustomErrorf(err)
+		}
+		affected, _ := result.RowsAffected()
+		count += affected
+	}
+	return count, nil
+}
+
+// func: 보관 기간이 지난 업로드 파일 조회
+// 같은 경로, 이름으로 다시 올린 파일(차수)은 마지막 작업일 기준
+// @param
+// - fileType: 파일 종류 (TBM, DEDUCTION, ADD_WORKER, ADD_DAILY_WORKER)
+// - cutoff: 기준일
+func (r *Repository) GetExpiredUploadFileList(ctx context.Context, db Queryer, fileType string, cutoff time.Time) ([]entity.UploadFile, error) {
+	var list []entity.UploadFile
+
+	query := `
+		SELECT
+			FILE_TYPE,
+			FILE_PATH,
+			FILE_NAME,
+			MAX(UPLOAD_ROUND) AS UPLOAD_ROUND,
+			MAX(WORK_DATE) AS WORK_DATE
+		FROM IRIS_UPLOADED_FILES
+		WHERE FILE_TYPE = :1
+		AND PURGE_DATE IS NULL
+		GROUP BY FILE_TYPE, FILE_PATH, FILE_NAME
+		HAVING MAX(WORK_DATE) < TRUNC(:2)
+		ORDER BY WORK_DATE, FILE_PATH, FILE_NAME`
+
+	if err := db.SelectContext(ctx, &list, query, fileType, cutoff); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 업로드 파일 삭제/이동 기록
+// @param
+// - file: FILE_TYPE, FILE_PATH, FILE_NAME
+// - archivePath: 옮긴 경로 (삭제한 경우 없음)
+func (r *Repository) ModifyUploadFilePurged(ctx context.Context, tx Execer, file entity.UploadFile, archivePath null.String) error {
+	query := `
+		UPDATE IRIS_UPLOADED_FILES
+		SET
+			PURGE_DATE = SYSDATE,
+			ARCHIVE_PATH = :1
+		WHERE FILE_TYPE = :2
+		AND FILE_PATH = :3
+		AND FILE_NAME = :4
+		AND PURGE_DATE IS NULL`
+
+	if _, err := tx.ExecContext(ctx, query, archivePath, file.FileType, file.FilePath, file.FileName); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"csm-api/auth"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"github.com/guregu/null"
+	"time"
+)
+
+// Oracle 세션 저장소 (IRIS_USER_SESSION)
+type OracleSessionStore struct {
+	DB interface {
+		Queryer
+		Execer
+	}
+}
+
+var _ auth.SessionStore = (*OracleSessionStore)(nil)
+
+type sessionRow struct {
+	SessionId       string      `db:"SESSION_ID"`
+	Uno             int64       `db:"UNO"`
+	UserId          null.String `db:"USER_ID"`
+	UserName        null.String `db:"USER_NAME"`
+	Role            null.String `db:"ROLE"`
+	IsSaved         null.String `db:"IS_SAVED"`
+	RefreshHash     null.String `db:"REFRESH_HASH"`
+	PrevRefreshHash null.String `db:"PREV_REFRESH_HASH"`
+	ExpireDate      null.Time   `db:"EXPIRE_DATE"`
+	RotateDate      null.Time   `db:"ROTATE_DATE"`
+	RevokeDate      null.Time   `db:"REVOKE_DATE"`
+}
+
+// 세션 생성
+func (o *OracleSessionStore) CreateSession(ctx context.Context, session auth.Session) error {
+	isSaved := "N"
+	if session.IsSaved {
+		isSaved = "Y"
+	}
+
+	query := `
+		INSERT INTO IRIS_USER_SESSION(SESSION_ID, UNO, USER_ID, USER_NAME, ROLE, IS_SAVED, REFRESH_HASH, EXPIRE_DATE, REG_DATE, REG_AGENT)
+		VALUES (:1, :2, :3, :4, :5, :6, :7, :8, SYSDATE, :9)`
+
+	if _, err := o.DB.ExecContext(ctx, query, session.SessionId, session.Uno, session.UserId, session.UserName, string(session.Role), isSaved, session.RefreshHash, session.ExpireDate, utils.GetAgent()); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 세션 조회
+func (o *OracleSessionStore) GetSession(ctx context.Context, sessionId string) (auth.Session, error) {
+	row := sessionRow{}
+
+	query := `
+		SELECT SESSION_ID, UNO, USER_ID, USER_NAME, ROLE, IS_SAVED, REFRESH_HASH, PREV_REFRESH_HASH, EXPIRE_DATE, ROTATE_DATE, REVOKE_DATE
+		FROM IRIS_USER_SESSION
+		WHERE SESSION_ID = :1`
+
+	if err := o.DB.GetContext(ctx, &row, query, sessionId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.Session{}, utils.CustomErrorf(auth.ErrSessionNotFound)
+		}
+		return auth.Session{}, utils.CustomErrorf(err)
+	}
+
+	return auth.Session{
+		SessionId:       row.SessionId,
+		Uno:             row.Uno,
+		UserId:          row.UserId.String,
+		UserName:        row.UserName.String,
+		Role:            auth.JWTRole(row.Role.String),
+		IsSaved:         row.IsSaved.String == "Y",
+		RefreshHash:     row.RefreshHash.String,
+		PrevRefreshHash: row.PrevRefreshHash.String,
+		ExpireDate:      row.ExpireDate.Time,
+		RotateDate:      row.RotateDate.Time,
+		RevokeDate:      row.RevokeDate.Time,
+	}, nil
+}
+
+// refresh token 교체 (저장된 해시가 다르면 이미 사용된 토큰)
+// 이전 해시는 동시 재발급 요청 확인용으로 PREV_REFRESH_HASH에 보관한다.
+func (o *OracleSessionStore) RotateSession(ctx context.Context, sessionId string, oldHash string, newHash string, expireDate time.Time, rotateDate time.Time) error {
+	query := `
+		UPDATE IRIS_USER_SESSION
+		SET PREV_REFRESH_HASH = REFRESH_HASH,
+			REFRESH_HASH = :1,
+			EXPIRE_DATE = :2,
+			ROTATE_DATE = :3,
+			MOD_DATE = SYSDATE
+		WHERE SESSION_ID = :4
+		AND REFRESH_HASH = :5
+		AND REVOKE_DATE IS NULL`
+
+	result, err := o.DB.ExecContext(ctx, query, newHash, expireDate, rotateDate, sessionId, oldHash)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if count == 0 {
+		return utils.CustomErrorf(auth.ErrRefreshReused)
+	}
+	return nil
+}
+
+// 세션 폐기
+func (o *OracleSessionStore) RevokeSession(ctx context.Context, sessionId string) error {
+	query := `
+		UPDATE IRIS_USER_SESSION
+		SET REVOKE_DATE = SYSDATE
+		WHERE SESSION_ID = :1
+		AND REVOKE_DATE IS NULL`
+
+	if _, err := o.DB.ExecContext(ctx, query, sessionId); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 사용자의 모든 세션 폐기
+func (o *OracleSessionStore) RevokeUserSessions(ctx context.Context, uno int64) error {
+	query := `
+		UPDATE IRIS_USER_SESSION
+		SET REVOKE_DATE = SYSDATE
+		WHERE UNO = :1
+		AND REVOKE_DATE IS NULL`
+
+	if _, err := o.DB.ExecContext(ctx, query, uno); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 폐기된 세션인지 확인 (없는 세션도 폐기로 처리)
+func (o *OracleSessionStore) IsRevoked(ctx context.Context, sessionId string) (bool, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM IRIS_USER_SESSION
+		WHERE SESSION_ID = :1
+		AND REVOKE_DATE IS NULL`
+
+	if err := o.DB.GetContext(ctx, &count, query, sessionId); err != nil {
+		return false, utils.CustomErrorf(err)
+	}
+	return count == 0, nil
+}
+
+// 만료, 폐기된 세션 삭제
+// @param
+// - before: 이 시간 이전에 만료, 폐기된 세션
+func (o *OracleSessionStore) PurgeSessions(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM IRIS_USER_SESSION
+		WHERE EXPIRE_DATE < :1
+		OR REVOKE_DATE < :2`
+
+	result, err := o.DB.ExecContext(ctx, query, before, before)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
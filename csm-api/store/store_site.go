@@ -10,6 +10,11 @@ import (
 	"time"
 )
 
+// 현장 목록 정렬 가능 필드
+var siteOrderFields = entity.OrderColumns(
+	"SNO", "SITE_NM", "LOC_CODE", "LOC_NAME", "ETC", "REG_DATE", "MOD_DATE",
+)
+
 /**
  * @author 작성자: 김진우
  * @created 작성일: 2025-02-12
@@ -88,19 +93,21 @@ func (r *Repository) GetSiteList(ctx context.Context, db Queryer, targetDate tim
 // -
 func (r *Repository) GetSiteNmList(ctx context.Context, db Queryer, page entity.PageSql, search entity.Site, nonSite int) (*entity.Sites, error) {
 
+	where := utils.NewWhereBuilder(nonSite)
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Sno.NullInt64, "t1.SNO")
-	condition = utils.StringWhereConvert(condition, search.SiteNm.NullString, "t1.SITE_NM")
-	condition = utils.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
-	condition = utils.StringWhereConvert(condition, search.LocName.NullString, "t1.LOC_NAME")
+	condition = where.Int64WhereConvert(condition, search.Sno.NullInt64, "t1.SNO")
+	condition = where.StringWhereConvert(condition, search.SiteNm.NullString, "t1.SITE_NM")
+	condition = where.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
+	condition = where.StringWhereConvert(condition, search.LocName.NullString, "t1.LOC_NAME")
 
 	sites := entity.Sites{}
 
-	order := ""
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(siteOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = "''"
 	}
 	query := fmt.Sprintf(`
@@ -120,7 +127,7 @@ func (r *Repository) GetSiteNmList(ctx context.Context, db Queryer, page entity.
 							OR ( 1=:1 AND sno = 100))
 							%s
 				    ) sorted_data
-					WHERE ROWNUM <= :2
+					WHERE ROWNUM <= %s
 					ORDER BY 
 					    CASE WHEN 
 							SNO = 100 
@@ -129,9 +136,9 @@ func (r *Repository) GetSiteNmList(ctx context.Context, db Queryer, page entity.
 						END,
 					    %s,
 						REG_DATE ASC, SNO DESC
-				) WHERE RNUM > :3`, condition, order)
+				) WHERE RNUM > %s`, condition, where.Bind(page.EndNum), order, where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &sites, query, nonSite, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &sites, query, where.Args()...); err != nil {
 		return &sites, utils.CustomErrorf(err)
 	}
 	return &sites, nil
@@ -143,12 +150,13 @@ func (r *Repository) GetSiteNmList(ctx context.Context, db Queryer, page entity.
 func (r *Repository) GetSiteNmCount(ctx context.Context, db Queryer, search entity.Site, nonSite int) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder(nonSite)
 	condition := ""
 
-	condition = utils.Int64WhereConvert(condition, search.Sno.NullInt64, "t1.SNO")
-	condition = utils.StringWhereConvert(condition, search.SiteNm.NullString, "t1.SITE_NM")
-	condition = utils.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
-	condition = utils.StringWhereConvert(condition, search.LocName.NullString, "t1.LOC_NAME")
+	condition = where.Int64WhereConvert(condition, search.Sno.NullInt64, "t1.SNO")
+	condition = where.StringWhereConvert(condition, search.SiteNm.NullString, "t1.SITE_NM")
+	condition = where.StringWhereConvert(condition, search.Etc.NullString, "t1.ETC")
+	condition = where.StringWhereConvert(condition, search.LocName.NullString, "t1.LOC_NAME")
 
 	query := fmt.Sprintf(`			        
 						SELECT 
@@ -159,7 +167,7 @@ func (r *Repository) GetSiteNmCount(ctx context.Context, db Queryer, search enti
 							%s
 				    `, condition)
 
-	if err := db.GetContext(ctx, &count, query, nonSite); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		return 0, utils.CustomErrorf(err)
 	}
 	return count, nil
@@ -179,3 +179,22 @@ func (r *Repository) GetSupervisorRole(ctx context.Context, db Queryer, uno int6
 	}
 	return role, nil
 }
+
+// func: 프로젝트에 부여된 사용자 권한 조회(IRIS_USER_ROLE_MAP)
+// @params
+// - jno: 프로젝트PK (JNO가 0인 권한은 전체 프로젝트 권한)
+// - uno: 유저PK
+func (r *Repository) GetProjectRoleMapList(ctx context.Context, db Queryer, jno int64, uno int64) ([]string, error) {
+	var roles []string
+
+	query := `
+		SELECT ROLE_CODE
+		FROM IRIS_USER_ROLE_MAP
+		WHERE USER_UNO = :1
+		AND JNO IN (0, :2)`
+
+	if err := db.SelectContext(ctx, &roles, query, uno, jno); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	return roles, nil
+}
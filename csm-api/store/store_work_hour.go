@@ -4,192 +4,187 @@ import (
 	"context"
 	"csm-api/entity"
 	"csm-api/utils"
+	"database/sql"
+	"errors"
 	"fmt"
-	"strings"
+	"github.com/guregu/null"
 )
 
-// 마감처리가 안된 특정프로젝트의 근로자의 공수 계산: jno는 필수, ids는 없으면 jno의 모든 근로자 계산 있으면 해당 id의 근로자만 계산
-func (r *Repository) ModifyWorkHourByJno(ctx context.Context, tx Execer, jno int64, user entity.Base, uuids []string) error {
-	var (
-		query strings.Builder
-		args  []interface{}
-	)
-
-	args = append(args, jno)
-
-	query.WriteString(`
-		MERGE INTO IRIS_WORKER_DAILY_SET T1
-		USING (
-			WITH BASE AS (
-				SELECT 
-					R1.JNO,
-					R1.RECORD_DATE,
-					R1.USER_KEY,
-					TO_CHAR(R2.IN_TIME + NUMTODSINTERVAL(R2.RESPITE_TIME, 'MINUTE'), 'HH24:MI') AS IN_LIMIT,
-					TO_CHAR(R2.OUT_TIME - NUMTODSINTERVAL(R2.RESPITE_TIME, 'MINUTE'), 'HH24:MI') AS OUT_LIMIT,
-					TO_CHAR(R1.IN_RECOG_TIME, 'HH24:MI') AS ACTUAL_IN,
-					TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI') AS ACTUAL_OUT,
-					TO_DATE(TO_CHAR(R1.RECORD_DATE, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.IN_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI') AS IN_DATETIME,
-					CASE 
-						WHEN R1.IS_OVERTIME = 'Y' 
-						THEN TO_DATE(TO_CHAR(R1.RECORD_DATE + 1, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI')
-						ELSE TO_DATE(TO_CHAR(R1.RECORD_DATE, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI')
-					END AS OUT_DATETIME
-				FROM IRIS_WORKER_DAILY_SET R1
-				JOIN IRIS_JOB_SET R2 ON R1.JNO = R2.JNO
-				WHERE TRUNC(R1.RECORD_DATE) < TRUNC(SYSDATE)
-				AND R1.IN_RECOG_TIME IS NOT NULL
-				AND R1.OUT_RECOG_TIME IS NOT NULL
-				AND R1.IS_DEADLINE = 'N'
-				AND R1.COMPARE_STATE = 'S'
-				AND R1.WORK_HOUR IS NULL 
-				AND R1.JNO = :1`)
+// 마감처리가 안되고 출퇴근이 둘다 있는 공수 미계산 근로자 조회 (공수 계산 대상)
+// 프로젝트 설정(IRIS_JOB_SET)이 없는 프로젝트는 제외
+// @param
+// - jno: 프로젝트pk (0이면 모든 프로젝트)
+// - uuids: 근로자 키 (없으면 프로젝트의 모든 근로자)
+func (r *Repository) GetWorkHourTargetList(ctx context.Context, db Queryer, jno int64, uuids []string) (entity.WorkerDailys, error) {
+	var list entity.WorkerDailys
 
+	where := utils.NewWhereBuilder()
+	condition := ""
+	if jno != 0 {
+		condition += fmt.Sprintf("\nAND R1.JNO = %s", where.Bind(jno))
+	}
 	if len(uuids) > 0 {
-		query.WriteString("\nAND R1.USER_KEY IN (")
-		for i, id := range uuids {
-			if i > 0 {
-				query.WriteString(", ")
-			}
-			query.WriteString(fmt.Sprintf(":%d", i+2))
-			args = append(args, id)
+		condition += fmt.Sprintf("\nAND R1.USER_KEY IN (%s)", where.BindIn(uuids))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			R1.SNO,
+			R1.JNO,
+			R1.USER_KEY,
+			R1.RECORD_DATE,
+			R1.IN_RECOG_TIME,
+			R1.OUT_RECOG_TIME,
+			R1.IS_OVERTIME
+		FROM IRIS_WORKER_DAILY_SET R1
+		JOIN IRIS_JOB_SET R2 ON R1.JNO = R2.JNO
+		WHERE TRUNC(R1.RECORD_DATE) < TRUNC(SYSDATE)
+		AND R1.IN_RECOG_TIME IS NOT NULL
+		AND R1.OUT_RECOG_TIME IS NOT NULL
+		AND R1.IS_DEADLINE = 'N'
+		AND R1.COMPARE_STATE = 'S'
+		AND R1.WORK_HOUR IS NULL %s`, condition)
+
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// 공수 계산 프로젝트 설정 조회 (출퇴근 시간, 유예시간, 배율, 반일 기준, 공수 단계, 휴게 시간)
+// 설정이 없으면 Jno만 있는 빈 설정
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetWorkHourSetting(ctx context.Context, db Queryer, jno int64) (*entity.ProjectSetting, error) {
+	setting := entity.ProjectSetting{}
+
+	query := `
+		SELECT
+			JNO,
+			IN_TIME,
+			OUT_TIME,
+			RESPITE_TIME,
+			WEEKEND_RATE,
+			HOLIDAY_RATE,
+			HALF_DAY_HOUR
+		FROM IRIS_JOB_SET
+		WHERE JNO = :1`
+
+	if err := db.GetContext(ctx, &setting, query, jno); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.CustomErrorf(err)
 		}
-		query.WriteString(")")
+		setting.Jno = null.IntFrom(jno)
+	}
+
+	manHours, err := r.GetManHourList(ctx, db, jno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	setting.ManHours = manHours
+
+	breaks, err := r.GetProjectBreakList(ctx, db, jno)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
 	}
+	setting.Breaks = breaks
 
-	modUserIndex := len(args) + 1
-	modUnoIndex := modUserIndex + 1
-
-	args = append(args, user.ModUser, user.ModUno)
-
-	query.WriteString(fmt.Sprintf(`
-			)
-			SELECT 
-				BASE.*,
-				FLOOR((
-					(BASE.OUT_DATETIME - BASE.IN_DATETIME)
-					- GREATEST(
-						LEAST(BASE.OUT_DATETIME, TO_DATE(TO_CHAR(BASE.RECORD_DATE, 'YYYY-MM-DD') || ' 13:00', 'YYYY-MM-DD HH24:MI'))
-						- GREATEST(BASE.IN_DATETIME, TO_DATE(TO_CHAR(BASE.RECORD_DATE, 'YYYY-MM-DD') || ' 12:00', 'YYYY-MM-DD HH24:MI')),
-						0
-					)
-				) * 24) AS W_HOUR
-			FROM BASE
-		) T3
-		ON (
-			T1.JNO = T3.JNO
-			AND T1.RECORD_DATE = T3.RECORD_DATE
-			AND T1.USER_KEY = T3.USER_KEY
-		)
-		WHEN MATCHED THEN
-		UPDATE SET 
-			T1.WORK_HOUR = CASE
-				WHEN T3.W_HOUR = 0 THEN 0
-				WHEN T3.W_HOUR >= (
-					SELECT MAX(WORK_HOUR) 
-					FROM IRIS_MAN_HOUR 
-					WHERE JNO = T3.JNO
-				) THEN 1.0
-				WHEN T3.ACTUAL_IN <= T3.IN_LIMIT
-				 AND T3.ACTUAL_OUT >= T3.OUT_LIMIT THEN 1.0
-				ELSE (
-					SELECT NVL(MAX(T4.MAN_HOUR), 0)
-					FROM IRIS_MAN_HOUR T4
-					WHERE T4.JNO = T3.JNO
-					  AND T4.WORK_HOUR = (
-						  SELECT MIN(WORK_HOUR)
-						  FROM IRIS_MAN_HOUR
-						  WHERE JNO = T3.JNO
-							AND WORK_HOUR >= T3.W_HOUR
-					  )
-				)
-			END,
-			T1.MOD_DATE = SYSDATE,
-			T1.MOD_USER = :%d,
-			T1.MOD_UNO  = :%d`, modUserIndex, modUnoIndex))
-
-	if _, err := tx.ExecContext(ctx, query.String(), args...); err != nil {
-		return utils.CustomErrorf(err)
+	return &setting, nil
+}
+
+// 계산한 공수 저장
+// 계산 후 다른 곳에서 공수를 입력했거나 마감한 경우는 변경하지 않는다.
+// @param
+// - workers: JNO, RECORD_DATE, USER_KEY, WORK_HOUR
+func (r *Repository) ModifyWorkHourList(ctx context.Context, tx Execer, workers entity.WorkerDailys, user entity.Base) error {
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			WORK_HOUR = :1,
+			MOD_DATE = SYSDATE,
+			MOD_USER = :2,
+			MOD_UNO = :3
+		WHERE JNO = :4
+		AND RECORD_DATE = :5
+		AND USER_KEY = :6
+		AND IS_DEADLINE = 'N'
+		AND WORK_HOUR IS NULL`
+
+	for _, w := range workers {
+		if _, err := tx.ExecContext(ctx, query, w.WorkHour, user.ModUser, user.ModUno, w.Jno, w.RecordDate, w.UserKey); err != nil {
+			return utils.CustomErrorf(err)
+		}
 	}
 	return nil
 }
 
-// 마감처이가 안되고 출퇴근이 둘다 있는 모든 근로자의 공수 계산
-func (r *Repository) ModifyWorkHour(ctx context.Context, tx Execer, user entity.Base) error {
+// 마감처리가 안되고 출퇴근이 둘다 있는 공수 미계산 근로자 조회 (공수 단계 변경 미리보기 대상)
+// 공수 단계 변경(MergeManHours)과 같이 이미 공수가 입력된 근로자는 제외
+// @param
+// - jno: 프로젝트pk
+func (r *Repository) GetWorkHourOpenList(ctx context.Context, db Queryer, jno int64) (entity.WorkerDailys, error) {
+	var list entity.WorkerDailys
 
 	query := `
-		MERGE INTO IRIS_WORKER_DAILY_SET T1
-		USING (
-			WITH BASE AS (
-				SELECT 
-					R1.JNO,
-					R1.RECORD_DATE,
-					R1.USER_KEY,
-					TO_CHAR(R2.IN_TIME + NUMTODSINTERVAL(R2.RESPITE_TIME, 'MINUTE'), 'HH24:MI') AS IN_LIMIT,
-					TO_CHAR(R2.OUT_TIME - NUMTODSINTERVAL(R2.RESPITE_TIME, 'MINUTE'), 'HH24:MI') AS OUT_LIMIT,
-					TO_CHAR(R1.IN_RECOG_TIME, 'HH24:MI') AS ACTUAL_IN,
-					TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI') AS ACTUAL_OUT,
-					TO_DATE(TO_CHAR(R1.RECORD_DATE, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.IN_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI') AS IN_DATETIME,
-					CASE 
-						WHEN R1.IS_OVERTIME = 'Y' 
-						THEN TO_DATE(TO_CHAR(R1.RECORD_DATE + 1, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI')
-						ELSE TO_DATE(TO_CHAR(R1.RECORD_DATE, 'YYYY-MM-DD') || ' ' || TO_CHAR(R1.OUT_RECOG_TIME, 'HH24:MI'), 'YYYY-MM-DD HH24:MI')
-					END AS OUT_DATETIME
-				FROM IRIS_WORKER_DAILY_SET R1
-				JOIN IRIS_JOB_SET R2 ON R1.JNO = R2.JNO
-				WHERE TRUNC(R1.RECORD_DATE) < TRUNC(SYSDATE)
-				AND R1.IN_RECOG_TIME IS NOT NULL
-				AND R1.OUT_RECOG_TIME IS NOT NULL
-				AND R1.IS_DEADLINE = 'N'
-				AND R1.COMPARE_STATE = 'S'
-				AND R1.WORK_HOUR IS NULL 
-			)
-			SELECT 
-				BASE.*,
-				FLOOR((
-					(BASE.OUT_DATETIME - BASE.IN_DATETIME)
-					- GREATEST(
-						LEAST(BASE.OUT_DATETIME, TO_DATE(TO_CHAR(BASE.RECORD_DATE, 'YYYY-MM-DD') || ' 13:00', 'YYYY-MM-DD HH24:MI'))
-						- GREATEST(BASE.IN_DATETIME, TO_DATE(TO_CHAR(BASE.RECORD_DATE, 'YYYY-MM-DD') || ' 12:00', 'YYYY-MM-DD HH24:MI')),
-						0
-					)
-				) * 24) AS W_HOUR
-			FROM BASE
-		) T3
-		ON (
-			T1.JNO = T3.JNO
-			AND T1.RECORD_DATE = T3.RECORD_DATE
-			AND T1.USER_KEY = T3.USER_KEY
-		)
-		WHEN MATCHED THEN
-		UPDATE SET 
-			T1.WORK_HOUR = CASE
-				WHEN T3.W_HOUR = 0 THEN 0
-				WHEN T3.W_HOUR >= (
-					SELECT MAX(WORK_HOUR) 
-					FROM IRIS_MAN_HOUR 
-					WHERE JNO = T3.JNO
-				) THEN 1.0
-				WHEN T3.ACTUAL_IN <= T3.IN_LIMIT
-				 AND T3.ACTUAL_OUT >= T3.OUT_LIMIT THEN 1.0
-				ELSE (
-					SELECT NVL(MAX(T4.MAN_HOUR), 0)
-					FROM IRIS_MAN_HOUR T4
-					WHERE T4.JNO = T3.JNO
-					  AND T4.WORK_HOUR = (
-						  SELECT MIN(WORK_HOUR)
-						  FROM IRIS_MAN_HOUR
-						  WHERE JNO = T3.JNO
-							AND WORK_HOUR >= T3.W_HOUR
-					  )
-				)
-			END,
-			T1.MOD_DATE = SYSDATE,
-			T1.MOD_USER = :1,
-			T1.MOD_UNO  = :2`
-
-	if _, err := tx.ExecContext(ctx, query, user.ModUser, user.ModUno); err != nil {
-		return utils.CustomErrorf(err)
+		SELECT
+			R1.SNO,
+			R1.JNO,
+			R1.USER_KEY,
+			R2.USER_NM,
+			R1.RECORD_DATE,
+			R1.IN_RECOG_TIME,
+			R1.OUT_RECOG_TIME,
+			R1.IS_OVERTIME,
+			R1.WORK_HOUR
+		FROM IRIS_WORKER_DAILY_SET R1
+		LEFT JOIN IRIS_WORKER_SET R2 ON R1.SNO = R2.SNO AND R1.USER_KEY = R2.USER_KEY
+		WHERE R1.JNO = :1
+		AND TRUNC(R1.RECORD_DATE) < TRUNC(SYSDATE)
+		AND R1.IN_RECOG_TIME IS NOT NULL
+		AND R1.OUT_RECOG_TIME IS NOT NULL
+		AND R1.IS_DEADLINE = 'N'
+		AND R1.COMPARE_STATE = 'S'
+		AND R1.WORK_HOUR IS NULL
+		ORDER BY R1.RECORD_DATE, R1.USER_KEY, R1.SNO`
+
+	if err := db.SelectContext(ctx, &list, query, jno); err != nil {
+		return list, utils.CustomErrorf(err)
 	}
-	return nil
+	return list, nil
+}
+
+// 미리보기한 공수 변경 저장
+// 미리보기 이후 공수가 입력되었거나 마감된 근로자는 변경하지 않는다.
+// @param
+// - rows: JNO, SNO, RECORD_DATE, USER_KEY, AFTER_WORK_HOUR(변경 후 공수)
+// @return
+// - 변경된 근로자 수
+func (r *Repository) ModifyWorkHourPreviewList(ctx context.Context, tx Execer, rows entity.ManHourPreviewRows, user entity.Base) (int64, error) {
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			WORK_HOUR = :1,
+			MOD_DATE = SYSDATE,
+			MOD_USER = :2,
+			MOD_UNO = :3
+		WHERE JNO = :4
+		AND SNO = :5
+		AND RECORD_DATE = :6
+		AND USER_KEY = :7
+		AND IS_DEADLINE = 'N'
+		AND WORK_HOUR IS NULL`
+
+	var count int64
+	for _, row := range rows {
+		result, err := tx.ExecContext(ctx, query, row.AfterWorkHour, user.ModUser, user.ModUno, row.Jno, row.Sno, row.RecordDate, row.UserKey)
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return count, utils.CustomErrorf(err)
+		}
+		count += n
+	}
+	return count, nil
 }
@@ -2,13 +2,40 @@ package store
 
 import (
 	"context"
+	"csm-api/crypto"
 	"csm-api/entity"
 	"csm-api/utils"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/guregu/null"
-	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+// 전체 근로자 목록 정렬 가능 필드
+var workerOrderFields = entity.OrderColumns(
+	"SNO", "SITE_NM", "JNO", "JOB_NAME", "USER_ID", "USER_NM", "DEPARTMENT", "DISC_NAME", "PHONE", "WORKER_TYPE",
+	"IS_RETIRE", "RETIRE_DATE", "IS_MANAGE", "REG_USER", "REG_DATE", "MOD_USER", "MOD_DATE",
+)
+
+// 전체 근로자 목록 keyset 정렬 (entity.Worker.CursorValues 순서)
+var workerKeyset = utils.Keyset{
+	{Column: "t1.SNO"},
+	{Column: "t1.USER_KEY"},
+}
+
+// 현장 근로자 목록 keyset 정렬 (entity.WorkerDaily.CursorValues 순서)
+var workerDailyKeyset = utils.Keyset{
+	{Column: "t1.RECORD_DATE", Desc: true},
+	{Column: "t1.USER_KEY"},
+	{Column: "t1.SNO"},
+}
+
+// 현장 근로자 목록 정렬 가능 필드
+var workerDailyOrderFields = entity.OrderColumns(
+	"SNO", "JNO", "USER_ID", "USER_NM", "DEPARTMENT", "RECORD_DATE", "IN_RECOG_TIME", "OUT_RECOG_TIME", "IS_DEADLINE",
+	"IS_OVERTIME", "REG_USER", "REG_DATE", "MOD_USER", "MOD_DATE", "WORK_STATE", "COMPARE_STATE", "WORK_HOUR",
 )
 
 /**
@@ -38,24 +65,30 @@ func (r *Repository) GetWorkerTotalList(ctx context.Context, db Queryer, page en
 		roleCondition = fmt.Sprintf("AND UNO = %s", uno)
 	}
 
+	where := utils.NewWhereBuilder()
 	condition := ""
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t1.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t1.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t1.DEPARTMENT")
-	condition = utils.StringWhereConvert(condition, search.Phone.NullString, "t1.PHONE")
-	condition = utils.StringWhereConvert(condition, search.WorkerType.NullString, "t1.WORKER_TYPE")
-	condition = utils.StringWhereConvert(condition, search.DiscName.NullString, "t1.DISC_NAME")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t1.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t1.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t1.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.Phone.NullString, "t1.PHONE")
+	condition = where.StringWhereConvert(condition, search.WorkerType.NullString, "t1.WORKER_TYPE")
+	condition = where.StringWhereConvert(condition, search.DiscName.NullString, "t1.DISC_NAME")
 	var columns []string
 	columns = append(columns, "t2.JOB_NAME")
 	columns = append(columns, "t1.USER_NM")
 	columns = append(columns, "t1.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(workerOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	rnumOrder, err := page.RnumOrderBy()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		order = `
 				(
 					CASE 
@@ -66,6 +99,20 @@ func (r *Repository) GetWorkerTotalList(ctx context.Context, db Queryer, page en
 				) DESC NULLS LAST`
 	}
 
+	// keyset 페이징: 커서 이후 행만 조회
+	cursorCondition := ""
+	if page.UseCursor {
+		order = workerKeyset.OrderBy()
+		if page.Cursor != nil {
+			var sno int64
+			var userKey string
+			if err = page.Cursor.Scan(&sno, &userKey); err != nil {
+				return nil, utils.CustomErrorf(err)
+			}
+			cursorCondition = where.KeysetWhereConvert(cursorCondition, workerKeyset, []any{sno, userKey})
+		}
+	}
+
 	query := fmt.Sprintf(`
 				WITH USER_IN_SNO AS (
 					SELECT DISTINCT SNO
@@ -129,7 +176,7 @@ func (r *Repository) GetWorkerTotalList(ctx context.Context, db Queryer, page en
 						sorted_data.REG_DATE,
 						sorted_data.MOD_USER,
 						sorted_data.MOD_DATE,
-						sorted_data.REG_NO
+						sorted_data.REG_NO_ENC
 					FROM (
 						SELECT 
 						    t1.WNO,
@@ -152,22 +199,22 @@ func (r *Repository) GetWorkerTotalList(ctx context.Context, db Queryer, page en
 							t1.REG_DATE,
 							t1.MOD_USER,
 							t1.MOD_DATE,
-							COMMON.FUNC_DECODE(t1.REG_NO) AS REG_NO
+							t1.REG_NO_ENC
 						FROM BASE t1, S_JOB_INFO t2, IRIS_SITE_SET t3, USER_IN_SNO t4
 						WHERE
 							t1.JNO = t2.JNO(+)
 							AND t1.SNO = t3.SNO(+)
 							AND t3.SNO = t4.SNO
 							AND t1.SNO > 100
-						%s %s
+						%s %s %s
 						ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :1
+					WHERE ROWNUM <= %s
 					ORDER BY RNUM %s
 				)
-				WHERE RNUM > :2`, roleCondition, condition, retryCondition, order, page.RnumOrder)
+				WHERE RNUM > %s`, roleCondition, condition, retryCondition, cursorCondition, order, where.Bind(page.EndNum), rnumOrder, where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &workers, query, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &workers, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -180,7 +227,8 @@ func (r *Repository) GetWorkerTotalList(ctx context.Context, db Queryer, page en
 // - uno string : uno를 string으로 받아 쿼리에 바로 넣음.
 // - searchTime string: 조회 날짜
 // - retry string: 통합검색 텍스트
-func (r *Repository) GetWorkerTotalCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.Worker, retry string) (int, error) {
+// - limit int: 최대로 셀 행 수 (0: 전체)
+func (r *Repository) GetWorkerTotalCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.Worker, retry string, limit int) (int, error) {
 	var count int
 
 	roleCondition := ""
@@ -190,19 +238,26 @@ func (r *Repository) GetWorkerTotalCount(ctx context.Context, db Queryer, isRole
 		roleCondition = fmt.Sprintf("AND UNO = %s", uno)
 	}
 
+	where := utils.NewWhereBuilder()
 	condition := ""
-	condition = utils.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t1.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t1.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t1.DEPARTMENT")
-	condition = utils.StringWhereConvert(condition, search.Phone.NullString, "t1.PHONE")
-	condition = utils.StringWhereConvert(condition, search.WorkerType.NullString, "t1.WORKER_TYPE")
+	condition = where.StringWhereConvert(condition, search.JobName.NullString, "t2.JOB_NAME")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t1.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t1.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t1.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.Phone.NullString, "t1.PHONE")
+	condition = where.StringWhereConvert(condition, search.WorkerType.NullString, "t1.WORKER_TYPE")
 
 	var columns []string
 	columns = append(columns, "t2.JOB_NAME")
 	columns = append(columns, "t1.USER_NM")
 	columns = append(columns, "t1.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
+
+	// 개수 추정: limit건까지만 센다
+	limitCondition := ""
+	if limit > 0 {
+		limitCondition = fmt.Sprintf("AND ROWNUM <= %s", where.Bind(limit))
+	}
 
 	query := fmt.Sprintf(`
 						WITH USER_IN_SNO AS (
@@ -252,9 +307,9 @@ func (r *Repository) GetWorkerTotalCount(ctx context.Context, db Queryer, isRole
 							AND t3.SNO = t4.SNO
 							AND t1.SNO > 100
 						--AND t3.IS_USE = 'Y'
-						%s %s`, roleCondition, condition, retryCondition)
+						%s %s %s`, roleCondition, condition, retryCondition, limitCondition)
 
-	if err := db.GetContext(ctx, &count, query); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -269,11 +324,12 @@ func (r *Repository) GetWorkerTotalCount(ctx context.Context, db Queryer, isRole
 func (r *Repository) GetAbsentWorkerList(ctx context.Context, db Queryer, page entity.PageSql, search entity.WorkerDaily, retry string) (*entity.Workers, error) {
 	workers := entity.Workers{}
 
+	where := utils.NewWhereBuilder(search.SearchStartTime, search.Jno, search.Sno, search.Jno, search.SearchStartTime)
 	var columns []string
 	columns = append(columns, "USER_ID")
 	columns = append(columns, "USER_NM")
 	columns = append(columns, "DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 				SELECT *
@@ -297,11 +353,11 @@ func (r *Repository) GetAbsentWorkerList(ctx context.Context, db Queryer, page e
 						)
 						%s
 					) sorted_data
-					WHERE ROWNUM <= :6
+					WHERE ROWNUM <= %s
 				)
-				WHERE RNUM > :7`, retryCondition)
+				WHERE RNUM > %s`, retryCondition, where.Bind(page.EndNum), where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &workers, query, search.SearchStartTime, search.Jno, search.Sno, search.Jno, search.SearchStartTime, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &workers, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -314,11 +370,12 @@ func (r *Repository) GetAbsentWorkerList(ctx context.Context, db Queryer, page e
 func (r *Repository) GetAbsentWorkerCount(ctx context.Context, db Queryer, search entity.WorkerDaily, retry string) (int, error) {
 	var count int
 
+	where := utils.NewWhereBuilder(search.Jno, search.Sno, search.Jno, search.SearchStartTime)
 	var columns []string
 	columns = append(columns, "USER_ID")
 	columns = append(columns, "USER_NM")
 	columns = append(columns, "DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 				SELECT COUNT(*)
@@ -333,7 +390,7 @@ func (r *Repository) GetAbsentWorkerCount(ctx context.Context, db Queryer, searc
 				)
 				%s`, retryCondition)
 
-	if err := db.GetContext(ctx, &count, query, search.Jno, search.Sno, search.Jno, search.SearchStartTime); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -363,8 +420,9 @@ func (r *Repository) GetWorkerDepartList(ctx context.Context, db Queryer, jno in
 }
 
 // func: 근로자 추가
+// 같은 근로자(아이디, 이름, 주민번호) 확인은 서비스에서 주민번호를 복호화해서 한다.
 // @param
-// -
+// - worker: REG_NO_ENC(주민번호 암호문)
 func (r *Repository) AddWorker(ctx context.Context, tx Execer, worker entity.Worker) (int64, error) {
 	agent := utils.GetAgent()
 
@@ -373,29 +431,18 @@ func (r *Repository) AddWorker(ctx context.Context, tx Execer, worker entity.Wor
 		INSERT INTO IRIS_WORKER_SET (
 			SNO, JNO, USER_ID, USER_NM, DEPARTMENT, 
 			DISC_NAME, PHONE, WORKER_TYPE, IS_RETIRE, DAILY_REASON,
-		    REG_DATE, REG_AGENT, REG_USER, REG_UNO, REG_NO, USER_KEY
+		    REG_DATE, REG_AGENT, REG_USER, REG_UNO, REG_NO_ENC, USER_KEY
 		)
-		SELECT
+		VALUES (
 			:1, :2, :3, :4, :5,
 			:6, REPLACE(:7, '-', ''), :8, :9, :10,
-			SYSDATE, :11, :12, :13, COMMON.FUNC_ENCODE(:14), GET_IRIS_USER_UUID()
-		FROM DUAL
-		WHERE NOT EXISTS (
-			SELECT 1
-			FROM IRIS_WORKER_SET
-			WHERE USER_ID = :15
-			  AND USER_NM = :16
-			  AND (
-				(COMMON.FUNC_DECODE(REG_NO) = :17) OR (REG_NO IS NULL AND :18 IS NULL)
-			  )
-			AND IS_DEL = 'N'
+			SYSDATE, :11, :12, :13, :14, GET_IRIS_USER_UUID()
 		)`
 
 	res, err := tx.ExecContext(ctx, insertQuery,
 		worker.Sno, worker.Jno, worker.UserId, worker.UserNm, worker.Department,
 		worker.DiscName, worker.Phone, worker.WorkerType, worker.IsRetire, worker.DailyReason,
-		/*, SYSDATE*/ agent, worker.RegUser, worker.RegUno, worker.RegNo,
-		worker.UserId, worker.UserNm, worker.RegNo, worker.RegNo,
+		/*, SYSDATE*/ agent, worker.RegUser, worker.RegUno, worker.RegNoEnc,
 	)
 	if err != nil {
 		return 0, utils.CustomErrorf(err)
@@ -419,20 +466,21 @@ func (r *Repository) ModifyWorker(ctx context.Context, tx Execer, worker entity.
 				SET 
 					R.USER_NM          = :1,
 					R.DEPARTMENT       = :2,
-					R.PHONE            = REPLACE(:3, '-', ''),
-					R.WORKER_TYPE      = :4,
-					R.IS_RETIRE        = :5,
-					R.RETIRE_DATE      = :6,
-					R.DAILY_REASON     = :7,
+					R.PHONE            = CASE WHEN :3 = 'Y' THEN R.PHONE ELSE REPLACE(:4, '-', '') END,
+					R.WORKER_TYPE      = :5,
+					R.IS_RETIRE        = :6,
+					R.RETIRE_DATE      = :7,
+					R.DAILY_REASON     = :8,
 					R.MOD_DATE         = SYSDATE,
-					R.MOD_AGENT        = :8,
-					R.MOD_USER         = :9,
-					R.MOD_UNO          = :10,
+					R.MOD_AGENT        = :9,
+					R.MOD_USER         = :10,
+					R.MOD_UNO          = :11,
 					R.TRG_EDITABLE_YN  = 'N',
-					R.REG_NO           = COMMON.FUNC_ENCODE(:11),
-					R.IS_MANAGE        = :12,
-					R.DISC_NAME        = :13
-				WHERE R.USER_KEY = :14
+					R.REG_NO           = CASE WHEN :12 = 'Y' THEN R.REG_NO END,
+					R.REG_NO_ENC       = CASE WHEN :13 = 'Y' THEN R.REG_NO_ENC ELSE :14 END,
+					R.IS_MANAGE        = :15,
+					R.DISC_NAME        = :16
+				WHERE R.USER_KEY = :17
 				  AND EXISTS (
 						SELECT 1
 						FROM IRIS_SITE_JOB J
@@ -440,9 +488,19 @@ func (r *Repository) ModifyWorker(ctx context.Context, tx Execer, worker entity.
 						  AND J.IS_USE = 'Y'
 				  )`
 
+	// 마스킹된 주민번호, 핸드폰번호(조회 권한 없음)는 변경하지 않는다.
+	keepRegNo := "N"
+	if crypto.IsMaskedRegNo(worker.RegNo.String) {
+		keepRegNo = "Y"
+	}
+	keepPhone := "N"
+	if crypto.IsMaskedPhone(worker.Phone.String) {
+		keepPhone = "Y"
+	}
+
 	result, err := tx.ExecContext(ctx, query,
-		worker.UserNm, worker.Department, worker.Phone, worker.WorkerType, worker.IsRetire,
-		worker.RetireDate, worker.DailyReason, agent, worker.ModUser, worker.ModUno, worker.RegNo,
+		worker.UserNm, worker.Department, keepPhone, worker.Phone, worker.WorkerType, worker.IsRetire,
+		worker.RetireDate, worker.DailyReason, agent, worker.ModUser, worker.ModUno, keepRegNo, keepRegNo, worker.RegNoEnc,
 		worker.IsManage, worker.DiscName, worker.UserKey,
 	)
 
@@ -463,6 +521,7 @@ func (r *Repository) ModifyWorker(ctx context.Context, tx Execer, worker entity.
 }
 
 // 근로자 엑셀 업로드
+// 같은 근로자(아이디, 이름, 주민번호)는 서비스에서 주민번호를 복호화해서 찾고 USER_KEY로 넘긴다. (없으면 새 근로자 키)
 func (r *Repository) MergeWorker(ctx context.Context, tx Execer, worker entity.Worker) (int64, error) {
 	agent := utils.GetAgent()
 
@@ -475,7 +534,7 @@ func (r *Repository) MergeWorker(ctx context.Context, tx Execer, worker entity.W
 					:1 AS SNO, -- 현장번호 
 					:2 AS JNO, -- 프로젝트번호
 					:3 AS USER_NM, -- 이름
-					COMMON.FUNC_ENCODE(:4) AS REG_NO, -- 주민번호
+					:4 AS REG_NO_ENC, -- 주민번호 암호문
 					:5 AS USER_ID, -- 아이디
 					:6 AS DEPARTMENT, -- 부서 / 조직명
 					:7 AS PHONE, -- 핸드폰번호
@@ -484,23 +543,17 @@ func (r *Repository) MergeWorker(ctx context.Context, tx Execer, worker entity.W
 					(SELECT CODE FROM IRIS_CODE_SET WHERE P_CODE = 'WORKER_TYPE' AND CODE_NM = :10) AS WORKER_TYPE, -- 근로자구분
 					:11 AS UNO, -- 등록자 UNO
 					:12 AS NAME, -- 등록자 NAME
-					:13 AS AGENT -- 등록자 AGENT
+					:13 AS AGENT, -- 등록자 AGENT
+					:14 AS USER_KEY -- 같은 근로자 키
 				FROM DUAL
 			)
 			SELECT 
-				w.*,
-				NVL(ws.USER_KEY, GET_IRIS_USER_UUID()) AS USER_KEY 
+				w.SNO, w.JNO, w.USER_NM, w.REG_NO_ENC, w.USER_ID,
+				w.DEPARTMENT, w.PHONE, w.DISC_NAME, w.IS_RETIRE, w.WORKER_TYPE,
+				w.UNO, w.NAME, w.AGENT,
+				NVL(w.USER_KEY, GET_IRIS_USER_UUID()) AS USER_KEY 
 			FROM 
 				worker w 
-			LEFT JOIN 
-				IRIS_WORKER_SET ws
-			ON
-				w.USER_ID = ws.USER_ID
-				AND ws.USER_NM = w.USER_NM
-				AND (
-					(COMMON.FUNC_DECODE(ws.REG_NO) = COMMON.FUNC_DECODE(w.REG_NO)) OR (ws.REG_NO IS NULL AND ws.REG_NO IS NULL)
-				)
-				AND ws.IS_DEL = 'N'
 		) W2
 		ON (
 			W1.USER_KEY = W2.USER_KEY
@@ -508,7 +561,8 @@ func (r *Repository) MergeWorker(ctx context.Context, tx Execer, worker entity.W
 			UPDATE SET 
 				W1.SNO = W2.SNO, 
 				W1.JNO = W2.JNO,
-				W1.REG_NO = W2.REG_NO,
+				W1.REG_NO = NULL,
+				W1.REG_NO_ENC = W2.REG_NO_ENC,
 				W1.DEPARTMENT = W2.DEPARTMENT,
 				W1.PHONE = W2.PHONE, 
 				W1.DISC_NAME = W2.DISC_NAME,
@@ -522,18 +576,19 @@ func (r *Repository) MergeWorker(ctx context.Context, tx Execer, worker entity.W
 			INSERT (
 				SNO, JNO, USER_ID, USER_NM, DEPARTMENT,
 				DISC_NAME, PHONE, WORKER_TYPE, IS_RETIRE, IS_DEL,
-				REG_DATE, REG_AGENT, REG_USER, REG_UNO, REG_NO, USER_KEY
+				REG_DATE, REG_AGENT, REG_USER, REG_UNO, REG_NO_ENC, USER_KEY
 			)
 			VALUES (
 				W2.SNO, W2.JNO, W2.USER_ID, W2.USER_NM, W2.DEPARTMENT,
 				W2.DISC_NAME, W2.PHONE, W2.WORKER_TYPE, W2.IS_RETIRE, 'N',
-				SYSDATE, W2.AGENT, W2.NAME, W2.UNO, W2.REG_NO, W2.USER_KEY
+				SYSDATE, W2.AGENT, W2.NAME, W2.UNO, W2.REG_NO_ENC, W2.USER_KEY
 			)
 		`
 	res, err := tx.ExecContext(ctx, query,
-		worker.Sno, worker.Jno, worker.UserNm, worker.RegNo,
+		worker.Sno, worker.Jno, worker.UserNm, worker.RegNoEnc,
 		worker.UserId, worker.Department, worker.Phone, worker.DiscName,
 		worker.IsRetire, worker.CodeNm, worker.RegUno, worker.RegUser, agent,
+		worker.UserKey,
 	)
 	if err != nil {
 		return 0, utils.CustomErrorf(err)
@@ -580,22 +635,28 @@ func (r *Repository) GetWorkerSiteBaseList(ctx context.Context, db Queryer, page
 		roleCondition = fmt.Sprintf("AND UNO = %s", uno)
 	}
 
+	where := utils.NewWhereBuilder(search.Jno, search.SearchStartTime, search.SearchEndTime)
 	condition := ""
 
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
 
 	var columns []string
 	columns = append(columns, "t2.USER_ID")
 	columns = append(columns, "t2.USER_NM")
 	columns = append(columns, "t2.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(workerDailyOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	rnumOrder, err := page.RnumOrderBy()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		//order = "RECORD_DATE DESC, OUT_RECOG_TIME DESC NULLS LAST"
 		order = `
 				RECORD_DATE DESC, (
@@ -607,6 +668,21 @@ func (r *Repository) GetWorkerSiteBaseList(ctx context.Context, db Queryer, page
 				) DESC NULLS LAST`
 	}
 
+	// keyset 페이징: 커서 이후 행만 조회
+	cursorCondition := ""
+	if page.UseCursor {
+		order = workerDailyKeyset.OrderBy()
+		if page.Cursor != nil {
+			var recordDate time.Time
+			var userKey string
+			var sno int64
+			if err = page.Cursor.Scan(&recordDate, &userKey, &sno); err != nil {
+				return nil, utils.CustomErrorf(err)
+			}
+			cursorCondition = where.KeysetWhereConvert(cursorCondition, workerDailyKeyset, []any{recordDate, userKey, sno})
+		}
+	}
+
 	query := fmt.Sprintf(`
 				WITH USER_IN_JNO AS (
 					SELECT DISTINCT J.JNO
@@ -649,15 +725,15 @@ func (r *Repository) GetWorkerSiteBaseList(ctx context.Context, db Queryer, page
 								AND t1.COMPARE_STATE in ('S', 'X')
 								AND t1.JNO = :1
 								AND TO_CHAR(t1.RECORD_DATE, 'yyyy-mm-dd') BETWEEN :2 AND :3
-							%s %s
+							%s %s %s
 							ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :4
+					WHERE ROWNUM <= %s
 					ORDER BY RNUM %s
 				)
-				WHERE RNUM > :5`, roleCondition, condition, retryCondition, order, page.RnumOrder)
+				WHERE RNUM > %s`, roleCondition, condition, retryCondition, cursorCondition, order, where.Bind(page.EndNum), rnumOrder, where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, search.Jno, search.SearchStartTime, search.SearchEndTime, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -667,7 +743,8 @@ func (r *Repository) GetWorkerSiteBaseList(ctx context.Context, db Queryer, page
 // func: 현장 근로자 개수 조회
 // @param
 // - searchTime string: 조회 날짜
-func (r *Repository) GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.WorkerDaily, retry string) (int, error) {
+// - limit int: 최대로 셀 행 수 (0: 전체)
+func (r *Repository) GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isRole bool, uno string, search entity.WorkerDaily, retry string, limit int) (int, error) {
 	var count int
 
 	roleCondition := ""
@@ -675,17 +752,24 @@ func (r *Repository) GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isR
 		roleCondition = fmt.Sprintf("AND UNO = %s", uno)
 	}
 
+	where := utils.NewWhereBuilder(search.Jno, search.SearchStartTime, search.SearchEndTime)
 	condition := ""
 
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
 
 	var columns []string
 	columns = append(columns, "t2.USER_ID")
 	columns = append(columns, "t2.USER_NM")
 	columns = append(columns, "t2.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
+
+	// 개수 추정: limit건까지만 센다
+	limitCondition := ""
+	if limit > 0 {
+		limitCondition = fmt.Sprintf("AND ROWNUM <= %s", where.Bind(limit))
+	}
 
 	query := fmt.Sprintf(`
 							WITH USER_IN_JNO AS (
@@ -708,9 +792,9 @@ func (r *Repository) GetWorkerSiteBaseCount(ctx context.Context, db Queryer, isR
 								AND t1.COMPARE_STATE in ('S', 'X')
 								AND t1.JNO = :1
 								AND TO_CHAR(t1.RECORD_DATE, 'yyyy-mm-dd') BETWEEN :2 AND :3
-							%s %s`, roleCondition, condition, retryCondition)
+							%s %s %s`, roleCondition, condition, retryCondition, limitCondition)
 
-	if err := db.GetContext(ctx, &count, query, search.Jno, search.SearchStartTime, search.SearchEndTime); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -728,22 +812,28 @@ func (r *Repository) GetWorkerSiteBaseListByCompany(ctx context.Context, db Quer
 
 	roleCondition := fmt.Sprintf("AND ID = %s", id)
 
+	where := utils.NewWhereBuilder(search.Jno, search.SearchStartTime, search.SearchEndTime)
 	condition := ""
 
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
 
 	var columns []string
 	columns = append(columns, "t2.USER_ID")
 	columns = append(columns, "t2.USER_NM")
 	columns = append(columns, "t2.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
-	var order string
-	if page.Order.Valid {
-		order = page.Order.String
-	} else {
+	order, err := page.OrderBy(workerDailyOrderFields)
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	rnumOrder, err := page.RnumOrderBy()
+	if err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+	if order == "" {
 		//order = "RECORD_DATE DESC, OUT_RECOG_TIME DESC NULLS LAST"
 		order = `
 				RECORD_DATE DESC, (
@@ -755,6 +845,21 @@ func (r *Repository) GetWorkerSiteBaseListByCompany(ctx context.Context, db Quer
 				) DESC NULLS LAST`
 	}
 
+	// keyset 페이징: 커서 이후 행만 조회
+	cursorCondition := ""
+	if page.UseCursor {
+		order = workerDailyKeyset.OrderBy()
+		if page.Cursor != nil {
+			var recordDate time.Time
+			var userKey string
+			var sno int64
+			if err = page.Cursor.Scan(&recordDate, &userKey, &sno); err != nil {
+				return nil, utils.CustomErrorf(err)
+			}
+			cursorCondition = where.KeysetWhereConvert(cursorCondition, workerDailyKeyset, []any{recordDate, userKey, sno})
+		}
+	}
+
 	query := fmt.Sprintf(`
 				WITH USER_IN_JNO AS (
 					SELECT DISTINCT J.JNO, S.COMP_NAME
@@ -798,15 +903,15 @@ func (r *Repository) GetWorkerSiteBaseListByCompany(ctx context.Context, db Quer
 								AND t1.COMPARE_STATE in ('S', 'X')
 								AND t1.JNO = :1
 								AND TO_CHAR(t1.RECORD_DATE, 'yyyy-mm-dd') BETWEEN :2 AND :3
-							%s %s
+							%s %s %s
 							ORDER BY %s
 					) sorted_data
-					WHERE ROWNUM <= :4
+					WHERE ROWNUM <= %s
 					ORDER BY RNUM %s
 				)
-				WHERE RNUM > :5`, roleCondition, condition, retryCondition, order, page.RnumOrder)
+				WHERE RNUM > %s`, roleCondition, condition, retryCondition, cursorCondition, order, where.Bind(page.EndNum), rnumOrder, where.Bind(page.StartNum))
 
-	if err := db.SelectContext(ctx, &list, query, search.Jno, search.SearchStartTime, search.SearchEndTime, page.EndNum, page.StartNum); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -816,22 +921,30 @@ func (r *Repository) GetWorkerSiteBaseListByCompany(ctx context.Context, db Quer
 // func: 현장 근로자 개수 조회 - 협력업체
 // @param
 // - searchTime string: 조회 날짜
-func (r *Repository) GetWorkerSiteBaseByCompanyCount(ctx context.Context, db Queryer, id string, search entity.WorkerDaily, retry string) (int, error) {
+// - limit int: 최대로 셀 행 수 (0: 전체)
+func (r *Repository) GetWorkerSiteBaseByCompanyCount(ctx context.Context, db Queryer, id string, search entity.WorkerDaily, retry string, limit int) (int, error) {
 	var count int
 
 	roleCondition := fmt.Sprintf("AND ID = %s", id)
 
+	where := utils.NewWhereBuilder(search.Jno, search.SearchStartTime, search.SearchEndTime)
 	condition := ""
 
-	condition = utils.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
-	condition = utils.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
-	condition = utils.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
+	condition = where.StringWhereConvert(condition, search.UserId.NullString, "t2.USER_ID")
+	condition = where.StringWhereConvert(condition, search.UserNm.NullString, "t2.USER_NM")
+	condition = where.StringWhereConvert(condition, search.Department.NullString, "t2.DEPARTMENT")
 
 	var columns []string
 	columns = append(columns, "t2.USER_ID")
 	columns = append(columns, "t2.USER_NM")
 	columns = append(columns, "t2.DEPARTMENT")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
+
+	// 개수 추정: limit건까지만 센다
+	limitCondition := ""
+	if limit > 0 {
+		limitCondition = fmt.Sprintf("AND ROWNUM <= %s", where.Bind(limit))
+	}
 
 	query := fmt.Sprintf(`
 							WITH USER_IN_JNO AS (
@@ -855,9 +968,9 @@ func (r *Repository) GetWorkerSiteBaseByCompanyCount(ctx context.Context, db Que
 								AND t1.COMPARE_STATE in ('S', 'X')
 								AND t1.JNO = :1
 								AND TO_CHAR(t1.RECORD_DATE, 'yyyy-mm-dd') BETWEEN :2 AND :3
-							%s %s`, roleCondition, condition, retryCondition)
+							%s %s %s`, roleCondition, condition, retryCondition, limitCondition)
 
-	if err := db.GetContext(ctx, &count, query, search.Jno, search.SearchStartTime, search.SearchEndTime); err != nil {
+	if err := db.GetContext(ctx, &count, query, where.Args()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
 		}
@@ -1038,24 +1151,65 @@ func (r *Repository) ModifyWorkerDefaultProject(ctx context.Context, tx Execer,
 	return nil
 }
 
+// 자동 마감 대상: 최근 7일 퇴근한 비교 확정(S) 미마감 기록 (철야 승인 대기, 자동 마감하지 않는 프로젝트, 마감 요청된 날짜 제외)
+const deadlineInitCondition = `
+			TRUNC(D.RECORD_DATE) >= TRUNC(SYSDATE) - 7
+			AND TRUNC(D.RECORD_DATE) < TRUNC(SYSDATE)
+			AND D.WORK_STATE = '02'
+			AND D.IS_DEADLINE = 'N'
+			AND D.COMPARE_STATE = 'S'
+			AND D.CNO NOT IN (
+				SELECT AFTER_CNO 
+				FROM IRIS_WORKER_OVERTIME 
+				WHERE STATUS = 'P'
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM IRIS_JOB_SET J
+				WHERE J.JNO = D.JNO
+				AND J.AUTO_CLOSE = 'N'
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM IRIS_DAILY_CLOSE C
+				WHERE C.JNO = D.JNO
+				AND C.RECORD_DATE = TRUNC(D.RECORD_DATE)
+				AND C.STATUS = 'S'
+			)`
+
+// func: 현장 근로자 일일 마감처리 대상 프로젝트, 날짜 조회
+// @param
+// -
+func (r *Repository) GetWorkerDeadlineInitDays(ctx context.Context, db Queryer) (entity.DailyCloses, error) {
+	list := entity.DailyCloses{}
+
+	query := `
+			SELECT DISTINCT D.JNO, TRUNC(D.RECORD_DATE) AS RECORD_DATE
+			FROM IRIS_WORKER_DAILY_SET D
+			WHERE ` + deadlineInitCondition + `
+			ORDER BY RECORD_DATE, JNO`
+
+	if err := db.SelectContext(ctx, &list, query); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
 // func: 현장 근로자 일일 마감처리
+// 자동 마감하지 않는 프로젝트(AUTO_CLOSE = 'N')는 마감 요청/승인(IRIS_DAILY_CLOSE)으로 마감한다.
 // @param
 // -
 func (r *Repository) ModifyWorkerDeadlineInit(ctx context.Context, tx Execer) error {
 	agent := utils.GetAgent()
 
 	query := `
-			UPDATE IRIS_WORKER_DAILY_SET 
+			UPDATE IRIS_WORKER_DAILY_SET D
 			SET 
 				IS_DEADLINE = 'Y',
 				MOD_DATE = SYSDATE,
 				MOD_AGENT = :1,
 				MOD_USER = 'Scheduled'
-			WHERE TRUNC(RECORD_DATE) >= TRUNC(SYSDATE) - 7
-			AND TRUNC(RECORD_DATE) < TRUNC(SYSDATE)
-			AND WORK_STATE = '02'
-			AND IS_DEADLINE = 'N'
-			AND COMPARE_STATE = 'S'`
+			WHERE ` + deadlineInitCondition
 
 	if _, err := tx.ExecContext(ctx, query, agent); err != nil {
 		return utils.CustomErrorf(err)
@@ -1064,7 +1218,9 @@ func (r *Repository) ModifyWorkerDeadlineInit(ctx context.Context, tx Execer) er
 	return nil
 }
 
-// func: 철야 근로자 조회
+// func: 철야 후보 조회
+// 최근 7일 동안 출근만 있는 날과 다음날 기록 쌍 (같은 근로자, 같은 현장, 미마감, 처리된 적 없는 쌍)
+// 다음날 출근 시각 이전 인식은 다음날 출근으로 분류되므로 다음날 첫 인식(출근 없으면 퇴근)을 퇴근 후보로 조회한다.
 // @param
 // -
 func (r *Repository) GetWorkerOverTime(ctx context.Context, db Queryer) (*entity.WorkerOverTimes, error) {
@@ -1073,21 +1229,32 @@ func (r *Repository) GetWorkerOverTime(ctx context.Context, db Queryer) (*entity
 	query := `
 			SELECT 
 				w1.CNO AS BEFORE_CNO, 
-				w2.OUT_RECOG_TIME AS OUT_RECOG_TIME, 
-				w2.CNO AS AFTER_CNO 
-			FROM iris_worker_daily_set w1 
-			INNER JOIN iris_worker_daily_set w2 
-			ON w1.user_id = w2.user_id AND w1.jno = w2.jno 
-			WHERE to_date(w2.record_date) = TRUNC(SYSDATE) 
-			  AND w2.IN_RECOG_TIME IS NULL 
-			  AND w2.OUT_RECOG_TIME IS NOT NULL 
-			  AND TO_DATE(w1.RECORD_DATE) = TRUNC(SYSDATE - 1) 
+				w2.CNO AS AFTER_CNO,
+				w1.SNO,
+				w1.JNO,
+				w1.USER_KEY,
+				w1.RECORD_DATE,
+				w1.IN_RECOG_TIME,
+				NVL(w2.IN_RECOG_TIME, w2.OUT_RECOG_TIME) AS OUT_RECOG_TIME
+			FROM IRIS_WORKER_DAILY_SET w1 
+			INNER JOIN IRIS_WORKER_DAILY_SET w2 
+			ON w1.USER_KEY = w2.USER_KEY AND w1.SNO = w2.SNO AND w1.JNO = w2.JNO
+			AND w2.RECORD_DATE = w1.RECORD_DATE + 1
+			WHERE w1.RECORD_DATE >= TRUNC(:1) - 7
 			  AND w1.IN_RECOG_TIME IS NOT NULL 
 			  AND w1.OUT_RECOG_TIME IS NULL
-			  AND W2.COMPARE_STATE = 'S'
+			  AND w1.IS_DEADLINE = 'N'
+			  AND (w2.IN_RECOG_TIME IS NOT NULL OR w2.OUT_RECOG_TIME IS NOT NULL)
+			  AND w2.IS_DEADLINE = 'N'
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM IRIS_WORKER_OVERTIME o
+				WHERE o.BEFORE_CNO = w1.CNO
+				AND o.AFTER_CNO = w2.CNO
+			  )
 		`
 
-	if err := db.SelectContext(ctx, &workerOverTimes, query); err != nil {
+	if err := db.SelectContext(ctx, &workerOverTimes, query, r.Clocker.Now()); err != nil {
 		return nil, utils.CustomErrorf(err)
 	}
 
@@ -1095,9 +1262,113 @@ func (r *Repository) GetWorkerOverTime(ctx context.Context, db Queryer) (*entity
 
 }
 
+// func: 철야 승인 요청 추가
+// @param
+// - workerOverTime: 철야 후보
+func (r *Repository) AddWorkerOverTime(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_WORKER_OVERTIME(
+			BEFORE_CNO, AFTER_CNO, SNO, JNO, USER_KEY,
+			RECORD_DATE, IN_RECOG_TIME, OUT_RECOG_TIME, STATUS, REG_DATE,
+			REG_AGENT, REG_USER, REG_UNO
+		) VALUES (
+			:1, :2, :3, :4, :5,
+			:6, :7, :8, 'P', SYSDATE,
+			:9, 'Scheduled', 0
+		)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		workerOverTime.BeforeCno, workerOverTime.AfterCno, workerOverTime.Sno, workerOverTime.Jno, workerOverTime.UserKey,
+		workerOverTime.RecordDate, workerOverTime.InRecogTime, workerOverTime.OutRecogTime, agent,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 철야 승인 요청 목록 조회
+// @param
+// - sno: 현장pk
+// - status: 처리 상태 (없으면 전체)
+func (r *Repository) GetWorkerOverTimeList(ctx context.Context, db Queryer, sno int64, status string) (*entity.WorkerOverTimes, error) {
+	workerOverTimes := entity.WorkerOverTimes{}
+
+	query := `
+		SELECT 
+			O.ONO, O.BEFORE_CNO, O.AFTER_CNO, O.SNO, O.JNO, 
+			O.USER_KEY, W.USER_ID, W.USER_NM, O.RECORD_DATE, O.IN_RECOG_TIME, 
+			O.OUT_RECOG_TIME, O.STATUS, O.REASON, O.REG_DATE, O.MOD_DATE, 
+			O.MOD_USER, O.MOD_UNO
+		FROM IRIS_WORKER_OVERTIME O
+		LEFT JOIN IRIS_WORKER_SET W ON W.USER_KEY = O.USER_KEY AND W.SNO = O.SNO
+		WHERE O.SNO = :1
+		AND (:2 IS NULL OR O.STATUS = :3)
+		ORDER BY O.RECORD_DATE DESC, O.ONO DESC`
+
+	if err := db.SelectContext(ctx, &workerOverTimes, query, sno, utils.ParseNullString(status), utils.ParseNullString(status)); err != nil {
+		return nil, utils.CustomErrorf(err)
+	}
+
+	return &workerOverTimes, nil
+}
+
+// func: 철야 승인 요청 조회 및 잠금 (승인/반려 처리용)
+// @param
+// - ono: 철야 승인 요청pk
+func (r *Repository) GetWorkerOverTimeForUpdate(ctx context.Context, db Queryer, ono int64) (entity.WorkerOverTime, error) {
+	workerOverTime := entity.WorkerOverTime{}
+
+	query := `
+		SELECT 
+			ONO, BEFORE_CNO, AFTER_CNO, SNO, JNO, 
+			USER_KEY, RECORD_DATE, IN_RECOG_TIME, OUT_RECOG_TIME, STATUS
+		FROM IRIS_WORKER_OVERTIME
+		WHERE ONO = :1
+		FOR UPDATE`
+
+	if err := db.GetContext(ctx, &workerOverTime, query, ono); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return workerOverTime, nil
+		}
+		return workerOverTime, utils.CustomErrorf(err)
+	}
+	return workerOverTime, nil
+}
+
+// func: 철야 승인 요청 처리 상태 변경
+// @param
+// - workerOverTime: Ono, Status, Reason, ModUser, ModUno
+func (r *Repository) ModifyWorkerOverTimeStatus(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_OVERTIME
+		SET 
+			STATUS = :1,
+			REASON = :2,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :3,
+			MOD_USER = :4,
+			MOD_UNO = :5
+		WHERE ONO = :6
+		AND STATUS = 'P'`
+
+	result, err := tx.ExecContext(ctx, query, workerOverTime.Status, workerOverTime.Reason, agent, workerOverTime.ModUser, workerOverTime.ModUno, workerOverTime.Ono)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if count, _ := result.RowsAffected(); count <= 0 {
+		return utils.CustomErrorf(fmt.Errorf("overtime request already processed: %d", workerOverTime.Ono.Int64))
+	}
+	return nil
+}
+
 // func: 현장 근로자 철야 처리
+// 출근한 날 기록에 다음날 퇴근시간을 합친다. 마감된 기록은 변경하지 않는다.
 // @param
-// - workerOverTime entity.WorkerOverTime: BeforeCno, AfterCno, OutRecogTime
+// - workerOverTime entity.WorkerOverTime: BeforeCno, OutRecogTime, ModUser, ModUno
 func (r *Repository) ModifyWorkerOverTime(ctx context.Context, tx Execer, workerOverTime entity.WorkerOverTime) error {
 	agent := utils.GetAgent()
 
@@ -1110,15 +1381,20 @@ func (r *Repository) ModifyWorkerOverTime(ctx context.Context, tx Execer, worker
 		    WORK_STATE = '02',
 			MOD_DATE = SYSDATE,
 			MOD_AGENT = :2,
-			MOD_USER = 'Scheduled'
+			MOD_USER = :3,
+			MOD_UNO = :4
 		WHERE 
-		    CNO = :3
-			
+		    CNO = :5
+		AND IS_DEADLINE = 'N'
 	`
 
-	if _, err := tx.ExecContext(ctx, query, workerOverTime.OutRecogTime, agent, workerOverTime.BeforeCno); err != nil {
+	result, err := tx.ExecContext(ctx, query, workerOverTime.OutRecogTime, agent, workerOverTime.ModUser, workerOverTime.ModUno, workerOverTime.BeforeCno)
+	if err != nil {
 		return utils.CustomErrorf(err)
 	}
+	if count, _ := result.RowsAffected(); count <= 0 {
+		return utils.CustomErrorf(fmt.Errorf("daily worker not found or deadline: %d", workerOverTime.BeforeCno.Int64))
+	}
 	return nil
 
 }
@@ -1131,10 +1407,44 @@ func (r *Repository) DeleteWorkerOverTime(ctx context.Context, tx Execer, cno nu
 	query := `
 		DELETE FROM iris_worker_daily_set
 		WHERE  CNO = :1
+		AND IS_DEADLINE = 'N'
 		`
-	if _, err := tx.ExecContext(ctx, query, cno); err != nil {
+	result, err := tx.ExecContext(ctx, query, cno)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if count, _ := result.RowsAffected(); count <= 0 {
+		return utils.CustomErrorf(fmt.Errorf("daily worker not found or deadline: %d", cno.Int64))
+	}
+	return nil
+}
+
+// func: 현장 근로자 철야 처리 후 다음날 기록 변경
+// 다음날 기록에서 철야 퇴근으로 옮긴 인식 시간을 뺀다. 마감된 기록은 변경하지 않는다.
+// @param
+// - worker: Cno, InRecogTime, OutRecogTime, ModUser, ModUno
+func (r *Repository) ModifyWorkerOverTimeAfter(ctx context.Context, tx Execer, worker entity.WorkerDaily) error {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			IN_RECOG_TIME = :1,
+			OUT_RECOG_TIME = :2,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :3,
+			MOD_USER = :4,
+			MOD_UNO = :5
+		WHERE CNO = :6
+		AND IS_DEADLINE = 'N'`
+
+	result, err := tx.ExecContext(ctx, query, worker.InRecogTime, worker.OutRecogTime, agent, worker.ModUser, worker.ModUno, worker.Cno)
+	if err != nil {
 		return utils.CustomErrorf(err)
 	}
+	if count, _ := result.RowsAffected(); count <= 0 {
+		return utils.CustomErrorf(fmt.Errorf("daily worker not found or deadline: %d", worker.Cno.Int64))
+	}
 	return nil
 }
 
@@ -1178,21 +1488,21 @@ func (r *Repository) ModifyDeadlineCancel(ctx context.Context, tx Execer, worker
 	return nil
 }
 
-// 현장 근로자 근로자 키 조회
-func (r *Repository) GetDailyWorkerUserKey(ctx context.Context, db Queryer, worker entity.WorkerDaily) (string, error) {
-	var userKey string
+// 현장 근로자 근로자 키 후보 조회
+// 생년월일(주민번호 앞자리) 비교는 서비스에서 주민번호 암호문을 복호화해서 한다.
+func (r *Repository) GetDailyWorkerUserKeyList(ctx context.Context, db Queryer, worker entity.WorkerDaily) (entity.Workers, error) {
+	list := entity.Workers{}
 	query := `
-		SELECT USER_KEY
+		SELECT USER_KEY, SNO, REG_NO_ENC
 		FROM IRIS_WORKER_SET
 		WHERE REPLACE(PHONE, '-', '') = :1
 		AND USER_NM = :2
-		AND SUBSTR(COMMON.FUNC_DECODE(REG_NO), 1, 6) = :3
-		AND SNO = :4
-		AND JNO = :5`
-	if err := db.GetContext(ctx, &userKey, query, worker.Phone, worker.UserNm, worker.RegNo, worker.Sno, worker.Jno); err != nil {
-		return "", utils.CustomErrorf(err)
+		AND SNO = :3
+		AND JNO = :4`
+	if err := db.SelectContext(ctx, &list, query, worker.Phone, worker.UserNm, worker.Sno, worker.Jno); err != nil {
+		return list, utils.CustomErrorf(err)
 	}
-	return userKey, nil
+	return list, nil
 }
 
 // 현장근로자 추가
@@ -1360,35 +1670,35 @@ func (r *Repository) GetRecdWorkerList(ctx context.Context, db Queryer) ([]entit
 	return list, nil
 }
 
-// user_key 조회::스케줄 용도
-func (r *Repository) GetRecdWorkerUserKey(ctx context.Context, db Queryer, worker entity.Worker) (string, error) {
-	var userKey string
+// user_key 후보 조회::스케줄 용도
+// 아이디, 이름이 같은 근로자를 최근 등록 순으로 조회한다. 병합된 근로자(IRIS_WORKER_MERGE)는 남길 근로자 키로 조회한다.
+// 주민번호 비교는 서비스에서 주민번호 암호문을 복호화해서 한다.
+func (r *Repository) GetRecdWorkerUserKeyList(ctx context.Context, db Queryer, worker entity.Worker) (entity.Workers, error) {
+	list := entity.Workers{}
 
 	query := `
-		SELECT USER_KEY
-		FROM (
-			SELECT USER_KEY 
-			FROM IRIS_WORKER_SET
-			WHERE USER_ID = :1 
-			AND USER_NM = :2
-			AND (
-				COMMON.FUNC_DECODE(REG_NO) = :3
-				OR (REG_NO IS NULL AND :4 IS NULL)
-			) AND IS_DEL = 'N'
-			ORDER BY REG_DATE DESC
-		)
-		WHERE ROWNUM = 1`
+		SELECT NVL(M.USER_KEY, W.USER_KEY) AS USER_KEY, W.SNO, W.REG_NO_ENC
+		FROM IRIS_WORKER_SET W
+		LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = W.USER_KEY AND M.STATUS = 'M'
+		WHERE W.USER_ID = :1 
+		AND W.USER_NM = :2
+		AND (NVL(W.IS_DEL, 'N') = 'N' OR M.MNO IS NOT NULL)
+		ORDER BY W.REG_DATE DESC`
+
+	if err := db.SelectContext(ctx, &list, query, worker.UserId, worker.UserNm); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
 
-	if err := db.GetContext(ctx, &userKey, query, worker.UserId, worker.UserNm, worker.RegNo, worker.RegNo); err != nil {
+// 새 user_key 생성::스케줄 용도
+func (r *Repository) GetNewWorkerUserKey(ctx context.Context, db Queryer) (string, error) {
+	var userKey string
+
+	query := `SELECT GET_IRIS_USER_UUID() AS USER_KEY FROM DUAL`
+	if err := db.GetContext(ctx, &userKey, query); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			query = `SELECT GET_IRIS_USER_UUID() AS USER_KEY FROM DUAL`
-			if err = db.GetContext(ctx, &userKey, query); err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					return "", utils.CustomErrorf(fmt.Errorf("user_key not found"))
-				}
-				return userKey, utils.CustomErrorf(err)
-			}
-			return userKey, nil
+			return "", utils.CustomErrorf(fmt.Errorf("user_key not found"))
 		}
 		return userKey, utils.CustomErrorf(err)
 	}
@@ -1410,7 +1720,7 @@ func (r *Repository) MergeRecdWorker(ctx context.Context, tx Execer, worker []en
 				:5 AS USER_NM,
 				:6 AS DEPARTMENT, 
 				:7 AS DISC_NAME, 
-				COMMON.FUNC_ENCODE(:8) AS REG_NO,
+				:8 AS REG_NO_ENC,
 				:9 AS WORKER_TYPE, 
 				:10 AS IS_MANAGE,
 				:11 AS IS_RETIRE,
@@ -1432,7 +1742,8 @@ func (r *Repository) MergeRecdWorker(ctx context.Context, tx Execer, worker []en
 				t1.IS_RETIRE = t2.IS_RETIRE,
 				t1.PHONE = t2.USER_ID, 
 				t1.DISC_NAME = t2.DISC_NAME,
-				t1.REG_NO = t2.REG_NO,
+				t1.REG_NO = NULL,
+				t1.REG_NO_ENC = t2.REG_NO_ENC,
 				t1.MOD_DATE = SYSDATE, 
 				t1.MOD_USER = 'TRG_IRIS_WORKER_SET',
 				t1.MOD_UNO = t2.MOD_UNO,
@@ -1441,11 +1752,11 @@ func (r *Repository) MergeRecdWorker(ctx context.Context, tx Execer, worker []en
 		WHEN NOT MATCHED THEN
 			INSERT (
 				USER_KEY, SNO, JNO, USER_ID, USER_NM,
-				DEPARTMENT, WORKER_TYPE, IS_MANAGE, IS_RETIRE, DISC_NAME, REG_NO, 
+				DEPARTMENT, WORKER_TYPE, IS_MANAGE, IS_RETIRE, DISC_NAME, REG_NO_ENC, 
 				REG_DATE, REG_USER, REG_UNO, REG_AGENT
 			) VALUES (
 				t2.USER_KEY, t2.SNO, t2.JNO, t2.USER_ID, t2.USER_NM, 
-				t2.DEPARTMENT, t2.WORKER_TYPE, t2.IS_MANAGE, t2.IS_RETIRE, t2.DISC_NAME, t2.REG_NO, 
+				t2.DEPARTMENT, t2.WORKER_TYPE, t2.IS_MANAGE, t2.IS_RETIRE, t2.DISC_NAME, t2.REG_NO_ENC, 
 				SYSDATE, 'TRG_IRIS_WORKER_SET', t2.MOD_UNO, t2.MOD_AGENT
 			)`
 
@@ -1455,7 +1766,7 @@ func (r *Repository) MergeRecdWorker(ctx context.Context, tx Execer, worker []en
 		WHERE IRIS_NO = :1`
 
 	for _, w := range worker {
-		if _, err := tx.ExecContext(ctx, query, w.UserKey, w.Sno, w.Jno, w.UserId, w.UserNm, w.Department, w.DiscName, w.RegNo, w.WorkerType, w.IsManage, w.IsRetire, agent); err != nil {
+		if _, err := tx.ExecContext(ctx, query, w.UserKey, w.Sno, w.Jno, w.UserId, w.UserNm, w.Department, w.DiscName, w.RegNoEnc, w.WorkerType, w.IsManage, w.IsRetire, agent); err != nil {
 			return utils.CustomErrorf(err)
 		}
 
@@ -1467,41 +1778,142 @@ func (r *Repository) MergeRecdWorker(ctx context.Context, tx Execer, worker []en
 	return nil
 }
 
+// 홍채인식기 데이터 반영 위치 잠금 및 조회
+// 반영 작업은 이 행을 잠근 트랜잭션 안에서 실행하므로 여러 서버에서 동시에 실행해도 한번에 하나씩 처리된다.
+// @param
+// - jobName: 반영 작업 이름
+func (r *Repository) LockRecdWatermark(ctx context.Context, db Queryer, tx Execer, jobName string) (entity.RecdWatermark, error) {
+	watermark := entity.RecdWatermark{}
+
+	query := `
+		MERGE INTO IRIS_RECD_WATERMARK t1
+		USING (
+			SELECT :1 AS JOB_NAME FROM DUAL
+		) t2
+		ON (
+			t1.JOB_NAME = t2.JOB_NAME
+		)
+		WHEN NOT MATCHED THEN
+			INSERT (JOB_NAME, REG_DATE) VALUES (t2.JOB_NAME, SYSDATE)`
+
+	if _, err := tx.ExecContext(ctx, query, jobName); err != nil {
+		return watermark, utils.CustomErrorf(err)
+	}
+
+	query = `
+		SELECT JOB_NAME, LAST_RECOG_TIME, LAST_IRIS_NO
+		FROM IRIS_RECD_WATERMARK
+		WHERE JOB_NAME = :1
+		FOR UPDATE`
+
+	if err := db.GetContext(ctx, &watermark, query, jobName); err != nil {
+		return watermark, utils.CustomErrorf(err)
+	}
+	return watermark, nil
+}
+
+// 홍채인식기 데이터 반영 위치 변경
+func (r *Repository) ModifyRecdWatermark(ctx context.Context, tx Execer, watermark entity.RecdWatermark) error {
+	query := `
+		UPDATE IRIS_RECD_WATERMARK
+		SET
+			LAST_RECOG_TIME = :1,
+			LAST_IRIS_NO = :2,
+			MOD_DATE = SYSDATE
+		WHERE JOB_NAME = :3`
+
+	if _, err := tx.ExecContext(ctx, query, watermark.LastRecogTime, watermark.LastIrisNo, watermark.JobName); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
 // 홍채인식기 데이터 현장근로자(IRIS_WORKER_DAILY_SET) 미반영 조회
-func (r *Repository) GetRecdDailyWorkerList(ctx context.Context, db Queryer) ([]entity.WorkerDaily, error) {
+// 반영 위치(IRIS_NO) 이후 저장된 기록을 저장 순서로 limit건 조회하고, 인식 시간 순서로 정렬해서 돌려준다. (RECORD_DATE: 인식 시간)
+// 반영 위치보다 먼저 채번됐지만 늦게 커밋된 기록은 저장 후 lagMinutes가 지나면 같이 조회한다.
+// 주민번호가 없는 기록은 주민번호가 없는 근로자의 키를 한번에 조회하며(병합된 근로자는 남길 근로자 키), 근로자 키가 없으면 USER_KEY는 NULL
+// 주민번호가 있는 기록은 서비스에서 주민번호 암호문을 복호화해서 비교하므로 USER_KEY는 NULL
+// @param
+// - lastIrisNo: 반영 위치 (마지막으로 반영한 IRIS_NO)
+// - lagMinutes: 반영 위치 이전 미반영 기록을 조회할 저장 후 경과 시간(분)
+// - limit: 조회 건수
+func (r *Repository) GetRecdDailyWorkerBatch(ctx context.Context, db Queryer, lastIrisNo null.Int, lagMinutes int, limit int) ([]entity.WorkerDaily, error) {
 	var list []entity.WorkerDaily
 
 	query := `
-		SELECT IRIS_NO, DNO, SNO, JNO, USER_ID, USER_NM, COMMON.FUNC_DECODE(REG_NO) AS REG_NO, RECOG_TIME AS RECORD_DATE
-		FROM IRIS_RECD_SET
-		WHERE IS_WORKER = 'Y'
-		AND IS_DAILY_WORKER = 'N'`
+		WITH PENDING AS (
+			SELECT *
+			FROM (
+				SELECT IRIS_NO, DNO, SNO, JNO, USER_ID, USER_NM, COMMON.FUNC_DECODE(REG_NO) AS REG_NO, RECOG_TIME
+				FROM IRIS_RECD_SET
+				WHERE IS_WORKER = 'Y'
+				AND IS_DAILY_WORKER = 'N'
+				AND (
+					IRIS_NO > NVL(:1, 0)
+					OR REG_DATE < SYSDATE - :2 / 1440
+				)
+				ORDER BY IRIS_NO
+			)
+			WHERE ROWNUM <= :3
+		),
+		WORKER_KEY AS (
+			SELECT 
+				P.IRIS_NO,
+				NVL(M.USER_KEY, W.USER_KEY) AS USER_KEY,
+				ROW_NUMBER() OVER (PARTITION BY P.IRIS_NO ORDER BY W.REG_DATE DESC) AS RN
+			FROM PENDING P
+			JOIN IRIS_WORKER_SET W ON W.USER_ID = P.USER_ID AND W.USER_NM = P.USER_NM
+			LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = W.USER_KEY AND M.STATUS = 'M'
+			WHERE P.REG_NO IS NULL
+			AND W.REG_NO_ENC IS NULL
+			AND (NVL(W.IS_DEL, 'N') = 'N' OR M.MNO IS NOT NULL)
+		)
+		SELECT 
+			P.IRIS_NO, P.DNO, P.SNO, P.JNO, P.USER_ID, P.USER_NM, P.REG_NO, P.RECOG_TIME AS RECORD_DATE, K.USER_KEY
+		FROM PENDING P
+		LEFT JOIN WORKER_KEY K ON K.IRIS_NO = P.IRIS_NO AND K.RN = 1
+		ORDER BY P.RECOG_TIME, P.IRIS_NO`
 
-	if err := db.SelectContext(ctx, &list, query); err != nil {
+	if err := db.SelectContext(ctx, &list, query, lastIrisNo, lagMinutes, limit); err != nil {
 		return list, utils.CustomErrorf(err)
 	}
 	return list, nil
 }
 
-// 출근 기록이 있는지 확인
-func (r *Repository) GetRecdDailyWorkerChk(ctx context.Context, db Queryer, userKey string, date null.Time) (bool, error) {
-	var chk bool
+// 홍채인식기 데이터 반영 대상 근로자의 기존 출퇴근 기록 조회
+// 새 인식 기록과 함께 다시 분류해서 반영 결과가 반영 순서에 따라 달라지지 않게 한다.
+// @param
+// - userKeys: 근로자 키 (1000건 이하)
+// - startDate, endDate: 조회 기간
+func (r *Repository) GetRecdDailyWorkerExistList(ctx context.Context, db Queryer, userKeys []string, startDate time.Time, endDate time.Time) ([]entity.WorkerDaily, error) {
+	var list []entity.WorkerDaily
+	if len(userKeys) == 0 {
+		return list, nil
+	}
+
+	where := utils.NewWhereBuilder()
+	placeholders := make([]string, 0, len(userKeys))
+	for _, userKey := range userKeys {
+		placeholders = append(placeholders, where.Bind(userKey))
+	}
+	start := where.Bind(startDate)
+	end := where.Bind(endDate)
 
-	qeury := `
-		SELECT 1
+	query := fmt.Sprintf(`
+		SELECT SNO, JNO, USER_KEY, RECORD_DATE, IN_RECOG_TIME, OUT_RECOG_TIME
 		FROM IRIS_WORKER_DAILY_SET
-		WHERE USER_KEY = :1
-		AND TRUNC(RECORD_DATE) = :2`
-	if err := db.GetContext(ctx, &chk, qeury, userKey, date); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return false, nil
-		}
-		return false, utils.CustomErrorf(err)
+		WHERE USER_KEY IN (%s)
+		AND RECORD_DATE BETWEEN TRUNC(%s) AND TRUNC(%s)`, strings.Join(placeholders, ", "), start, end)
+
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
+		return list, utils.CustomErrorf(err)
 	}
-	return true, nil
+	return list, nil
 }
 
 // 홍채인식기 데이터 현장근로자(IRIS_WORKER_DAILY_SET) 테이블에 반영
+// 근로자/현장/근무일별로 분류한 출퇴근 기록을 반영한다. (반영 완료 처리는 ModifyRecdDailyWorkerDone)
+// 기존 출퇴근 기록을 포함해서 분류한 결과이므로 바뀐 출퇴근 시간만 덮어쓴다.
 func (r *Repository) MergeRecdDailyWorker(ctx context.Context, tx Execer, worker []entity.WorkerDaily) error {
 	agent := utils.GetAgent()
 
@@ -1528,15 +1940,16 @@ func (r *Repository) MergeRecdDailyWorker(ctx context.Context, tx Execer, worker
 		)
 		WHEN MATCHED THEN
 			UPDATE SET
-				t1.OUT_RECOG_TIME = t2.OUT_RECOG_TIME,
-				t1.WORK_STATE = t2.WORK_STATE,
+				t1.IN_RECOG_TIME = NVL(t2.IN_RECOG_TIME, t1.IN_RECOG_TIME),
+				t1.OUT_RECOG_TIME = NVL(t2.OUT_RECOG_TIME, t1.OUT_RECOG_TIME),
+				t1.WORK_STATE = NVL2(t2.OUT_RECOG_TIME, t2.WORK_STATE, t1.WORK_STATE),
 				t1.MOD_DATE = SYSDATE,
 				t1.MOD_USER = 'TRG_IRIS_WORKER_DAILY_SET',
 				t1.MOD_UNO = t2.MOD_UNO,
 				t1.MOD_AGENT = t2.MOD_AGENT,
 				t1.DNO = t2.DNO
-			WHERE t2.OUT_RECOG_TIME IS NOT NULL
-			AND (t1.OUT_RECOG_TIME IS NULL OR t2.OUT_RECOG_TIME > t1.OUT_RECOG_TIME)
+			WHERE (t2.IN_RECOG_TIME IS NOT NULL AND (t1.IN_RECOG_TIME IS NULL OR t1.IN_RECOG_TIME <> t2.IN_RECOG_TIME))
+			OR (t2.OUT_RECOG_TIME IS NOT NULL AND (t1.OUT_RECOG_TIME IS NULL OR t1.OUT_RECOG_TIME <> t2.OUT_RECOG_TIME))
 		WHEN NOT MATCHED THEN
 			INSERT (
 				SNO, JNO, USER_KEY, RECORD_DATE, IN_RECOG_TIME, 
@@ -1548,18 +1961,35 @@ func (r *Repository) MergeRecdDailyWorker(ctx context.Context, tx Execer, worker
 				t2.MOD_AGENT, t2.DNO
 			)`
 
-	query2 := `
-		UPDATE IRIS_RECD_SET
-		SET IS_DAILY_WORKER = 'Y'
-		WHERE IRIS_NO = :1`
-
 	for _, w := range worker {
 		if _, err := tx.ExecContext(ctx, query, w.Sno, w.Jno, w.UserKey, w.RecordDate, w.InRecogTime, w.OutRecogTime, w.WorkState, agent, w.Dno); err != nil {
 			return utils.CustomErrorf(err)
 		}
-		if _, err := tx.ExecContext(ctx, query2, w.IrisNo); err != nil {
-			return utils.CustomErrorf(err)
-		}
+	}
+	return nil
+}
+
+// 홍채인식기 데이터 현장근로자 반영 완료 처리
+// @param
+// - irisNos: 반영한 인식 기록 번호 (1000건 이하)
+func (r *Repository) ModifyRecdDailyWorkerDone(ctx context.Context, tx Execer, irisNos []int64) error {
+	if len(irisNos) == 0 {
+		return nil
+	}
+
+	where := utils.NewWhereBuilder()
+	placeholders := make([]string, 0, len(irisNos))
+	for _, irisNo := range irisNos {
+		placeholders = append(placeholders, where.Bind(irisNo))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE IRIS_RECD_SET
+		SET IS_DAILY_WORKER = 'Y'
+		WHERE IRIS_NO IN (%s)`, strings.Join(placeholders, ", "))
+
+	if _, err := tx.ExecContext(ctx, query, where.Args()...); err != nil {
+		return utils.CustomErrorf(err)
 	}
 	return nil
 }
@@ -1606,20 +2036,21 @@ func (r *Repository) AddHistoryDailyWorkers(ctx context.Context, tx Execer, work
 			SNO, JNO, USER_KEY, RECORD_DATE, IN_RECOG_TIME,
 			OUT_RECOG_TIME, IS_DEADLINE, WORK_STATE, IS_OVERTIME, WORK_HOUR,
 			HIS_STATUS, REASON, REASON_TYPE, REG_DATE, REG_AGENT,
-			REG_USER, REG_UNO
+			REG_USER, REG_UNO, HIS_SEQ
 		) VALUES (
 			:1, :2, :3, :4, :5, 
 			:6, :7, :8, :9, :10,
 		    :11, :12, :13, :14, :15, 
-		    :16, :17
+		    :16, :17, :18
 		)`
 
-	for _, w := range workers {
+	// HIS_SEQ: 목록 순번 (변경전/변경후 목록은 같은 순서로 저장하므로 같은 변경에서 변경전/변경후 짝)
+	for i, w := range workers {
 		if _, err := tx.ExecContext(ctx, insetQuery,
 			w.Sno, w.Jno, w.UserKey, w.RecordDate, w.InRecogTime,
 			w.OutRecogTime, w.IsDeadline, w.WorkState, w.IsOvertime, w.WorkHour,
 			w.HisStatus, w.Reason, w.ReasonType, w.RegDate, agent,
-			w.ModUser, w.ModUno,
+			w.ModUser, w.ModUno, i+1,
 		); err != nil {
 			return utils.CustomErrorf(err)
 		}
@@ -1628,6 +2059,7 @@ func (r *Repository) AddHistoryDailyWorkers(ctx context.Context, tx Execer, work
 }
 
 // 변경 이력 조회
+// 병합된 근로자의 이력은 병합 내역(IRIS_WORKER_MERGE)으로 남길 근로자 키로 조회한다.
 func (r *Repository) GetHistoryDailyWorkers(ctx context.Context, db Queryer, startDate string, endDate string, sno int64, retry string, userKeys []string) (entity.WorkerDailys, error) {
 	var list entity.WorkerDailys
 
@@ -1635,7 +2067,18 @@ func (r *Repository) GetHistoryDailyWorkers(ctx context.Context, db Queryer, sta
 	columns = append(columns, "T1.REASON_TYPE")
 	columns = append(columns, "T2.USER_NM")
 	columns = append(columns, "T2.USER_ID")
-	retryCondition := utils.RetrySearchTextConvert(retry, columns)
+
+	where := utils.NewWhereBuilder()
+
+	// userKeys가 있는 경우 userKeys에 해당하는 이력만 조회, 없는 경우 모든 이력 조회
+	isAllUser := 1
+	if len(userKeys) > 0 {
+		isAllUser = 0
+	} else {
+		userKeys = []string{"dummy"}
+	}
+	userCondition := fmt.Sprintf(`AND ( %s = 1 OR NVL(M.USER_KEY, T1.USER_KEY) IN (%s))`, where.Bind(isAllUser), where.BindIn(userKeys))
+	retryCondition := where.RetrySearchTextConvert(retry, columns)
 
 	query := fmt.Sprintf(`
 		SELECT
@@ -1671,6 +2114,12 @@ func (r *Repository) GetHistoryDailyWorkers(ctx context.Context, db Queryer, sta
 						'07', '마감취소', 
 						'08', '수정/마감',
 						'09', '근태업로드',  
+						'10', '철야',
+						'11', '마감취소(기한초과)',
+						'12', '정정요청',
+						'13', '이력복원',
+						'14', '근로자병합',
+						'15', '공수단계변경',
 						''
 				) AS REASON_TYPE,
 				T1.REASON,
@@ -1699,42 +2148,29 @@ func (r *Repository) GetHistoryDailyWorkers(ctx context.Context, db Queryer, sta
 				T1.IS_DEADLINE,
 				T1.CNO
 			FROM IRIS_WORKER_DAILY_HIS T1
-			LEFT JOIN IRIS_WORKER_SET T2 ON T1.SNO = T2.SNO AND T1.USER_KEY = T2.USER_KEY
+			LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = T1.USER_KEY AND M.STATUS = 'M'
+			LEFT JOIN IRIS_WORKER_SET T2 ON T1.SNO = T2.SNO AND NVL(M.USER_KEY, T1.USER_KEY) = T2.USER_KEY
 			LEFT JOIN S_JOB_INFO T3 ON T1.JNO = T3.JNO
 			WHERE 1=1
-			 AND ( ? = 1 OR T1.USER_KEY IN (?))
+			 %s
 			%s
 		)
-		WHERE TO_CHAR(FIXED_RECORD_DATE, 'YYYY-MM-DD') BETWEEN ? AND ?
-		  AND FIXED_SNO = ?
+		WHERE TO_CHAR(FIXED_RECORD_DATE, 'YYYY-MM-DD') BETWEEN %s AND %s
+		  AND FIXED_SNO = %s
 		ORDER BY
 			REG_DATE DESC,
 			USER_ID,
 			FIXED_RECORD_DATE DESC,
 			CASE WHEN HIS_STATUS = 'BEFORE' THEN 0 ELSE 1 END,
 			HIS_STATUS DESC
-		`, retryCondition)
-
-	var args []any
-	var err error
-	if len(userKeys) > 0 { // userKeys가 있는 경우 userKeys에 해당하는 이력만 조회
-		query, args, err = sqlx.In(query, 0, userKeys, startDate, endDate, sno)
-	} else { // userKeys가 없는 경우 모든 이력 조회
-		query, args, err = sqlx.In(query, 1, []string{"dummy"}, startDate, endDate, sno)
-	}
-
-	if err != nil {
-		return nil, utils.CustomErrorf(err)
-	}
-	query = db.Rebind(query)
+		`, userCondition, retryCondition, where.Bind(startDate), where.Bind(endDate), where.Bind(sno))
 
-	if err = db.SelectContext(ctx, &list, query, args...); err != nil {
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
 		return list, utils.CustomErrorf(err)
 	}
 	return list, nil
 }
 
-// 변경 이력 사유 조회
 func (r *Repository) GetHistoryDailyWorkerReason(ctx context.Context, db Queryer, cno int64) (string, error) {
 	var reason string
 
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/guregu/null"
+)
+
+// func: 출퇴근 정정 요청 대상 근로자 조회 - 협력업체
+// 협력업체 현장 근로자 조회(GetWorkerSiteBaseListByCompany)에서 보이는 근로자 기록만 조회한다.
+// @param
+// - id: 협력업체 사용자 uno
+// - correction: JNO, USER_KEY, RECORD_DATE
+func (r *Repository) GetWorkerCorrectionTarget(ctx context.Context, db Queryer, id string, correction entity.WorkerCorrection) (*entity.WorkerDaily, error) {
+	worker := entity.WorkerDaily{}
+
+	query := `
+		WITH USER_IN_JNO AS (
+			SELECT DISTINCT J.JNO, S.COMP_NAME
+			FROM JOB_SUBCON_INFO S, IRIS_SITE_JOB J
+			WHERE
+				J.JNO = S.JNO(+)
+				AND S.JNO IS NOT NULL
+				AND ID = :1
+		)
+		SELECT
+			t1.SNO,
+			t1.JNO,
+			t1.USER_KEY,
+			t2.USER_NM,
+			t2.DEPARTMENT,
+			t1.RECORD_DATE,
+			t1.IN_RECOG_TIME,
+			t1.OUT_RECOG_TIME,
+			t1.IS_DEADLINE,
+			t1.WORK_HOUR
+		FROM IRIS_WORKER_DAILY_SET t1, IRIS_WORKER_SET t2, USER_IN_JNO t3
+		WHERE
+			t1.USER_KEY = t2.USER_KEY(+)
+			AND t1.sno = t2.sno(+)
+			AND t1.jno = t3.jno
+			AND t1.SNO > 100
+			AND t2.DEPARTMENT LIKE '%' || TRIM(REPLACE(NVL(t3.COMP_NAME, ''), '주식회사', '')) || '%'
+			AND T2.IS_DEL = 'N'
+			AND t1.COMPARE_STATE in ('S', 'X')
+			AND t1.JNO = :2
+			AND t1.USER_KEY = :3
+			AND TRUNC(t1.RECORD_DATE) = TRUNC(:4)`
+
+	if err := db.GetContext(ctx, &worker, query, id, correction.Jno, correction.UserKey, correction.RecordDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &worker, nil
+}
+
+// func: 승인 대기 중인 출퇴근 정정 요청 수 조회
+// @param
+// - correction: JNO, USER_KEY, RECORD_DATE
+func (r *Repository) GetWorkerCorrectionPendingCount(ctx context.Context, db Queryer, correction entity.WorkerCorrection) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM IRIS_WORKER_CORRECTION
+		WHERE JNO = :1
+		AND USER_KEY = :2
+		AND TRUNC(RECORD_DATE) = TRUNC(:3)
+		AND STATUS = 'P'`
+
+	if err := db.GetContext(ctx, &count, query, correction.Jno, correction.UserKey, correction.RecordDate); err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	return count, nil
+}
+
+// func: 출퇴근 정정 요청 저장
+// @param
+// - correction: 요청 당시 기록(BEFORE_*)과 정정 내용, 사유, 첨부파일, 요청자
+func (r *Repository) AddWorkerCorrection(ctx context.Context, tx Execer, correction entity.WorkerCorrection) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_WORKER_CORRECTION(
+			CRNO, SNO, JNO, USER_KEY, RECORD_DATE,
+			BEFORE_IN_RECOG_TIME, BEFORE_OUT_RECOG_TIME, BEFORE_WORK_HOUR, IN_RECOG_TIME, OUT_RECOG_TIME,
+			WORK_HOUR, REASON, FILE_PATH, FILE_NAME, STATUS,
+			REG_DATE, REG_AGENT, REG_USER, REG_UNO
+		) VALUES (
+			SEQ_IRIS_WORKER_CORRECTION.NEXTVAL, :1, :2, :3, :4,
+			:5, :6, :7, :8, :9,
+			:10, :11, :12, :13, 'P',
+			SYSDATE, :14, :15, :16
+		)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		correction.Sno, correction.Jno, correction.UserKey, correction.RecordDate,
+		correction.BeforeInRecogTime, correction.BeforeOutRecogTime, correction.BeforeWorkHour, correction.InRecogTime, correction.OutRecogTime,
+		correction.WorkHour, correction.Reason, correction.FilePath, correction.FileName,
+		agent, correction.RegUser, correction.RegUno,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// func: 출퇴근 정정 요청 목록 조회
+// @param
+// - jno: 프로젝트pk
+// - status: 처리 상태 (없으면 전체)
+// - startDate, endDate: 근무일 조회 기간 (YYYY-MM-DD)
+// - regUno: 요청자 uno (협력업체는 본인 요청만 조회, 없으면 전체)
+func (r *Repository) GetWorkerCorrectionList(ctx context.Context, db Queryer, jno int64, status string, startDate string, endDate string, regUno null.Int) (entity.WorkerCorrections, error) {
+	list := entity.WorkerCorrections{}
+
+	query := `
+		SELECT
+			C.CRNO, C.SNO, C.JNO, C.USER_KEY, W.USER_NM,
+			W.DEPARTMENT, C.RECORD_DATE, C.BEFORE_IN_RECOG_TIME, C.BEFORE_OUT_RECOG_TIME, C.BEFORE_WORK_HOUR,
+			C.IN_RECOG_TIME, C.OUT_RECOG_TIME, C.WORK_HOUR, C.REASON, C.FILE_NAME,
+			C.STATUS, C.REVIEW_UNO, C.REVIEW_USER, C.REVIEW_DATE, C.REVIEW_REASON,
+			C.REG_DATE, C.REG_USER, C.REG_UNO
+		FROM IRIS_WORKER_CORRECTION C
+		LEFT JOIN IRIS_WORKER_SET W ON W.USER_KEY = C.USER_KEY AND W.SNO = C.SNO
+		WHERE C.JNO = :1
+		AND (:2 IS NULL OR C.STATUS = :3)
+		AND C.RECORD_DATE >= TO_DATE(:4, 'YYYY-MM-DD')
+		AND C.RECORD_DATE < TO_DATE(:5, 'YYYY-MM-DD') + 1
+		AND (:6 IS NULL OR C.REG_UNO = :7)
+		ORDER BY C.RECORD_DATE DESC, C.CRNO DESC`
+
+	nullStatus := utils.ParseNullString(status)
+	if err := db.SelectContext(ctx, &list, query, jno, nullStatus, nullStatus, startDate, endDate, regUno, regUno); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 출퇴근 정정 요청 조회
+// @param
+// - crno: 출퇴근 정정 요청pk
+func (r *Repository) GetWorkerCorrection(ctx context.Context, db Queryer, crno int64) (*entity.WorkerCorrection, error) {
+	correction := entity.WorkerCorrection{}
+
+	query := `
+		SELECT
+			CRNO, SNO, JNO, USER_KEY, RECORD_DATE,
+			FILE_PATH, FILE_NAME, STATUS, REG_UNO
+		FROM IRIS_WORKER_CORRECTION
+		WHERE CRNO = :1`
+
+	if err := db.GetContext(ctx, &correction, query, crno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &correction, nil
+}
+
+// func: 출퇴근 정정 요청 조회 및 잠금 (승인/반려 처리용)
+// @param
+// - crno: 출퇴근 정정 요청pk
+func (r *Repository) GetWorkerCorrectionForUpdate(ctx context.Context, db Queryer, crno int64) (*entity.WorkerCorrection, error) {
+	correction := entity.WorkerCorrection{}
+
+	query := `
+		SELECT
+			CRNO, SNO, JNO, USER_KEY, RECORD_DATE,
+			BEFORE_IN_RECOG_TIME, BEFORE_OUT_RECOG_TIME, BEFORE_WORK_HOUR, IN_RECOG_TIME, OUT_RECOG_TIME,
+			WORK_HOUR, REASON, STATUS, REG_UNO
+		FROM IRIS_WORKER_CORRECTION
+		WHERE CRNO = :1
+		FOR UPDATE`
+
+	if err := db.GetContext(ctx, &correction, query, crno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &correction, nil
+}
+
+// func: 출퇴근 정정 요청 처리 상태 변경
+// @param
+// - correction: Crno, Status, ReviewReason, ReviewUno, ReviewUser
+func (r *Repository) ModifyWorkerCorrectionStatus(ctx context.Context, tx Execer, correction entity.WorkerCorrection) error {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_CORRECTION
+		SET
+			STATUS = :1,
+			REVIEW_UNO = :2,
+			REVIEW_USER = :3,
+			REVIEW_DATE = SYSDATE,
+			REVIEW_REASON = :4,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :5,
+			MOD_USER = :6,
+			MOD_UNO = :7
+		WHERE CRNO = :8
+		AND STATUS = 'P'`
+
+	result, err := tx.ExecContext(ctx, query,
+		correction.Status, correction.ReviewUno, correction.ReviewUser, correction.ReviewReason,
+		agent, correction.ReviewUser, correction.ReviewUno, correction.Crno,
+	)
+	if err != nil {
+		return utils.CustomErrorf(err)
+	}
+	if count, _ := result.RowsAffected(); count <= 0 {
+		return utils.CustomErrorf(fmt.Errorf("correction request already processed: %d", correction.Crno.Int64))
+	}
+	return nil
+}
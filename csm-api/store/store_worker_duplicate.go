@@ -0,0 +1,270 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// func: 중복 근로자 비교 대상 조회
+// 현장의 삭제되지 않은 근로자와 출퇴근 기록 수. 생년월일(주민번호 앞 6자리)은 서비스에서 암호문을 복호화해서 구한다.
+// @param
+// - sno: 현장pk
+func (r *Repository) GetWorkerIdentityList(ctx context.Context, db Queryer, sno int64) (entity.WorkerIdentities, error) {
+	list := entity.WorkerIdentities{}
+
+	query := `
+		SELECT
+			W.USER_KEY,
+			W.SNO,
+			W.USER_ID,
+			W.USER_NM,
+			W.PHONE,
+			W.DEPARTMENT,
+			W.REG_NO_ENC,
+			NVL(D.DAILY_COUNT, 0) AS DAILY_COUNT,
+			D.LAST_RECORD_DATE,
+			W.REG_DATE
+		FROM IRIS_WORKER_SET W
+		LEFT JOIN (
+			SELECT USER_KEY, COUNT(*) AS DAILY_COUNT, MAX(RECORD_DATE) AS LAST_RECORD_DATE
+			FROM IRIS_WORKER_DAILY_SET
+			WHERE SNO = :1
+			GROUP BY USER_KEY
+		) D ON D.USER_KEY = W.USER_KEY
+		WHERE W.SNO = :2
+		AND W.IS_DEL = 'N'`
+
+	if err := db.SelectContext(ctx, &list, query, sno, sno); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 중복 근로자 처리 내역 조회
+// 병합, 중복 아님으로 처리한 근로자 쌍
+// @param
+// - sno: 현장pk
+func (r *Repository) GetWorkerMergeList(ctx context.Context, db Queryer, sno int64) ([]entity.WorkerMerge, error) {
+	var list []entity.WorkerMerge
+
+	query := `
+		SELECT MNO, SNO, USER_KEY, MERGED_USER_KEY, STATUS, REASON, DAILY_COUNT, REG_DATE, REG_USER, REG_UNO
+		FROM IRIS_WORKER_MERGE
+		WHERE SNO = :1
+		ORDER BY MNO DESC`
+
+	if err := db.SelectContext(ctx, &list, query, sno); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 현장 근로자 조회 및 잠금 (병합 처리용, 삭제된 근로자 제외)
+// @param
+// - sno: 현장pk
+// - userKey: 근로자
+func (r *Repository) GetWorkerForUpdate(ctx context.Context, db Queryer, sno int64, userKey string) (*entity.Worker, error) {
+	worker := entity.Worker{}
+
+	query := `
+		SELECT USER_KEY, SNO, JNO, USER_ID, USER_NM, DEPARTMENT
+		FROM IRIS_WORKER_SET
+		WHERE SNO = :1
+		AND USER_KEY = :2
+		AND IS_DEL = 'N'
+		FOR UPDATE`
+
+	if err := db.GetContext(ctx, &worker, query, sno, userKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &worker, nil
+}
+
+// func: 두 근로자가 같은 현장, 같은 날 출퇴근 기록이 있는 날짜 조회 (병합 불가)
+// 병합은 모든 현장의 기록을 옮기므로 모든 현장을 확인한다.
+// @param
+// - userKey: 남길 근로자
+// - mergedUserKey: 병합할 근로자
+func (r *Repository) GetWorkerMergeConflictDates(ctx context.Context, db Queryer, userKey string, mergedUserKey string) ([]string, error) {
+	var dates []string
+
+	query := `
+		SELECT TO_CHAR(M.RECORD_DATE, 'YYYY-MM-DD')
+		FROM IRIS_WORKER_DAILY_SET M
+		WHERE M.USER_KEY = :1
+		AND EXISTS (
+			SELECT 1
+			FROM IRIS_WORKER_DAILY_SET K
+			WHERE K.SNO = M.SNO
+			AND K.USER_KEY = :2
+			AND TRUNC(K.RECORD_DATE) = TRUNC(M.RECORD_DATE)
+		)
+		ORDER BY M.RECORD_DATE`
+
+	if err := db.SelectContext(ctx, &dates, query, mergedUserKey, userKey); err != nil {
+		return dates, utils.CustomErrorf(err)
+	}
+	return dates, nil
+}
+
+// func: 근로자의 모든 현장 출퇴근 기록 조회 (병합 변경 이력용)
+// @param
+// - userKey: 근로자
+func (r *Repository) GetDailyWorkerListByUserKey(ctx context.Context, db Queryer, userKey string) (entity.WorkerDailys, error) {
+	list := entity.WorkerDailys{}
+
+	query := `
+		SELECT
+			SNO, JNO, USER_KEY, RECORD_DATE, IN_RECOG_TIME,
+			OUT_RECOG_TIME, IS_DEADLINE, WORK_STATE, IS_OVERTIME, WORK_HOUR,
+			REG_DATE, REG_AGENT, REG_USER, REG_UNO
+		FROM IRIS_WORKER_DAILY_SET
+		WHERE USER_KEY = :1
+		ORDER BY SNO, RECORD_DATE`
+
+	if err := db.SelectContext(ctx, &list, query, userKey); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 모든 현장 출퇴근 기록 근로자 변경 (병합)
+// @param
+// - merge: USER_KEY(남길 근로자), MERGED_USER_KEY(병합할 근로자), RegUser, RegUno
+// @return
+// - 옮긴 기록 수
+func (r *Repository) ModifyDailyWorkerUserKey(ctx context.Context, tx Execer, merge entity.WorkerMerge) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			USER_KEY = :1,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :2,
+			MOD_USER = :3,
+			MOD_UNO = :4
+		WHERE USER_KEY = :5`
+
+	result, err := tx.ExecContext(ctx, query, merge.UserKey, agent, merge.RegUser, merge.RegUno, merge.MergedUserKey)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 병합된 근로자 삭제 처리 (모든 현장)
+// 남길 근로자가 있는 현장은 병합된 근로자를 삭제하고, 없는 현장은 남길 근로자로 바꾼다.
+// 삭제한 근로자는 병합 내역(IRIS_WORKER_MERGE)으로 남길 근로자를 찾는다.
+// @param
+// - merge: USER_KEY, MERGED_USER_KEY, RegUser, RegUno
+func (r *Repository) RemoveMergedWorker(ctx context.Context, tx Execer, merge entity.WorkerMerge) error {
+	agent := utils.GetAgent()
+
+	removeQuery := `
+		UPDATE IRIS_WORKER_SET W
+		SET
+			IS_DEL = 'Y',
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :1,
+			MOD_USER = :2,
+			MOD_UNO = :3
+		WHERE W.USER_KEY = :4
+		AND EXISTS (
+			SELECT 1
+			FROM IRIS_WORKER_SET K
+			WHERE K.SNO = W.SNO
+			AND K.USER_KEY = :5
+		)`
+
+	if _, err := tx.ExecContext(ctx, removeQuery, agent, merge.RegUser, merge.RegUno, merge.MergedUserKey, merge.UserKey); err != nil {
+		return utils.CustomErrorf(err)
+	}
+
+	modifyQuery := `
+		UPDATE IRIS_WORKER_SET W
+		SET
+			USER_KEY = :1,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :2,
+			MOD_USER = :3,
+			MOD_UNO = :4
+		WHERE W.USER_KEY = :5
+		AND NOT EXISTS (
+			SELECT 1
+			FROM IRIS_WORKER_SET K
+			WHERE K.SNO = W.SNO
+			AND K.USER_KEY = :6
+		)`
+
+	if _, err := tx.ExecContext(ctx, modifyQuery, merge.UserKey, agent, merge.RegUser, merge.RegUno, merge.MergedUserKey, merge.UserKey); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
+
+// 병합할 때 근로자를 바꾸는 테이블 (근로자 테이블, 현장 출퇴근 기록 제외)
+// 변경 이력, 로그, 개인정보 접근 기록은 기록 당시의 근로자 키를 유지하고 조회할 때 병합 내역으로 남길 근로자를 찾는다.
+// IRIS_WORKER_MERGE는 병합 내역만 바꿔서 이전에 병합된 근로자도 한 번에 남길 근로자를 찾게 한다.
+var workerMergeTables = []struct {
+	table string
+	where string
+}{
+	{table: "IRIS_WORKER_OVERTIME"},
+	{table: "IRIS_WORKER_CORRECTION"},
+	{table: "IRIS_COMPARE_EXCEPTION"},
+	{table: "IRIS_WORKER_MERGE", where: "AND STATUS = 'M'"},
+}
+
+// func: 근로자 키를 쓰는 테이블의 근로자 변경 (병합, 모든 현장)
+// @param
+// - merge: USER_KEY(남길 근로자), MERGED_USER_KEY(병합할 근로자)
+func (r *Repository) ModifyWorkerMergeUserKey(ctx context.Context, tx Execer, merge entity.WorkerMerge) error {
+	for _, t := range workerMergeTables {
+		query := fmt.Sprintf(`
+			UPDATE %s
+			SET USER_KEY = :1
+			WHERE USER_KEY = :2
+			%s`, t.table, t.where)
+
+		if _, err := tx.ExecContext(ctx, query, merge.UserKey, merge.MergedUserKey); err != nil {
+			return utils.CustomMessageErrorf(t.table, err)
+		}
+	}
+	return nil
+}
+
+// func: 중복 근로자 처리 내역 저장
+// @param
+// - merge: SNO, USER_KEY, MERGED_USER_KEY, STATUS, REASON, DAILY_COUNT, RegUser, RegUno
+func (r *Repository) AddWorkerMerge(ctx context.Context, tx Execer, merge entity.WorkerMerge) error {
+	agent := utils.GetAgent()
+
+	query := `
+		INSERT INTO IRIS_WORKER_MERGE(
+			MNO, SNO, USER_KEY, MERGED_USER_KEY, STATUS,
+			REASON, DAILY_COUNT, REG_DATE, REG_AGENT, REG_USER,
+			REG_UNO
+		) VALUES (
+			SEQ_IRIS_WORKER_MERGE.NEXTVAL, :1, :2, :3, :4,
+			:5, :6, SYSDATE, :7, :8,
+			:9
+		)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		merge.Sno, merge.UserKey, merge.MergedUserKey, merge.Status,
+		merge.Reason, merge.DailyCount, agent, merge.RegUser,
+		merge.RegUno,
+	); err != nil {
+		return utils.CustomErrorf(err)
+	}
+	return nil
+}
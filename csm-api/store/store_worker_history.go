@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// func: 변경 이력 비교용 조회
+// 삭제 이력(변경후)은 SNO, RECORD_DATE가 없으므로 같은 변경(USER_KEY, REG_DATE)의 값으로 채운다. (JNO는 비어있음)
+// 병합된 근로자의 이력은 병합 내역(IRIS_WORKER_MERGE)으로 남길 근로자 키로 조회한다.
+// @param
+// - startDate, endDate: 근무일 조회 기간 (YYYY-MM-DD)
+// - sno: 현장pk
+// - userKeys: 근로자 (없으면 전체)
+func (r *Repository) GetHistoryDailyWorkerList(ctx context.Context, db Queryer, startDate string, endDate string, sno int64, userKeys []string) (entity.WorkerHistories, error) {
+	list := entity.WorkerHistories{}
+
+	where := utils.NewWhereBuilder()
+
+	// userKeys가 있는 경우 userKeys에 해당하는 이력만 조회, 없는 경우 모든 이력 조회
+	isAllUser := 1
+	if len(userKeys) > 0 {
+		isAllUser = 0
+	} else {
+		userKeys = []string{"dummy"}
+	}
+	userCondition := fmt.Sprintf(`AND ( %s = 1 OR NVL(M.USER_KEY, T1.USER_KEY) IN (%s))`, where.Bind(isAllUser), where.BindIn(userKeys))
+
+	query := fmt.Sprintf(`
+		SELECT *
+		FROM (
+			SELECT
+				T1.CNO,
+				T1.HIS_STATUS,
+				T1.REASON_TYPE,
+				DECODE(T1.REASON_TYPE,
+						'01', '추가',
+						'02', '수정',
+						'03', '마감',
+						'04', '공수입력',
+						'05', '프로젝트변경',
+						'06', '삭제',
+						'07', '마감취소',
+						'08', '수정/마감',
+						'09', '근태업로드',
+						'10', '철야',
+						'11', '마감취소(기한초과)',
+						'12', '정정요청',
+						'13', '이력복원',
+						'14', '근로자병합',
+						'15', '공수단계변경',
+						''
+				) AS REASON_NAME,
+				T1.REASON,
+				T1.REG_DATE,
+				T1.REG_USER,
+				T1.REG_UNO,
+				NVL(M.USER_KEY, T1.USER_KEY) AS USER_KEY,
+				T2.USER_ID,
+				T2.USER_NM,
+				T2.DEPARTMENT,
+				T1.JNO,
+				COALESCE(
+				  T1.RECORD_DATE,
+				  MAX(T1.RECORD_DATE) OVER (
+					PARTITION BY T1.USER_KEY, T1.REG_DATE
+				  )
+				) AS RECORD_DATE,
+				COALESCE(
+				  T1.SNO,
+				  MAX(T1.SNO) OVER (
+					PARTITION BY T1.USER_KEY, T1.REG_DATE
+				  )
+				) AS SNO,
+				T1.IN_RECOG_TIME,
+				T1.OUT_RECOG_TIME,
+				T1.WORK_HOUR,
+				T1.WORK_STATE,
+				T1.IS_OVERTIME,
+				T1.IS_DEADLINE,
+				T1.HIS_SEQ
+			FROM IRIS_WORKER_DAILY_HIS T1
+			LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = T1.USER_KEY AND M.STATUS = 'M'
+			LEFT JOIN IRIS_WORKER_SET T2 ON T1.SNO = T2.SNO AND NVL(M.USER_KEY, T1.USER_KEY) = T2.USER_KEY
+			WHERE 1=1
+			%s
+		)
+		WHERE TO_CHAR(RECORD_DATE, 'YYYY-MM-DD') BETWEEN %s AND %s
+		  AND SNO = %s
+		ORDER BY
+			REG_DATE DESC,
+			USER_KEY,
+			CNO`, userCondition, where.Bind(startDate), where.Bind(endDate), where.Bind(sno))
+
+	if err := db.SelectContext(ctx, &list, query, where.Args()...); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 변경 이력 한 건 조회 (복원용)
+// 병합된 근로자의 이력은 남길 근로자 키로 조회한다.
+// @param
+// - cno: 변경 이력pk
+func (r *Repository) GetHistoryDailyWorker(ctx context.Context, db Queryer, cno int64) (*entity.WorkerDaily, error) {
+	worker := entity.WorkerDaily{}
+
+	query := `
+		SELECT
+			H.CNO, H.SNO, H.JNO, NVL(M.USER_KEY, H.USER_KEY) AS USER_KEY, H.RECORD_DATE,
+			H.IN_RECOG_TIME, H.OUT_RECOG_TIME, H.IS_DEADLINE, H.WORK_STATE, H.IS_OVERTIME,
+			H.WORK_HOUR, H.HIS_STATUS
+		FROM IRIS_WORKER_DAILY_HIS H
+		LEFT JOIN IRIS_WORKER_MERGE M ON M.MERGED_USER_KEY = H.USER_KEY AND M.STATUS = 'M'
+		WHERE H.CNO = :1`
+
+	if err := db.GetContext(ctx, &worker, query, cno); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, utils.CustomErrorf(err)
+	}
+	return &worker, nil
+}
+
+// func: 현장 근로자 이력 복원
+// 프로젝트, 출퇴근 시간, 공수, 근무 상태, 철야 여부를 복원한다. 마감된 기록은 변경하지 않는다.
+// @param
+// - worker: SNO, USER_KEY, RECORD_DATE(대상), 복원할 값, ModUser, ModUno
+// @return
+// - 변경된 행 수
+func (r *Repository) ModifyDailyWorkerRestore(ctx context.Context, tx Execer, worker entity.WorkerDaily) (int64, error) {
+	agent := utils.GetAgent()
+
+	query := `
+		UPDATE IRIS_WORKER_DAILY_SET
+		SET
+			JNO = :1,
+			IN_RECOG_TIME = :2,
+			OUT_RECOG_TIME = :3,
+			WORK_STATE = :4,
+			IS_OVERTIME = :5,
+			WORK_HOUR = :6,
+			MOD_DATE = SYSDATE,
+			MOD_AGENT = :7,
+			MOD_USER = :8,
+			MOD_UNO = :9
+		WHERE SNO = :10
+		AND USER_KEY = :11
+		AND TRUNC(RECORD_DATE) = TRUNC(:12)
+		AND IS_DEADLINE = 'N'`
+
+	result, err := tx.ExecContext(ctx, query,
+		worker.Jno, worker.InRecogTime, worker.OutRecogTime, worker.WorkState, worker.IsOvertime,
+		worker.WorkHour, agent, worker.ModUser, worker.ModUno, worker.Sno,
+		worker.UserKey, worker.RecordDate,
+	)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
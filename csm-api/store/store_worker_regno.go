@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"csm-api/entity"
+	"csm-api/utils"
+)
+
+// func: 이전 방식(REG_NO) 주민번호 근로자 조회
+// 암호문(REG_NO_ENC)이 없고 이전 방식으로만 저장된 근로자 (SNO, USER_KEY 순)
+// 이전 방식은 DB 함수로 암호화되어 있어 옮길 때 한번만 DB에서 복호화한다. 옮긴 후에는 REG_NO를 읽지 않는다.
+// @param
+// - lastSno, lastUserKey: 이전 조회의 마지막 SNO, USER_KEY (처음은 0, "")
+// - limit: 조회 건수
+func (r *Repository) GetRegNoLegacyList(ctx context.Context, db Queryer, lastSno int64, lastUserKey string, limit int) (entity.Workers, error) {
+	list := entity.Workers{}
+
+	query := `
+		SELECT USER_KEY, SNO, REG_NO
+		FROM (
+			SELECT
+				USER_KEY,
+				SNO,
+				COMMON.FUNC_DECODE(REG_NO) AS REG_NO
+			FROM IRIS_WORKER_SET
+			WHERE REG_NO IS NOT NULL
+			AND REG_NO_ENC IS NULL
+			AND (SNO > :1 OR (SNO = :2 AND USER_KEY > NVL(:3, ' ')))
+			ORDER BY SNO, USER_KEY
+		)
+		WHERE ROWNUM <= :4`
+
+	if err := db.SelectContext(ctx, &list, query, lastSno, lastSno, lastUserKey, limit); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 이전 방식 주민번호를 암호문으로 옮김
+// 암호문을 저장하고 REG_NO는 비운다. 조회 후 암호문이 저장된 근로자는 건너뛴다.
+// @param
+// - worker: USER_KEY, SNO
+// - regNoEnc: 암호문
+// @return
+// - 저장된 근로자 수
+func (r *Repository) ModifyRegNoLegacy(ctx context.Context, tx Execer, worker entity.Worker, regNoEnc string) (int64, error) {
+	query := `
+		UPDATE IRIS_WORKER_SET
+		SET
+			REG_NO_ENC = :1,
+			REG_NO = NULL
+		WHERE USER_KEY = :2
+		AND SNO = :3
+		AND REG_NO_ENC IS NULL
+		AND REG_NO IS NOT NULL`
+
+	result, err := tx.ExecContext(ctx, query, regNoEnc, worker.UserKey, worker.Sno)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 주민번호 재암호화 대상 근로자 조회
+// 현재 키로 암호화되지 않은 암호문(REG_NO_ENC)이 있는 근로자 (SNO, USER_KEY 순)
+// @param
+// - activeKeyID: 현재 암호화 키 아이디
+// - lastSno, lastUserKey: 이전 조회의 마지막 SNO, USER_KEY (처음은 0, "")
+// - limit: 조회 건수
+func (r *Repository) GetRegNoEncryptionList(ctx context.Context, db Queryer, activeKeyID string, lastSno int64, lastUserKey string, limit int) (entity.Workers, error) {
+	list := entity.Workers{}
+
+	query := `
+		SELECT USER_KEY, SNO, REG_NO_ENC
+		FROM (
+			SELECT
+				USER_KEY,
+				SNO,
+				REG_NO_ENC
+			FROM IRIS_WORKER_SET
+			WHERE REG_NO_ENC IS NOT NULL
+			AND INSTR(REG_NO_ENC, :1 || ':') != 1
+			AND (SNO > :2 OR (SNO = :3 AND USER_KEY > NVL(:4, ' ')))
+			ORDER BY SNO, USER_KEY
+		)
+		WHERE ROWNUM <= :5`
+
+	if err := db.SelectContext(ctx, &list, query, activeKeyID, lastSno, lastSno, lastUserKey, limit); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
+
+// func: 주민번호 암호문 저장
+// 조회 후 암호문이 바뀐 근로자는 건너뛴다.
+// @param
+// - worker: USER_KEY, SNO, REG_NO_ENC(조회한 암호문)
+// - regNoEnc: 새 암호문
+// @return
+// - 저장된 근로자 수
+func (r *Repository) ModifyRegNoEncryption(ctx context.Context, tx Execer, worker entity.Worker, regNoEnc string) (int64, error) {
+	query := `
+		UPDATE IRIS_WORKER_SET
+		SET REG_NO_ENC = :1
+		WHERE USER_KEY = :2
+		AND SNO = :3
+		AND REG_NO_ENC = :4`
+
+	result, err := tx.ExecContext(ctx, query, regNoEnc, worker.UserKey, worker.Sno, worker.RegNoEnc)
+	if err != nil {
+		return 0, utils.CustomErrorf(err)
+	}
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// func: 같은 아이디, 이름의 근로자 주민번호 암호문 조회
+// 주민번호 비교는 서비스에서 복호화해서 한다. (최근 등록 순)
+// @param
+// - userId: 근로자 아이디
+// - userNm: 근로자명
+func (r *Repository) GetWorkerRegNoList(ctx context.Context, db Queryer, userId string, userNm string) (entity.Workers, error) {
+	list := entity.Workers{}
+
+	query := `
+		SELECT USER_KEY, SNO, REG_NO_ENC
+		FROM IRIS_WORKER_SET
+		WHERE USER_ID = :1
+		AND USER_NM = :2
+		AND IS_DEL = 'N'
+		ORDER BY REG_DATE DESC`
+
+	if err := db.SelectContext(ctx, &list, query, userId, userNm); err != nil {
+		return list, utils.CustomErrorf(err)
+	}
+	return list, nil
+}
@@ -34,75 +34,59 @@ func (r *Repository) GetUserInfo(ctx context.Context, db Queryer, userId string)
 	return user, nil
 }
 
-// 직원 로그인
-func (r *Repository) GetUserValid(ctx context.Context, db Queryer, userId string, userPwd string) (entity.User, error) {
-	user := entity.User{}
+// 직원 로그인 비밀번호 조회
+// 비밀번호 비교는 service에서 CredentialVerifier로 처리
+func (r *Repository) GetUserPassword(ctx context.Context, db Queryer, userId string) (string, error) {
+	var userPwd string
 
 	sql := `
 		SELECT
-			T1.UNO,
-			T1.USER_ID,
-			T1.USER_NAME,
-			T1.DEPT_NAME,
-			T1.TEAM_NAME,
-			CASE 
-				WHEN T1.JOBDUTY_SORT_NO <= 'C030' THEN 'EXECUTIVE'
-				WHEN T2.ROLE_CODE IS NOT NULL THEN T2.ROLE_CODE
-				ELSE 'USER'
-			END AS ROLE_CODE
+			T1.USER_PWD
 		FROM
 			COMMON.V_BIZ_USER_INFO T1
-			LEFT JOIN IRIS_USER_ROLE_MAP T2 ON T1.UNO = T2.USER_UNO
 		WHERE T1.IS_USE = 'Y'
-		AND T1.USER_ID = :1
-		AND t1.USER_PWD = :2`
+		AND T1.USER_ID = :1`
 
-	if err := db.GetContext(ctx, &user, sql, userId, userPwd); err != nil {
-		return user, utils.CustomErrorf(err)
+	if err := db.GetContext(ctx, &userPwd, sql, userId); err != nil {
+		return "", utils.CustomErrorf(err)
 	}
-	return user, nil
+	return userPwd, nil
 }
 
-// 협력업체 로그인
-func (r *Repository) GetCompanyUserValid(ctx context.Context, db Queryer, userId string, userPwd string) (entity.CompanyInfo, error) {
-	company := entity.CompanyInfo{}
+// 협력업체 로그인 정보 조회 (비밀번호 포함)
+// 아이디는 프로젝트, 업체별로 등록되어 중복될 수 있으므로 같은 아이디의 계정을 모두 조회한다.
+func (r *Repository) GetCompanyUserList(ctx context.Context, db Queryer, userId string) ([]entity.CompanyInfo, error) {
+	var list []entity.CompanyInfo
 
 	sql := `
 		SELECT 
 		    S.JNO,
 			S.CNO,
-			S.ID
+			S.ID,
+			S.PW
 		FROM 
-			JOB_SUBCON_INFO S, 
-			S_SYS_USER_SET U
-		WHERE S.UNO = U.UNO(+)
-		AND S.IS_USE = 'Y'
+			JOB_SUBCON_INFO S
+		WHERE S.IS_USE = 'Y'
 		AND S.ID = :1
-		AND S.PW = :2`
+		ORDER BY S.JNO, S.CNO`
 
-	if err := db.GetContext(ctx, &company, sql, userId, userPwd); err != nil {
-		return company, utils.CustomErrorf(err)
+	if err := db.SelectContext(ctx, &list, sql, userId); err != nil {
+		return nil, utils.CustomErrorf(err)
 	}
-	return company, nil
+	return list, nil
 }
 
-func (r *Repository) GetCompanyUser(ctx context.Context, db Queryer, userId string) (entity.CompanyInfo, error) {
-	company := entity.CompanyInfo{}
-
-	sql := `
-		SELECT 
-		    S.JNO,
-			S.CNO,
-			S.ID
-		FROM 
-			JOB_SUBCON_INFO S, 
-			S_SYS_USER_SET U
-		WHERE S.UNO = U.UNO(+)
-		AND S.IS_USE = 'Y'
-		AND S.ID = :1`
+// 협력업체 비밀번호 변경 (평문 비밀번호를 해시로 전환할 때 사용)
+func (r *Repository) ModifyCompanyUserPassword(ctx context.Context, tx Execer, company entity.CompanyInfo) error {
+	query := `
+		UPDATE JOB_SUBCON_INFO
+		SET PW = :1
+		WHERE JNO = :2
+		AND CNO = :3
+		AND ID = :4`
 
-	if err := db.GetContext(ctx, &company, sql, userId); err != nil {
-		return company, utils.CustomErrorf(err)
+	if _, err := tx.ExecContext(ctx, query, company.Pw, company.Jno, company.Cno, company.Id); err != nil {
+		return utils.CustomErrorf(err)
 	}
-	return company, nil
+	return nil
 }
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyset(cursor) 페이징 정렬 컬럼
+// 마지막 컬럼은 유일한 값이어야 같은 값이 페이지 경계에 걸려도 누락/중복이 없다.
+type KeysetColumn struct {
+	Column string
+	Desc   bool
+}
+
+type Keyset []KeysetColumn
+
+// func: keyset 정렬 ORDER BY 절 (ORDER BY 제외)
+func (k Keyset) OrderBy() string {
+	orders := make([]string, 0, len(k))
+	for _, key := range k {
+		if key.Desc {
+			orders = append(orders, key.Column+" DESC")
+		} else {
+			orders = append(orders, key.Column+" ASC")
+		}
+	}
+	return strings.Join(orders, ", ")
+}
+
+// func: 커서 다음 행 조건 추가
+// Oracle은 (a, b) > (:1, :2) 비교를 지원하지 않으므로 a > :1 OR (a = :2 AND b > :3) 형태로 풀어서 쓴다.
+// @param
+// - keyset: 정렬 컬럼
+// - values: 커서(마지막 행)의 정렬 컬럼 값 (keyset 순서)
+func (w *WhereBuilder) KeysetWhereConvert(condition string, keyset Keyset, values []any) string {
+	if len(keyset) == 0 || len(keyset) != len(values) {
+		return condition
+	}
+	return condition + ` AND ` + w.keyset(keyset, values)
+}
+
+func (w *WhereBuilder) keyset(keyset Keyset, values []any) string {
+	operator := ">"
+	if keyset[0].Desc {
+		operator = "<"
+	}
+
+	next := fmt.Sprintf(`%s %s %s`, keyset[0].Column, operator, w.Bind(values[0]))
+	if len(keyset) == 1 {
+		return next
+	}
+	same := fmt.Sprintf(`%s = %s`, keyset[0].Column, w.Bind(values[0]))
+	return fmt.Sprintf(`(%s OR (%s AND %s))`, next, same, w.keyset(keyset[1:], values[1:]))
+}
@@ -6,61 +6,96 @@ import (
 	"strings"
 )
 
-func StringWhereConvert(condition string, sqlValue sql.NullString, target string) string {
+// 바인드 변수(:n)를 사용하는 WHERE 조건 빌더
+// 검색값은 SQL 문자열에 넣지 않고 바인드 변수로 추가한다. (컬럼명은 코드에서 넘긴 값만 사용)
+// Oracle은 바인드 변수를 쿼리에 나오는 순서대로 바인드하므로, 조건 변환과 Bind는 쿼리에 나오는 순서대로 호출해야 한다.
+type WhereBuilder struct {
+	args []any
+}
+
+// func: 빌더 생성
+// @param
+// - args: 조건보다 앞에 나오는 바인드 변수 값 (:1부터 순서대로)
+func NewWhereBuilder(args ...any) *WhereBuilder {
+	return &WhereBuilder{args: args}
+}
+
+// 바인드 변수 추가 후 placeholder(:n) 반환
+func (w *WhereBuilder) Bind(value any) string {
+	w.args = append(w.args, value)
+	return fmt.Sprintf(":%d", len(w.args))
+}
+
+// IN 절에 사용할 바인드 변수 목록 추가 후 placeholder 목록(:n, :n+1, ...) 반환
+func (w *WhereBuilder) BindIn(values []string) string {
+	placeholders := make([]string, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, w.Bind(value))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// 바인드 변수 값 (쿼리 실행시 인자로 사용)
+func (w *WhereBuilder) Args() []any {
+	return w.args
+}
+
+func (w *WhereBuilder) like(target string, value string) string {
+	return fmt.Sprintf(`LOWER(%s) LIKE LOWER(%s)`, target, w.Bind("%"+value+"%"))
+}
+
+func (w *WhereBuilder) StringWhereConvert(condition string, sqlValue sql.NullString, target string) string {
 	if sqlValue.Valid {
 		value := strings.TrimSpace(sqlValue.String)
 		if value != "" {
-			condition += fmt.Sprintf(` AND LOWER(%s) LIKE LOWER('%%%s%%')`, target, value)
+			condition += ` AND ` + w.like(target, value)
 		}
 	}
 	return condition
 }
 
-func Int64WhereConvert(condition string, sqlValue sql.NullInt64, target string) string {
+func (w *WhereBuilder) Int64WhereConvert(condition string, sqlValue sql.NullInt64, target string) string {
 	if sqlValue.Valid {
 		value := sqlValue.Int64
 		if value != 0 {
-			condition += fmt.Sprintf(` AND %s = %d`, target, value)
+			condition += fmt.Sprintf(` AND %s = %s`, target, w.Bind(value))
 		}
 	}
 	return condition
 }
 
-func TimeWhereConvert(condition string, sqlValue sql.NullString, target string) string {
+func (w *WhereBuilder) TimeWhereConvert(condition string, sqlValue sql.NullString, target string) string {
 	if sqlValue.Valid {
 		value := strings.TrimSpace(sqlValue.String)
 		if value != "" {
-			condition += fmt.Sprintf(` AND TO_CHAR(%s, 'YYYY-MM-DD') = '%s'`, target, value)
+			condition += fmt.Sprintf(` AND TO_CHAR(%s, 'YYYY-MM-DD') = %s`, target, w.Bind(value))
 		}
 	}
 	return condition
 }
 
-func TimeBetweenWhereConvert(condition string, sqlValue1 sql.NullString, sqlValue2 sql.NullString, target string) string {
-	if sqlValue1.Valid && sqlValue2.Valid {
-		value1 := strings.TrimSpace(sqlValue1.String)
-		value2 := strings.TrimSpace(sqlValue2.String)
+func (w *WhereBuilder) TimeBetweenWhereConvert(condition string, sqlValue1 sql.NullString, sqlValue2 sql.NullString, target string) string {
+	return w.timeBetween(condition, "AND", sqlValue1, sqlValue2, target)
+}
 
-		if value1 != "" && value2 != "" {
-			condition += fmt.Sprintf(` AND TO_CHAR(%s, 'YYYY-MM-DD') BETWEEN '%s' AND '%s'`, target, value1, value2)
-		}
-	}
-	return condition
+func (w *WhereBuilder) OrTimeBetweenWhereConvert(condition string, sqlValue1 sql.NullString, sqlValue2 sql.NullString, target string) string {
+	return w.timeBetween(condition, "OR", sqlValue1, sqlValue2, target)
 }
 
-func OrTimeBetweenWhereConvert(condition string, sqlValue1 sql.NullString, sqlValue2 sql.NullString, target string) string {
+func (w *WhereBuilder) timeBetween(condition string, operator string, sqlValue1 sql.NullString, sqlValue2 sql.NullString, target string) string {
 	if sqlValue1.Valid && sqlValue2.Valid {
 		value1 := strings.TrimSpace(sqlValue1.String)
 		value2 := strings.TrimSpace(sqlValue2.String)
 
 		if value1 != "" && value2 != "" {
-			condition += fmt.Sprintf(` OR TO_CHAR(%s, 'YYYY-MM-DD') BETWEEN '%s' AND '%s'`, target, value1, value2)
+			condition += fmt.Sprintf(` %s TO_CHAR(%s, 'YYYY-MM-DD') BETWEEN %s AND %s`, operator, target, w.Bind(value1), w.Bind(value2))
 		}
 	}
 	return condition
 }
 
 // 테이블 컬럼(columns)과 검색 쿼리(retry)를 받아 SQL WHERE절(AND, OR 조건 조각)로 변환
+// 검색값은 바인드 변수로 추가하고, 컬럼명은 columns에 있는 값만 사용한다.
 // parameter
 // - columns: ex) []string{"T1.REASON_TYPE", "T2.USER_NM", "T2.USER_ID"}
 // - retry: ex) "ALL:마감?USER_NM?USER_ID;03?REASON_TYPE;07?REASON_TYPE;08?REASON_TYPE|테스트~USER_ID:010123|ALL"
@@ -76,21 +111,13 @@ func OrTimeBetweenWhereConvert(condition string, sqlValue1 sql.NullString, sqlVa
 //  6. retry에 있는 검색 컬럼이 columns에 없으면 해당 필드는 where절 변환에서 무시
 //
 // return
-// ex):
+// ex): retry "ALL:마감?USER_NM~USER_ID:010123", 빌더에 앞서 추가된 바인드 변수가 없는 경우
+//
+//	AND (LOWER(T2.USER_NM) LIKE LOWER(:1))
+//	AND LOWER(T2.USER_ID) LIKE LOWER(:2)
 //
-//	AND (LOWER(T2.USER_NM) LIKE LOWER('%마감%')
-//	     OR LOWER(T2.USER_ID) LIKE LOWER('%마감%')
-//	     OR LOWER(T1.REASON_TYPE) LIKE LOWER('%03%')
-//	     OR LOWER(T1.REASON_TYPE) LIKE LOWER('%07%')
-//	     OR LOWER(T1.REASON_TYPE) LIKE LOWER('%08%'))
-//	AND (LOWER(T1.REASON_TYPE) LIKE LOWER('%테스트%')
-//	     OR LOWER(T2.USER_NM) LIKE LOWER('%테스트%')
-//	     OR LOWER(T2.USER_ID) LIKE LOWER('%테스트%'))
-//	AND LOWER(T2.USER_ID) LIKE LOWER('%010123%')
-//	AND (LOWER(T1.REASON_TYPE) LIKE LOWER('%ALL%')
-//	     OR LOWER(T2.USER_NM) LIKE LOWER('%ALL%')
-//	     OR LOWER(T2.USER_ID) LIKE LOWER('%ALL%'))
-func RetrySearchTextConvert(retry string, columns []string) string {
+//	args: ["%마감%", "%010123%"]
+func (w *WhereBuilder) RetrySearchTextConvert(retry string, columns []string) string {
 	where := ""
 	trimRetry := strings.TrimSpace(retry)
 	if trimRetry == "" {
@@ -129,11 +156,11 @@ func RetrySearchTextConvert(retry string, columns []string) string {
 					for _, word := range strings.Split(searchWord, "|") { // 전체검색 시 |로 구분되는 값이 조회 되지 않아 코드 추가
 						for _, column := range columns {
 							if isAllColumn {
-								temp = append(temp, fmt.Sprintf(`LOWER(%s) LIKE LOWER('%%%s%%')`, column, word))
+								temp = append(temp, w.like(column, word))
 							} else {
 								for _, f := range fieldTargets {
 									if strings.HasSuffix(column, f) {
-										temp = append(temp, fmt.Sprintf(`LOWER(%s) LIKE LOWER('%%%s%%')`, column, word))
+										temp = append(temp, w.like(column, word))
 										break
 									}
 								}
@@ -154,7 +181,7 @@ func RetrySearchTextConvert(retry string, columns []string) string {
 					v = strings.TrimSpace(v)
 					for _, column := range columns {
 						if strings.HasSuffix(column, key) {
-							where += fmt.Sprintf("AND LOWER(%s) LIKE LOWER('%%%s%%') ", column, v)
+							where += "AND " + w.like(column, v) + " "
 						}
 					}
 				}
@@ -163,7 +190,7 @@ func RetrySearchTextConvert(retry string, columns []string) string {
 			// *** : 없는 건 전체 columns OR ***
 			var temp []string
 			for _, column := range columns {
-				temp = append(temp, fmt.Sprintf(`LOWER(%s) LIKE LOWER('%%%s%%')`, column, andGroup))
+				temp = append(temp, w.like(column, andGroup))
 			}
 			if len(temp) > 0 {
 				where += "AND (" + strings.Join(temp, " OR ") + ") "
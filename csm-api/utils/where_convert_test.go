@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	whereTestColumns = []string{"T1.REASON_TYPE", "T2.USER_NM", "T2.USER_ID"}
+	wherePlaceholder = regexp.MustCompile(`:(\d+)`)
+	whereLike        = regexp.MustCompile(`LOWER\((T1\.REASON_TYPE|T2\.USER_NM|T2\.USER_ID)\) LIKE LOWER\(:\d+\)`)
+
+	// FuzzWhereConvert에서 변환한 조건 (검색어, 기간, 날짜 조건 순서)
+	whereConvertPattern = regexp.MustCompile(`^( AND LOWER\(T2\.USER_NM\) LIKE LOWER\(:\d+\))?` +
+		`( AND TO_CHAR\(T1\.RECORD_DATE, 'YYYY-MM-DD'\) BETWEEN :\d+ AND :\d+)?` +
+		`( AND TO_CHAR\(T1\.RECORD_DATE, 'YYYY-MM-DD'\) = :\d+)?$`)
+)
+
+// 변환한 조건이 컬럼명, LIKE 조건, AND/OR, 괄호로만 이루어졌는지 확인하고
+// placeholder가 prefix 다음 번호부터 순서대로 바인드 변수와 같은 수만큼 나오는지 확인
+func checkWhere(t *testing.T, where string, w *WhereBuilder, prefix int) {
+	t.Helper()
+
+	rest := whereLike.ReplaceAllString(where, "LIKE")
+	for _, token := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(rest)) {
+		switch token {
+		case "AND", "OR", "LIKE":
+		default:
+			t.Fatalf("unexpected token %q in where: %q", token, where)
+		}
+	}
+
+	matches := wherePlaceholder.FindAllStringSubmatch(where, -1)
+	if len(matches) != len(w.Args())-prefix {
+		t.Fatalf("placeholder count %d, bind count %d: %q", len(matches), len(w.Args())-prefix, where)
+	}
+	for i, match := range matches {
+		n, err := strconv.Atoi(match[1])
+		if err != nil || n != prefix+i+1 {
+			t.Fatalf("placeholder %d out of order (want :%d): %q", n, prefix+i+1, where)
+		}
+	}
+	for _, arg := range w.Args()[prefix:] {
+		value, ok := arg.(string)
+		if !ok || !strings.HasPrefix(value, "%") || !strings.HasSuffix(value, "%") {
+			t.Fatalf("unexpected bind value %#v", arg)
+		}
+	}
+}
+
+func TestRetrySearchTextConvert(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry string
+		where string
+		args  []any
+	}{
+		{
+			name:  "빈 검색",
+			retry: "  ",
+			where: "",
+			args:  nil,
+		},
+		{
+			name:  "ALL 필드 지정, 필드 검색",
+			retry: "ALL:마감?USER_NM~USER_ID:010123",
+			where: "AND (LOWER(T2.USER_NM) LIKE LOWER(:1)) AND LOWER(T2.USER_ID) LIKE LOWER(:2)",
+			args:  []any{"%마감%", "%010123%"},
+		},
+		{
+			name:  "필드 없는 검색은 전체 컬럼 OR",
+			retry: "홍길동",
+			where: "AND (LOWER(T1.REASON_TYPE) LIKE LOWER(:1) OR LOWER(T2.USER_NM) LIKE LOWER(:2) OR LOWER(T2.USER_ID) LIKE LOWER(:3))",
+			args:  []any{"%홍길동%", "%홍길동%", "%홍길동%"},
+		},
+		{
+			name:  "없는 필드는 무시",
+			retry: "PASSWORD:1234",
+			where: "",
+			args:  nil,
+		},
+		{
+			name:  "검색값의 SQL은 바인드 변수로",
+			retry: "USER_NM:' OR 1=1 --",
+			where: "AND LOWER(T2.USER_NM) LIKE LOWER(:1)",
+			args:  []any{"%' OR 1=1 --%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWhereBuilder()
+			where := w.RetrySearchTextConvert(tt.retry, whereTestColumns)
+			if where != tt.where {
+				t.Fatalf("where = %q, want %q", where, tt.where)
+			}
+			if fmt.Sprint(w.Args()) != fmt.Sprint(tt.args) {
+				t.Fatalf("args = %v, want %v", w.Args(), tt.args)
+			}
+		})
+	}
+}
+
+func FuzzRetrySearchTextConvert(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"홍길동",
+		"ALL:마감?USER_NM?USER_ID;03?REASON_TYPE;07?REASON_TYPE;08?REASON_TYPE|테스트~USER_ID:010123|ALL",
+		"USER_NM:' OR 1=1 --",
+		"ALL:?;|~:",
+		"USER_ID:a|b|c~REASON_TYPE:)",
+		"~~~ALL:x?NOT_A_COLUMN",
+	} {
+		f.Add(seed, 0)
+		f.Add(seed, 2)
+	}
+
+	f.Fuzz(func(t *testing.T, retry string, prefix int) {
+		if prefix < 0 || prefix > 10 {
+			return
+		}
+		args := make([]any, prefix)
+		for i := range args {
+			args[i] = i
+		}
+
+		w := NewWhereBuilder(args...)
+		where := w.RetrySearchTextConvert(retry, whereTestColumns)
+		checkWhere(t, where, w, prefix)
+	})
+}
+
+func FuzzWhereConvert(f *testing.F) {
+	f.Add("홍길동", "2025-01-01", "2025-01-31", true)
+	f.Add("' OR 1=1 --", ") OR (1=1", "", false)
+	f.Add("   ", "", " ", true)
+
+	f.Fuzz(func(t *testing.T, text string, start string, end string, valid bool) {
+		w := NewWhereBuilder()
+		condition := w.StringWhereConvert("", sql.NullString{String: text, Valid: valid}, "T2.USER_NM")
+		condition = w.TimeBetweenWhereConvert(condition, sql.NullString{String: start, Valid: valid}, sql.NullString{String: end, Valid: valid}, "T1.RECORD_DATE")
+		condition = w.TimeWhereConvert(condition, sql.NullString{String: start, Valid: valid}, "T1.RECORD_DATE")
+
+		// 검색값은 조건 문자열에 들어가지 않고 바인드 변수로만 추가
+		if !whereConvertPattern.MatchString(condition) {
+			t.Fatalf("unexpected condition %q", condition)
+		}
+
+		matches := wherePlaceholder.FindAllStringSubmatch(condition, -1)
+		if len(matches) != len(w.Args()) {
+			t.Fatalf("placeholder count %d, bind count %d: %q", len(matches), len(w.Args()), condition)
+		}
+		for i, match := range matches {
+			if match[1] != strconv.Itoa(i+1) {
+				t.Fatalf("placeholder :%s out of order: %q", match[1], condition)
+			}
+		}
+	})
+}
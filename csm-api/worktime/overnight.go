@@ -0,0 +1,60 @@
+package worktime
+
+import "time"
+
+// 프로젝트 출근시간이 없을 때 철야 판단 기준 시각
+const DefaultDayStart = 8 * time.Hour
+
+// 철야로 볼 수 있는 최대 근무 시간
+const MaxOvernight = 24 * time.Hour
+
+// func: 철야 여부
+// 출근한 날 다음날, 다음날 출근 시각(DayStart) 이전에 퇴근한 경우 철야로 본다.
+// @param
+// - in: 출근한 날 출근 시간
+// - out: 다음날 퇴근 시간
+func (r Rule) IsOvernight(in, out time.Time) bool {
+	if in.IsZero() || out.IsZero() || !out.After(in) || out.Sub(in) >= MaxOvernight {
+		return false
+	}
+
+	next := time.Date(in.Year(), in.Month(), in.Day()+1, 0, 0, 0, 0, in.Location())
+	out = out.In(in.Location())
+	if out.Before(next) || !out.Before(next.AddDate(0, 0, 1)) {
+		return false
+	}
+
+	dayStart := r.DayStart
+	if dayStart <= 0 {
+		dayStart = DefaultDayStart
+	}
+	return out.Before(next.Add(dayStart))
+}
+
+// func: 철야 기록 합치기
+// 출근만 있는 날(prev) 다음날(next) 첫 인식(출근 없으면 퇴근)이 철야면 prev 퇴근으로 옮긴다.
+// 분류 규칙에서 다음날 출근 시각 이전 인식은 다음날 출근으로 분류되므로 다음날 출근을 먼저 본다.
+// @param
+// - prev: 출근한 날 근무
+// - next: 다음날 근무
+func (r Rule) SplitOvernight(prev, next Attendance) (Attendance, Attendance, bool) {
+	if prev.In.IsZero() || !prev.Out.IsZero() {
+		return prev, next, false
+	}
+
+	out := next.In
+	if out.IsZero() {
+		out = next.Out
+	}
+	if !r.IsOvernight(prev.In, out) {
+		return prev, next, false
+	}
+
+	prev.Out = out
+	if !next.In.IsZero() {
+		next.In = time.Time{}
+	} else {
+		next.Out = time.Time{}
+	}
+	return prev, next, true
+}
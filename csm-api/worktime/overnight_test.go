@@ -0,0 +1,90 @@
+package worktime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleSplitOvernight(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		prev     Attendance
+		next     Attendance
+		wantPrev Attendance
+		wantNext Attendance
+		wantOk   bool
+	}{
+		{
+			name:     "다음날 출근 시각 이전 출근 인식은 철야 퇴근",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{In: at(1, 2, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0), Out: at(1, 2, 0, 0)},
+			wantNext: Attendance{},
+			wantOk:   true,
+		},
+		{
+			name:     "다음날 퇴근은 남김",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{In: at(1, 3, 0, 0), Out: at(1, 18, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0), Out: at(1, 3, 0, 0)},
+			wantNext: Attendance{Out: at(1, 18, 0, 0)},
+			wantOk:   true,
+		},
+		{
+			name:     "다음날 출근 없으면 퇴근 인식",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{Out: at(1, 5, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0), Out: at(1, 5, 0, 0)},
+			wantNext: Attendance{},
+			wantOk:   true,
+		},
+		{
+			name:     "다음날 출근 시각 이후 인식은 철야 아님",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{In: at(1, 8, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0)},
+			wantNext: Attendance{In: at(1, 8, 0, 0)},
+		},
+		{
+			name:     "프로젝트 출근시간 기준",
+			rule:     Rule{Shifts: DefaultRule.Shifts, Policy: ScanLast, DayStart: 6 * time.Hour},
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{In: at(1, 7, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0)},
+			wantNext: Attendance{In: at(1, 7, 0, 0)},
+		},
+		{
+			name:     "출근한 날 퇴근이 있으면 철야 아님",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0), Out: at(0, 18, 0, 0)},
+			next:     Attendance{In: at(1, 2, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0), Out: at(0, 18, 0, 0)},
+			wantNext: Attendance{In: at(1, 2, 0, 0)},
+		},
+		{
+			name:     "이틀 뒤 인식은 철야 아님",
+			rule:     DefaultRule,
+			prev:     Attendance{In: at(0, 8, 0, 0)},
+			next:     Attendance{In: at(2, 2, 0, 0)},
+			wantPrev: Attendance{In: at(0, 8, 0, 0)},
+			wantNext: Attendance{In: at(2, 2, 0, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next, ok := tt.rule.SplitOvernight(tt.prev, tt.next)
+			if ok != tt.wantOk {
+				t.Fatalf("SplitOvernight() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !sameAttendance(prev, tt.wantPrev) || !sameAttendance(next, tt.wantNext) {
+				t.Errorf("SplitOvernight() = %+v, %+v, want %+v, %+v", prev, next, tt.wantPrev, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package worktime
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+/**
+ * 홍채인식 기록 출퇴근 분류
+ * DB 접근 없이 인식 시간만으로 분류하므로 기록된 인식 순서를 그대로 넣어 결과를 재현할 수 있다.
+ */
+
+// 잘못된 출퇴근 분류 규칙, 공수 계산 설정
+var ErrInvalidRule = errors.New("invalid work rule")
+
+// 같은 근무에 인식이 여러번 있을 때 퇴근 시간 결정 방식
+type ScanPolicy string
+
+const (
+	ScanLast  ScanPolicy = "LAST"  // 마지막 인식이 퇴근
+	ScanFirst ScanPolicy = "FIRST" // 출근 이후 첫 인식이 퇴근
+)
+
+const day = 24 * time.Hour
+
+// 근무 시간대
+// 시각은 모두 자정부터의 시간이며, End가 Start 이하면 다음날 끝나는 근무(야간 근무)
+type Shift struct {
+	Name    string
+	Start   time.Duration // 근무 시작 시각
+	End     time.Duration // 근무 종료 시각
+	InUntil time.Duration // 첫 인식이 이 시각 이전이면 출근, 이후면 퇴근. 0이면 근무 시간의 중간
+}
+
+// 프로젝트 출퇴근 분류 규칙
+type Rule struct {
+	Shifts   []Shift
+	Policy   ScanPolicy
+	MinGap   time.Duration // 이 시간 안에 다시 인식한 기록은 무시
+	DayStart time.Duration // 프로젝트 출근 시각 (철야 판단 기준, 0이면 DefaultDayStart)
+}
+
+// 근무 시간대가 설정되지 않은 프로젝트의 분류 규칙
+// 하루 단위로 15시 이전 첫 인식은 출근, 그 외에는 퇴근이며 마지막 인식이 퇴근
+var DefaultRule = Rule{
+	Shifts: []Shift{{Name: "DEFAULT", Start: 0, End: day, InUntil: 15 * time.Hour}},
+	Policy: ScanLast,
+}
+
+// 인식 기록
+type Scan struct {
+	Time time.Time
+	Ref  int // 호출하는 쪽에서 원래 기록을 찾기 위한 값
+}
+
+// 근무 한번의 출퇴근 분류 결과
+type Attendance struct {
+	WorkDate time.Time // 근무 시작일 (자정)
+	Shift    string
+	In       time.Time // 출근 시간 (없으면 zero)
+	Out      time.Time // 퇴근 시간 (없으면 zero)
+	Refs     []int     // 이 근무로 분류된 인식 기록 (무시한 기록 제외)
+}
+
+// func: 규칙 확인
+func (r Rule) Validate() error {
+	if len(r.Shifts) == 0 {
+		return fmt.Errorf("%w: no shift", ErrInvalidRule)
+	}
+	if r.Policy != ScanLast && r.Policy != ScanFirst {
+		return fmt.Errorf("%w: policy %q", ErrInvalidRule, r.Policy)
+	}
+	if r.MinGap < 0 {
+		return fmt.Errorf("%w: min gap %s", ErrInvalidRule, r.MinGap)
+	}
+	for _, shift := range r.Shifts {
+		if shift.Start < 0 || shift.Start >= day || shift.End <= 0 || shift.End > day || shift.InUntil < 0 || shift.InUntil > day {
+			return fmt.Errorf("%w: shift %q time", ErrInvalidRule, shift.Name)
+		}
+		start, end, until := shift.span()
+		if until <= start || until > end {
+			return fmt.Errorf("%w: shift %q in_until", ErrInvalidRule, shift.Name)
+		}
+	}
+	return nil
+}
+
+// func: 근무 시작일 자정 기준 시작, 종료, 출근 인정 시각
+func (s Shift) span() (start, end, until time.Duration) {
+	start, end = s.Start, s.End
+	if end <= start {
+		end += day
+	}
+	until = s.InUntil
+	if until == 0 {
+		until = start + (end-start)/2
+	} else if until <= start {
+		until += day
+	}
+	return
+}
+
+// 근무 한번 (근무 시간대 + 근무 시작일)
+type occurrence struct {
+	shift      int
+	date       time.Time
+	start, end time.Time
+	until      time.Time
+}
+
+// func: 인식 시간과 가장 가까운 근무 찾기
+// 근무 시간 안이면 거리는 0, 밖이면 가까운 시작/종료 시각까지의 거리. 같으면 먼저 시작하는 근무
+func (r Rule) nearest(t time.Time) occurrence {
+	var (
+		best     occurrence
+		bestDist time.Duration = -1
+	)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for offset := -1; offset <= 1; offset++ {
+		date := midnight.AddDate(0, 0, offset)
+		for i, shift := range r.Shifts {
+			start, end, until := shift.span()
+			o := occurrence{shift: i, date: date, start: date.Add(start), end: date.Add(end), until: date.Add(until)}
+
+			var dist time.Duration
+			if t.Before(o.start) {
+				dist = o.start.Sub(t)
+			} else if t.After(o.end) {
+				dist = t.Sub(o.end)
+			}
+
+			if bestDist < 0 || dist < bestDist || (dist == bestDist && o.start.Before(best.start)) {
+				best, bestDist = o, dist
+			}
+		}
+	}
+	return best
+}
+
+// func: 인식 기록 출퇴근 분류
+// 인식 기록마다 가장 가까운 근무를 찾고, 근무별로 첫 인식이 출근 인정 시각 이전이면 출근, 나머지는 퇴근 후보로 본다.
+// 퇴근은 규칙에 따라 마지막(ScanLast) 또는 첫(ScanFirst) 퇴근 후보
+// @param
+// - scans: 인식 기록 (순서 무관)
+// @return
+// - 근무 시작 순서로 정렬된 출퇴근
+func (r Rule) Classify(scans []Scan) []Attendance {
+	if len(r.Shifts) == 0 {
+		r = DefaultRule
+	}
+
+	sorted := make([]Scan, len(scans))
+	copy(sorted, scans)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	type group struct {
+		occurrence
+		scans []Scan
+	}
+	var groups []*group
+	index := make(map[string]*group)
+
+	for _, scan := range sorted {
+		if scan.Time.IsZero() {
+			continue
+		}
+		o := r.nearest(scan.Time)
+		key := fmt.Sprintf("%d|%s", o.shift, o.date.Format("20060102"))
+		g, ok := index[key]
+		if !ok {
+			g = &group{occurrence: o}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		// 짧은 시간 안에 다시 인식한 기록 무시
+		if n := len(g.scans); n > 0 && scan.Time.Sub(g.scans[n-1].Time) < r.MinGap {
+			continue
+		}
+		g.scans = append(g.scans, scan)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].start.Before(groups[j].start)
+	})
+
+	list := make([]Attendance, 0, len(groups))
+	for _, g := range groups {
+		att := Attendance{
+			WorkDate: g.date,
+			Shift:    r.Shifts[g.shift].Name,
+			Refs:     make([]int, 0, len(g.scans)),
+		}
+
+		outs := g.scans
+		if g.scans[0].Time.Before(g.until) {
+			att.In = g.scans[0].Time
+			outs = g.scans[1:]
+		}
+		if len(outs) > 0 {
+			if r.Policy == ScanFirst {
+				att.Out = outs[0].Time
+			} else {
+				att.Out = outs[len(outs)-1].Time
+			}
+		}
+
+		for _, scan := range g.scans {
+			att.Refs = append(att.Refs, scan.Ref)
+		}
+		list = append(list, att)
+	}
+	return list
+}
@@ -0,0 +1,200 @@
+package worktime
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+// 2025-03-03 기준 일시
+func at(dayOffset int, hour int, minute int, second int) time.Time {
+	return time.Date(2025, 3, 3+dayOffset, hour, minute, second, 0, kst)
+}
+
+func date(dayOffset int) time.Time {
+	return at(dayOffset, 0, 0, 0)
+}
+
+func scans(times ...time.Time) []Scan {
+	list := make([]Scan, 0, len(times))
+	for i, t := range times {
+		list = append(list, Scan{Time: t, Ref: i})
+	}
+	return list
+}
+
+var (
+	dayShift   = Shift{Name: "DAY", Start: 8 * time.Hour, End: 17 * time.Hour}
+	nightShift = Shift{Name: "NIGHT", Start: 20 * time.Hour, End: 5 * time.Hour}
+)
+
+func TestRuleClassify(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		scans []Scan
+		want  []Attendance
+	}{
+		{
+			name:  "인식 기록 없음",
+			rule:  DefaultRule,
+			scans: nil,
+			want:  []Attendance{},
+		},
+		{
+			name:  "기본 규칙: 첫 인식 출근, 마지막 인식 퇴근",
+			rule:  Rule{},
+			scans: scans(at(0, 7, 50, 0), at(0, 12, 0, 0), at(0, 18, 10, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", In: at(0, 7, 50, 0), Out: at(0, 18, 10, 0), Refs: []int{0, 1, 2}},
+			},
+		},
+		{
+			name:  "기본 규칙: 15시 직전 첫 인식은 출근",
+			rule:  DefaultRule,
+			scans: scans(at(0, 14, 59, 59)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", In: at(0, 14, 59, 59), Refs: []int{0}},
+			},
+		},
+		{
+			name:  "기본 규칙: 15시 정각 첫 인식은 퇴근",
+			rule:  DefaultRule,
+			scans: scans(at(0, 15, 0, 0), at(0, 18, 0, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", Out: at(0, 18, 0, 0), Refs: []int{0, 1}},
+			},
+		},
+		{
+			name:  "기본 규칙: 자정을 넘긴 인식은 다음날 출근",
+			rule:  DefaultRule,
+			scans: scans(at(0, 8, 0, 0), at(1, 1, 0, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", In: at(0, 8, 0, 0), Refs: []int{0}},
+				{WorkDate: date(1), Shift: "DEFAULT", In: at(1, 1, 0, 0), Refs: []int{1}},
+			},
+		},
+		{
+			name:  "야간 근무: 다음날 퇴근은 근무 시작일로",
+			rule:  Rule{Shifts: []Shift{{Name: "NIGHT", Start: 22 * time.Hour, End: 6 * time.Hour}}, Policy: ScanLast},
+			scans: scans(at(0, 21, 40, 0), at(1, 6, 20, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "NIGHT", In: at(0, 21, 40, 0), Out: at(1, 6, 20, 0), Refs: []int{0, 1}},
+			},
+		},
+		{
+			name:  "야간 근무: 출근 인정 시각(근무 중간) 이후 첫 인식은 퇴근",
+			rule:  Rule{Shifts: []Shift{{Name: "NIGHT", Start: 22 * time.Hour, End: 6 * time.Hour}}, Policy: ScanLast},
+			scans: scans(at(1, 2, 0, 0), at(1, 6, 0, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "NIGHT", Out: at(1, 6, 0, 0), Refs: []int{0, 1}},
+			},
+		},
+		{
+			name:  "주간/야간 근무: 가까운 근무로 분류",
+			rule:  Rule{Shifts: []Shift{dayShift, nightShift}, Policy: ScanLast},
+			scans: scans(at(0, 7, 30, 0), at(0, 17, 20, 0), at(0, 19, 40, 0), at(1, 5, 10, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DAY", In: at(0, 7, 30, 0), Out: at(0, 17, 20, 0), Refs: []int{0, 1}},
+				{WorkDate: date(0), Shift: "NIGHT", In: at(0, 19, 40, 0), Out: at(1, 5, 10, 0), Refs: []int{2, 3}},
+			},
+		},
+		{
+			name:  "주간/야간 근무: 두 근무의 중간 인식은 먼저 시작하는 근무",
+			rule:  Rule{Shifts: []Shift{dayShift, nightShift}, Policy: ScanLast},
+			scans: scans(at(0, 8, 0, 0), at(0, 18, 30, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DAY", In: at(0, 8, 0, 0), Out: at(0, 18, 30, 0), Refs: []int{0, 1}},
+			},
+		},
+		{
+			name:  "근무 종료 시각 정각 인식",
+			rule:  Rule{Shifts: []Shift{dayShift, nightShift}, Policy: ScanLast},
+			scans: scans(at(0, 8, 0, 0), at(0, 17, 0, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DAY", In: at(0, 8, 0, 0), Out: at(0, 17, 0, 0), Refs: []int{0, 1}},
+			},
+		},
+		{
+			name:  "첫 퇴근 후보를 퇴근으로 (ScanFirst)",
+			rule:  Rule{Shifts: []Shift{dayShift}, Policy: ScanFirst},
+			scans: scans(at(0, 8, 0, 0), at(0, 12, 40, 0), at(0, 17, 10, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DAY", In: at(0, 8, 0, 0), Out: at(0, 12, 40, 0), Refs: []int{0, 1, 2}},
+			},
+		},
+		{
+			name:  "짧은 시간 안에 다시 인식한 기록 무시",
+			rule:  Rule{Shifts: DefaultRule.Shifts, Policy: ScanLast, MinGap: time.Minute},
+			scans: scans(at(0, 8, 0, 0), at(0, 8, 0, 30), at(0, 8, 1, 0), at(0, 18, 0, 0)),
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", In: at(0, 8, 0, 0), Out: at(0, 18, 0, 0), Refs: []int{0, 2, 3}},
+			},
+		},
+		{
+			name: "순서 무관, 시간 없는 기록 무시",
+			rule: DefaultRule,
+			scans: []Scan{
+				{Time: at(0, 18, 0, 0), Ref: 0},
+				{Ref: 1},
+				{Time: at(0, 8, 0, 0), Ref: 2},
+			},
+			want: []Attendance{
+				{WorkDate: date(0), Shift: "DEFAULT", In: at(0, 8, 0, 0), Out: at(0, 18, 0, 0), Refs: []int{2, 0}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.Classify(tt.scans)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Classify() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !sameAttendance(got[i], tt.want[i]) {
+					t.Errorf("Classify()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func sameAttendance(a Attendance, b Attendance) bool {
+	return a.WorkDate.Equal(b.WorkDate) &&
+		a.Shift == b.Shift &&
+		a.In.Equal(b.In) &&
+		a.Out.Equal(b.Out) &&
+		slices.Equal(a.Refs, b.Refs)
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{name: "기본 규칙", rule: DefaultRule},
+		{name: "주간/야간 근무", rule: Rule{Shifts: []Shift{dayShift, nightShift}, Policy: ScanFirst}},
+		{name: "근무 시간대 없음", rule: Rule{Policy: ScanLast}, wantErr: true},
+		{name: "분류 방식 없음", rule: Rule{Shifts: []Shift{dayShift}}, wantErr: true},
+		{name: "음수 재인식 간격", rule: Rule{Shifts: []Shift{dayShift}, Policy: ScanLast, MinGap: -time.Minute}, wantErr: true},
+		{name: "하루를 넘는 시각", rule: Rule{Shifts: []Shift{{Name: "X", Start: 8 * time.Hour, End: 25 * time.Hour}}, Policy: ScanLast}, wantErr: true},
+		{name: "출근 인정 시각이 근무 밖", rule: Rule{Shifts: []Shift{{Name: "X", Start: 8 * time.Hour, End: 17 * time.Hour, InUntil: 18 * time.Hour}}, Policy: ScanLast}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRule) {
+				t.Fatalf("Validate() error = %v, want ErrInvalidRule", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package worktime
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+/**
+ * 공수 계산
+ * 출퇴근 시간, 프로젝트 설정, 공수 단계만으로 계산하므로 같은 입력이면 언제든 같은 공수가 나온다.
+ */
+
+// 휴게 시간 (자정부터의 시간)
+type Break struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// 휴게 시간이 설정되지 않은 프로젝트의 휴게 시간 (점심 12:00 ~ 13:00)
+var DefaultBreaks = []Break{{Start: 12 * time.Hour, End: 13 * time.Hour}}
+
+// 공수 단계 (IRIS_MAN_HOUR): 근무 시간(WorkHour) 이하면 공수(ManHour)
+type ManHour struct {
+	WorkHour int64
+	ManHour  float64
+}
+
+// 공수 계산 설정
+type WorkSetting struct {
+	InTime      time.Duration // 출근 시각
+	OutTime     time.Duration // 퇴근 시각
+	Respite     time.Duration // 출/퇴근 유예시간
+	Breaks      []Break       // 휴게 시간 (없으면 DefaultBreaks)
+	WeekendRate float64       // 주말(토, 일) 공수 배율 (0이면 적용 안함)
+	HolidayRate float64       // 공휴일 공수 배율 (0이면 적용 안함, 주말보다 우선)
+	HalfDayHour int64         // 반일 기준 근무 시간: 이 시간 이상 근무하면 최소 0.5 공수 (0이면 적용 안함)
+}
+
+// 반일 공수
+const HalfDay = 0.5
+
+// func: 공수 계산 설정 확인
+func (s WorkSetting) Validate() error {
+	for _, b := range s.Breaks {
+		if b.Start < 0 || b.End > day || b.End <= b.Start {
+			return fmt.Errorf("%w: break %s ~ %s", ErrInvalidRule, b.Start, b.End)
+		}
+	}
+	// 겹치는 휴게 시간은 두번 빼게 되므로 허용하지 않음
+	breaks := make([]Break, len(s.Breaks))
+	copy(breaks, s.Breaks)
+	sort.Slice(breaks, func(i, j int) bool {
+		return breaks[i].Start < breaks[j].Start
+	})
+	for i := 1; i < len(breaks); i++ {
+		if breaks[i].Start < breaks[i-1].End {
+			return fmt.Errorf("%w: break overlap %s ~ %s, %s ~ %s", ErrInvalidRule, breaks[i-1].Start, breaks[i-1].End, breaks[i].Start, breaks[i].End)
+		}
+	}
+	if s.WeekendRate < 0 || s.HolidayRate < 0 {
+		return fmt.Errorf("%w: rate", ErrInvalidRule)
+	}
+	if s.HalfDayHour < 0 {
+		return fmt.Errorf("%w: half day hour %d", ErrInvalidRule, s.HalfDayHour)
+	}
+	return nil
+}
+
+// 공수 계산 결과
+type WorkResult struct {
+	WorkHour int64   // 휴게 시간을 뺀 근무 시간 (시간 단위 내림)
+	ManHour  float64 // 공수
+	Reversed bool    // 퇴근이 출근보다 빠른 기록 (계산하지 않음)
+}
+
+// func: 공수 계산
+// 1. 근무 시간 = 퇴근 - 출근 - 휴게 시간 (시간 단위 내림, 분 단위 출퇴근 시간 기준)
+// 2. 근무 시간이 0이면 0, 가장 긴 공수 단계 이상이면 1
+// 3. 출근 시각+유예시간 이전 출근, 퇴근 시각-유예시간 이후 퇴근이면 1
+// 4. 그 외에는 근무 시간 이상인 가장 짧은 공수 단계의 공수 (없으면 0)
+// 5. 반일 기준 시간 이상 근무했으면 최소 0.5
+// 6. 공휴일이면 공휴일 배율, 주말이면 주말 배율을 곱한다. (소수점 둘째 자리 반올림)
+//
+// 출퇴근 시간은 근무일 + 시각으로 본다. (기존 공수 계산 SQL과 같음)
+// 철야면 퇴근은 다음날이고, 철야 표시 없이 퇴근 시각이 출근 시각보다 빠르면
+// 실제 퇴근 기록이 다음날인 경우(자정을 넘기는 야간 근무)만 다음날 퇴근으로 본다.
+// 그 외에는 날짜를 임의로 더하지 않고 Reversed로 표시한다. (공수 0, 호출하는 쪽에서 저장하지 않음)
+// 휴게 시간은 근무일의 휴게 시간만 뺀다. (철야로 다음날 휴게 시간을 지나도 빼지 않음, 기존 계산과 같음)
+// @param
+// - setting: 공수 계산 설정
+// - manHours: 공수 단계
+// - recordDate: 근무일
+// - in, out: 출근, 퇴근 시간
+// - overtime: 철야 여부 (퇴근이 다음날)
+// - holiday: 근무일이 공휴일인지 여부
+func Calculate(setting WorkSetting, manHours []ManHour, recordDate, in, out time.Time, overtime bool, holiday bool) WorkResult {
+	loc := recordDate.Location()
+	date := time.Date(recordDate.Year(), recordDate.Month(), recordDate.Day(), 0, 0, 0, 0, loc)
+
+	inClock := clock(in.In(loc))
+	outClock := clock(out.In(loc))
+
+	inTime := date.Add(inClock)
+	outTime := date.Add(outClock)
+	if overtime || (outTime.Before(inTime) && !out.In(loc).Before(date.AddDate(0, 0, 1))) {
+		outTime = outTime.AddDate(0, 0, 1)
+	}
+	if outTime.Before(inTime) {
+		return WorkResult{Reversed: true}
+	}
+
+	breaks := setting.Breaks
+	if len(breaks) == 0 {
+		breaks = DefaultBreaks
+	}
+	work := outTime.Sub(inTime)
+	for _, b := range breaks {
+		work -= overlap(inTime, outTime, date.Add(b.Start), date.Add(b.End))
+	}
+
+	result := WorkResult{WorkHour: int64(math.Floor(work.Hours()))}
+	result.ManHour = manHour(setting, manHours, result.WorkHour, inClock, outClock)
+
+	// 반일
+	if setting.HalfDayHour > 0 && result.WorkHour >= setting.HalfDayHour && result.ManHour < HalfDay {
+		result.ManHour = HalfDay
+	}
+
+	// 공휴일, 주말 배율
+	rate := 1.0
+	if holiday && setting.HolidayRate > 0 {
+		rate = setting.HolidayRate
+	} else if weekday := date.Weekday(); (weekday == time.Saturday || weekday == time.Sunday) && setting.WeekendRate > 0 {
+		rate = setting.WeekendRate
+	}
+	result.ManHour = math.Round(result.ManHour*rate*100) / 100
+
+	return result
+}
+
+// func: 근무 시간에 해당하는 공수
+func manHour(setting WorkSetting, manHours []ManHour, workHour int64, inClock, outClock time.Duration) float64 {
+	if workHour == 0 {
+		return 0
+	}
+
+	if len(manHours) > 0 {
+		maxWorkHour := manHours[0].WorkHour
+		for _, m := range manHours[1:] {
+			if m.WorkHour > maxWorkHour {
+				maxWorkHour = m.WorkHour
+			}
+		}
+		if workHour >= maxWorkHour {
+			return 1.0
+		}
+	}
+
+	// 유예시간 안에 출근하고 퇴근한 경우 (시각만 비교)
+	inLimit := (setting.InTime + setting.Respite) % day
+	outLimit := (setting.OutTime - setting.Respite + day) % day
+	if inClock <= inLimit && outClock >= outLimit {
+		return 1.0
+	}
+
+	var (
+		found bool
+		step  ManHour
+	)
+	for _, m := range manHours {
+		if m.WorkHour < workHour {
+			continue
+		}
+		if !found || m.WorkHour < step.WorkHour || (m.WorkHour == step.WorkHour && m.ManHour > step.ManHour) {
+			step, found = m, true
+		}
+	}
+	if !found {
+		return 0
+	}
+	return step.ManHour
+}
+
+// func: 자정부터의 시간 (분 단위)
+func clock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// func: 두 구간이 겹치는 시간
+func overlap(start, end, bStart, bEnd time.Time) time.Duration {
+	if bStart.After(start) {
+		start = bStart
+	}
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
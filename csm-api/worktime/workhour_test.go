@@ -0,0 +1,191 @@
+package worktime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var manHourSteps = []ManHour{{WorkHour: 4, ManHour: 0.5}, {WorkHour: 8, ManHour: 1}}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  WorkSetting
+		manHours []ManHour
+		record   time.Time
+		in       time.Time
+		out      time.Time
+		overtime bool
+		holiday  bool
+		want     WorkResult
+	}{
+		{
+			name:     "점심 시간 제외",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(0, 17, 0, 0),
+			want:     WorkResult{WorkHour: 8, ManHour: 1},
+		},
+		{
+			name:     "근무 시간 이상인 가장 짧은 공수 단계",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(0, 13, 30, 0),
+			want:     WorkResult{WorkHour: 4, ManHour: 0.5},
+		},
+		{
+			name:     "초 단위는 버림",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 59),
+			out:      at(0, 13, 0, 0),
+			want:     WorkResult{WorkHour: 4, ManHour: 0.5},
+		},
+		{
+			name:     "점심 시간 안의 근무는 0",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 12, 10, 0),
+			out:      at(0, 12, 50, 0),
+			want:     WorkResult{WorkHour: 0, ManHour: 0},
+		},
+		{
+			name:     "유예시간 안에 출퇴근하면 1",
+			setting:  WorkSetting{InTime: 8 * time.Hour, OutTime: 17 * time.Hour, Respite: 10 * time.Minute},
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 10, 0),
+			out:      at(0, 16, 50, 0),
+			want:     WorkResult{WorkHour: 7, ManHour: 1},
+		},
+		{
+			name:     "프로젝트 휴게 시간",
+			setting:  WorkSetting{Breaks: []Break{{Start: 12 * time.Hour, End: 13 * time.Hour}, {Start: 15 * time.Hour, End: 15*time.Hour + 30*time.Minute}}},
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(0, 17, 0, 0),
+			want:     WorkResult{WorkHour: 7, ManHour: 1},
+		},
+		{
+			name:     "철야: 다음날 휴게 시간은 빼지 않음",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 20, 0, 0),
+			out:      at(1, 14, 0, 0),
+			overtime: true,
+			want:     WorkResult{WorkHour: 18, ManHour: 1},
+		},
+		{
+			name:     "철야: 근무일 휴게 시간만 뺌",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(1, 2, 0, 0),
+			overtime: true,
+			want:     WorkResult{WorkHour: 17, ManHour: 1},
+		},
+		{
+			name:     "자정을 넘기는 야간 근무",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 22, 0, 0),
+			out:      at(1, 3, 0, 0),
+			want:     WorkResult{WorkHour: 5, ManHour: 1},
+		},
+		{
+			name:     "퇴근이 출근보다 빠른 기록은 계산하지 않음",
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 18, 0, 0),
+			out:      at(0, 8, 0, 0),
+			want:     WorkResult{Reversed: true},
+		},
+		{
+			name:     "반일 기준 시간 이상이면 0.5",
+			setting:  WorkSetting{HalfDayHour: 4},
+			manHours: []ManHour{{WorkHour: 4, ManHour: 0.3}, {WorkHour: 8, ManHour: 1}},
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(0, 13, 0, 0),
+			want:     WorkResult{WorkHour: 4, ManHour: 0.5},
+		},
+		{
+			name:   "공수 단계 없음",
+			record: date(0),
+			in:     at(0, 8, 0, 0),
+			out:    at(0, 17, 0, 0),
+			want:   WorkResult{WorkHour: 8, ManHour: 0},
+		},
+		{
+			name:     "주말 배율",
+			setting:  WorkSetting{WeekendRate: 1.5, HolidayRate: 2},
+			manHours: manHourSteps,
+			record:   date(5),
+			in:       at(5, 8, 0, 0),
+			out:      at(5, 13, 30, 0),
+			want:     WorkResult{WorkHour: 4, ManHour: 0.75},
+		},
+		{
+			name:     "공휴일 배율이 주말보다 우선",
+			setting:  WorkSetting{WeekendRate: 1.5, HolidayRate: 2},
+			manHours: manHourSteps,
+			record:   date(5),
+			in:       at(5, 8, 0, 0),
+			out:      at(5, 17, 0, 0),
+			holiday:  true,
+			want:     WorkResult{WorkHour: 8, ManHour: 2},
+		},
+		{
+			name:     "배율 없는 공휴일",
+			setting:  WorkSetting{WeekendRate: 1.5},
+			manHours: manHourSteps,
+			record:   date(0),
+			in:       at(0, 8, 0, 0),
+			out:      at(0, 17, 0, 0),
+			holiday:  true,
+			want:     WorkResult{WorkHour: 8, ManHour: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Calculate(tt.setting, tt.manHours, tt.record, tt.in, tt.out, tt.overtime, tt.holiday)
+			if got != tt.want {
+				t.Errorf("Calculate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkSettingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting WorkSetting
+		wantErr bool
+	}{
+		{name: "기본 설정", setting: WorkSetting{}},
+		{name: "휴게 시간 여러개", setting: WorkSetting{Breaks: []Break{{Start: 15 * time.Hour, End: 16 * time.Hour}, {Start: 12 * time.Hour, End: 13 * time.Hour}}}},
+		{name: "이어지는 휴게 시간", setting: WorkSetting{Breaks: []Break{{Start: 12 * time.Hour, End: 13 * time.Hour}, {Start: 13 * time.Hour, End: 14 * time.Hour}}}},
+		{name: "겹치는 휴게 시간", setting: WorkSetting{Breaks: []Break{{Start: 12 * time.Hour, End: 13 * time.Hour}, {Start: 12*time.Hour + 30*time.Minute, End: 14 * time.Hour}}}, wantErr: true},
+		{name: "포함되는 휴게 시간", setting: WorkSetting{Breaks: []Break{{Start: 15 * time.Hour, End: 15*time.Hour + 10*time.Minute}, {Start: 12 * time.Hour, End: 18 * time.Hour}}}, wantErr: true},
+		{name: "종료가 시작보다 빠른 휴게 시간", setting: WorkSetting{Breaks: []Break{{Start: 13 * time.Hour, End: 12 * time.Hour}}}, wantErr: true},
+		{name: "음수 배율", setting: WorkSetting{WeekendRate: -1}, wantErr: true},
+		{name: "음수 반일 기준", setting: WorkSetting{HalfDayHour: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.setting.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRule) {
+				t.Fatalf("Validate() error = %v, want ErrInvalidRule", err)
+			}
+		})
+	}
+}